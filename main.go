@@ -11,6 +11,7 @@ import (
 
 	"github.com/apenwarr/fixconsole"
 	"github.com/stashapp/stash/pkg/api"
+	"github.com/stashapp/stash/pkg/cli"
 	"github.com/stashapp/stash/pkg/manager"
 
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
@@ -32,6 +33,11 @@ func init() {
 }
 
 func main() {
+	// run maintenance commands without starting the server
+	if cli.IsCommand(os.Args[1:]) {
+		os.Exit(cli.Run(os.Args))
+	}
+
 	manager.Initialize()
 	api.Start(uiBox, loginUIBox)
 
@@ -74,6 +74,46 @@ func (t changesetTranslator) getFields() []string {
 	return ret
 }
 
+// userFieldSources returns a user-attributed FieldSource for each tracked
+// field present in the input. trackedFields maps input field names to the
+// object field names used for provenance.
+func (t changesetTranslator) userFieldSources(trackedFields map[string]string) []models.FieldSource {
+	return t.fieldSources(trackedFields, nil)
+}
+
+// fieldSources returns a FieldSource for each tracked field present in the
+// input. Fields with an entry in sources are attributed to the provided
+// source, all other fields are attributed to the user. Entries in sources
+// for fields not present in the input are ignored.
+func (t changesetTranslator) fieldSources(trackedFields map[string]string, sources []*models.FieldSourceInput) []models.FieldSource {
+	sourceMap := make(map[string]string)
+	for _, s := range sources {
+		if s.Source != "" {
+			sourceMap[s.Field] = s.Source
+		}
+	}
+
+	fieldsBySource := make(map[string][]string)
+	for inputField, field := range trackedFields {
+		if !t.hasField(inputField) {
+			continue
+		}
+
+		source := models.FieldSourceUser
+		if s, found := sourceMap[field]; found {
+			source = s
+		}
+		fieldsBySource[source] = append(fieldsBySource[source], field)
+	}
+
+	var ret []models.FieldSource
+	for source, fields := range fieldsBySource {
+		ret = append(ret, models.NewFieldSources(source, fields)...)
+	}
+
+	return ret
+}
+
 func (t changesetTranslator) nullString(value *string, field string) *sql.NullString {
 	if !t.hasField(field) {
 		return nil
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangesetTranslatorFieldSources(t *testing.T) {
+	const scraperID = "scraperID"
+
+	translator := changesetTranslator{
+		inputMap: map[string]interface{}{
+			"id":        "1",
+			"title":     "title",
+			"details":   "details",
+			"studio_id": "2",
+		},
+	}
+
+	sources := []*models.FieldSourceInput{
+		{Field: "title", Source: scraperID},
+		{Field: "studio", Source: scraperID},
+		// not present in the input
+		{Field: "url", Source: scraperID},
+	}
+
+	got := translator.fieldSources(sceneTrackedFields, sources)
+	sort.Slice(got, func(i, j int) bool {
+		return got[i].Field < got[j].Field
+	})
+
+	assert.Len(t, got, 3)
+
+	want := []struct {
+		field  string
+		source string
+	}{
+		{"details", models.FieldSourceUser},
+		{"studio", scraperID},
+		{"title", scraperID},
+	}
+
+	for i, w := range want {
+		assert.Equal(t, w.field, got[i].Field)
+		assert.Equal(t, w.source, got[i].Source)
+	}
+
+	// without sources, all fields are attributed to the user
+	for _, s := range translator.userFieldSources(sceneTrackedFields) {
+		assert.Equal(t, models.FieldSourceUser, s.Source)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/playlist"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/tag"
 )
@@ -64,6 +65,8 @@ func getEverySceneJSON(ctx context.Context) []byte {
 	txnManager := manager.GetInstance().TxnManager
 	var scenes []*models.Scene
 	var vrTag *models.Tag
+	var playlistLibraries []SceneLibrary
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	err = txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
 		pageSize := -1
 
@@ -86,13 +89,51 @@ func getEverySceneJSON(ctx context.Context) []byte {
 			logger.Errorf("Could not retrieve scene list: %s", err.Error())
 			return err
 		}
+
+		// each playlist is presented as a separate library
+		playlists, err := r.Playlist().All()
+		if err != nil {
+			logger.Errorf("Could not retrieve playlists: %s", err.Error())
+			return err
+		}
+
+		for _, p := range playlists {
+			playlistScenes, err := playlist.Scenes(r, p)
+			if err != nil {
+				logger.Warnf("Could not retrieve scenes of playlist %q: %s", p.Name, err.Error())
+				continue
+			}
+
+			playlistLibraries = append(playlistLibraries, SceneLibrary{
+				Name: p.Name,
+				List: getSlimDeoScenes(baseURL, playlistScenes),
+			})
+		}
+
 		return nil
 	})
 	if err != nil {
 		return nil
 	}
 
-	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	library := SceneLibrary{
+		Name: "Library",
+		List: getSlimDeoScenes(baseURL, scenes),
+	}
+	libraries := append([]SceneLibrary{library}, playlistLibraries...)
+
+	response := MultipleVideoJsonResponse{
+		Scenes: libraries,
+	}
+
+	jsonBytes, err := json.Marshal(response)
+	if err != nil {
+		logger.Errorf("Could not marshal JSON for deoVR all scenes: %s", err.Error())
+	}
+	return jsonBytes
+}
+
+func getSlimDeoScenes(baseURL string, scenes []*models.Scene) []SlimDeoScene {
 	var list []SlimDeoScene
 	for _, sceneModel := range scenes {
 		builder := urlbuilders.NewSceneURLBuilder(baseURL, sceneModel.ID)
@@ -107,21 +148,7 @@ func getEverySceneJSON(ctx context.Context) []byte {
 		list = append(list, x)
 	}
 
-	library := SceneLibrary{
-		Name: "Library",
-		List: list,
-	}
-	libraries := []SceneLibrary{library}
-
-	response := MultipleVideoJsonResponse{
-		Scenes: libraries,
-	}
-
-	jsonBytes, err := json.Marshal(response)
-	if err != nil {
-		logger.Errorf("Could not marshal JSON for deoVR all scenes: %s", err.Error())
-	}
-	return jsonBytes
+	return list
 }
 
 func getSingleSceneJSON(ctx context.Context, sceneModel *models.Scene) []byte {
@@ -59,6 +59,9 @@ func (r *Resolver) Image() models.ImageResolver {
 func (r *Resolver) SceneMarker() models.SceneMarkerResolver {
 	return &sceneMarkerResolver{r}
 }
+func (r *Resolver) SceneMarkerSuggestion() models.SceneMarkerSuggestionResolver {
+	return &sceneMarkerSuggestionResolver{r}
+}
 func (r *Resolver) Studio() models.StudioResolver {
 	return &studioResolver{r}
 }
@@ -71,6 +74,21 @@ func (r *Resolver) Subscription() models.SubscriptionResolver {
 func (r *Resolver) Tag() models.TagResolver {
 	return &tagResolver{r}
 }
+func (r *Resolver) FieldSource() models.FieldSourceResolver {
+	return &fieldSourceResolver{r}
+}
+func (r *Resolver) Playlist() models.PlaylistResolver {
+	return &playlistResolver{r}
+}
+func (r *Resolver) Folder() models.FolderResolver {
+	return &folderResolver{r}
+}
+func (r *Resolver) VideoFile() models.VideoFileResolver {
+	return &videoFileResolver{r}
+}
+func (r *Resolver) VideoCaption() models.VideoCaptionResolver {
+	return &videoCaptionResolver{r}
+}
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -80,10 +98,16 @@ type galleryResolver struct{ *Resolver }
 type performerResolver struct{ *Resolver }
 type sceneResolver struct{ *Resolver }
 type sceneMarkerResolver struct{ *Resolver }
+type sceneMarkerSuggestionResolver struct{ *Resolver }
 type imageResolver struct{ *Resolver }
 type studioResolver struct{ *Resolver }
 type movieResolver struct{ *Resolver }
 type tagResolver struct{ *Resolver }
+type fieldSourceResolver struct{ *Resolver }
+type playlistResolver struct{ *Resolver }
+type videoFileResolver struct{ *Resolver }
+type videoCaptionResolver struct{ *Resolver }
+type folderResolver struct{ *Resolver }
 
 func (r *Resolver) withTxn(ctx context.Context, fn func(r models.Repository) error) error {
 	return r.txnManager.WithTxn(ctx, fn)
@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *fieldSourceResolver) UpdatedAt(ctx context.Context, obj *models.FieldSource) (*time.Time, error) {
+	return &obj.UpdatedAt.Timestamp, nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+func (r *folderResolver) Name(ctx context.Context, obj *models.Folder) (string, error) {
+	return obj.Basename(), nil
+}
+
+func (r *folderResolver) ParentFolder(ctx context.Context, obj *models.Folder) (ret *models.Folder, err error) {
+	if !obj.ParentFolderID.Valid {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Folder().Find(int(obj.ParentFolderID.Int64))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *folderResolver) ModTime(ctx context.Context, obj *models.Folder) (*time.Time, error) {
+	if obj.ModTime.Valid {
+		return &obj.ModTime.Timestamp, nil
+	}
+	return nil, nil
+}
+
+func (r *folderResolver) CreatedAt(ctx context.Context, obj *models.Folder) (*time.Time, error) {
+	return &obj.CreatedAt.Timestamp, nil
+}
+
+func (r *folderResolver) UpdatedAt(ctx context.Context, obj *models.Folder) (*time.Time, error) {
+	return &obj.UpdatedAt.Timestamp, nil
+}
+
+func (r *folderResolver) FolderCount(ctx context.Context, obj *models.Folder) (ret int, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		children, err := repo.Folder().FindByParentFolderID(obj.ID)
+		ret = len(children)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *folderResolver) SceneCount(ctx context.Context, obj *models.Folder, depth *int) (ret int, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = scene.CountByFolderID(repo.Scene(), obj.ID, depth)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *folderResolver) ImageCount(ctx context.Context, obj *models.Folder, depth *int) (ret int, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = image.CountByFolderID(repo.Image(), obj.ID, depth)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *folderResolver) GalleryCount(ctx context.Context, obj *models.Folder, depth *int) (ret int, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = gallery.CountByFolderID(repo.Gallery(), obj.ID, depth)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
@@ -103,6 +103,21 @@ func (r *galleryResolver) Scenes(ctx context.Context, obj *models.Gallery) (ret
 	return ret, nil
 }
 
+func (r *galleryResolver) Folder(ctx context.Context, obj *models.Gallery) (ret *models.Folder, err error) {
+	if !obj.FolderID.Valid {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Folder().Find(int(obj.FolderID.Int64))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *galleryResolver) Studio(ctx context.Context, obj *models.Gallery) (ret *models.Studio, err error) {
 	if !obj.StudioID.Valid {
 		return nil, nil
@@ -7,6 +7,7 @@ import (
 	"github.com/stashapp/stash/pkg/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func (r *imageResolver) Title(ctx context.Context, obj *models.Image) (*string, error) {
@@ -14,6 +15,14 @@ func (r *imageResolver) Title(ctx context.Context, obj *models.Image) (*string,
 	return &ret, nil
 }
 
+func (r *imageResolver) Date(ctx context.Context, obj *models.Image) (*string, error) {
+	if obj.Date.Valid {
+		result := utils.GetYMDFromDatabaseDate(obj.Date.String)
+		return &result, nil
+	}
+	return nil, nil
+}
+
 func (r *imageResolver) Rating(ctx context.Context, obj *models.Image) (*int, error) {
 	if obj.Rating.Valid {
 		rating := int(obj.Rating.Int64)
@@ -26,11 +35,48 @@ func (r *imageResolver) File(ctx context.Context, obj *models.Image) (*models.Im
 	width := int(obj.Width.Int64)
 	height := int(obj.Height.Int64)
 	size := int(obj.Size.Int64)
-	return &models.ImageFileType{
+	ret := &models.ImageFileType{
 		Size:   &size,
 		Width:  &width,
 		Height: &height,
-	}, nil
+	}
+
+	if obj.Duration.Valid {
+		ret.Duration = &obj.Duration.Float64
+	}
+
+	if obj.Orientation.Valid {
+		orientation := int(obj.Orientation.Int64)
+		ret.Orientation = &orientation
+	}
+	if obj.CameraMake.Valid {
+		ret.CameraMake = &obj.CameraMake.String
+	}
+	if obj.CameraModel.Valid {
+		ret.CameraModel = &obj.CameraModel.String
+	}
+	if obj.Lens.Valid {
+		ret.Lens = &obj.Lens.String
+	}
+	if obj.FocalLength.Valid {
+		ret.FocalLength = &obj.FocalLength.Float64
+	}
+	if obj.Aperture.Valid {
+		ret.Aperture = &obj.Aperture.Float64
+	}
+	if obj.ExposureTime.Valid {
+		ret.ExposureTime = &obj.ExposureTime.Float64
+	}
+	if obj.ISO.Valid {
+		iso := int(obj.ISO.Int64)
+		ret.ISO = &iso
+	}
+	if obj.Latitude.Valid && obj.Longitude.Valid {
+		ret.Latitude = &obj.Latitude.Float64
+		ret.Longitude = &obj.Longitude.Float64
+	}
+
+	return ret, nil
 }
 
 func (r *imageResolver) Paths(ctx context.Context, obj *models.Image) (*models.ImagePathsType, error) {
@@ -56,6 +102,21 @@ func (r *imageResolver) Galleries(ctx context.Context, obj *models.Image) (ret [
 	return ret, nil
 }
 
+func (r *imageResolver) Folder(ctx context.Context, obj *models.Image) (ret *models.Folder, err error) {
+	if !obj.FolderID.Valid {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Folder().Find(int(obj.FolderID.Int64))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *imageResolver) Studio(ctx context.Context, obj *models.Image) (ret *models.Studio, err error) {
 	if !obj.StudioID.Valid {
 		return nil, nil
@@ -104,3 +165,25 @@ func (r *imageResolver) UpdatedAt(ctx context.Context, obj *models.Image) (*time
 func (r *imageResolver) FileModTime(ctx context.Context, obj *models.Image) (*time.Time, error) {
 	return &obj.FileModTime.Timestamp, nil
 }
+
+func (r *imageResolver) FileStatus(ctx context.Context, obj *models.Image) (*models.FileStatusEnum, error) {
+	if obj.FileStatus.Valid {
+		ret := models.FileStatusEnum(obj.FileStatus.String)
+		return &ret, nil
+	}
+	return nil, nil
+}
+
+func (r *imageResolver) FileStatusError(ctx context.Context, obj *models.Image) (*string, error) {
+	if obj.FileStatusError.Valid {
+		return &obj.FileStatusError.String, nil
+	}
+	return nil, nil
+}
+
+func (r *imageResolver) FileCheckedAt(ctx context.Context, obj *models.Image) (*time.Time, error) {
+	if obj.FileCheckedAt.Valid {
+		return &obj.FileCheckedAt.Timestamp, nil
+	}
+	return nil, nil
+}
@@ -210,6 +210,17 @@ func (r *performerResolver) StashIds(ctx context.Context, obj *models.Performer)
 	return ret, nil
 }
 
+func (r *performerResolver) FieldSources(ctx context.Context, obj *models.Performer) (ret []*models.FieldSource, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Performer().GetFieldSources(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *performerResolver) Rating(ctx context.Context, obj *models.Performer) (*int, error) {
 	if obj.Rating.Valid {
 		rating := int(obj.Rating.Int64)
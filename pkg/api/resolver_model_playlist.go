@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/api/urlbuilders"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/playlist"
+)
+
+func (r *playlistResolver) SavedFilter(ctx context.Context, obj *models.Playlist) (ret *models.SavedFilter, err error) {
+	if !obj.IsSmart() {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.SavedFilter().Find(int(obj.SavedFilterID.Int64))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *playlistResolver) Sort(ctx context.Context, obj *models.Playlist) (*string, error) {
+	if obj.Sort.Valid {
+		return &obj.Sort.String, nil
+	}
+	return nil, nil
+}
+
+func (r *playlistResolver) Direction(ctx context.Context, obj *models.Playlist) (*models.SortDirectionEnum, error) {
+	if obj.SortDirection.Valid {
+		direction := models.SortDirectionEnum(obj.SortDirection.String)
+		return &direction, nil
+	}
+	return nil, nil
+}
+
+func (r *playlistResolver) Limit(ctx context.Context, obj *models.Playlist) (*int, error) {
+	if obj.SceneLimit.Valid {
+		limit := int(obj.SceneLimit.Int64)
+		return &limit, nil
+	}
+	return nil, nil
+}
+
+func (r *playlistResolver) SceneCount(ctx context.Context, obj *models.Playlist) (ret int, err error) {
+	scenes, err := r.Scenes(ctx, obj)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(scenes), nil
+}
+
+func (r *playlistResolver) Scenes(ctx context.Context, obj *models.Playlist) (ret []*models.Scene, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = playlist.Scenes(repo, obj)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *playlistResolver) M3uPath(ctx context.Context, obj *models.Playlist) (string, error) {
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	return urlbuilders.NewPlaylistURLBuilder(baseURL, obj).GetM3UURL(), nil
+}
+
+func (r *playlistResolver) XspfPath(ctx context.Context, obj *models.Playlist) (string, error) {
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	return urlbuilders.NewPlaylistURLBuilder(baseURL, obj).GetXSPFURL(), nil
+}
+
+func (r *playlistResolver) CreatedAt(ctx context.Context, obj *models.Playlist) (*time.Time, error) {
+	return &obj.CreatedAt.Timestamp, nil
+}
+
+func (r *playlistResolver) UpdatedAt(ctx context.Context, obj *models.Playlist) (*time.Time, error) {
+	return &obj.UpdatedAt.Timestamp, nil
+}
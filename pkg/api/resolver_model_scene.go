@@ -85,6 +85,17 @@ func (r *sceneResolver) File(ctx context.Context, obj *models.Scene) (*models.Sc
 	}, nil
 }
 
+func (r *sceneResolver) Files(ctx context.Context, obj *models.Scene) (ret []*models.SceneFile, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.SceneFile().FindBySceneID(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*models.ScenePathsType, error) {
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj.ID)
@@ -114,6 +125,28 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*models.S
 	}, nil
 }
 
+func (r *sceneResolver) Captions(ctx context.Context, obj *models.Scene) (ret []*models.SceneCaption, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Scene().GetCaptions(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) AudioStreams(ctx context.Context, obj *models.Scene) (ret []*models.SceneAudioStream, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Scene().GetAudioStreams(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) SceneMarkers(ctx context.Context, obj *models.Scene) (ret []*models.SceneMarker, err error) {
 	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
 		ret, err = repo.SceneMarker().FindBySceneID(obj.ID)
@@ -136,6 +169,21 @@ func (r *sceneResolver) Galleries(ctx context.Context, obj *models.Scene) (ret [
 	return ret, nil
 }
 
+func (r *sceneResolver) Folder(ctx context.Context, obj *models.Scene) (ret *models.Folder, err error) {
+	if !obj.FolderID.Valid {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Folder().Find(int(obj.FolderID.Int64))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) Studio(ctx context.Context, obj *models.Scene) (ret *models.Studio, err error) {
 	if !obj.StudioID.Valid {
 		return nil, nil
@@ -220,6 +268,17 @@ func (r *sceneResolver) StashIds(ctx context.Context, obj *models.Scene) (ret []
 	return ret, nil
 }
 
+func (r *sceneResolver) FieldSources(ctx context.Context, obj *models.Scene) (ret []*models.FieldSource, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Scene().GetFieldSources(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) Phash(ctx context.Context, obj *models.Scene) (*string, error) {
 	if obj.Phash.Valid {
 		hexval := utils.PhashToString(obj.Phash.Int64)
@@ -239,3 +298,25 @@ func (r *sceneResolver) UpdatedAt(ctx context.Context, obj *models.Scene) (*time
 func (r *sceneResolver) FileModTime(ctx context.Context, obj *models.Scene) (*time.Time, error) {
 	return &obj.FileModTime.Timestamp, nil
 }
+
+func (r *sceneResolver) FileStatus(ctx context.Context, obj *models.Scene) (*models.FileStatusEnum, error) {
+	if obj.FileStatus.Valid {
+		ret := models.FileStatusEnum(obj.FileStatus.String)
+		return &ret, nil
+	}
+	return nil, nil
+}
+
+func (r *sceneResolver) FileStatusError(ctx context.Context, obj *models.Scene) (*string, error) {
+	if obj.FileStatusError.Valid {
+		return &obj.FileStatusError.String, nil
+	}
+	return nil, nil
+}
+
+func (r *sceneResolver) FileCheckedAt(ctx context.Context, obj *models.Scene) (*time.Time, error) {
+	if obj.FileCheckedAt.Valid {
+		return &obj.FileCheckedAt.Timestamp, nil
+	}
+	return nil, nil
+}
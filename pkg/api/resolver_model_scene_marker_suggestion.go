@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *sceneMarkerSuggestionResolver) Scene(ctx context.Context, obj *models.SceneMarkerSuggestion) (ret *models.Scene, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Scene().Find(obj.SceneID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneMarkerSuggestionResolver) PrimaryTag(ctx context.Context, obj *models.SceneMarkerSuggestion) (ret *models.Tag, err error) {
+	if !obj.PrimaryTagID.Valid {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Tag().Find(int(obj.PrimaryTagID.Int64))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneMarkerSuggestionResolver) CreatedAt(ctx context.Context, obj *models.SceneMarkerSuggestion) (*time.Time, error) {
+	return &obj.CreatedAt.Timestamp, nil
+}
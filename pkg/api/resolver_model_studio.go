@@ -129,6 +129,17 @@ func (r *studioResolver) StashIds(ctx context.Context, obj *models.Studio) (ret
 	return ret, nil
 }
 
+func (r *studioResolver) FieldSources(ctx context.Context, obj *models.Studio) (ret []*models.FieldSource, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Studio().GetFieldSources(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *studioResolver) Rating(ctx context.Context, obj *models.Studio) (*int, error) {
 	if obj.Rating.Valid {
 		rating := int(obj.Rating.Int64)
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/api/urlbuilders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *videoCaptionResolver) ID(ctx context.Context, obj *models.SceneCaption) (string, error) {
+	return strconv.Itoa(obj.ID), nil
+}
+
+func (r *videoCaptionResolver) URL(ctx context.Context, obj *models.SceneCaption) (string, error) {
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj.SceneID)
+	return builder.GetCaptionURL(obj.ID), nil
+}
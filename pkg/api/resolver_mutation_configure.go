@@ -10,6 +10,7 @@ import (
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -45,6 +46,12 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input models.Co
 					return makeConfigGeneralResult(), err
 				}
 			}
+
+			if s.OrganizeTemplate != nil {
+				if err := scene.ValidateOrganizeTemplate(*s.OrganizeTemplate); err != nil {
+					return makeConfigGeneralResult(), err
+				}
+			}
 		}
 		c.Set(config.Stash, input.Stashes)
 	}
@@ -84,6 +91,30 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input models.Co
 		c.Set(config.Database, input.DatabasePath)
 	}
 
+	if input.BackupDirectory != nil {
+		if err := validateDir(config.BackupDirectory, *input.BackupDirectory, true); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+
+		c.Set(config.BackupDirectory, input.BackupDirectory)
+	}
+
+	if input.BackupCount != nil {
+		if *input.BackupCount < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("backup count must not be negative")
+		}
+
+		c.Set(config.BackupCount, *input.BackupCount)
+	}
+
+	if input.BackupInterval != nil {
+		if *input.BackupInterval < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("backup interval must not be negative")
+		}
+
+		c.Set(config.BackupInterval, *input.BackupInterval)
+	}
+
 	existingGeneratedPath := c.GetGeneratedPath()
 	if input.GeneratedPath != nil && existingGeneratedPath != *input.GeneratedPath {
 		if err := validateDir(config.Generated, *input.GeneratedPath, false); err != nil {
@@ -167,6 +198,19 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input models.Co
 		c.Set(config.PreviewPreset, input.PreviewPreset.String())
 	}
 
+	if input.ChapterDetectionThreshold != nil {
+		if *input.ChapterDetectionThreshold <= 0 || *input.ChapterDetectionThreshold >= 1 {
+			return makeConfigGeneralResult(), fmt.Errorf("chapter detection threshold must be between 0 and 1")
+		}
+		c.Set(config.ChapterDetectionThreshold, *input.ChapterDetectionThreshold)
+	}
+	if input.ChapterMinimumLength != nil {
+		c.Set(config.ChapterMinimumLength, *input.ChapterMinimumLength)
+	}
+	if input.ChapterPrimaryTag != nil {
+		c.Set(config.ChapterPrimaryTag, *input.ChapterPrimaryTag)
+	}
+
 	if input.MaxTranscodeSize != nil {
 		c.Set(config.MaxTranscodeSize, input.MaxTranscodeSize.String())
 	}
@@ -238,6 +282,18 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input models.Co
 		c.Set(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
 	}
 
+	if input.CreateImageClipsFromVideos != nil {
+		c.Set(config.CreateImageClipsFromVideos, input.CreateImageClipsFromVideos)
+	}
+
+	if input.CreateImageKeywordTags != nil {
+		c.Set(config.CreateImageKeywordTags, input.CreateImageKeywordTags)
+	}
+
+	if input.SetImageMetadataRating != nil {
+		c.Set(config.SetImageMetadataRating, input.SetImageMetadataRating)
+	}
+
 	if input.CustomPerformerImageLocation != nil {
 		c.Set(config.CustomPerformerImageLocation, *input.CustomPerformerImageLocation)
 		initialiseCustomImages()
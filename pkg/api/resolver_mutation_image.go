@@ -99,6 +99,7 @@ func (r *mutationResolver) imageUpdate(input models.ImageUpdateInput, translator
 	}
 
 	updatedImage.Title = translator.nullString(input.Title, "title")
+	updatedImage.Date = translator.sqliteDate(input.Date, "date")
 	updatedImage.Rating = translator.nullInt64(input.Rating, "rating")
 	updatedImage.StudioID = translator.nullInt64FromString(input.StudioID, "studio_id")
 	updatedImage.Organized = input.Organized
@@ -174,6 +175,7 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input models.Bul
 	}
 
 	updatedImage.Title = translator.nullString(input.Title, "title")
+	updatedImage.Date = translator.sqliteDate(input.Date, "date")
 	updatedImage.Rating = translator.nullInt64(input.Rating, "rating")
 	updatedImage.StudioID = translator.nullInt64FromString(input.StudioID, "studio_id")
 	updatedImage.Organized = input.Organized
@@ -102,6 +102,21 @@ func (r *mutationResolver) MetadataClean(ctx context.Context, input models.Clean
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataOrganize(ctx context.Context, input models.OrganizeInput) (string, error) {
+	jobID := manager.GetInstance().Organize(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataExportNfo(ctx context.Context, input models.ExportNfoInput) (string, error) {
+	jobID := manager.GetInstance().ExportNfo(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataVerify(ctx context.Context, input models.VerifyMetadataInput) (string, error) {
+	jobID := manager.GetInstance().Verify(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MigrateHashNaming(ctx context.Context) (string, error) {
 	jobID := manager.GetInstance().MigrateHash(ctx)
 	return strconv.Itoa(jobID), nil
@@ -124,12 +139,17 @@ func (r *mutationResolver) BackupDatabase(ctx context.Context, input models.Back
 		backupPath = f.Name()
 		f.Close()
 	} else {
-		backupPath = database.DatabaseBackupPath()
+		var err error
+		backupPath, err = mgr.BackupDatabase()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err := database.Backup(database.DB, backupPath)
-	if err != nil {
-		return nil, err
+	if download {
+		if err := database.Backup(database.DB, backupPath); err != nil {
+			return nil, err
+		}
 	}
 
 	if download {
@@ -147,3 +167,11 @@ func (r *mutationResolver) BackupDatabase(ctx context.Context, input models.Back
 
 	return nil, nil
 }
+
+func (r *mutationResolver) RestoreDatabase(ctx context.Context, backup string) (bool, error) {
+	if err := manager.GetInstance().RestoreDatabase(ctx, backup); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -13,6 +13,32 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
+// performerTrackedFields maps performer input fields to the performer fields
+// that have their provenance tracked.
+var performerTrackedFields = map[string]string{
+	"name":          "name",
+	"url":           "url",
+	"gender":        "gender",
+	"birthdate":     "birthdate",
+	"ethnicity":     "ethnicity",
+	"country":       "country",
+	"eye_color":     "eye_color",
+	"height":        "height",
+	"measurements":  "measurements",
+	"fake_tits":     "fake_tits",
+	"career_length": "career_length",
+	"tattoos":       "tattoos",
+	"piercings":     "piercings",
+	"aliases":       "aliases",
+	"twitter":       "twitter",
+	"instagram":     "instagram",
+	"details":       "details",
+	"death_date":    "death_date",
+	"hair_color":    "hair_color",
+	"weight":        "weight",
+	"tag_ids":       "tags",
+}
+
 func (r *mutationResolver) getPerformer(ctx context.Context, id int) (ret *models.Performer, err error) {
 	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
 		ret, err = repo.Performer().Find(id)
@@ -248,6 +274,10 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 			return err
 		}
 
+		if err := qb.UpdateFieldSources(p.ID, translator.userFieldSources(performerTrackedFields)); err != nil {
+			return err
+		}
+
 		// Save the tags
 		if translator.hasField("tag_ids") {
 			if err := r.updatePerformerTags(qb, p.ID, input.TagIds); err != nil {
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+var errPlaylistSceneIDs = errors.New("scene ids cannot be set for a smart playlist")
+
+func (r *mutationResolver) getPlaylist(ctx context.Context, id int) (ret *models.Playlist, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Playlist().Find(id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// validatePlaylistSavedFilter returns an error if the saved filter does not
+// exist or does not filter scenes.
+func validatePlaylistSavedFilter(qb models.SavedFilterReader, savedFilterID int64) error {
+	savedFilter, err := qb.Find(int(savedFilterID))
+	if err != nil {
+		return err
+	}
+
+	if savedFilter == nil {
+		return fmt.Errorf("saved filter with id %d not found", savedFilterID)
+	}
+
+	if savedFilter.Mode != models.FilterModeScenes {
+		return fmt.Errorf("saved filter %q is not a scene filter", savedFilter.Name)
+	}
+
+	return nil
+}
+
+func validatePlaylistLimit(limit *int) error {
+	if limit != nil && *limit <= 0 {
+		return errors.New("limit must be greater than zero")
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) PlaylistCreate(ctx context.Context, input models.PlaylistCreateInput) (*models.Playlist, error) {
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, errors.New("name must be non-empty")
+	}
+
+	if input.SavedFilterID != nil && len(input.SceneIds) > 0 {
+		return nil, errPlaylistSceneIDs
+	}
+
+	if err := validatePlaylistLimit(input.Limit); err != nil {
+		return nil, err
+	}
+
+	sceneIDs, err := utils.StringSliceToIntSlice(input.SceneIds)
+	if err != nil {
+		return nil, err
+	}
+
+	newPlaylist := models.NewPlaylist(input.Name)
+
+	if input.SavedFilterID != nil {
+		savedFilterID, err := strconv.ParseInt(*input.SavedFilterID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		newPlaylist.SavedFilterID = sql.NullInt64{Int64: savedFilterID, Valid: true}
+	}
+
+	if input.Sort != nil {
+		newPlaylist.Sort = sql.NullString{String: *input.Sort, Valid: true}
+	}
+
+	if input.Direction != nil {
+		newPlaylist.SortDirection = sql.NullString{String: input.Direction.String(), Valid: true}
+	}
+
+	if input.Limit != nil {
+		newPlaylist.SceneLimit = sql.NullInt64{Int64: int64(*input.Limit), Valid: true}
+	}
+
+	var playlist *models.Playlist
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		if newPlaylist.IsSmart() {
+			if err := validatePlaylistSavedFilter(repo.SavedFilter(), newPlaylist.SavedFilterID.Int64); err != nil {
+				return err
+			}
+		}
+
+		qb := repo.Playlist()
+		playlist, err = qb.Create(*newPlaylist)
+		if err != nil {
+			return err
+		}
+
+		if len(sceneIDs) > 0 {
+			if err := qb.UpdateScenes(playlist.ID, sceneIDs); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.getPlaylist(ctx, playlist.ID)
+}
+
+func (r *mutationResolver) PlaylistUpdate(ctx context.Context, input models.PlaylistUpdateInput) (*models.Playlist, error) {
+	playlistID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil && strings.TrimSpace(*input.Name) == "" {
+		return nil, errors.New("name must be non-empty")
+	}
+
+	if err := validatePlaylistLimit(input.Limit); err != nil {
+		return nil, err
+	}
+
+	updatedPlaylist := models.PlaylistPartial{
+		ID:        playlistID,
+		Name:      input.Name,
+		UpdatedAt: &models.SQLiteTimestamp{Timestamp: time.Now()},
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	updatedPlaylist.SavedFilterID = translator.nullInt64FromString(input.SavedFilterID, "saved_filter_id")
+	updatedPlaylist.Sort = translator.nullString(input.Sort, "sort")
+	updatedPlaylist.SceneLimit = translator.nullInt64(input.Limit, "limit")
+
+	if translator.hasField("direction") {
+		updatedPlaylist.SortDirection = &sql.NullString{}
+		if input.Direction != nil {
+			updatedPlaylist.SortDirection.String = input.Direction.String()
+			updatedPlaylist.SortDirection.Valid = true
+		}
+	}
+
+	var sceneIDs []int
+	sceneIDsIncluded := translator.hasField("scene_ids")
+	if sceneIDsIncluded {
+		sceneIDs, err = utils.StringSliceToIntSlice(input.SceneIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		if updatedPlaylist.SavedFilterID != nil && updatedPlaylist.SavedFilterID.Valid {
+			if err := validatePlaylistSavedFilter(repo.SavedFilter(), updatedPlaylist.SavedFilterID.Int64); err != nil {
+				return err
+			}
+		}
+
+		qb := repo.Playlist()
+		playlist, err := qb.Update(updatedPlaylist)
+		if err != nil {
+			return err
+		}
+
+		if playlist.IsSmart() {
+			if len(sceneIDs) > 0 {
+				return errPlaylistSceneIDs
+			}
+
+			// smart playlists have no scenes of their own
+			sceneIDsIncluded = updatedPlaylist.SavedFilterID != nil
+		}
+
+		if sceneIDsIncluded {
+			return qb.UpdateScenes(playlist.ID, sceneIDs)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.getPlaylist(ctx, playlistID)
+}
+
+func (r *mutationResolver) PlaylistDestroy(ctx context.Context, input models.PlaylistDestroyInput) (bool, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		return repo.Playlist().Destroy(id)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
@@ -16,6 +17,19 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
+// sceneTrackedFields maps scene input fields to the scene fields that have
+// their provenance tracked.
+var sceneTrackedFields = map[string]string{
+	"title":         "title",
+	"details":       "details",
+	"url":           "url",
+	"date":          "date",
+	"rating":        "rating",
+	"studio_id":     "studio",
+	"performer_ids": "performers",
+	"tag_ids":       "tags",
+}
+
 func (r *mutationResolver) getScene(ctx context.Context, id int) (ret *models.Scene, err error) {
 	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
 		ret, err = repo.Scene().Find(id)
@@ -126,6 +140,10 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	if err := qb.UpdateFieldSources(sceneID, translator.fieldSources(sceneTrackedFields, input.FieldSources)); err != nil {
+		return nil, err
+	}
+
 	// update cover table
 	if len(coverImageData) > 0 {
 		if err := qb.UpdateCover(sceneID, coverImageData); err != nil {
@@ -271,6 +289,10 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input models.Bul
 
 			ret = append(ret, scene)
 
+			if err := qb.UpdateFieldSources(sceneID, translator.userFieldSources(sceneTrackedFields)); err != nil {
+				return err
+			}
+
 			// Save the performers
 			if translator.hasField("performer_ids") {
 				performerIDs, err := adjustScenePerformerIDs(qb, sceneID, *input.PerformerIds)
@@ -697,6 +719,84 @@ func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (b
 	return true, nil
 }
 
+func (r *mutationResolver) SceneMarkerSuggestionsAccept(ctx context.Context, input models.SceneMarkerSuggestionsAcceptInput) ([]*models.SceneMarker, error) {
+	ids, err := utils.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var primaryTagID *int
+	if input.PrimaryTagID != nil {
+		id, err := strconv.Atoi(*input.PrimaryTagID)
+		if err != nil {
+			return nil, err
+		}
+		primaryTagID = &id
+	}
+
+	var ret []*models.SceneMarker
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.SceneMarker()
+
+		suggestions, err := qb.FindSuggestions(ids)
+		if err != nil {
+			return err
+		}
+
+		currentTime := time.Now()
+		for _, s := range suggestions {
+			newSceneMarker := models.SceneMarker{
+				Title:     s.Title,
+				Seconds:   s.Seconds,
+				SceneID:   sql.NullInt64{Int64: int64(s.SceneID), Valid: true},
+				CreatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+				UpdatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+			}
+
+			switch {
+			case primaryTagID != nil:
+				newSceneMarker.PrimaryTagID = *primaryTagID
+			case s.PrimaryTagID.Valid:
+				newSceneMarker.PrimaryTagID = int(s.PrimaryTagID.Int64)
+			default:
+				return fmt.Errorf("primary tag is required for suggestion %q", s.Title)
+			}
+
+			marker, err := qb.Create(newSceneMarker)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, marker)
+		}
+
+		return qb.DestroySuggestions(ids)
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, marker := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, marker.ID, plugin.SceneMarkerCreatePost, input, nil)
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneMarkerSuggestionsReject(ctx context.Context, ids []string) (bool, error) {
+	suggestionIDs, err := utils.StringSliceToIntSlice(ids)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		return repo.SceneMarker().RejectSuggestions(suggestionIDs)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) changeMarker(ctx context.Context, changeType int, changedMarker models.SceneMarker, tagIDs []int) (*models.SceneMarker, error) {
 	var existingMarker *models.SceneMarker
 	var sceneMarker *models.SceneMarker
@@ -821,3 +921,224 @@ func (r *mutationResolver) SceneGenerateScreenshot(ctx context.Context, id strin
 
 	return "todo", nil
 }
+
+func (r *mutationResolver) SceneTrim(ctx context.Context, id string, ranges []*models.TrimRangeInput, mode *models.SceneTrimMode) (string, error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return "", err
+	}
+
+	var removed []ffmpeg.TrimRange
+	for _, r := range ranges {
+		removed = append(removed, ffmpeg.TrimRange{Start: r.Start, End: r.End})
+	}
+
+	// validate the ranges before queueing the job
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		s, err := repo.Scene().Find(sceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		if s.Duration.Valid {
+			_, err = scene.KeptRanges(removed, s.Duration.Float64)
+		}
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	trimMode := models.SceneTrimModeKeepOriginal
+	if mode != nil {
+		trimMode = *mode
+	}
+
+	jobID := manager.GetInstance().Trim(ctx, sceneID, removed, trimMode)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) SceneSetCoverCandidate(ctx context.Context, id string, at float64) (*models.Scene, error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := r.getScene(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("scene with id %d not found", sceneID)
+	}
+
+	coverImageData, err := manager.GetSceneFrameCover(s, at)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting frame: %v", err)
+	}
+
+	updater := scene.UpdateSet{
+		ID:         sceneID,
+		CoverImage: coverImageData,
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		_, err := updater.Update(repo.Scene(), &scene.PathsScreenshotSetter{
+			Paths:               manager.GetInstance().Paths,
+			FileNamingAlgorithm: config.GetInstance().GetVideoFileNamingAlgorithm(),
+		})
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, sceneID, plugin.SceneUpdatePost, updater.UpdateInput(), nil)
+	return r.getScene(ctx, sceneID)
+}
+
+func (r *mutationResolver) SceneAssignFile(ctx context.Context, input models.SceneAssignFileInput) (*models.Scene, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileID, err := strconv.Atoi(input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr := manager.GetInstance()
+	fileNamingAlgo := mgr.Config.GetVideoFileNamingAlgorithm()
+
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        *file.NewDeleter(),
+		FileNamingAlgo: fileNamingAlgo,
+		Paths:          mgr.Paths,
+	}
+
+	var origin *models.Scene
+	var oldHash string
+	originDestroyed := false
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.Scene()
+		fqb := repo.SceneFile()
+
+		destination, err := qb.Find(sceneID)
+		if err != nil {
+			return err
+		}
+
+		if destination == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		f, err := fqb.Find(fileID)
+		if err != nil {
+			return err
+		}
+
+		if f == nil {
+			return fmt.Errorf("file with id %d not found", fileID)
+		}
+
+		if f.Primary {
+			current, err := qb.Find(f.SceneID)
+			if err != nil {
+				return err
+			}
+
+			if current != nil {
+				// kill any running encoders of the file
+				manager.KillRunningStreams(current, fileNamingAlgo)
+				oldHash = current.GetHash(fileNamingAlgo)
+			}
+		}
+
+		var remaining int
+		origin, remaining, err = scene.AssignFile(qb, fqb, f, destination)
+		if err != nil {
+			return err
+		}
+
+		if remaining == 0 {
+			originDestroyed = true
+			return scene.Destroy(origin, repo, fileDeleter, true, false)
+		}
+
+		return nil
+	}); err != nil {
+		fileDeleter.Rollback()
+		return nil, err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+
+	if originDestroyed {
+		r.hookExecutor.ExecutePostHooks(ctx, origin.ID, plugin.SceneDestroyPost, plugin.SceneDestroyInput{
+			Checksum: origin.Checksum.String,
+			OSHash:   origin.OSHash.String,
+			Path:     origin.Path,
+		}, nil)
+	} else {
+		if newHash := origin.GetHash(fileNamingAlgo); oldHash != "" && newHash != oldHash {
+			scene.MigrateHash(mgr.Paths, oldHash, newHash)
+		}
+
+		r.hookExecutor.ExecutePostHooks(ctx, origin.ID, plugin.SceneUpdatePost, input, nil)
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, sceneID, plugin.SceneUpdatePost, input, nil)
+
+	return r.getScene(ctx, sceneID)
+}
+
+func (r *mutationResolver) SceneSetPrimaryFile(ctx context.Context, input models.SceneSetPrimaryFileInput) (*models.Scene, error) {
+	sceneID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileID, err := strconv.Atoi(input.FileID)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr := manager.GetInstance()
+	fileNamingAlgo := mgr.Config.GetVideoFileNamingAlgorithm()
+
+	var s *models.Scene
+	var oldHash string
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.Scene()
+		s, err = qb.Find(sceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		// kill any running encoders of the current primary file
+		manager.KillRunningStreams(s, fileNamingAlgo)
+
+		oldHash = s.GetHash(fileNamingAlgo)
+		return scene.SetPrimaryFile(qb, repo.SceneFile(), s, fileID)
+	}); err != nil {
+		return nil, err
+	}
+
+	if newHash := s.GetHash(fileNamingAlgo); newHash != oldHash {
+		scene.MigrateHash(mgr.Paths, oldHash, newHash)
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, s.ID, plugin.SceneUpdatePost, input, nil)
+
+	return r.getScene(ctx, sceneID)
+}
@@ -14,6 +14,16 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
+// studioTrackedFields maps studio input fields to the studio fields that
+// have their provenance tracked.
+var studioTrackedFields = map[string]string{
+	"name":      "name",
+	"url":       "url",
+	"parent_id": "parent_studio",
+	"details":   "details",
+	"aliases":   "aliases",
+}
+
 func (r *mutationResolver) getStudio(ctx context.Context, id int) (ret *models.Studio, err error) {
 	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
 		ret, err = repo.Studio().Find(id)
@@ -162,6 +172,10 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 			return err
 		}
 
+		if err := qb.UpdateFieldSources(s.ID, translator.userFieldSources(studioTrackedFields)); err != nil {
+			return err
+		}
+
 		// update image table
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(s.ID, imageData); err != nil {
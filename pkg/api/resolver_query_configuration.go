@@ -65,6 +65,9 @@ func makeConfigGeneralResult() *models.ConfigGeneralResult {
 	return &models.ConfigGeneralResult{
 		Stashes:                      config.GetStashPaths(),
 		DatabasePath:                 config.GetDatabasePath(),
+		BackupDirectory:              config.GetBackupDirectory(),
+		BackupCount:                  config.GetBackupCount(),
+		BackupInterval:               config.GetBackupInterval(),
 		GeneratedPath:                config.GetGeneratedPath(),
 		MetadataPath:                 config.GetMetadataPath(),
 		ConfigFilePath:               config.GetConfigFile(),
@@ -79,6 +82,9 @@ func makeConfigGeneralResult() *models.ConfigGeneralResult {
 		PreviewExcludeStart:          config.GetPreviewExcludeStart(),
 		PreviewExcludeEnd:            config.GetPreviewExcludeEnd(),
 		PreviewPreset:                config.GetPreviewPreset(),
+		ChapterDetectionThreshold:    config.GetChapterDetectionThreshold(),
+		ChapterMinimumLength:         config.GetChapterMinimumLength(),
+		ChapterPrimaryTag:            config.GetChapterPrimaryTag(),
 		MaxTranscodeSize:             &maxTranscodeSize,
 		MaxStreamingTranscodeSize:    &maxStreamingTranscodeSize,
 		WriteImageThumbnails:         config.IsWriteImageThumbnails(),
@@ -94,6 +100,9 @@ func makeConfigGeneralResult() *models.ConfigGeneralResult {
 		ImageExtensions:              config.GetImageExtensions(),
 		GalleryExtensions:            config.GetGalleryExtensions(),
 		CreateGalleriesFromFolders:   config.GetCreateGalleriesFromFolders(),
+		CreateImageClipsFromVideos:   config.GetCreateImageClipsFromVideos(),
+		CreateImageKeywordTags:       config.GetCreateImageKeywordTags(),
+		SetImageMetadataRating:       config.GetSetImageMetadataRating(),
 		Excludes:                     config.GetExcludes(),
 		ImageExcludes:                config.GetImageExcludes(),
 		CustomPerformerImageLocation: &customPerformerImageLocation,
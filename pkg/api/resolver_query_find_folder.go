@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindFolder(ctx context.Context, id string) (ret *models.Folder, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.Folder().Find(idInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindFolders(ctx context.Context, parentFolderID *string, filter *models.FindFilterType) (ret *models.FindFoldersResultType, err error) {
+	var parentID *int
+	if parentFolderID != nil {
+		id, err := strconv.Atoi(*parentFolderID)
+		if err != nil {
+			return nil, err
+		}
+		parentID = &id
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		folders, total, err := repo.Folder().Query(parentID, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &models.FindFoldersResultType{
+			Count:   total,
+			Folders: folders,
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
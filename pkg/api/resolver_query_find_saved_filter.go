@@ -2,8 +2,11 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/savedfilter"
 )
 
 func (r *queryResolver) FindSavedFilters(ctx context.Context, mode models.FilterMode) (ret []*models.SavedFilter, err error) {
@@ -25,3 +28,105 @@ func (r *queryResolver) FindDefaultFilter(ctx context.Context, mode models.Filte
 	}
 	return ret, err
 }
+
+func (r *queryResolver) getSavedFilter(ctx context.Context, id string) (ret *models.SavedFilter, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.SavedFilter().Find(idInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		return nil, fmt.Errorf("saved filter with id %s not found", id)
+	}
+
+	return ret, nil
+}
+
+// overrideFindFilter sets the non-nil fields of override in the find filter
+// of a saved filter.
+func overrideFindFilter(findFilter *models.FindFilterType, override *models.FindFilterType) *models.FindFilterType {
+	if override == nil {
+		return findFilter
+	}
+
+	if override.Q != nil {
+		findFilter.Q = override.Q
+	}
+	if override.Page != nil {
+		findFilter.Page = override.Page
+	}
+	if override.PerPage != nil {
+		findFilter.PerPage = override.PerPage
+	}
+	if override.Sort != nil {
+		findFilter.Sort = override.Sort
+	}
+	if override.Direction != nil {
+		findFilter.Direction = override.Direction
+	}
+
+	return findFilter
+}
+
+func (r *queryResolver) FindScenesBySavedFilter(ctx context.Context, id string, filter *models.FindFilterType) (*models.FindScenesResultType, error) {
+	savedFilter, err := r.getSavedFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sceneFilter, findFilter, err := savedfilter.SceneFilter(savedFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindScenes(ctx, sceneFilter, nil, overrideFindFilter(findFilter, filter))
+}
+
+func (r *queryResolver) FindImagesBySavedFilter(ctx context.Context, id string, filter *models.FindFilterType) (*models.FindImagesResultType, error) {
+	savedFilter, err := r.getSavedFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	imageFilter, findFilter, err := savedfilter.ImageFilter(savedFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindImages(ctx, imageFilter, nil, overrideFindFilter(findFilter, filter))
+}
+
+func (r *queryResolver) FindGalleriesBySavedFilter(ctx context.Context, id string, filter *models.FindFilterType) (*models.FindGalleriesResultType, error) {
+	savedFilter, err := r.getSavedFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	galleryFilter, findFilter, err := savedfilter.GalleryFilter(savedFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindGalleries(ctx, galleryFilter, overrideFindFilter(findFilter, filter))
+}
+
+func (r *queryResolver) FindPerformersBySavedFilter(ctx context.Context, id string, filter *models.FindFilterType) (*models.FindPerformersResultType, error) {
+	savedFilter, err := r.getSavedFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	performerFilter, findFilter, err := savedfilter.PerformerFilter(savedFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindPerformers(ctx, performerFilter, overrideFindFilter(findFilter, filter))
+}
@@ -194,6 +194,17 @@ func (r *queryResolver) ParseSceneFilenames(ctx context.Context, filter *models.
 	return ret, nil
 }
 
+func (r *queryResolver) OrganizePreview(ctx context.Context, input models.OrganizeInput) (ret []*models.OrganizeOperation, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = manager.GetOrganizeOperations(ctx, repo, input)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) FindDuplicateScenes(ctx context.Context, distance *int) (ret [][]*models.Scene, err error) {
 	dist := 0
 	if distance != nil {
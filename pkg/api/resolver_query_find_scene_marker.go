@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -24,3 +25,23 @@ func (r *queryResolver) FindSceneMarkers(ctx context.Context, sceneMarkerFilter
 
 	return ret, nil
 }
+
+func (r *queryResolver) FindSceneMarkerSuggestions(ctx context.Context, sceneID *string) (ret []*models.SceneMarkerSuggestion, err error) {
+	var id *int
+	if sceneID != nil {
+		sid, err := strconv.Atoi(*sceneID)
+		if err != nil {
+			return nil, err
+		}
+		id = &sid
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.SceneMarker().GetSuggestions(id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -10,3 +10,7 @@ import (
 func (r *queryResolver) SystemStatus(ctx context.Context) (*models.SystemStatus, error) {
 	return manager.GetInstance().GetSystemStatus(), nil
 }
+
+func (r *queryResolver) DatabaseBackups(ctx context.Context) ([]*models.DatabaseBackup, error) {
+	return manager.GetInstance().ListDatabaseBackups()
+}
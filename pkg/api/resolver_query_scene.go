@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*models.SceneStreamEndpoint, error) {
@@ -32,3 +34,53 @@ func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*models
 
 	return manager.GetSceneStreamPaths(scene, builder.GetStreamURL(), config.GetInstance().GetMaxStreamingTranscodeSize())
 }
+
+func (r *queryResolver) SceneCoverCandidates(ctx context.Context, id string, count *int) ([]*models.SceneCoverCandidate, error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var scene *models.Scene
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		var err error
+		scene, err = repo.Scene().Find(sceneID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if scene == nil {
+		return nil, fmt.Errorf("scene with id %d not found", sceneID)
+	}
+
+	n := 0
+	if count != nil {
+		n = *count
+	}
+
+	candidates, err := manager.GetSceneCoverCandidates(scene, n)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*models.SceneCoverCandidate
+	for _, c := range candidates {
+		data, err := c.JPEG()
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, &models.SceneCoverCandidate{
+			At:         c.At,
+			Image:      "data:image/jpeg;base64," + utils.GetBase64StringFromData(data),
+			Brightness: c.Brightness,
+			Contrast:   c.Contrast,
+			Sharpness:  c.Sharpness,
+			Score:      c.Score,
+			Poor:       c.IsPoor(),
+		})
+	}
+
+	return ret, nil
+}
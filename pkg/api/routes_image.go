@@ -34,17 +34,40 @@ func (rs imageRoutes) Routes() chi.Router {
 
 func (rs imageRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
 	img := r.Context().Value(imageKey).(*models.Image)
-	filepath := manager.GetInstance().Paths.Generated.GetThumbnailPath(img.Checksum, models.DefaultGthumbWidth)
+
+	size := models.DefaultGthumbWidth
+	if sizeParam := r.URL.Query().Get("size"); sizeParam != "" {
+		requested, err := strconv.Atoi(sizeParam)
+		if err != nil || requested <= 0 {
+			http.Error(w, "invalid size", http.StatusBadRequest)
+			return
+		}
+		size = image.GetThumbnailSize(requested)
+	}
+
+	format := image.NegotiateThumbnailFormat(r.Header.Get("Accept"))
+	if !image.IsThumbnailFormatSupported(format) {
+		format = image.ThumbnailFormatJPEG
+	}
+	w.Header().Add("Vary", "Accept")
+
+	// thumbnails of animated images have a fixed format
+	format = image.GetThumbnailFormat(img, size, format)
+
+	paths := manager.GetInstance().Paths
+	filepath := image.GetThumbnailPath(paths, img.Checksum, size, format)
 
 	w.Header().Add("Cache-Control", "max-age=604800000")
 
 	// if the thumbnail doesn't exist, encode on the fly
 	exists, _ := utils.FileExists(filepath)
 	if exists {
+		// the mime type of some formats is not known on all systems
+		w.Header().Set("Content-Type", format.MimeType())
 		http.ServeFile(w, r, filepath)
 	} else {
 		encoder := image.NewThumbnailEncoder(manager.GetInstance().FFMPEG)
-		data, err := encoder.GetThumbnail(img, models.DefaultGthumbWidth)
+		data, encodedFormat, err := encoder.GetThumbnailWithFallback(img, size, format)
 		if err != nil {
 			logger.Errorf("error generating thumbnail for image: %s", err.Error())
 
@@ -53,12 +76,19 @@ func (rs imageRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if encodedFormat != format {
+			format = encodedFormat
+			filepath = image.GetThumbnailPath(paths, img.Checksum, size, format)
+		}
+
 		// write the generated thumbnail to disk if enabled
 		if manager.GetInstance().Config.IsWriteImageThumbnails() {
 			if err := utils.WriteFile(filepath, data); err != nil {
 				logger.Errorf("error writing thumbnail for image %s: %s", img.Path, err)
 			}
 		}
+
+		w.Header().Set("Content-Type", format.MimeType())
 		if n, err := w.Write(data); err != nil {
 			logger.Errorf("error writing thumbnail response. Wrote %v bytes: %v", n, err)
 		}
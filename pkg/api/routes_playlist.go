@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/stashapp/stash/pkg/api/urlbuilders"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/playlist"
+)
+
+type playlistRoutes struct {
+	txnManager models.TransactionManager
+}
+
+func (rs playlistRoutes) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{playlistId}", func(r chi.Router) {
+		r.Use(PlaylistCtx)
+		r.Get("/m3u", rs.M3U)
+		r.Get("/xspf", rs.XSPF)
+	})
+
+	return r
+}
+
+// entries returns the export entries of the scenes of the playlist in the
+// request context.
+func (rs playlistRoutes) entries(r *http.Request) ([]playlist.Entry, error) {
+	p := r.Context().Value(playlistKey).(*models.Playlist)
+
+	var scenes []*models.Scene
+	if err := rs.txnManager.WithReadTxn(r.Context(), func(repo models.ReaderRepository) error {
+		var err error
+		scenes, err = playlist.Scenes(repo, p)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	baseURL, _ := r.Context().Value(BaseURLCtxKey).(string)
+	var ret []playlist.Entry
+	for _, s := range scenes {
+		builder := urlbuilders.NewSceneURLBuilder(baseURL, s.ID)
+		ret = append(ret, playlist.Entry{
+			Title:    s.GetTitle(),
+			Duration: s.Duration.Float64,
+			URL:      builder.GetStreamURL(),
+			ImageURL: builder.GetScreenshotURL(s.UpdatedAt.Timestamp),
+		})
+	}
+
+	return ret, nil
+}
+
+func (rs playlistRoutes) M3U(w http.ResponseWriter, r *http.Request) {
+	entries, err := rs.entries(r)
+	if err != nil {
+		logger.Errorf("error getting playlist scenes: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "audio/x-mpegurl")
+	if err := playlist.WriteM3U(w, entries); err != nil {
+		logger.Warnf("error writing m3u playlist: %v", err)
+	}
+}
+
+func (rs playlistRoutes) XSPF(w http.ResponseWriter, r *http.Request) {
+	p := r.Context().Value(playlistKey).(*models.Playlist)
+
+	entries, err := rs.entries(r)
+	if err != nil {
+		logger.Errorf("error getting playlist scenes: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xspf+xml")
+	if err := playlist.WriteXSPF(w, p.Name, entries); err != nil {
+		logger.Warnf("error writing xspf playlist: %v", err)
+	}
+}
+
+func PlaylistCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		playlistID, err := strconv.Atoi(chi.URLParam(r, "playlistId"))
+		if err != nil {
+			http.Error(w, http.StatusText(404), 404)
+			return
+		}
+
+		var p *models.Playlist
+		if err := manager.GetInstance().TxnManager.WithReadTxn(r.Context(), func(repo models.ReaderRepository) error {
+			var err error
+			p, err = repo.Playlist().Find(playlistID)
+			return err
+		}); err != nil || p == nil {
+			http.Error(w, http.StatusText(404), 404)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), playlistKey, p)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
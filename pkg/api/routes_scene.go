@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -26,18 +27,23 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Use(SceneCtx)
 
 		// streaming endpoints
-		r.Get("/stream", rs.StreamDirect)
-		r.Get("/stream.mkv", rs.StreamMKV)
-		r.Get("/stream.webm", rs.StreamWebM)
-		r.Get("/stream.m3u8", rs.StreamHLS)
-		r.Get("/stream.ts", rs.StreamTS)
-		r.Get("/stream.mp4", rs.StreamMp4)
+		r.Group(func(r chi.Router) {
+			r.Use(SceneFileCtx)
+
+			r.Get("/stream", rs.StreamDirect)
+			r.Get("/stream.mkv", rs.StreamMKV)
+			r.Get("/stream.webm", rs.StreamWebM)
+			r.Get("/stream.m3u8", rs.StreamHLS)
+			r.Get("/stream.ts", rs.StreamTS)
+			r.Get("/stream.mp4", rs.StreamMp4)
+		})
 
 		r.Get("/screenshot", rs.Screenshot)
 		r.Get("/preview", rs.Preview)
 		r.Get("/webp", rs.Webp)
 		r.Get("/vtt/chapter", rs.ChapterVtt)
 		r.Get("/funscript", rs.Funscript)
+		r.Get("/caption/{captionId}", rs.Caption)
 		r.Get("/interactive_heatmap", rs.InteractiveHeatmap)
 
 		r.Get("/deovr.json", rs.DeoVRJSON)
@@ -72,9 +78,59 @@ func getSceneFileContainer(scene *models.Scene) ffmpeg.Container {
 	return container
 }
 
+// getAudioStream returns the audio stream of the video file selected with
+// the audio query parameter. Returns nil if the parameter is not set, and an
+// error if it does not refer to an audio stream of the video file.
+func getAudioStream(r *http.Request, videoFile *ffmpeg.VideoFile) (*ffmpeg.FFProbeStream, error) {
+	audioParam := r.URL.Query().Get("audio")
+	if audioParam == "" {
+		return nil, nil
+	}
+
+	index, err := strconv.Atoi(audioParam)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audio stream %q", audioParam)
+	}
+
+	ret := videoFile.FindAudioStream(index)
+	if ret == nil {
+		return nil, fmt.Errorf("audio stream %d not found", index)
+	}
+
+	return ret, nil
+}
+
 func (rs sceneRoutes) StreamDirect(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
+	// the file can only be served directly with its default audio stream.
+	// Remux it with the selected audio stream otherwise.
+	if r.URL.Query().Get("audio") != "" {
+		ffprobe := manager.GetInstance().FFProbe
+		videoFile, err := ffprobe.NewVideoFile(scene.Path, false)
+		if err != nil {
+			logger.Errorf("[stream] error reading video file: %v", err)
+			return
+		}
+
+		audioStream, err := getAudioStream(r, videoFile)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if audioStream != videoFile.DefaultAudioStream() {
+			codec := ffmpeg.GetRemuxCodec(*videoFile)
+			if manager.HasTranscode(scene, config.GetInstance().GetVideoFileNamingAlgorithm()) {
+				// the video stream of the file is not supported by browsers
+				codec = ffmpeg.CodecH264
+			}
+
+			rs.streamVideoFile(w, r, videoFile, codec, config.GetInstance().GetMaxStreamingTranscodeSize())
+			return
+		}
+	}
+
 	ss := manager.SceneServer{
 		TXNManager: rs.txnManager,
 	}
@@ -115,6 +171,17 @@ func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the audio and profile parameters are passed on to the segment URLs
+	if _, err := getAudioStream(r, videoFile); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := getTranscodeProfile(r, ffmpeg.Mpegts); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	logger.Debug("Returning HLS playlist")
 
 	// getting the playlist manifest only
@@ -141,10 +208,49 @@ func (rs sceneRoutes) StreamTS(w http.ResponseWriter, r *http.Request) {
 	rs.streamTranscode(w, r, ffmpeg.CodecHLS)
 }
 
+// getTranscodeProfile returns the transcode profile to stream with, for an
+// endpoint with the provided container. The profile is selected with the
+// profile query parameter. Otherwise, the first configured profile with the
+// container is used. Returns nil if no profile applies.
+func getTranscodeProfile(r *http.Request, container ffmpeg.Container) (*models.TranscodeProfile, error) {
+	profiles := config.GetInstance().GetTranscodeProfiles()
+
+	name := r.URL.Query().Get("profile")
+	if name == "" {
+		return profiles.FindByContainer(string(container)), nil
+	}
+
+	ret := profiles.Find(name)
+	if ret == nil {
+		return nil, fmt.Errorf("transcode profile %q not found", name)
+	}
+
+	if ret.Container != string(container) {
+		return nil, fmt.Errorf("transcode profile %q does not use the %s container", name, container)
+	}
+
+	return ret, nil
+}
+
 func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, videoCodec ffmpeg.Codec) {
-	logger.Debugf("Streaming as %s", videoCodec.MimeType)
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
+	maxTranscodeSize := config.GetInstance().GetMaxStreamingTranscodeSize()
+	profile, err := getTranscodeProfile(r, videoCodec.Container())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if profile != nil {
+		videoCodec = ffmpeg.ProfileStreamCodec(*profile)
+		if profile.MaxResolution != "" {
+			maxTranscodeSize = profile.MaxResolution
+		}
+	}
+
+	logger.Debugf("Streaming as %s", videoCodec.MimeType)
+
 	// needs to be transcoded
 	ffprobe := manager.GetInstance().FFProbe
 	videoFile, err := ffprobe.NewVideoFile(scene.Path, false)
@@ -153,6 +259,18 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, vi
 		return
 	}
 
+	rs.streamVideoFile(w, r, videoFile, videoCodec, maxTranscodeSize)
+}
+
+func (rs sceneRoutes) streamVideoFile(w http.ResponseWriter, r *http.Request, videoFile *ffmpeg.VideoFile, videoCodec ffmpeg.Codec, maxTranscodeSize models.StreamingResolutionEnum) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	audioStream, err := getAudioStream(r, videoFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// start stream based on query param, if provided
 	if err = r.ParseForm(); err != nil {
 		logger.Warnf("[stream] error parsing query form: %v", err)
@@ -169,8 +287,13 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, vi
 	}
 
 	options := ffmpeg.GetTranscodeStreamOptions(*videoFile, videoCodec, audioCodec)
+	if audioStream != nil {
+		index := audioStream.Index
+		options.AudioStreamIndex = &index
+		options.VideoOnly = ffmpeg.AudioCodec(audioStream.CodecName) == ffmpeg.MissingUnsupported
+	}
 	options.StartTime = startTime
-	options.MaxTranscodeSize = config.GetInstance().GetMaxStreamingTranscodeSize()
+	options.MaxTranscodeSize = maxTranscodeSize
 	if requestedSize != "" {
 		options.MaxTranscodeSize = models.StreamingResolutionEnum(requestedSize)
 	}
@@ -284,6 +407,55 @@ func (rs sceneRoutes) Funscript(w http.ResponseWriter, r *http.Request) {
 	utils.ServeFileNoCache(w, r, funscript)
 }
 
+func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+	captionID, _ := strconv.Atoi(chi.URLParam(r, "captionId"))
+	var caption *models.SceneCaption
+	if err := rs.txnManager.WithReadTxn(r.Context(), func(repo models.ReaderRepository) error {
+		captions, err := repo.Scene().GetCaptions(scene.ID)
+		for _, c := range captions {
+			if c.ID == captionID {
+				caption = c
+			}
+		}
+		return err
+	}); err != nil {
+		logger.Warnf("Error when getting scene captions: %s", err.Error())
+		http.Error(w, http.StatusText(500), 500)
+		return
+	}
+
+	if caption == nil {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vtt")
+
+	// WebVTT files are served as is
+	if caption.Source == models.CaptionSourceFile && caption.CaptionType == models.CaptionTypeVTT {
+		utils.ServeFileNoCache(w, r, caption.Path.String)
+		return
+	}
+
+	path := caption.Path.String
+	streamIndex := -1
+	if caption.Source == models.CaptionSourceEmbedded {
+		path = scene.Path
+		streamIndex = int(caption.StreamIndex.Int64)
+	}
+
+	encoder := manager.GetInstance().FFMPEG
+	data, err := encoder.CaptionToWebVTT(path, streamIndex)
+	if err != nil {
+		logger.Errorf("[caption] error converting caption of %s: %v", scene.Path, err)
+		http.Error(w, http.StatusText(500), 500)
+		return
+	}
+
+	_, _ = w.Write(data)
+}
+
 func (rs sceneRoutes) InteractiveHeatmap(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	w.Header().Set("Content-Type", "image/png")
@@ -430,3 +602,44 @@ func SceneCtx(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// SceneFileCtx replaces the scene in the context with a copy using the file
+// provided in the file_id query parameter as its primary file. The scene is
+// left as is if no file_id is provided.
+func SceneFileCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fileIDParam := r.URL.Query().Get("file_id")
+		if fileIDParam == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		fileID, err := strconv.Atoi(fileIDParam)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		scene := r.Context().Value(sceneKey).(*models.Scene)
+
+		var f *models.SceneFile
+		readTxnErr := manager.GetInstance().TxnManager.WithReadTxn(r.Context(), func(repo models.ReaderRepository) error {
+			f, _ = repo.SceneFile().Find(fileID)
+			return nil
+		})
+		if readTxnErr != nil {
+			logger.Warnf("error executing SceneFileCtx transaction: %v", readTxnErr)
+		}
+
+		if f == nil || f.SceneID != scene.ID {
+			http.Error(w, http.StatusText(404), 404)
+			return
+		}
+
+		fileScene := *scene
+		fileScene.SetPrimaryFile(*f)
+
+		ctx := context.WithValue(r.Context(), sceneKey, &fileScene)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
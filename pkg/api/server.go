@@ -134,6 +134,9 @@ func Start(uiBox embed.FS, loginUIBox embed.FS) {
 	r.Mount("/tag", tagRoutes{
 		txnManager: txnManager,
 	}.Routes())
+	r.Mount("/playlist", playlistRoutes{
+		txnManager: txnManager,
+	}.Routes())
 	r.Mount("/downloads", downloadsRoutes{}.Routes())
 
 	r.HandleFunc("/deovr", func(w http.ResponseWriter, r *http.Request) {
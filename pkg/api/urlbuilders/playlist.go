@@ -0,0 +1,27 @@
+package urlbuilders
+
+import (
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type PlaylistURLBuilder struct {
+	BaseURL    string
+	PlaylistID string
+}
+
+func NewPlaylistURLBuilder(baseURL string, playlist *models.Playlist) PlaylistURLBuilder {
+	return PlaylistURLBuilder{
+		BaseURL:    baseURL,
+		PlaylistID: strconv.Itoa(playlist.ID),
+	}
+}
+
+func (b PlaylistURLBuilder) GetM3UURL() string {
+	return b.BaseURL + "/playlist/" + b.PlaylistID + "/m3u"
+}
+
+func (b PlaylistURLBuilder) GetXSPFURL() string {
+	return b.BaseURL + "/playlist/" + b.PlaylistID + "/xspf"
+}
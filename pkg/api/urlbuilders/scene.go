@@ -28,6 +28,16 @@ func (b SceneURLBuilder) GetStreamURL() string {
 	return fmt.Sprintf("%s/scene/%s/stream%s", b.BaseURL, b.SceneID, apiKeyParam)
 }
 
+// GetFileStreamURL returns the direct stream URL of the scene file with the
+// provided id.
+func (b SceneURLBuilder) GetFileStreamURL(fileID int) string {
+	apiKeyParam := ""
+	if b.APIKey != "" {
+		apiKeyParam = fmt.Sprintf("&apikey=%s", b.APIKey)
+	}
+	return fmt.Sprintf("%s/scene/%s/stream?file_id=%d%s", b.BaseURL, b.SceneID, fileID, apiKeyParam)
+}
+
 func (b SceneURLBuilder) GetStreamPreviewURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/preview"
 }
@@ -82,3 +92,9 @@ func (b SceneURLBuilder) GetDeoVRURL(useDeoVRProto bool) string {
 func (b SceneURLBuilder) GetInteractiveHeatmapURL() string {
 	return b.BaseURL + "/scene/" + b.SceneID + "/interactive_heatmap"
 }
+
+// GetCaptionURL returns the URL of the caption with the provided id,
+// converted to WebVTT.
+func (b SceneURLBuilder) GetCaptionURL(captionID int) string {
+	return b.BaseURL + "/scene/" + b.SceneID + "/caption/" + strconv.Itoa(captionID)
+}
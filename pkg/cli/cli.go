@@ -0,0 +1,318 @@
+// Package cli provides headless command-line subcommands that run
+// maintenance tasks in-process, without starting the web server.
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime/pprof"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/stashapp/stash/pkg/database"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager"
+	"github.com/stashapp/stash/pkg/manager/config"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK = 0
+	// ExitError indicates that the command could not be run, or failed.
+	ExitError = 1
+	// ExitUsage indicates that the command line was invalid.
+	ExitUsage = 2
+	// ExitCompletedWithErrors indicates that the job ran to completion, but
+	// errors were logged while it was running.
+	ExitCompletedWithErrors = 3
+	// ExitCancelled indicates that the job was cancelled by an interrupt.
+	ExitCancelled = 130
+)
+
+// how often the status of a running job is polled
+const jobPollInterval = 500 * time.Millisecond
+
+// log items are broadcast to subscribers at most once per second. Wait
+// this long after a job has finished so that late errors are counted.
+const logFlushDelay = 1100 * time.Millisecond
+
+const helpCommand = "help"
+
+// errUsage is returned by a command when the provided arguments are invalid.
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	name        string
+	description string
+	// if true, the command may be run against a database that requires
+	// migration
+	allowMigrationRequired bool
+
+	// flags registers the command flags. The returned function is called
+	// once the flags have been parsed, to run the command.
+	flags func(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error
+}
+
+func findCommand(name string) *command {
+	for _, c := range commands {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// splitArgs finds the command named by the first argument that is not a
+// global flag or flag value. It returns the command, the arguments preceding
+// it and the arguments following it. Global flags must therefore be provided
+// before the command name. Returns a nil command if the first non-flag
+// argument does not name a command.
+func splitArgs(args []string) (cmd *command, global []string, cmdArgs []string) {
+	globalFlags := config.FlagSet()
+
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			break
+		}
+
+		if strings.HasPrefix(a, "-") {
+			// skip the value of the flag
+			if flagTakesValue(globalFlags, a) {
+				i++
+			}
+			continue
+		}
+
+		if c := findCommand(a); c != nil {
+			return c, args[:i], args[i+1:]
+		}
+
+		break
+	}
+
+	return nil, args, nil
+}
+
+// flagTakesValue returns true if arg is a flag in fs whose value is
+// provided in the following argument.
+func flagTakesValue(fs *pflag.FlagSet, arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+
+	var f *pflag.Flag
+	switch {
+	case strings.HasPrefix(arg, "--"):
+		f = fs.Lookup(arg[2:])
+	case len(arg) == 2:
+		f = fs.ShorthandLookup(arg[1:])
+	}
+
+	// boolean flags have a default value when the value is omitted
+	return f != nil && f.NoOptDefVal == ""
+}
+
+// IsCommand returns true if the provided command line arguments, excluding
+// the program name, invoke a command or request the command usage.
+func IsCommand(args []string) bool {
+	if len(args) > 0 && args[0] == helpCommand {
+		return true
+	}
+
+	cmd, _, _ := splitArgs(args)
+	return cmd != nil
+}
+
+// Run runs the command named in args, which is expected to be os.Args.
+// The manager is initialised using the global flags that precede the
+// command name. Returns the exit code of the command.
+func Run(args []string) int {
+	if len(args) > 1 && args[1] == helpCommand {
+		printUsage(os.Stdout)
+		return ExitOK
+	}
+
+	cmd, global, cmdArgs := splitArgs(args[1:])
+	if cmd == nil {
+		printUsage(os.Stderr)
+		return ExitUsage
+	}
+
+	// the global flags are parsed from os.Args when the configuration is
+	// initialised
+	os.Args = append([]string{args[0]}, global...)
+
+	mgr, err := manager.InitializeHeadless()
+	defer shutdown()
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return ExitError
+	}
+
+	if mgr.Config.IsNewSystem() {
+		fmt.Fprintln(os.Stderr, "stash has not been set up. Start the server to complete the setup process.")
+		return ExitError
+	}
+
+	// command flag defaults may depend on the configuration, so the flags
+	// are registered after initialisation
+	fs := pflag.NewFlagSet(args[0]+" "+cmd.name, pflag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: stash [global flags] %s [flags]\n\n%s\n\nFlags:\n", cmd.name, cmd.description)
+		fs.PrintDefaults()
+	}
+	run := cmd.flags(fs)
+
+	if err := fs.Parse(cmdArgs); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	if fs.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+		return ExitUsage
+	}
+
+	if err := database.Ready(); err != nil && !cmd.allowMigrationRequired {
+		if database.NeedsMigration() {
+			fmt.Fprintln(os.Stderr, "The database requires migration. Run 'stash migrate' first.")
+		} else {
+			fmt.Fprintf(os.Stderr, "database is not ready: %v\n", err)
+		}
+		return ExitError
+	}
+
+	r := &runner{
+		out: os.Stdout,
+	}
+
+	if err := run(context.Background(), r); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			fs.Usage()
+			return ExitUsage
+		}
+
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd.name, err)
+		return ExitError
+	}
+
+	return r.exitCode
+}
+
+func shutdown() {
+	// stop any profiling at exit
+	pprof.StopCPUProfile()
+
+	if err := database.Close(); err != nil {
+		logger.Errorf("Error closing database: %s", err)
+	}
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: stash [global flags] <command> [flags]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.description)
+	}
+	fmt.Fprintln(w, "\nRun 'stash <command> --help' for the flags of a command.")
+}
+
+type runner struct {
+	out      io.Writer
+	exitCode int
+}
+
+// runJob waits for the job with the provided id to complete, printing its
+// progress. An interrupt cancels the job. Sets the exit code according to
+// the outcome of the job.
+func (r *runner) runJob(jobID int) {
+	jobManager := manager.GetInstance().JobManager
+
+	// count errors logged while the job is running. Log items are broadcast
+	// in batches, so earlier items may still be received.
+	start := time.Now()
+	var errorCount int32
+	stopLog := make(chan int)
+	logItems := logger.SubscribeToLog(stopLog)
+	go func() {
+		for items := range logItems {
+			for _, l := range items {
+				if l.Type == "error" && !l.Time.Before(start) {
+					atomic.AddInt32(&errorCount, 1)
+				}
+			}
+		}
+	}()
+	defer close(stopLog)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	var lastStatus string
+	var j *job.Job
+	for {
+		select {
+		case <-signals:
+			fmt.Fprintln(r.out, "Cancelling...")
+			jobManager.CancelJob(jobID)
+		case <-ticker.C:
+		}
+
+		j = jobManager.GetJob(jobID)
+		if j == nil || j.Status == job.StatusFinished || j.Status == job.StatusCancelled {
+			break
+		}
+
+		if status := formatJobStatus(*j); status != lastStatus {
+			fmt.Fprintln(r.out, status)
+			lastStatus = status
+		}
+	}
+
+	time.Sleep(logFlushDelay)
+
+	switch {
+	case j != nil && j.Status == job.StatusCancelled:
+		fmt.Fprintln(r.out, "Cancelled")
+		r.exitCode = ExitCancelled
+	case atomic.LoadInt32(&errorCount) > 0:
+		fmt.Fprintf(r.out, "Finished with %d error(s)\n", atomic.LoadInt32(&errorCount))
+		r.exitCode = ExitCompletedWithErrors
+	default:
+		fmt.Fprintln(r.out, "Finished")
+	}
+}
+
+func formatJobStatus(j job.Job) string {
+	ret := j.Description
+	if j.Progress >= 0 {
+		ret = fmt.Sprintf("%s %.1f%%", ret, j.Progress*100)
+	}
+
+	if len(j.Details) > 0 {
+		ret += " - " + j.Details[0]
+	}
+
+	return ret
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCmd    string
+		wantGlobal []string
+		wantArgs   []string
+	}{
+		{
+			"no command",
+			[]string{"--port", "9999"},
+			"",
+			[]string{"--port", "9999"},
+			nil,
+		},
+		{
+			"command only",
+			[]string{"scan"},
+			"scan",
+			[]string{},
+			[]string{},
+		},
+		{
+			"global and command flags",
+			[]string{"-c", "config.yml", "generate", "--sprites", "--scene-id", "1"},
+			"generate",
+			[]string{"-c", "config.yml"},
+			[]string{"--sprites", "--scene-id", "1"},
+		},
+		{
+			"command name as flag value",
+			[]string{"clean", "--path", "scan"},
+			"clean",
+			[]string{},
+			[]string{"--path", "scan"},
+		},
+		{
+			"command name as global flag value",
+			[]string{"--config", "scan"},
+			"",
+			[]string{"--config", "scan"},
+			nil,
+		},
+		{
+			"command name as global shorthand flag value",
+			[]string{"-c", "scan", "scan"},
+			"scan",
+			[]string{"-c", "scan"},
+			[]string{},
+		},
+		{
+			"global flag with inline value",
+			[]string{"--config=config.yml", "scan"},
+			"scan",
+			[]string{"--config=config.yml"},
+			[]string{},
+		},
+		{
+			"global boolean flag",
+			[]string{"--nobrowser", "scan"},
+			"scan",
+			[]string{"--nobrowser"},
+			[]string{},
+		},
+		{
+			"command after non-flag argument",
+			[]string{"unknown", "scan"},
+			"",
+			[]string{"unknown", "scan"},
+			nil,
+		},
+		{
+			"after terminator",
+			[]string{"--", "scan"},
+			"",
+			[]string{"--", "scan"},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, global, args := splitArgs(tt.args)
+
+			cmdName := ""
+			if cmd != nil {
+				cmdName = cmd.name
+			}
+
+			assert.Equal(t, tt.wantCmd, cmdName)
+			assert.Equal(t, tt.wantGlobal, global)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestIsCommand(t *testing.T) {
+	assert.True(t, IsCommand([]string{"help"}))
+	assert.True(t, IsCommand([]string{"--nobrowser", "backup"}))
+	assert.False(t, IsCommand([]string{"--nobrowser"}))
+	assert.False(t, IsCommand(nil))
+}
@@ -0,0 +1,317 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/stashapp/stash/pkg/database"
+	"github.com/stashapp/stash/pkg/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+var commands = []*command{
+	{
+		name:        "scan",
+		description: "Scan the library for new, changed and missing files",
+		flags:       scanFlags,
+	},
+	{
+		name:        "generate",
+		description: "Generate supporting files for scenes, markers and images",
+		flags:       generateFlags,
+	},
+	{
+		name:        "autotag",
+		description: "Tag files with performers, studios and tags matching their paths",
+		flags:       autoTagFlags,
+	},
+	{
+		name:        "identify",
+		description: "Identify scenes using the default identify sources",
+		flags:       identifyFlags,
+	},
+	{
+		name:        "clean",
+		description: "Remove objects whose files no longer exist",
+		flags:       cleanFlags,
+	},
+	{
+		name:        "export",
+		description: "Export the database to the metadata directory",
+		flags:       exportFlags,
+	},
+	{
+		name:        "import",
+		description: "Replace the database with the contents of the metadata directory",
+		flags:       importFlags,
+	},
+	{
+		name:                   "migrate",
+		description:            "Migrate the database to the current schema version",
+		allowMigrationRequired: true,
+		flags:                  migrateFlags,
+	},
+	{
+		name:        "backup",
+		description: "Back up the database into the backup directory",
+		flags:       backupFlags,
+	},
+}
+
+func scanFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	var paths []string
+	fs.StringSliceVar(&paths, "path", nil, "path to scan. May be repeated. Defaults to all library paths")
+
+	// defaults are taken from the configured scan settings
+	var opts models.ScanMetadataOptions
+	if defaults := manager.GetInstance().Config.GetDefaultScanSettings(); defaults != nil {
+		opts = *defaults
+	}
+
+	fs.BoolVar(&opts.UseFileMetadata, "use-file-metadata", opts.UseFileMetadata, "set name, date and details from file metadata")
+	fs.BoolVar(&opts.UseNfoMetadata, "use-nfo-metadata", opts.UseNfoMetadata, "set scene metadata from adjacent NFO files")
+	fs.BoolVar(&opts.StripFileExtension, "strip-file-extension", opts.StripFileExtension, "strip the file extension from titles")
+	fs.BoolVar(&opts.ScanGeneratePreviews, "previews", opts.ScanGeneratePreviews, "generate previews during scan")
+	fs.BoolVar(&opts.ScanGenerateImagePreviews, "image-previews", opts.ScanGenerateImagePreviews, "generate image previews during scan")
+	fs.BoolVar(&opts.ScanGenerateSprites, "sprites", opts.ScanGenerateSprites, "generate sprites during scan")
+	fs.BoolVar(&opts.ScanGeneratePhashes, "phashes", opts.ScanGeneratePhashes, "generate phashes during scan")
+	fs.BoolVar(&opts.ScanGenerateThumbnails, "thumbnails", opts.ScanGenerateThumbnails, "generate image thumbnails during scan")
+
+	return func(ctx context.Context, r *runner) error {
+		input := models.ScanMetadataInput{
+			Paths:                     paths,
+			UseFileMetadata:           &opts.UseFileMetadata,
+			UseNfoMetadata:            &opts.UseNfoMetadata,
+			StripFileExtension:        &opts.StripFileExtension,
+			ScanGeneratePreviews:      &opts.ScanGeneratePreviews,
+			ScanGenerateImagePreviews: &opts.ScanGenerateImagePreviews,
+			ScanGenerateSprites:       &opts.ScanGenerateSprites,
+			ScanGeneratePhashes:       &opts.ScanGeneratePhashes,
+			ScanGenerateThumbnails:    &opts.ScanGenerateThumbnails,
+		}
+
+		jobID, err := manager.GetInstance().Scan(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		r.runJob(jobID)
+		return nil
+	}
+}
+
+func generateFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	// defaults are taken from the configured generate settings
+	defaults := manager.GetInstance().Config.GetDefaultGenerateSettings()
+	if defaults == nil {
+		defaults = &models.GenerateMetadataOptions{}
+	}
+
+	var (
+		sprites             = fs.Bool("sprites", utils.IsTrue(defaults.Sprites), "generate sprites")
+		previews            = fs.Bool("previews", utils.IsTrue(defaults.Previews), "generate previews")
+		imagePreviews       = fs.Bool("image-previews", utils.IsTrue(defaults.ImagePreviews), "generate image previews")
+		markers             = fs.Bool("markers", utils.IsTrue(defaults.Markers), "generate marker previews")
+		markerImagePreviews = fs.Bool("marker-image-previews", utils.IsTrue(defaults.MarkerImagePreviews), "generate marker image previews")
+		markerScreenshots   = fs.Bool("marker-screenshots", utils.IsTrue(defaults.MarkerScreenshots), "generate marker screenshots")
+		transcodes          = fs.Bool("transcodes", utils.IsTrue(defaults.Transcodes), "generate transcodes")
+		forceTranscodes     = fs.Bool("force-transcodes", false, "generate transcodes even if not required")
+		phashes             = fs.Bool("phashes", utils.IsTrue(defaults.Phashes), "generate phashes")
+		heatmaps            = fs.Bool("heatmaps", utils.IsTrue(defaults.InteractiveHeatmapsSpeeds), "generate interactive heatmaps and speeds")
+		overwrite           = fs.Bool("overwrite", false, "overwrite existing files")
+		sceneIDs            = fs.StringSlice("scene-id", nil, "id of a scene to generate for. May be repeated. Defaults to all scenes")
+	)
+
+	return func(ctx context.Context, r *runner) error {
+		input := models.GenerateMetadataInput{
+			Sprites:                   sprites,
+			Previews:                  previews,
+			ImagePreviews:             imagePreviews,
+			Markers:                   markers,
+			MarkerImagePreviews:       markerImagePreviews,
+			MarkerScreenshots:         markerScreenshots,
+			Transcodes:                transcodes,
+			ForceTranscodes:           forceTranscodes,
+			Phashes:                   phashes,
+			InteractiveHeatmapsSpeeds: heatmaps,
+			SceneIDs:                  *sceneIDs,
+			Overwrite:                 overwrite,
+		}
+
+		if !*sprites && !*previews && !*imagePreviews && !*markers && !*markerImagePreviews &&
+			!*markerScreenshots && !*transcodes && !*phashes && !*heatmaps {
+			return fmt.Errorf("%w: nothing selected to generate", errUsage)
+		}
+
+		jobID, err := manager.GetInstance().Generate(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		r.runJob(jobID)
+		return nil
+	}
+}
+
+func autoTagFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	var input models.AutoTagMetadataInput
+	fs.StringSliceVar(&input.Paths, "path", nil, "path to tag. May be repeated. Defaults to all files")
+	fs.StringSliceVar(&input.Performers, "performers", nil, `ids of performers to tag with, or "*" for all`)
+	fs.StringSliceVar(&input.Studios, "studios", nil, `ids of studios to tag with, or "*" for all`)
+	fs.StringSliceVar(&input.Tags, "tags", nil, `ids of tags to tag with, or "*" for all`)
+
+	return func(ctx context.Context, r *runner) error {
+		// if no objects are selected, then use the configured defaults,
+		// falling back to all objects
+		if len(input.Performers) == 0 && len(input.Studios) == 0 && len(input.Tags) == 0 {
+			defaults := manager.GetInstance().Config.GetDefaultAutoTagSettings()
+			if defaults != nil {
+				input.Performers = defaults.Performers
+				input.Studios = defaults.Studios
+				input.Tags = defaults.Tags
+			}
+
+			if len(input.Performers) == 0 && len(input.Studios) == 0 && len(input.Tags) == 0 {
+				all := []string{"*"}
+				input.Performers = all
+				input.Studios = all
+				input.Tags = all
+			}
+		}
+
+		jobID := manager.GetInstance().AutoTag(ctx, input)
+
+		r.runJob(jobID)
+		return nil
+	}
+}
+
+func identifyFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	var input models.IdentifyMetadataInput
+	fs.StringSliceVar(&input.SceneIDs, "scene-id", nil, "id of a scene to identify. May be repeated")
+	fs.StringSliceVar(&input.Paths, "path", nil, "path of scenes to identify. May be repeated. Ignored if scene ids are set")
+
+	return func(ctx context.Context, r *runner) error {
+		defaults := manager.GetInstance().Config.GetDefaultIdentifySettings()
+		if defaults == nil || len(defaults.Sources) == 0 {
+			return errors.New("no default identify sources configured")
+		}
+
+		// the configured options have the same structure as the input
+		if err := convertIdentifyOptions(defaults, &input); err != nil {
+			return fmt.Errorf("reading default identify settings: %w", err)
+		}
+
+		mgr := manager.GetInstance()
+		jobID := mgr.JobManager.Add(ctx, "Identifying...", manager.CreateIdentifyJob(input))
+
+		r.runJob(jobID)
+		return nil
+	}
+}
+
+func convertIdentifyOptions(options *models.IdentifyMetadataTaskOptions, input *models.IdentifyMetadataInput) error {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, input)
+}
+
+func cleanFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	var input models.CleanMetadataInput
+	fs.StringSliceVar(&input.Paths, "path", nil, "path to clean. May be repeated. Defaults to all library paths")
+	fs.BoolVar(&input.DryRun, "dry-run", false, "log what would be removed without removing anything")
+
+	return func(ctx context.Context, r *runner) error {
+		jobID := manager.GetInstance().Clean(ctx, input)
+
+		r.runJob(jobID)
+		return nil
+	}
+}
+
+func exportFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	return func(ctx context.Context, r *runner) error {
+		jobID, err := manager.GetInstance().Export(ctx)
+		if err != nil {
+			return err
+		}
+
+		r.runJob(jobID)
+		return nil
+	}
+}
+
+func importFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	confirm := fs.Bool("yes", false, "confirm that the existing database will be replaced")
+
+	return func(ctx context.Context, r *runner) error {
+		if !*confirm {
+			return fmt.Errorf("%w: import replaces the existing database, pass --yes to confirm", errUsage)
+		}
+
+		jobID, err := manager.GetInstance().Import(ctx)
+		if err != nil {
+			return err
+		}
+
+		r.runJob(jobID)
+		return nil
+	}
+}
+
+func migrateFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	var input models.MigrateInput
+	fs.StringVar(&input.BackupPath, "backup-path", "", "keep the pre-migration backup at this path. By default the backup is removed after a successful migration")
+
+	return func(ctx context.Context, r *runner) error {
+		if !database.NeedsMigration() {
+			fmt.Fprintf(r.out, "Database is already at schema version %d\n", database.Version())
+			return nil
+		}
+
+		fmt.Fprintf(r.out, "Migrating database from schema version %d to %d\n", database.Version(), database.AppSchemaVersion())
+		if err := manager.GetInstance().Migrate(ctx, input); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(r.out, "Finished")
+		return nil
+	}
+}
+
+func backupFlags(fs *pflag.FlagSet) func(ctx context.Context, r *runner) error {
+	list := fs.Bool("list", false, "list the existing backups instead of creating one")
+
+	return func(ctx context.Context, r *runner) error {
+		mgr := manager.GetInstance()
+
+		if *list {
+			backups, err := mgr.ListDatabaseBackups()
+			if err != nil {
+				return err
+			}
+
+			for _, b := range backups {
+				fmt.Fprintf(r.out, "%s\t%d\t%s\n", b.Name, b.Size, b.CreatedAt.Format(time.RFC3339))
+			}
+			return nil
+		}
+
+		backupPath, err := mgr.BackupDatabase()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(r.out, "Database backed up to %s\n", backupPath)
+		return nil
+	}
+}
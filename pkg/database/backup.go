@@ -0,0 +1,389 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// ErrIntegrityCheckFailed indicates that a database file failed the sqlite
+// integrity check.
+var ErrIntegrityCheckFailed = errors.New("database integrity check failed")
+
+const (
+	integrityCheckOK = "ok"
+	restoreSuffix    = ".restore"
+	replacedSuffix   = ".replaced"
+)
+
+// backupMu prevents backups from running while the database is restored.
+var backupMu sync.Mutex
+
+// BackupFileInfo describes a database backup file.
+type BackupFileInfo struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+func openReadOnly(path string) (*sqlx.DB, error) {
+	return sqlx.Connect(sqlite3Driver, "file:"+path+"?mode=ro")
+}
+
+func runIntegrityCheck(path string, pragma string) error {
+	db, err := openReadOnly(path)
+	if err != nil {
+		return fmt.Errorf("open database %s failed: %v", path, err)
+	}
+	defer db.Close()
+
+	var results []string
+	if err := db.Select(&results, "PRAGMA "+pragma); err != nil {
+		return fmt.Errorf("%w: %v", ErrIntegrityCheckFailed, err)
+	}
+
+	if len(results) != 1 || results[0] != integrityCheckOK {
+		return fmt.Errorf("%w: %s", ErrIntegrityCheckFailed, strings.Join(results, "; "))
+	}
+
+	return nil
+}
+
+// IntegrityCheck runs a full integrity check on the database file at the
+// provided path. Returns an error wrapping ErrIntegrityCheckFailed if the
+// database is corrupt.
+func IntegrityCheck(path string) error {
+	return runIntegrityCheck(path, "integrity_check")
+}
+
+// QuickCheck runs a faster, less thorough version of IntegrityCheck.
+func QuickCheck(path string) error {
+	return runIntegrityCheck(path, "quick_check")
+}
+
+// backupPrefix returns the file name prefix of the backups of the database
+// at the provided path.
+func backupPrefix(databasePath string) string {
+	return filepath.Base(databasePath) + "."
+}
+
+// BackupPath returns the path of a new backup file in the provided directory.
+func BackupPath(dir string) string {
+	base := filepath.Join(dir, filepath.Base(DatabaseBackupPath()))
+
+	// backups made within the same second would otherwise share a name
+	ret := base
+	for i := 1; ; i++ {
+		if exists, _ := utils.FileExists(ret); !exists {
+			return ret
+		}
+		ret = fmt.Sprintf("%s_%d", base, i)
+	}
+}
+
+// ListBackups returns the backups of the database at databasePath in the
+// provided directory, newest first. The database path is provided
+// explicitly so that backups can be found before the database is
+// initialized.
+func ListBackups(dir string, databasePath string) ([]BackupFileInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := backupPrefix(databasePath)
+	var ret []BackupFileInfo
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		// exclude the files of an incomplete restore
+		if strings.HasSuffix(name, restoreSuffix) || strings.HasSuffix(name, replacedSuffix) {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, BackupFileInfo{
+			Path:    filepath.Join(dir, name),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ModTime.After(ret[j].ModTime)
+	})
+
+	return ret, nil
+}
+
+// LatestGoodBackup returns the newest backup of the database at
+// databasePath in the provided directory that passes the integrity check.
+// Returns nil if there is no such backup.
+func LatestGoodBackup(dir string, databasePath string) (*BackupFileInfo, error) {
+	backups, err := ListBackups(dir, databasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range backups {
+		if err := IntegrityCheck(b.Path); err != nil {
+			logger.Warnf("Skipping database backup %s: %v", b.Path, err)
+			continue
+		}
+
+		ret := b
+		return &ret, nil
+	}
+
+	return nil, nil
+}
+
+// RotateBackup backs up the database into the provided directory and
+// verifies the backup with an integrity check. If keep is greater than
+// zero, then the oldest backups are deleted so that only keep backups
+// remain. Returns the path of the new backup.
+func RotateBackup(dir string, keep int) (string, error) {
+	if err := utils.EnsureDir(dir); err != nil {
+		return "", fmt.Errorf("could not create backup directory %v: %w", dir, err)
+	}
+
+	backupMu.Lock()
+	defer backupMu.Unlock()
+
+	if err := Ready(); err != nil {
+		return "", err
+	}
+
+	backupPath := BackupPath(dir)
+	if err := Backup(DB, backupPath); err != nil {
+		return "", err
+	}
+
+	if err := IntegrityCheck(backupPath); err != nil {
+		if removeErr := os.Remove(backupPath); removeErr != nil {
+			logger.Warnf("error removing failed database backup %s: %v", backupPath, removeErr)
+		}
+		return "", fmt.Errorf("verifying backup: %w", err)
+	}
+
+	if keep > 0 {
+		backups, err := ListBackups(dir, dbPath)
+		if err != nil {
+			return backupPath, fmt.Errorf("listing backups: %w", err)
+		}
+
+		for i := keep; i < len(backups); i++ {
+			logger.Infof("Removing old database backup %s", backups[i].Path)
+			if err := os.Remove(backups[i].Path); err != nil {
+				logger.Warnf("error removing old database backup %s: %v", backups[i].Path, err)
+			}
+		}
+	}
+
+	return backupPath, nil
+}
+
+func backupSchemaVersion(path string) (uint, error) {
+	db, err := openReadOnly(path)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	var version uint
+	if err := db.Get(&version, "SELECT version FROM schema_migrations LIMIT 1"); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// databaseFiles returns the paths of the database file at databasePath and
+// its wal and shm files.
+func databaseFiles(databasePath string) []string {
+	return []string{databasePath, databasePath + "-wal", databasePath + "-shm"}
+}
+
+// moveDatabaseFiles renames the existing database files at databasePath so
+// that they have the provided suffix. Returns the paths that were moved.
+// Files that were moved before an error occurred are moved back.
+func moveDatabaseFiles(databasePath string, suffix string) ([]string, error) {
+	var moved []string
+	for _, f := range databaseFiles(databasePath) {
+		if exists, _ := utils.FileExists(f); !exists {
+			continue
+		}
+
+		if err := os.Rename(f, f+suffix); err != nil {
+			restoreDatabaseFiles(moved, suffix)
+			return nil, fmt.Errorf("moving %s: %w", f, err)
+		}
+		moved = append(moved, f)
+	}
+
+	return moved, nil
+}
+
+// restoreDatabaseFiles moves the files moved by moveDatabaseFiles back into
+// place, replacing any files at their original paths.
+func restoreDatabaseFiles(moved []string, suffix string) {
+	for _, f := range moved {
+		if err := os.Rename(f+suffix, f); err != nil {
+			logger.Errorf("error restoring %s from %s: %v", f, f+suffix, err)
+		}
+	}
+}
+
+// Restore replaces the database at databasePath with the backup at
+// backupPath. The database path is provided explicitly so that a database
+// that could not be initialized can be restored. The backup is verified
+// before the existing database connections are closed, and the backup file
+// is left in place. If the backup has an older schema version, then the
+// database is migrated to the current version.
+//
+// Writes and backups are blocked while the database file is replaced. If
+// the restore fails after the database was closed, then the original
+// database files are put back and reopened.
+func Restore(backupPath string, databasePath string) error {
+	if err := IntegrityCheck(backupPath); err != nil {
+		return err
+	}
+
+	version, err := backupSchemaVersion(backupPath)
+	if err != nil {
+		return fmt.Errorf("reading backup schema version: %w", err)
+	}
+
+	if version > appSchemaVersion {
+		return fmt.Errorf("backup schema version %d is incompatible with required schema version %d", version, appSchemaVersion)
+	}
+
+	logger.Infof("Restoring backup database %s into %s", backupPath, databasePath)
+
+	// copy to a temporary file first so that the database is not left
+	// partially written if the copy fails
+	tmpPath := databasePath + restoreSuffix
+	if err := copyFile(backupPath, tmpPath); err != nil {
+		if removeErr := os.Remove(tmpPath); removeErr != nil {
+			logger.Warnf("error removing %s: %v", tmpPath, removeErr)
+		}
+		return fmt.Errorf("copying backup: %w", err)
+	}
+
+	backupMu.Lock()
+	defer backupMu.Unlock()
+	WriteMu.Lock()
+	defer WriteMu.Unlock()
+
+	wasOpen := DB != nil
+	if err := closeDB(); err != nil {
+		return fmt.Errorf("closing database: %w", err)
+	}
+
+	if err := replaceDatabase(tmpPath, databasePath); err != nil {
+		if removeErr := os.Remove(tmpPath); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+			logger.Warnf("error removing %s: %v", tmpPath, removeErr)
+		}
+
+		if wasOpen {
+			if initErr := Initialize(databasePath); initErr != nil {
+				logger.Errorf("error reopening database %s: %v", databasePath, initErr)
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// replaceDatabase replaces the closed database at databasePath with the
+// database at newPath, and initializes it. The original database files are
+// put back if the new database cannot be initialized.
+func replaceDatabase(newPath string, databasePath string) error {
+	// the wal and shm files belong to the replaced database, so they are
+	// moved aside with it
+	moved, err := moveDatabaseFiles(databasePath, replacedSuffix)
+	if err != nil {
+		return err
+	}
+
+	err = func() error {
+		if err := os.Rename(newPath, databasePath); err != nil {
+			return fmt.Errorf("replacing database: %w", err)
+		}
+
+		if err := Initialize(databasePath); err != nil {
+			return fmt.Errorf("initializing restored database: %w", err)
+		}
+
+		if NeedsMigration() {
+			if err := RunMigrations(); err != nil {
+				return fmt.Errorf("migrating restored database: %w", err)
+			}
+		}
+
+		return nil
+	}()
+
+	if err != nil {
+		if closeErr := closeDB(); closeErr != nil {
+			logger.Warnf("error closing restored database: %v", closeErr)
+		}
+
+		for _, f := range databaseFiles(databasePath) {
+			if removeErr := os.Remove(f); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) {
+				logger.Warnf("error removing %s: %v", f, removeErr)
+			}
+		}
+
+		restoreDatabaseFiles(moved, replacedSuffix)
+		return err
+	}
+
+	for _, f := range moved {
+		if err := os.Remove(f + replacedSuffix); err != nil {
+			logger.Warnf("error removing replaced database file %s: %v", f+replacedSuffix, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+//go:build integration
+// +build integration
+
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateBackup(t *testing.T) {
+	dir, err := os.MkdirTemp("", "stash-backup-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := Initialize(filepath.Join(dir, "stash-go.sqlite")); err != nil {
+		t.Fatalf("error initializing database: %v", err)
+	}
+	defer Close()
+
+	backupDir := filepath.Join(dir, "backups")
+	const keep = 2
+
+	var created []string
+	for i := 0; i < 3; i++ {
+		backupPath, err := RotateBackup(backupDir, keep)
+		if err != nil {
+			t.Fatalf("error backing up database: %v", err)
+		}
+		created = append(created, backupPath)
+	}
+
+	backups, err := ListBackups(backupDir, dbPath)
+	if err != nil {
+		t.Fatalf("error listing backups: %v", err)
+	}
+
+	assert.Len(t, backups, keep)
+
+	// the oldest backup should have been removed
+	_, err = os.Stat(created[0])
+	assert.True(t, os.IsNotExist(err))
+
+	for _, b := range backups {
+		assert.Nil(t, IntegrityCheck(b.Path))
+	}
+
+	latest, err := LatestGoodBackup(backupDir, dbPath)
+	if err != nil {
+		t.Fatalf("error finding latest backup: %v", err)
+	}
+
+	assert.Equal(t, backups[0].Path, latest.Path)
+
+	// corrupt the newest backup
+	if err := os.WriteFile(backups[0].Path, []byte("not a database"), 0644); err != nil {
+		t.Fatalf("error corrupting backup: %v", err)
+	}
+
+	assert.NotNil(t, IntegrityCheck(backups[0].Path))
+	assert.NotNil(t, Restore(backups[0].Path, dbPath))
+
+	assert.Nil(t, Restore(backups[1].Path, dbPath))
+	assert.Nil(t, Ready())
+}
+
+func TestRestoreCorruptDatabase(t *testing.T) {
+	dir, err := os.MkdirTemp("", "stash-restore-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	databasePath := filepath.Join(dir, "stash-go.sqlite")
+	backupDir := filepath.Join(dir, "backups")
+
+	if err := Initialize(databasePath); err != nil {
+		t.Fatalf("error initializing database: %v", err)
+	}
+	defer Close()
+
+	const insertTag = "INSERT INTO tags (name, created_at, updated_at) VALUES (?, datetime('now'), datetime('now'))"
+	if _, err := DB.Exec(insertTag, "backed up"); err != nil {
+		t.Fatalf("error inserting tag: %v", err)
+	}
+
+	backupPath, err := RotateBackup(backupDir, 0)
+	if err != nil {
+		t.Fatalf("error backing up database: %v", err)
+	}
+
+	if _, err := DB.Exec(insertTag, "not backed up"); err != nil {
+		t.Fatalf("error inserting tag: %v", err)
+	}
+
+	if err := Close(); err != nil {
+		t.Fatalf("error closing database: %v", err)
+	}
+
+	// corrupt the database
+	if err := os.WriteFile(databasePath, []byte("not a database"), 0644); err != nil {
+		t.Fatalf("error corrupting database: %v", err)
+	}
+
+	// a corrupt database is not initialized on startup
+	dbPath = ""
+
+	assert.NotNil(t, QuickCheck(databasePath))
+
+	latest, err := LatestGoodBackup(backupDir, databasePath)
+	if err != nil {
+		t.Fatalf("error finding latest backup: %v", err)
+	}
+	if !assert.NotNil(t, latest) {
+		return
+	}
+	assert.Equal(t, backupPath, latest.Path)
+
+	if err := Restore(latest.Path, databasePath); err != nil {
+		t.Fatalf("error restoring backup: %v", err)
+	}
+
+	assert.Nil(t, Ready())
+	assert.Nil(t, QuickCheck(databasePath))
+
+	var names []string
+	if err := DB.Select(&names, "SELECT name FROM tags ORDER BY id"); err != nil {
+		t.Fatalf("error querying tags: %v", err)
+	}
+
+	assert.Equal(t, []string{"backed up"}, names)
+}
+
+func TestRestoreFailureReopensDatabase(t *testing.T) {
+	dir, err := os.MkdirTemp("", "stash-restore-test")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	databasePath := filepath.Join(dir, "stash-go.sqlite")
+	backupDir := filepath.Join(dir, "backups")
+
+	if err := Initialize(databasePath); err != nil {
+		t.Fatalf("error initializing database: %v", err)
+	}
+	defer Close()
+
+	backupPath, err := RotateBackup(backupDir, 0)
+	if err != nil {
+		t.Fatalf("error backing up database: %v", err)
+	}
+
+	const insertTag = "INSERT INTO tags (name, created_at, updated_at) VALUES (?, datetime('now'), datetime('now'))"
+	if _, err := DB.Exec(insertTag, "not backed up"); err != nil {
+		t.Fatalf("error inserting tag: %v", err)
+	}
+
+	// prevent the database from being moved aside
+	blocker := filepath.Join(databasePath+replacedSuffix, "blocker")
+	if err := os.MkdirAll(blocker, 0755); err != nil {
+		t.Fatalf("error creating directory: %v", err)
+	}
+
+	assert.NotNil(t, Restore(backupPath, databasePath))
+
+	// the original database should be open and unchanged
+	if !assert.Nil(t, Ready()) {
+		return
+	}
+
+	var names []string
+	if err := DB.Select(&names, "SELECT name FROM tags ORDER BY id"); err != nil {
+		t.Fatalf("error querying tags: %v", err)
+	}
+
+	assert.Equal(t, []string{"not backed up"}, names)
+
+	_, err = os.Stat(databasePath + restoreSuffix)
+	assert.True(t, os.IsNotExist(err))
+}
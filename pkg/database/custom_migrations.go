@@ -15,6 +15,10 @@ func runCustomMigrations() error {
 		return err
 	}
 
+	if err := initFullTextSearch(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
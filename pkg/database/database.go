@@ -23,7 +23,7 @@ import (
 var DB *sqlx.DB
 var WriteMu sync.Mutex
 var dbPath string
-var appSchemaVersion uint = 29
+var appSchemaVersion uint = 40
 var databaseSchemaVersion uint
 
 //go:embed migrations/*.sql
@@ -99,6 +99,12 @@ func Close() error {
 	WriteMu.Lock()
 	defer WriteMu.Unlock()
 
+	return closeDB()
+}
+
+// closeDB closes the database connection. WriteMu must be held by the
+// caller.
+func closeDB() error {
 	if DB != nil {
 		if err := DB.Close(); err != nil {
 			return err
@@ -0,0 +1,255 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// full-text search is only enabled if the sqlite library includes the FTS5
+// extension
+var fullTextSearchAvailable bool
+
+// FullTextSearchAvailable returns true if the full-text search indexes are
+// available for querying.
+func FullTextSearchAvailable() bool {
+	return fullTextSearchAvailable
+}
+
+type fullTextTrigger struct {
+	name string
+	// trigger definition, following the trigger name
+	definition string
+}
+
+// fullTextIndex is an FTS5 table indexing an object table, keyed by the
+// rowid of the indexed object. The index is maintained by triggers.
+type fullTextIndex struct {
+	table    string
+	columns  []string
+	populate string
+	triggers []fullTextTrigger
+}
+
+// createSQL returns the statement creating the index table. The trigram
+// tokenizer is used so that search terms match any part of the indexed
+// fields.
+func (i fullTextIndex) createSQL() string {
+	var columns []string
+	for _, c := range i.columns {
+		columns = append(columns, "`"+c+"`")
+	}
+
+	return fmt.Sprintf("CREATE VIRTUAL TABLE `%s` USING fts5(%s, tokenize = 'trigram')", i.table, strings.Join(columns, ", "))
+}
+
+func (t fullTextTrigger) createSQL() string {
+	return "CREATE TRIGGER `" + t.name + "` " + t.definition
+}
+
+var fullTextIndexes = []fullTextIndex{
+	{
+		table:   "scenes_fts",
+		columns: []string{"title", "details", "path", "checksum", "oshash", "markers"},
+		populate: "INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `path`, `checksum`, `oshash`, `markers`) " +
+			"SELECT `id`, `title`, `details`, `path`, `checksum`, `oshash`, " +
+			"(SELECT group_concat(`title`, ' ') FROM `scene_markers` WHERE `scene_id` = `scenes`.`id`) FROM `scenes`",
+		triggers: []fullTextTrigger{
+			{"scenes_fts_insert", "AFTER INSERT ON `scenes` BEGIN " +
+				"INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `path`, `checksum`, `oshash`) " +
+				"VALUES (NEW.`id`, NEW.`title`, NEW.`details`, NEW.`path`, NEW.`checksum`, NEW.`oshash`); END"},
+			{"scenes_fts_update", "AFTER UPDATE OF `title`, `details`, `path`, `checksum`, `oshash` ON `scenes` BEGIN " +
+				"UPDATE `scenes_fts` SET `title` = NEW.`title`, `details` = NEW.`details`, `path` = NEW.`path`, `checksum` = NEW.`checksum`, `oshash` = NEW.`oshash` " +
+				"WHERE `rowid` = NEW.`id`; END"},
+			{"scenes_fts_delete", "AFTER DELETE ON `scenes` BEGIN " +
+				"DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`id`; END"},
+			{"scene_markers_fts_insert", "AFTER INSERT ON `scene_markers` BEGIN " +
+				"UPDATE `scenes_fts` SET `markers` = (SELECT group_concat(`title`, ' ') FROM `scene_markers` WHERE `scene_id` = NEW.`scene_id`) " +
+				"WHERE `rowid` = NEW.`scene_id`; END"},
+			{"scene_markers_fts_update", "AFTER UPDATE OF `title`, `scene_id` ON `scene_markers` BEGIN " +
+				"UPDATE `scenes_fts` SET `markers` = (SELECT group_concat(`title`, ' ') FROM `scene_markers` WHERE `scene_id` = OLD.`scene_id`) " +
+				"WHERE `rowid` = OLD.`scene_id`; " +
+				"UPDATE `scenes_fts` SET `markers` = (SELECT group_concat(`title`, ' ') FROM `scene_markers` WHERE `scene_id` = NEW.`scene_id`) " +
+				"WHERE `rowid` = NEW.`scene_id`; END"},
+			{"scene_markers_fts_delete", "AFTER DELETE ON `scene_markers` BEGIN " +
+				"UPDATE `scenes_fts` SET `markers` = (SELECT group_concat(`title`, ' ') FROM `scene_markers` WHERE `scene_id` = OLD.`scene_id`) " +
+				"WHERE `rowid` = OLD.`scene_id`; END"},
+		},
+	},
+	{
+		table:    "images_fts",
+		columns:  []string{"title", "path", "checksum"},
+		populate: "INSERT INTO `images_fts` (`rowid`, `title`, `path`, `checksum`) SELECT `id`, `title`, `path`, `checksum` FROM `images`",
+		triggers: []fullTextTrigger{
+			{"images_fts_insert", "AFTER INSERT ON `images` BEGIN " +
+				"INSERT INTO `images_fts` (`rowid`, `title`, `path`, `checksum`) " +
+				"VALUES (NEW.`id`, NEW.`title`, NEW.`path`, NEW.`checksum`); END"},
+			{"images_fts_update", "AFTER UPDATE OF `title`, `path`, `checksum` ON `images` BEGIN " +
+				"UPDATE `images_fts` SET `title` = NEW.`title`, `path` = NEW.`path`, `checksum` = NEW.`checksum` " +
+				"WHERE `rowid` = NEW.`id`; END"},
+			{"images_fts_delete", "AFTER DELETE ON `images` BEGIN " +
+				"DELETE FROM `images_fts` WHERE `rowid` = OLD.`id`; END"},
+		},
+	},
+	{
+		table:    "performers_fts",
+		columns:  []string{"name", "aliases"},
+		populate: "INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`) SELECT `id`, `name`, `aliases` FROM `performers`",
+		triggers: []fullTextTrigger{
+			{"performers_fts_insert", "AFTER INSERT ON `performers` BEGIN " +
+				"INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`) VALUES (NEW.`id`, NEW.`name`, NEW.`aliases`); END"},
+			{"performers_fts_update", "AFTER UPDATE OF `name`, `aliases` ON `performers` BEGIN " +
+				"UPDATE `performers_fts` SET `name` = NEW.`name`, `aliases` = NEW.`aliases` WHERE `rowid` = NEW.`id`; END"},
+			{"performers_fts_delete", "AFTER DELETE ON `performers` BEGIN " +
+				"DELETE FROM `performers_fts` WHERE `rowid` = OLD.`id`; END"},
+		},
+	},
+	aliasedFullTextIndex("studios", "studio_aliases", "studio_id"),
+	aliasedFullTextIndex("tags", "tag_aliases", "tag_id"),
+}
+
+// aliasedFullTextIndex returns the index of the names of the objects in
+// table and their aliases in aliasTable.
+func aliasedFullTextIndex(table string, aliasTable string, idColumn string) fullTextIndex {
+	ftsTable := table + "_fts"
+	aliases := fmt.Sprintf("(SELECT group_concat(`alias`, ' ') FROM `%s` WHERE `%s` = %%s)", aliasTable, idColumn)
+
+	return fullTextIndex{
+		table:   ftsTable,
+		columns: []string{"name", "aliases"},
+		populate: fmt.Sprintf("INSERT INTO `%s` (`rowid`, `name`, `aliases`) SELECT `id`, `name`, %s FROM `%s`",
+			ftsTable, fmt.Sprintf(aliases, "`"+table+"`.`id`"), table),
+		triggers: []fullTextTrigger{
+			{table + "_fts_insert", fmt.Sprintf("AFTER INSERT ON `%s` BEGIN "+
+				"INSERT INTO `%s` (`rowid`, `name`) VALUES (NEW.`id`, NEW.`name`); END", table, ftsTable)},
+			{table + "_fts_update", fmt.Sprintf("AFTER UPDATE OF `name` ON `%s` BEGIN "+
+				"UPDATE `%s` SET `name` = NEW.`name` WHERE `rowid` = NEW.`id`; END", table, ftsTable)},
+			{table + "_fts_delete", fmt.Sprintf("AFTER DELETE ON `%s` BEGIN "+
+				"DELETE FROM `%s` WHERE `rowid` = OLD.`id`; END", table, ftsTable)},
+			{aliasTable + "_fts_insert", fmt.Sprintf("AFTER INSERT ON `%s` BEGIN "+
+				"UPDATE `%s` SET `aliases` = %s WHERE `rowid` = NEW.`%s`; END",
+				aliasTable, ftsTable, fmt.Sprintf(aliases, "NEW.`"+idColumn+"`"), idColumn)},
+			{aliasTable + "_fts_delete", fmt.Sprintf("AFTER DELETE ON `%s` BEGIN "+
+				"UPDATE `%s` SET `aliases` = %s WHERE `rowid` = OLD.`%s`; END",
+				aliasTable, ftsTable, fmt.Sprintf(aliases, "OLD.`"+idColumn+"`"), idColumn)},
+		},
+	}
+}
+
+func checkFullTextSearch() (bool, error) {
+	db, err := sqlx.Connect(sqlite3Driver, ":memory:")
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	var enabled bool
+	if err := db.Get(&enabled, "SELECT sqlite_compileoption_used('ENABLE_FTS5')"); err != nil {
+		return false, err
+	}
+
+	return enabled, nil
+}
+
+// initFullTextSearch creates or rebuilds the full-text search indexes if
+// the FTS5 extension is available. Otherwise, the triggers maintaining the
+// indexes are removed, so that the indexed tables can still be written,
+// and searches fall back to matching each column.
+// The indexes are not created by a schema migration, since the extension
+// may be available to one build and not another. They are checked each
+// time the database is initialized instead.
+func initFullTextSearch() error {
+	available, err := checkFullTextSearch()
+	if err != nil {
+		return fmt.Errorf("checking for FTS5 extension: %w", err)
+	}
+
+	fullTextSearchAvailable = false
+
+	if !available {
+		logger.Warn("sqlite FTS5 extension not available: full-text search is disabled. Build with the sqlite_fts5 build tag to enable it.")
+		return WithTxn(func(tx *sqlx.Tx) error {
+			for _, i := range fullTextIndexes {
+				if err := dropFullTextTriggers(tx, i); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	for _, i := range fullTextIndexes {
+		if err := WithTxn(func(tx *sqlx.Tx) error {
+			return ensureFullTextIndex(tx, i)
+		}); err != nil {
+			return fmt.Errorf("creating full-text index %s: %w", i.table, err)
+		}
+	}
+
+	fullTextSearchAvailable = true
+	return nil
+}
+
+func dropFullTextTriggers(tx *sqlx.Tx, i fullTextIndex) error {
+	for _, t := range i.triggers {
+		if _, err := tx.Exec("DROP TRIGGER IF EXISTS `" + t.name + "`"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureFullTextIndex rebuilds the index if the index table or any of its
+// triggers are missing or out of date. The index may be stale if the
+// triggers were removed while the FTS5 extension was unavailable.
+func ensureFullTextIndex(tx *sqlx.Tx, i fullTextIndex) error {
+	var schema []struct {
+		Type string `db:"type"`
+		Name string `db:"name"`
+		SQL  string `db:"sql"`
+	}
+	if err := tx.Select(&schema, "SELECT type, name, sql FROM sqlite_master WHERE tbl_name = ? OR type = 'trigger'", i.table); err != nil {
+		return err
+	}
+
+	current := make(map[string]string)
+	for _, s := range schema {
+		current[s.Type+":"+s.Name] = s.SQL
+	}
+
+	upToDate := current["table:"+i.table] == i.createSQL()
+	for _, t := range i.triggers {
+		upToDate = upToDate && current["trigger:"+t.name] == t.createSQL()
+	}
+
+	if upToDate {
+		return nil
+	}
+
+	logger.Infof("Building full-text search index %s", i.table)
+
+	if err := dropFullTextTriggers(tx, i); err != nil {
+		return err
+	}
+
+	stmts := []string{
+		"DROP TABLE IF EXISTS `" + i.table + "`",
+		i.createSQL(),
+		i.populate,
+	}
+	for _, t := range i.triggers {
+		stmts = append(stmts, t.createSQL())
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
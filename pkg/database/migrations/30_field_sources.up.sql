@@ -0,0 +1,26 @@
+CREATE TABLE `scene_field_sources` (
+  `scene_id` integer not null,
+  `field` varchar(255) not null,
+  `source` varchar(255) not null,
+  `updated_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `field`)
+);
+
+CREATE TABLE `performer_field_sources` (
+  `performer_id` integer not null,
+  `field` varchar(255) not null,
+  `source` varchar(255) not null,
+  `updated_at` datetime not null,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  PRIMARY KEY(`performer_id`, `field`)
+);
+
+CREATE TABLE `studio_field_sources` (
+  `studio_id` integer not null,
+  `field` varchar(255) not null,
+  `source` varchar(255) not null,
+  `updated_at` datetime not null,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `field`)
+);
@@ -0,0 +1,52 @@
+CREATE TABLE `tombstones` (
+  `id` integer not null primary key autoincrement,
+  `object_type` varchar(255) not null,
+  `checksum` varchar(255),
+  `oshash` varchar(255),
+  `name` varchar(255),
+  `deleted_at` datetime not null
+);
+
+CREATE INDEX `index_tombstones_on_deleted_at` on `tombstones` (`deleted_at`);
+
+CREATE TRIGGER `scenes_tombstone` AFTER DELETE ON `scenes`
+BEGIN
+  INSERT INTO `tombstones` (`object_type`, `checksum`, `oshash`, `deleted_at`)
+  VALUES ('scene', OLD.`checksum`, OLD.`oshash`, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER `images_tombstone` AFTER DELETE ON `images`
+BEGIN
+  INSERT INTO `tombstones` (`object_type`, `checksum`, `deleted_at`)
+  VALUES ('image', OLD.`checksum`, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER `galleries_tombstone` AFTER DELETE ON `galleries`
+BEGIN
+  INSERT INTO `tombstones` (`object_type`, `checksum`, `deleted_at`)
+  VALUES ('gallery', OLD.`checksum`, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER `performers_tombstone` AFTER DELETE ON `performers`
+BEGIN
+  INSERT INTO `tombstones` (`object_type`, `checksum`, `name`, `deleted_at`)
+  VALUES ('performer', OLD.`checksum`, OLD.`name`, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER `studios_tombstone` AFTER DELETE ON `studios`
+BEGIN
+  INSERT INTO `tombstones` (`object_type`, `checksum`, `name`, `deleted_at`)
+  VALUES ('studio', OLD.`checksum`, OLD.`name`, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER `movies_tombstone` AFTER DELETE ON `movies`
+BEGIN
+  INSERT INTO `tombstones` (`object_type`, `checksum`, `name`, `deleted_at`)
+  VALUES ('movie', OLD.`checksum`, OLD.`name`, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER `tags_tombstone` AFTER DELETE ON `tags`
+BEGIN
+  INSERT INTO `tombstones` (`object_type`, `name`, `deleted_at`)
+  VALUES ('tag', OLD.`name`, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
@@ -0,0 +1,25 @@
+CREATE TABLE `playlists` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(255) not null,
+  `saved_filter_id` integer,
+  `sort` varchar(255),
+  `sort_direction` varchar(255),
+  `scene_limit` integer,
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`saved_filter_id`) references `saved_filters`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_playlists_on_name` on `playlists` (`name`);
+CREATE INDEX `index_playlists_on_saved_filter_id` on `playlists` (`saved_filter_id`);
+
+CREATE TABLE `playlists_scenes` (
+  `playlist_id` integer not null,
+  `scene_id` integer not null,
+  `scene_index` integer not null,
+  foreign key(`playlist_id`) references `playlists`(`id`) on delete CASCADE,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_playlists_scenes_on_playlist_id` on `playlists_scenes` (`playlist_id`);
+CREATE INDEX `index_playlists_scenes_on_scene_id` on `playlists_scenes` (`scene_id`);
@@ -0,0 +1,21 @@
+CREATE TABLE `folders` (
+  `id` integer not null primary key autoincrement,
+  `path` varchar(510) not null,
+  `parent_folder_id` integer,
+  `mod_time` datetime,
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`parent_folder_id`) references `folders`(`id`) on delete CASCADE
+);
+
+CREATE UNIQUE INDEX `index_folders_on_path_unique` on `folders` (`path`);
+CREATE INDEX `index_folders_on_parent_folder_id` on `folders` (`parent_folder_id`);
+
+-- folders are populated by the next scan
+ALTER TABLE `scenes` ADD COLUMN `folder_id` integer REFERENCES `folders`(`id`) ON DELETE SET NULL;
+ALTER TABLE `images` ADD COLUMN `folder_id` integer REFERENCES `folders`(`id`) ON DELETE SET NULL;
+ALTER TABLE `galleries` ADD COLUMN `folder_id` integer REFERENCES `folders`(`id`) ON DELETE SET NULL;
+
+CREATE INDEX `index_scenes_on_folder_id` on `scenes` (`folder_id`);
+CREATE INDEX `index_images_on_folder_id` on `images` (`folder_id`);
+CREATE INDEX `index_galleries_on_folder_id` on `galleries` (`folder_id`);
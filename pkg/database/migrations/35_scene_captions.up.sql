@@ -0,0 +1,13 @@
+CREATE TABLE `scene_captions` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer not null,
+  `language_code` varchar(255) not null,
+  `caption_type` varchar(255) not null,
+  `source` varchar(255) not null,
+  `path` varchar(510),
+  `stream_index` integer,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_captions_on_scene_id` on `scene_captions` (`scene_id`);
+CREATE INDEX `index_scene_captions_on_language_code` on `scene_captions` (`language_code`);
@@ -0,0 +1,11 @@
+CREATE TABLE `scene_audio_streams` (
+  `scene_id` integer not null,
+  `stream_index` integer not null,
+  `codec` varchar(255) not null,
+  `language_code` varchar(255) not null,
+  `channels` integer not null,
+  `title` varchar(255) not null,
+  `is_default` boolean not null default '0',
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `stream_index`)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE `images` ADD COLUMN `is_animated` boolean not null default '0';
+ALTER TABLE `images` ADD COLUMN `duration` float;
+-- existing images are checked for animation on the next scan
+ALTER TABLE `images` ADD COLUMN `animation_checked` boolean not null default '0';
@@ -0,0 +1,12 @@
+ALTER TABLE `images` ADD COLUMN `date` date;
+ALTER TABLE `images` ADD COLUMN `orientation` tinyint;
+ALTER TABLE `images` ADD COLUMN `camera_make` varchar(255);
+ALTER TABLE `images` ADD COLUMN `camera_model` varchar(255);
+ALTER TABLE `images` ADD COLUMN `lens` varchar(255);
+ALTER TABLE `images` ADD COLUMN `focal_length` float;
+ALTER TABLE `images` ADD COLUMN `aperture` float;
+ALTER TABLE `images` ADD COLUMN `exposure_time` float;
+ALTER TABLE `images` ADD COLUMN `iso` integer;
+ALTER TABLE `images` ADD COLUMN `latitude` float;
+ALTER TABLE `images` ADD COLUMN `longitude` float;
+CREATE INDEX `index_images_on_date` ON `images` (`date`);
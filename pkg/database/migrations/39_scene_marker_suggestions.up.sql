@@ -0,0 +1,27 @@
+CREATE TABLE `scene_marker_suggestions` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer not null,
+  `title` varchar(255) not null,
+  `seconds` float not null,
+  `end_seconds` float not null,
+  `primary_tag_id` integer,
+  `created_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`primary_tag_id`) references `tags`(`id`) on delete SET NULL
+);
+
+CREATE INDEX `index_scene_marker_suggestions_on_scene_id` on `scene_marker_suggestions` (`scene_id`);
+
+CREATE TABLE `scene_chapter_detections` (
+  `scene_id` integer not null primary key,
+  `detected_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE TABLE `scene_marker_suggestion_rejections` (
+  `scene_id` integer not null,
+  `seconds` float not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_marker_suggestion_rejections_on_scene_id` on `scene_marker_suggestion_rejections` (`scene_id`);
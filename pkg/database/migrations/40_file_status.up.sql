@@ -0,0 +1,8 @@
+ALTER TABLE `scenes` ADD COLUMN `file_status` varchar(255);
+ALTER TABLE `scenes` ADD COLUMN `file_status_error` text;
+ALTER TABLE `scenes` ADD COLUMN `file_checked_at` datetime;
+ALTER TABLE `images` ADD COLUMN `file_status` varchar(255);
+ALTER TABLE `images` ADD COLUMN `file_status_error` text;
+ALTER TABLE `images` ADD COLUMN `file_checked_at` datetime;
+CREATE INDEX `index_scenes_on_file_status` ON `scenes` (`file_status`);
+CREATE INDEX `index_images_on_file_status` ON `images` (`file_status`);
@@ -39,6 +39,7 @@ import (
 	"github.com/anacrolix/dms/upnpav"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/playlist"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -245,43 +246,14 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 		}
 	}
 
-	// Saved searches
-	// if obj.Path == "saved-searches" {
-	// 	var savedPlaylists []models.Playlist
-	// 	db, _ := models.GetDB()
-	// 	db.Where("is_deo_enabled = ?", true).Order("ordering asc").Find(&savedPlaylists)
-	// 	db.Close()
-
-	// 	for _, playlist := range savedPlaylists {
-	// 		objs = append(objs, upnpav.Container{Object: upnpav.Object{
-	// 			ID:         "saved-searches/" + strconv.Itoa(int(playlist.ID)),
-	// 			Restricted: 1,
-	// 			ParentID:   "saved-searches",
-	// 			Class:      "object.container.storageFolder",
-	// 			Title:      playlist.Name,
-	// 		}})
-	// 	}
-	// }
-
-	// if strings.HasPrefix(obj.Path, "saved-searches/") {
-	// 	id := strings.Split(obj.Path, "/")
-
-	// 	var savedPlaylist models.Playlist
-	// 	db, _ := models.GetDB()
-	// 	db.Where("id = ?", id[1]).First(&savedPlaylist)
-	// 	db.Close()
-
-	// 	var r models.RequestSceneList
-	// 	if err := json.Unmarshal([]byte(savedPlaylist.SearchParams), &r); err == nil {
-	// 		r.IsAccessible = optional.NewBool(true)
-	// 		r.IsAvailable = optional.NewBool(true)
-	// 		data := models.QueryScenesFull(r)
+	// Playlists
+	if obj.Path == "playlists" {
+		objs = me.getPlaylists()
+	}
 
-	// 		for i := range data.Scenes {
-	// 			objs = append(objs, me.sceneToContainer(data.Scenes[i], "sites/"+id[1], host))
-	// 		}
-	// 	}
-	// }
+	if strings.HasPrefix(obj.Path, "playlists/") {
+		objs = me.getPlaylistScenes(childPath(paths), host)
+	}
 
 	// Studios
 	if obj.Path == "studios" {
@@ -424,6 +396,7 @@ func getRootObjects() []interface{} {
 	objs = append(objs, makeStorageFolder("studios", "studios", rootID))
 	objs = append(objs, makeStorageFolder("movies", "movies", rootID))
 	objs = append(objs, makeStorageFolder("rating", "rating", rootID))
+	objs = append(objs, makeStorageFolder("playlists", "playlists", rootID))
 
 	return objs
 }
@@ -664,6 +637,61 @@ func (me *contentDirectoryService) getMovieScenes(paths []string, host string) [
 	return me.getVideos(sceneFilter, parentID, host)
 }
 
+func (me *contentDirectoryService) getPlaylists() []interface{} {
+	var objs []interface{}
+
+	if err := me.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		playlists, err := r.Playlist().All()
+		if err != nil {
+			return err
+		}
+
+		for _, p := range playlists {
+			objs = append(objs, makeStorageFolder("playlists/"+strconv.Itoa(p.ID), p.Name, "playlists"))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf(err.Error())
+	}
+
+	return objs
+}
+
+// getPlaylistScenes returns the scenes of the playlist in playlist order.
+// Playlist scenes are not paged, since paging would not preserve the order.
+func (me *contentDirectoryService) getPlaylistScenes(paths []string, host string) []interface{} {
+	playlistID, err := strconv.Atoi(paths[0])
+	if err != nil {
+		return nil
+	}
+
+	parentID := "playlists/" + strings.Join(paths, "/")
+
+	var objs []interface{}
+	if err := me.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		p, err := r.Playlist().Find(playlistID)
+		if err != nil || p == nil {
+			return err
+		}
+
+		scenes, err := playlist.Scenes(r, p)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			objs = append(objs, sceneToContainer(s, parentID, host))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
 func (me *contentDirectoryService) getRating() []interface{} {
 	var objs []interface{}
 
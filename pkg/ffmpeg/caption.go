@@ -0,0 +1,45 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// subtitleCaptionTypes maps the text-based subtitle codecs, which can be
+// converted to WebVTT, to their caption types. Image-based subtitles are
+// not supported.
+var subtitleCaptionTypes = map[string]string{
+	"subrip":   "srt",
+	"srt":      "srt",
+	"webvtt":   "vtt",
+	"ass":      "ass",
+	"ssa":      "ass",
+	"mov_text": "mov_text",
+	"text":     "txt",
+}
+
+// SubtitleCaptionType returns the caption type of the subtitle codec.
+// Returns an empty string if the subtitle codec cannot be converted to
+// WebVTT.
+func SubtitleCaptionType(codec string) string {
+	return subtitleCaptionTypes[strings.ToLower(codec)]
+}
+
+// CaptionToWebVTT converts a caption to WebVTT. If streamIndex is negative,
+// then path is a caption file. Otherwise, path is a video file and the
+// subtitle stream with the provided index is converted.
+func (e *Encoder) CaptionToWebVTT(path string, streamIndex int) ([]byte, error) {
+	args := []string{
+		"-v", "error",
+		"-i", path,
+	}
+
+	if streamIndex >= 0 {
+		args = append(args, "-map", fmt.Sprintf("0:%d", streamIndex))
+	}
+
+	args = append(args, "-f", "webvtt", "-")
+
+	data, err := e.run(path, args, nil)
+	return []byte(data), err
+}
@@ -2,6 +2,7 @@ package ffmpeg
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"os/exec"
@@ -136,7 +137,12 @@ func (e *Encoder) runTranscode(probeResult VideoFile, args []string) (string, er
 }
 
 func (e *Encoder) run(sourcePath string, args []string, stdin io.Reader) (string, error) {
-	cmd := exec.Command(string(*e), args...)
+	return e.runContext(context.Background(), sourcePath, args, stdin)
+}
+
+// runContext runs ffmpeg, killing the process if the context is cancelled.
+func (e *Encoder) runContext(ctx context.Context, sourcePath string, args []string, stdin io.Reader) (string, error) {
+	cmd := exec.CommandContext(ctx, string(*e), args...)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -0,0 +1,50 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// sceneCutWidth is the width the video is scaled to before detecting shot
+// changes, to speed up detection.
+const sceneCutWidth = 160
+
+var sceneCutTimeRE = regexp.MustCompile(`pts_time:([0-9.]+)`)
+
+// SceneCuts returns the times in seconds of the shot changes in the video.
+// The threshold is the minimum scene change score of a shot change, between
+// 0 and 1.
+func (e *Encoder) SceneCuts(probeResult VideoFile, threshold float64) ([]float64, error) {
+	args := []string{
+		"-v", "error",
+		"-i", probeResult.Path,
+		"-an",
+		"-sn",
+		"-vf", fmt.Sprintf("scale=%d:-2,select='gt(scene,%v)',metadata=print:file=-", sceneCutWidth, threshold),
+		"-f", "null",
+		"-",
+	}
+
+	data, err := e.run(probeResult.Path, args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSceneCuts(data), nil
+}
+
+func parseSceneCuts(data string) []float64 {
+	var ret []float64
+	for _, match := range sceneCutTimeRE.FindAllStringSubmatch(data, -1) {
+		t, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, t)
+	}
+
+	sort.Float64s(ret)
+	return ret
+}
@@ -109,3 +109,27 @@ func (e *Encoder) CopyVideo(probeResult VideoFile, options TranscodeOptions) {
 	}
 	_, _ = e.runTranscode(probeResult, args)
 }
+
+// TranscodeWithProfile transcodes the video using the provided profile. The
+// audio is removed if videoOnly is true.
+func (e *Encoder) TranscodeWithProfile(probeResult VideoFile, profile models.TranscodeProfile, options TranscodeOptions, videoOnly bool) {
+	args := append([]string{}, profile.InputArgs...)
+	args = append(args, "-i", probeResult.Path)
+
+	if videoOnly {
+		args = append(args, "-an")
+	}
+
+	args = append(args, "-c:v", profile.VideoCodec)
+
+	// don't set scale when copying video stream
+	if profile.VideoCodec != CopyStreamCodec {
+		scale := calculateTranscodeScale(probeResult, options.MaxTranscodeSize)
+		args = append(args, "-vf", "scale="+scale)
+	}
+
+	args = append(args, profileCodecArgs(profile)...)
+	args = append(args, "-f", profile.Container, options.OutputPath)
+
+	_, _ = e.runTranscode(probeResult, args)
+}
@@ -0,0 +1,152 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TrimRange is a section of a video, in seconds.
+type TrimRange struct {
+	Start float64
+	End   float64
+}
+
+// Duration returns the length of the section in seconds.
+func (r TrimRange) Duration() float64 {
+	return r.End - r.Start
+}
+
+type TrimOptions struct {
+	OutputPath string
+	// Ranges are the sections of the video to keep, in order.
+	Ranges []TrimRange
+	// StreamCopy copies the streams without re-encoding. The start of each
+	// range must be on a keyframe.
+	StreamCopy bool
+}
+
+// Trim writes the provided sections of the video to the output file. The
+// ffmpeg process is killed if the context is cancelled.
+func (e *Encoder) Trim(ctx context.Context, probeResult VideoFile, options TrimOptions) error {
+	if len(options.Ranges) == 0 {
+		return fmt.Errorf("no sections to keep")
+	}
+
+	if options.StreamCopy {
+		return e.trimCopy(ctx, probeResult, options)
+	}
+
+	args := []string{
+		"-v", "error",
+		"-i", probeResult.Path,
+		"-filter_complex", trimFilter(options.Ranges, probeResult.AudioCodec != ""),
+		"-map", "[v]",
+	}
+
+	if probeResult.AudioCodec != "" {
+		args = append(args, "-map", "[a]")
+	}
+
+	args = append(args, trimCodecArgs(options.OutputPath)...)
+	args = append(args, "-y", options.OutputPath)
+
+	_, err := e.runContext(ctx, probeResult.Path, args, nil)
+	return err
+}
+
+// trimCopy joins the sections of the video using the concat demuxer,
+// copying the streams.
+func (e *Encoder) trimCopy(ctx context.Context, probeResult VideoFile, options TrimOptions) error {
+	listFile, err := os.CreateTemp("", "stash-trim-*.txt")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(listFile.Name())
+
+	_, err = listFile.WriteString(concatList(probeResult.Path, options.Ranges))
+	if closeErr := listFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"-v", "error",
+		"-f", "concat",
+		"-safe", "0",
+		"-i", listFile.Name(),
+		"-map", "0:v:0",
+		"-map", "0:a?",
+		"-c", "copy",
+		"-avoid_negative_ts", "make_zero",
+		"-y", options.OutputPath,
+	}
+
+	_, err = e.runContext(ctx, probeResult.Path, args, nil)
+	return err
+}
+
+// concatList returns a concat demuxer script of the sections of the file.
+func concatList(path string, ranges []TrimRange) string {
+	// single quotes are escaped by closing the quoted string
+	quoted := "'" + strings.ReplaceAll(path, "'", `'\''`) + "'"
+
+	var b strings.Builder
+	b.WriteString("ffconcat version 1.0\n")
+	for _, r := range ranges {
+		fmt.Fprintf(&b, "file %s\ninpoint %v\noutpoint %v\n", quoted, r.Start, r.End)
+	}
+
+	return b.String()
+}
+
+// trimFilter returns a filter graph that joins the sections of the first
+// video and audio streams, producing the [v] and [a] outputs.
+func trimFilter(ranges []TrimRange, audio bool) string {
+	var filters []string
+	var inputs string
+	for i, r := range ranges {
+		filters = append(filters, fmt.Sprintf("[0:v:0]trim=start=%v:end=%v,setpts=PTS-STARTPTS[v%d]", r.Start, r.End, i))
+		inputs += fmt.Sprintf("[v%d]", i)
+
+		if audio {
+			filters = append(filters, fmt.Sprintf("[0:a:0]atrim=start=%v:end=%v,asetpts=PTS-STARTPTS[a%d]", r.Start, r.End, i))
+			inputs += fmt.Sprintf("[a%d]", i)
+		}
+	}
+
+	a := 0
+	outputs := "[v]"
+	if audio {
+		a = 1
+		outputs += "[a]"
+	}
+
+	filters = append(filters, fmt.Sprintf("%sconcat=n=%d:v=1:a=%d%s", inputs, len(ranges), a, outputs))
+	return strings.Join(filters, ";")
+}
+
+// trimCodecArgs returns the codec arguments used to re-encode trimmed
+// videos, based on the container of the output file.
+func trimCodecArgs(outputPath string) []string {
+	if strings.EqualFold(filepath.Ext(outputPath), ".webm") {
+		return []string{
+			"-c:v", "libvpx-vp9",
+			"-crf", "30",
+			"-b:v", "0",
+			"-c:a", "libopus",
+		}
+	}
+
+	return []string{
+		"-c:v", "libx264",
+		"-pix_fmt", "yuv420p",
+		"-preset", "fast",
+		"-crf", "18",
+		"-c:a", "aac",
+	}
+}
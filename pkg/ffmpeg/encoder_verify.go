@@ -0,0 +1,113 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/desktop"
+)
+
+type VerifyOptions struct {
+	// Samples is the number of evenly spaced sections of the video to
+	// decode. The entire video is decoded if zero.
+	Samples int
+	// SampleDuration is the length in seconds of each section.
+	SampleDuration float64
+}
+
+// VerifyResult is the result of decoding a video.
+type VerifyResult struct {
+	// Errors are the errors reported while decoding, in order.
+	Errors []string
+	// Failed is true if ffmpeg could not decode the video.
+	Failed bool
+}
+
+// Verify decodes the video and audio streams of the video, discarding the
+// output, and returns the errors reported by ffmpeg. An error is returned
+// if ffmpeg could not be run, or if the context is cancelled.
+func (e *Encoder) Verify(ctx context.Context, probeResult VideoFile, options VerifyOptions) (*VerifyResult, error) {
+	ret := &VerifyResult{}
+	for _, section := range verifySections(probeResult.Duration, options) {
+		args := []string{"-nostdin", "-v", "error"}
+		if section != nil {
+			args = append(args,
+				"-ss", fmt.Sprint(section.Start),
+				"-t", fmt.Sprint(section.Duration()),
+			)
+		}
+		args = append(args,
+			"-i", probeResult.Path,
+			"-map", "0:v:0",
+			"-map", "0:a?",
+			"-f", "null",
+			"-",
+		)
+
+		errs, failed, err := e.runVerify(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.Errors = append(ret.Errors, errs...)
+		ret.Failed = ret.Failed || failed
+	}
+
+	return ret, nil
+}
+
+// verifySections returns the sections of a video of the provided duration
+// to decode. A nil section is the entire video. The first and last sections
+// are at the start and end of the video, since truncated files are usually
+// missing the end.
+func verifySections(duration float64, options VerifyOptions) []*TrimRange {
+	if options.Samples <= 0 || duration <= float64(options.Samples)*options.SampleDuration {
+		return []*TrimRange{nil}
+	}
+
+	if options.Samples == 1 {
+		return []*TrimRange{{Start: 0, End: options.SampleDuration}}
+	}
+
+	step := (duration - options.SampleDuration) / float64(options.Samples-1)
+	ret := make([]*TrimRange, options.Samples)
+	for i := range ret {
+		start := float64(i) * step
+		ret[i] = &TrimRange{Start: start, End: start + options.SampleDuration}
+	}
+
+	return ret
+}
+
+// runVerify runs ffmpeg, returning the lines written to stderr and whether
+// ffmpeg exited with an error.
+func (e *Encoder) runVerify(ctx context.Context, args []string) ([]string, bool, error) {
+	cmd := exec.CommandContext(ctx, string(*e), args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	desktop.HideExecShell(cmd)
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return nil, false, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, false, err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, err != nil, nil
+}
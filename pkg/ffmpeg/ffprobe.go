@@ -1,12 +1,14 @@
 package ffmpeg
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -199,9 +201,11 @@ func IsStreamable(videoCodec string, audioCodec AudioCodec, container Container)
 }
 
 type VideoFile struct {
-	JSON        FFProbeJSON
-	AudioStream *FFProbeStream
-	VideoStream *FFProbeStream
+	JSON            FFProbeJSON
+	AudioStream     *FFProbeStream
+	VideoStream     *FFProbeStream
+	AudioStreams    []*FFProbeStream
+	SubtitleStreams []*FFProbeStream
 
 	Path         string
 	Title        string
@@ -264,6 +268,42 @@ func (f *FFProbe) GetReadFrameCount(vf *VideoFile) (int64, error) {
 	return fc.FrameCount, err
 }
 
+// KeyFrames returns the times in seconds of the keyframes of the first video
+// stream of the file.
+func (f *FFProbe) KeyFrames(ctx context.Context, videoPath string) ([]float64, error) {
+	args := []string{"-v", "error", "-select_streams", "v:0", "-show_entries", "packet=pts_time,flags", "-of", "csv=p=0", videoPath}
+	cmd := exec.CommandContext(ctx, string(*f), args...)
+	desktop.HideExecShell(cmd)
+	out, err := cmd.Output()
+
+	if err != nil {
+		return nil, fmt.Errorf("FFProbe encountered an error reading keyframes of <%s>: %s", videoPath, err.Error())
+	}
+
+	return parseKeyFrames(string(out)), nil
+}
+
+// parseKeyFrames parses the keyframe times from ffprobe packet output of
+// the form <pts_time>,<flags>.
+func parseKeyFrames(data string) []float64 {
+	var ret []float64
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) < 2 || !strings.Contains(fields[1], "K") {
+			continue
+		}
+
+		t, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, t)
+	}
+
+	sort.Float64s(ret)
+	return ret
+}
+
 func parse(filePath string, probeJSON *FFProbeJSON, stripExt bool) (*VideoFile, error) {
 	if probeJSON == nil {
 		return nil, fmt.Errorf("failed to get ffprobe json for <%s>", filePath)
@@ -306,6 +346,9 @@ func parse(filePath string, probeJSON *FFProbeJSON, stripExt bool) (*VideoFile,
 		result.AudioStream = audioStream
 	}
 
+	result.AudioStreams = result.GetAudioStreams()
+	result.SubtitleStreams = result.GetSubtitleStreams()
+
 	videoStream := result.GetVideoStream()
 	if videoStream != nil {
 		result.VideoStream = videoStream
@@ -358,6 +401,57 @@ func (v *VideoFile) GetVideoStream() *FFProbeStream {
 	return nil
 }
 
+// GetAudioStreams returns all audio streams of the video file.
+func (v *VideoFile) GetAudioStreams() []*FFProbeStream {
+	return v.getStreams("audio")
+}
+
+// GetSubtitleStreams returns all subtitle streams of the video file.
+func (v *VideoFile) GetSubtitleStreams() []*FFProbeStream {
+	return v.getStreams("subtitle")
+}
+
+// FindAudioStream returns the audio stream with the provided stream index,
+// or nil if the video file has no such audio stream.
+func (v *VideoFile) FindAudioStream(index int) *FFProbeStream {
+	for _, s := range v.AudioStreams {
+		if s.Index == index {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// DefaultAudioStream returns the audio stream that is played by default.
+// This is the first audio stream with the default disposition, or the first
+// audio stream if none has it. Returns nil if the video file has no audio
+// streams.
+func (v *VideoFile) DefaultAudioStream() *FFProbeStream {
+	for _, s := range v.AudioStreams {
+		if s.Disposition.Default == 1 {
+			return s
+		}
+	}
+
+	if len(v.AudioStreams) > 0 {
+		return v.AudioStreams[0]
+	}
+
+	return nil
+}
+
+func (v *VideoFile) getStreams(fileType string) []*FFProbeStream {
+	var ret []*FFProbeStream
+	for i, stream := range v.JSON.Streams {
+		if stream.CodecType == fileType {
+			ret = append(ret, &v.JSON.Streams[i])
+		}
+	}
+
+	return ret
+}
+
 func (v *VideoFile) getStreamIndex(fileType string, probeJSON FFProbeJSON) int {
 	for i, stream := range probeJSON.Streams {
 		if stream.CodecType == fileType {
@@ -3,6 +3,7 @@ package ffmpeg
 import (
 	"fmt"
 	"io"
+	"net/url"
 	"strings"
 )
 
@@ -21,6 +22,16 @@ func WriteHLSPlaylist(probeResult VideoFile, baseUrl string, w io.Writer) {
 	leftover := duration
 	upTo := 0.0
 
+	// retain the query parameters of the playlist, such as the selected
+	// audio stream, in the segment URLs
+	var query url.Values
+	if i := strings.Index(baseUrl, "?"); i != -1 {
+		query, _ = url.ParseQuery(baseUrl[i+1:])
+		baseUrl = baseUrl[0:i]
+	} else {
+		query = url.Values{}
+	}
+
 	i := strings.LastIndex(baseUrl, ".m3u8")
 	tsURL := baseUrl[0:i] + ".ts"
 
@@ -31,7 +42,8 @@ func WriteHLSPlaylist(probeResult VideoFile, baseUrl string, w io.Writer) {
 		}
 
 		fmt.Fprintf(w, "#EXTINF: %f,\n", thisLength)
-		fmt.Fprintf(w, "%s?start=%f\n", tsURL, upTo)
+		query.Set("start", fmt.Sprintf("%f", upTo))
+		fmt.Fprintf(w, "%s?%s\n", tsURL, query.Encode())
 
 		leftover -= thisLength
 		upTo += thisLength
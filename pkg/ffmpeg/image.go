@@ -4,11 +4,29 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 )
 
 var ErrUnsupportedFormat = errors.New("unsupported image format")
 
-func (e *Encoder) ImageThumbnail(image *bytes.Buffer, format *string, maxDimensions int, path string) ([]byte, error) {
+// orientationFilters are the filters that correct the EXIF orientations of
+// images.
+var orientationFilters = map[int]string{
+	2: "hflip",
+	3: "hflip,vflip",
+	4: "vflip",
+	5: "transpose=0",
+	6: "transpose=1",
+	7: "transpose=3",
+	8: "transpose=2",
+}
+
+// ImageThumbnail returns a thumbnail of the image resized to fit within
+// maxDimensions, encoded in the output format. The output format may be jpeg,
+// webp or avif. The image is rotated according to its EXIF orientation.
+func (e *Encoder) ImageThumbnail(image *bytes.Buffer, format *string, outputFormat string, maxDimensions int, orientation int, path string) ([]byte, error) {
 	// ffmpeg spends a long sniffing image format when data is piped through stdio, so we pass the format explicitly instead
 	ffmpegformat := ""
 	if format == nil {
@@ -22,19 +40,103 @@ func (e *Encoder) ImageThumbnail(image *bytes.Buffer, format *string, maxDimensi
 		ffmpegformat = "png_pipe"
 	case "webp":
 		ffmpegformat = "webp_pipe"
+	case "gif":
+		ffmpegformat = "gif"
+	}
+
+	vf := fmt.Sprintf("scale=%v:%v:force_original_aspect_ratio=decrease", maxDimensions, maxDimensions)
+	if filter, ok := orientationFilters[orientation]; ok {
+		vf = filter + "," + vf
 	}
 
+	// the orientation is applied explicitly
 	args := []string{
+		"-noautorotate",
 		"-f", ffmpegformat,
 		"-i", "-",
-		"-vf", fmt.Sprintf("scale=%v:%v:force_original_aspect_ratio=decrease", maxDimensions, maxDimensions),
-		"-c:v", "mjpeg",
-		"-q:v", "5",
-		"-f", "image2pipe",
-		"-",
+		"-vf", vf,
+	}
+
+	switch outputFormat {
+	case "webp":
+		args = append(args,
+			"-c:v", "libwebp",
+			"-quality", "70",
+		)
+	case "avif":
+		return e.avifImageThumbnail(image, args, path)
+	default:
+		args = append(args,
+			"-c:v", "mjpeg",
+			"-q:v", "5",
+		)
 	}
 
+	args = append(args, "-f", "image2pipe", "-")
+
 	data, err := e.run(path, args, image)
 
 	return []byte(data), err
 }
+
+func (e *Encoder) avifImageThumbnail(image *bytes.Buffer, args []string, path string) ([]byte, error) {
+	// the avif muxer needs a seekable output
+	out, err := os.CreateTemp("", "stash-thumbnail-*.avif")
+	if err != nil {
+		return nil, err
+	}
+	out.Close()
+	defer os.Remove(out.Name())
+
+	args = append(args,
+		"-c:v", "libaom-av1",
+		"-still-picture", "1",
+		"-crf", "35",
+		"-f", "avif",
+		"-y", out.Name(),
+	)
+
+	if _, err := e.run(path, args, image); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(out.Name())
+}
+
+// AnimatedImageThumbnail returns a looping animated WebP thumbnail of the
+// animated image or video clip at the provided path, limited to maxDuration
+// seconds. If format is set, the input is read from stdin with that format.
+func (e *Encoder) AnimatedImageThumbnail(path string, stdin io.Reader, format string, maxDimensions int, maxDuration float64) ([]byte, error) {
+	// the webp muxer needs a seekable output
+	out, err := os.CreateTemp("", "stash-thumbnail-*.webp")
+	if err != nil {
+		return nil, err
+	}
+	out.Close()
+	defer os.Remove(out.Name())
+
+	input := path
+	var args []string
+	if format != "" {
+		input = "-"
+		args = append(args, "-f", format)
+	}
+
+	args = append(args,
+		"-i", input,
+		"-t", strconv.FormatFloat(maxDuration, 'f', -1, 64),
+		"-vf", fmt.Sprintf("fps=15,scale=%v:%v:force_original_aspect_ratio=decrease", maxDimensions, maxDimensions),
+		"-an",
+		"-c:v", "libwebp",
+		"-loop", "0",
+		"-quality", "70",
+		"-f", "webp",
+		"-y", out.Name(),
+	)
+
+	if _, err := e.run(path, args, stdin); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(out.Name())
+}
@@ -47,8 +47,16 @@ type Codec struct {
 	Codec     string
 	format    string
 	MimeType  string
+	inputArgs []string
 	extraArgs []string
 	hls       bool
+	// copyAudio is true if the audio stream is copied as is
+	copyAudio bool
+}
+
+// Container returns the output container of the codec.
+func (c Codec) Container() Container {
+	return Container(c.format)
 }
 
 var CodecHLS = Codec{
@@ -126,6 +134,43 @@ var CodecMKVAudio = Codec{
 	},
 }
 
+// CodecRemuxMP4 copies the video stream, transcodes the audio and serves as
+// fragmented MP4. Used to play a non-default audio stream of a file that can
+// otherwise be streamed directly.
+var CodecRemuxMP4 = Codec{
+	Codec:    CopyStreamCodec,
+	format:   "mp4",
+	MimeType: MimeMp4,
+	extraArgs: []string{
+		"-movflags", "frag_keyframe+empty_moov",
+		"-c:a", "aac",
+	},
+}
+
+// CodecRemuxWebm is the WebM equivalent of CodecRemuxMP4, for VP8 and VP9
+// video streams.
+var CodecRemuxWebm = Codec{
+	Codec:    CopyStreamCodec,
+	format:   "webm",
+	MimeType: MimeWebm,
+	extraArgs: []string{
+		"-c:a", "libopus",
+		"-b:a", "96k",
+		"-vbr", "on",
+	},
+}
+
+// GetRemuxCodec returns the codec used to remux the video file with a
+// different audio stream.
+func GetRemuxCodec(probeResult VideoFile) Codec {
+	switch probeResult.VideoCodec {
+	case Vp8, Vp9:
+		return CodecRemuxWebm
+	default:
+		return CodecRemuxMP4
+	}
+}
+
 type TranscodeStreamOptions struct {
 	ProbeResult      VideoFile
 	Codec            Codec
@@ -135,6 +180,9 @@ type TranscodeStreamOptions struct {
 	// in some videos where the audio codec is not supported by ffmpeg
 	// ffmpeg fails if you try to transcode the audio
 	VideoOnly bool
+	// AudioStreamIndex is the index of the audio stream to include. The
+	// stream chosen by ffmpeg is used if nil.
+	AudioStreamIndex *int
 }
 
 func GetTranscodeStreamOptions(probeResult VideoFile, videoCodec Codec, audioCodec AudioCodec) TranscodeStreamOptions {
@@ -166,10 +214,18 @@ func (o TranscodeStreamOptions) getStreamArgs() []string {
 		args = append(args, "-t", strconv.Itoa(int(hlsSegmentLength)))
 	}
 
+	args = append(args, o.Codec.inputArgs...)
 	args = append(args,
 		"-i", o.ProbeResult.Path,
 	)
 
+	if o.AudioStreamIndex != nil {
+		args = append(args, "-map", "0:v:0")
+		if !o.VideoOnly {
+			args = append(args, "-map", "0:"+strconv.Itoa(*o.AudioStreamIndex))
+		}
+	}
+
 	if o.VideoOnly {
 		args = append(args, "-an")
 	}
@@ -190,9 +246,12 @@ func (o TranscodeStreamOptions) getStreamArgs() []string {
 		args = append(args, o.Codec.extraArgs...)
 	}
 
-	args = append(args,
+	if !o.VideoOnly && !o.Codec.copyAudio {
 		// this is needed for 5-channel ac3 files
-		"-ac", "2",
+		args = append(args, "-ac", "2")
+	}
+
+	args = append(args,
 		"-f", o.Codec.format,
 		"pipe:",
 	)
@@ -0,0 +1,65 @@
+package ffmpeg
+
+import (
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var profileMimeTypes = map[Container]string{
+	Mp4:      MimeMp4,
+	Webm:     MimeWebm,
+	Mpegts:   MimeMpegts,
+	Matroska: MimeMkv,
+}
+
+// ProfileStreamCodec returns the codec used to stream with the transcode
+// profile.
+func ProfileStreamCodec(p models.TranscodeProfile) Codec {
+	container := Container(p.Container)
+	ret := Codec{
+		Codec:     p.VideoCodec,
+		format:    p.Container,
+		MimeType:  profileMimeTypes[container],
+		inputArgs: p.InputArgs,
+		hls:       container == Mpegts,
+		copyAudio: p.AudioCodec == CopyStreamCodec,
+	}
+
+	if container == Mp4 {
+		// required to stream mp4 to a pipe
+		ret.extraArgs = append(ret.extraArgs, "-movflags", "frag_keyframe+empty_moov")
+	}
+
+	ret.extraArgs = append(ret.extraArgs, profileCodecArgs(p)...)
+
+	return ret
+}
+
+// profileCodecArgs returns the ffmpeg arguments of the transcode profile,
+// apart from the video codec and input arguments.
+func profileCodecArgs(p models.TranscodeProfile) []string {
+	var args []string
+
+	if p.AudioCodec != "" {
+		args = append(args, "-c:a", p.AudioCodec)
+	}
+
+	if p.AudioBitrate != "" {
+		args = append(args, "-b:a", p.AudioBitrate)
+	}
+
+	if p.Preset != "" {
+		args = append(args, "-preset", p.Preset)
+	}
+
+	if p.CRF != 0 {
+		args = append(args, "-crf", strconv.Itoa(p.CRF))
+	}
+
+	if p.VideoBitrate != "" {
+		args = append(args, "-b:v", p.VideoBitrate)
+	}
+
+	return append(args, p.OutputArgs...)
+}
@@ -0,0 +1,122 @@
+package file
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type movedFile struct {
+	from string
+	to   string
+}
+
+// Mover is used to safely move files on the filesystem. During a transaction,
+// files are moved using the Move method, creating the destination
+// directories as needed. If the transaction is rolled back, then the files
+// can be moved back to their original location with the Rollback method,
+// which also removes the created directories. If the transaction is
+// committed, the moves are cleared using the Commit method.
+type Mover struct {
+	RenamerRemover RenamerRemover
+	mkdir          func(path string, perm fs.FileMode) error
+	files          []movedFile
+	dirs           []string
+}
+
+func NewMover() *Mover {
+	return &Mover{
+		RenamerRemover: renamerRemoverImpl{
+			RenameFn:    os.Rename,
+			RemoveFn:    os.Remove,
+			RemoveAllFn: os.RemoveAll,
+			StatFn:      os.Stat,
+		},
+		mkdir: os.Mkdir,
+	}
+}
+
+// Move moves the file from the provided path to the destination path. An
+// error is returned if the destination already exists. Rollback should be
+// called to restore moved files if this function returns an error.
+func (m *Mover) Move(from string, to string) error {
+	// a case-only rename refers to the same file on case-insensitive
+	// filesystems
+	if !strings.EqualFold(from, to) {
+		if _, err := m.RenamerRemover.Stat(to); err == nil {
+			return fmt.Errorf("moving %q: destination %q already exists", from, to)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("check destination %q exists: %w", to, err)
+		}
+	}
+
+	if err := m.makeDirs(filepath.Dir(to)); err != nil {
+		return err
+	}
+
+	if err := m.RenamerRemover.Rename(from, to); err != nil {
+		return fmt.Errorf("moving %q to %q: %w", from, to, err)
+	}
+
+	m.files = append(m.files, movedFile{from: from, to: to})
+	return nil
+}
+
+// makeDirs creates the directory and any missing parent directories,
+// recording each created directory.
+func (m *Mover) makeDirs(dir string) error {
+	if _, err := m.RenamerRemover.Stat(dir); err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("check directory %q exists: %w", dir, err)
+	}
+
+	parent := filepath.Dir(dir)
+	if parent != dir {
+		if err := m.makeDirs(parent); err != nil {
+			return err
+		}
+	}
+
+	if err := m.mkdir(dir, 0755); err != nil {
+		return fmt.Errorf("creating directory %q: %w", dir, err)
+	}
+
+	m.dirs = append(m.dirs, dir)
+	return nil
+}
+
+// Rollback tries to move all moved files back to their original location,
+// removes the created directories and clears the moved list. Any errors
+// encountered are logged. All files will be attempted regardless of any
+// errors occurred.
+func (m *Mover) Rollback() {
+	for i := len(m.files) - 1; i >= 0; i-- {
+		f := m.files[i]
+		if err := m.RenamerRemover.Rename(f.to, f.from); err != nil {
+			logger.Warnf("Error restoring %q: %v", f.from, err)
+		}
+	}
+
+	// directories are removed in reverse order, so that sub-directories
+	// are removed before their parents
+	for i := len(m.dirs) - 1; i >= 0; i-- {
+		if err := m.RenamerRemover.Remove(m.dirs[i]); err != nil {
+			logger.Warnf("Error removing directory %q: %v", m.dirs[i], err)
+		}
+	}
+
+	m.files = nil
+	m.dirs = nil
+}
+
+// Commit clears the moved list.
+func (m *Mover) Commit() {
+	m.files = nil
+	m.dirs = nil
+}
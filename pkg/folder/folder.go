@@ -0,0 +1,97 @@
+package folder
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// ObjectFolderPath returns the path of the folder containing the object with
+// the provided path. For files within zip files, this is the folder
+// containing the zip file.
+func ObjectFolderPath(path string) string {
+	if file.IsZipPath(path) {
+		path, _ = file.ZipFilePath(path)
+	}
+
+	return filepath.Dir(path)
+}
+
+// isRoot returns true if the folder has no parent folder. This is the case
+// for library paths, and for folders outside of the library paths.
+func isRoot(path string, libraryPaths []string) bool {
+	parent := filepath.Dir(path)
+	if parent == path {
+		return true
+	}
+
+	for _, p := range libraryPaths {
+		if path == filepath.Clean(p) {
+			return true
+		}
+	}
+
+	for _, p := range libraryPaths {
+		if utils.IsPathInDir(p, path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getModTime(path string) models.NullSQLiteTimestamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return models.NullSQLiteTimestamp{}
+	}
+
+	// truncate to seconds, since we don't store beyond that in the database
+	return models.NullSQLiteTimestamp{
+		Timestamp: info.ModTime().Truncate(time.Second),
+		Valid:     true,
+	}
+}
+
+// GetOrCreate returns the folder with the provided path, creating it if it
+// does not exist. Parent folders are created as needed, up to the library
+// path containing the folder. The modification time of an existing folder
+// is updated if it has changed.
+func GetOrCreate(qb models.FolderReaderWriter, path string, libraryPaths []string) (*models.Folder, error) {
+	path = filepath.Clean(path)
+	modTime := getModTime(path)
+
+	existing, err := qb.FindByPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		if !modTime.Valid || existing.ModTime.Timestamp.Equal(modTime.Timestamp) {
+			return existing, nil
+		}
+
+		existing.ModTime = modTime
+		existing.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+		return qb.UpdateFull(*existing)
+	}
+
+	newFolder := models.NewFolder(path)
+	newFolder.ModTime = modTime
+
+	if !isRoot(path, libraryPaths) {
+		parent, err := GetOrCreate(qb, filepath.Dir(path), libraryPaths)
+		if err != nil {
+			return nil, err
+		}
+
+		newFolder.ParentFolderID = sql.NullInt64{Int64: int64(parent.ID), Valid: true}
+	}
+
+	return qb.Create(*newFolder)
+}
@@ -0,0 +1,63 @@
+package folder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetOrCreate(t *testing.T) {
+	const (
+		libraryID = 1
+		parentID  = 2
+		folderID  = 3
+	)
+
+	libraryPath := filepath.Join("stash", "library")
+	parentPath := filepath.Join(libraryPath, "parent")
+	folderPath := filepath.Join(parentPath, "folder")
+	existingPath := filepath.Join(libraryPath, "existing")
+
+	qb := &mocks.FolderReaderWriter{}
+
+	qb.On("FindByPath", folderPath).Return(nil, nil).Once()
+	qb.On("FindByPath", parentPath).Return(nil, nil).Once()
+	qb.On("FindByPath", libraryPath).Return(nil, nil).Once()
+
+	// library path has no parent
+	qb.On("Create", mock.MatchedBy(func(f models.Folder) bool {
+		return f.Path == libraryPath && !f.ParentFolderID.Valid
+	})).Return(&models.Folder{ID: libraryID, Path: libraryPath}, nil).Once()
+	qb.On("Create", mock.MatchedBy(func(f models.Folder) bool {
+		return f.Path == parentPath && f.ParentFolderID.Int64 == libraryID
+	})).Return(&models.Folder{ID: parentID, Path: parentPath}, nil).Once()
+	qb.On("Create", mock.MatchedBy(func(f models.Folder) bool {
+		return f.Path == folderPath && f.ParentFolderID.Int64 == parentID
+	})).Return(&models.Folder{ID: folderID, Path: folderPath}, nil).Once()
+
+	f, err := GetOrCreate(qb, folderPath, []string{libraryPath})
+	assert.Nil(t, err)
+	assert.Equal(t, folderID, f.ID)
+
+	// existing folder is returned as is
+	existing := &models.Folder{ID: folderID + 1, Path: existingPath}
+	qb.On("FindByPath", existingPath).Return(existing, nil).Once()
+
+	f, err = GetOrCreate(qb, existingPath, []string{libraryPath})
+	assert.Nil(t, err)
+	assert.Equal(t, existing, f)
+
+	qb.AssertExpectations(t)
+}
+
+func TestObjectFolderPath(t *testing.T) {
+	dir := filepath.Join("stash", "library")
+	zipPath := filepath.Join(dir, "gallery.zip")
+
+	assert.Equal(t, dir, ObjectFolderPath(filepath.Join(dir, "scene.mp4")))
+	assert.Equal(t, dir, ObjectFolderPath(zipPath+"\x00image.jpg"))
+}
@@ -0,0 +1,105 @@
+package gallery
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// MovedImage is an image whose path was updated when its gallery was moved.
+type MovedImage struct {
+	ID      int
+	OldPath string
+	NewPath string
+}
+
+// MoveZipImages updates the paths of the images within a moved zip gallery
+// to refer to the new zip file path. Returns the moved images.
+func MoveZipImages(qb models.ImageReaderWriter, galleryID int, oldZipPath string, newZipPath string) ([]MovedImage, error) {
+	images, err := qb.FindByGalleryID(galleryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []MovedImage
+	for _, i := range images {
+		zipPath, filename := file.ZipFilePath(i.Path)
+		if zipPath != oldZipPath {
+			continue
+		}
+
+		newPath := file.ZipFilename(newZipPath, filename)
+		logger.Debugf("%s moved from %s", file.ZipPathDisplayName(newPath), file.ZipPathDisplayName(i.Path))
+		if _, err := qb.Update(models.ImagePartial{
+			ID:        i.ID,
+			Path:      &newPath,
+			UpdatedAt: &models.SQLiteTimestamp{Timestamp: time.Now()},
+		}); err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, MovedImage{
+			ID:      i.ID,
+			OldPath: i.Path,
+			NewPath: newPath,
+		})
+	}
+
+	return ret, nil
+}
+
+// FindMovedFolderGallery returns the folder-based gallery of the provided
+// image if the gallery folder no longer exists, indicating that the folder
+// has been moved or renamed to folderPath. Returns nil if no such gallery
+// exists.
+func FindMovedFolderGallery(qb models.GalleryReader, imageID int, folderPath string, caseSensitiveFs bool) (*models.Gallery, error) {
+	galleries, err := qb.FindByImageID(imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range galleries {
+		if g.Zip || !g.Path.Valid || g.Path.String == folderPath {
+			continue
+		}
+
+		exists, _ := utils.FileExists(g.Path.String)
+		if !caseSensitiveFs {
+			// #1426 - if folder exists but is a case-insensitive match for
+			// the original folder name, then treat it as a move
+			if exists && strings.EqualFold(folderPath, g.Path.String) {
+				exists = false
+			}
+		}
+
+		if !exists {
+			return g, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// MoveFolderGallery updates the path and folder of a folder-based gallery
+// whose folder has been moved or renamed.
+func MoveFolderGallery(qb models.GalleryWriter, galleryID int, folderPath string, folderID sql.NullInt64) (*models.Gallery, error) {
+	checksum := utils.MD5FromString(folderPath)
+
+	partial := models.GalleryPartial{
+		ID:        galleryID,
+		Path:      &sql.NullString{String: folderPath, Valid: true},
+		Checksum:  &checksum,
+		UpdatedAt: &models.SQLiteTimestamp{Timestamp: time.Now()},
+	}
+
+	if folderID.Valid {
+		partial.FolderID = &folderID
+	}
+
+	return qb.UpdatePartial(partial)
+}
@@ -0,0 +1,113 @@
+package gallery
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMoveZipImages(t *testing.T) {
+	const (
+		galleryID    = 1
+		zipImageID   = 2
+		otherImageID = 3
+		imageName    = "image.jpg"
+		oldZipPath   = "old.zip"
+		newZipPath   = "new.zip"
+		otherPath    = "other.jpg"
+	)
+
+	qb := &mocks.ImageReaderWriter{}
+
+	qb.On("FindByGalleryID", galleryID).Return([]*models.Image{
+		{ID: zipImageID, Path: file.ZipFilename(oldZipPath, imageName)},
+		{ID: otherImageID, Path: otherPath},
+	}, nil).Once()
+
+	newPath := file.ZipFilename(newZipPath, imageName)
+	qb.On("Update", mock.MatchedBy(func(p models.ImagePartial) bool {
+		return p.ID == zipImageID && *p.Path == newPath
+	})).Return(nil, nil).Once()
+
+	moved, err := MoveZipImages(qb, galleryID, oldZipPath, newZipPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []MovedImage{
+		{
+			ID:      zipImageID,
+			OldPath: file.ZipFilename(oldZipPath, imageName),
+			NewPath: newPath,
+		},
+	}, moved)
+
+	qb.AssertExpectations(t)
+}
+
+func TestFindMovedFolderGallery(t *testing.T) {
+	const (
+		imageID         = 1
+		existingImageID = 2
+		movedGalleryID  = 3
+	)
+
+	dir := t.TempDir()
+	newPath := filepath.Join(dir, "new")
+
+	zipGallery := &models.Gallery{
+		ID:   movedGalleryID + 1,
+		Zip:  true,
+		Path: models.NullString(filepath.Join(dir, "missing.zip")),
+	}
+	existingGallery := &models.Gallery{
+		ID:   movedGalleryID + 2,
+		Path: models.NullString(dir),
+	}
+	movedGallery := &models.Gallery{
+		ID:   movedGalleryID,
+		Path: models.NullString(filepath.Join(dir, "old")),
+	}
+
+	qb := &mocks.GalleryReaderWriter{}
+	qb.On("FindByImageID", imageID).Return([]*models.Gallery{
+		zipGallery,
+		existingGallery,
+		movedGallery,
+	}, nil).Once()
+	qb.On("FindByImageID", existingImageID).Return([]*models.Gallery{
+		existingGallery,
+	}, nil).Once()
+
+	g, err := FindMovedFolderGallery(qb, imageID, newPath, true)
+	assert.Nil(t, err)
+	assert.Equal(t, movedGallery, g)
+
+	// gallery folder still exists
+	g, err = FindMovedFolderGallery(qb, existingImageID, newPath, true)
+	assert.Nil(t, err)
+	assert.Nil(t, g)
+
+	qb.AssertExpectations(t)
+}
+
+func TestMoveFolderGallery(t *testing.T) {
+	const (
+		galleryID = 1
+		folderID  = 2
+		newPath   = "new"
+	)
+
+	qb := &mocks.GalleryReaderWriter{}
+	qb.On("UpdatePartial", mock.MatchedBy(func(p models.GalleryPartial) bool {
+		return p.ID == galleryID && p.Path.String == newPath && p.FolderID.Int64 == folderID
+	})).Return(&models.Gallery{ID: galleryID}, nil).Once()
+
+	_, err := MoveFolderGallery(qb, galleryID, newPath, sql.NullInt64{Int64: folderID, Valid: true})
+	assert.Nil(t, err)
+
+	qb.AssertExpectations(t)
+}
@@ -38,3 +38,40 @@ func CountByTagID(r models.GalleryReader, id int) (int, error) {
 
 	return r.QueryCount(filter, nil)
 }
+
+// CountByFolderID returns the number of galleries in the folder. Galleries in
+// sub-folders up to the provided depth are included.
+func CountByFolderID(r models.GalleryReader, id int, depth *int) (int, error) {
+	filter := &models.GalleryFilterType{
+		ParentFolder: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{strconv.Itoa(id)},
+			Modifier: models.CriterionModifierIncludes,
+			Depth:    depth,
+		},
+	}
+
+	return r.QueryCount(filter, nil)
+}
+
+// FindByFolderID returns the folder-based gallery of the provided folder.
+// Returns nil if no such gallery exists.
+func FindByFolderID(r models.GalleryReader, folderID int) (*models.Gallery, error) {
+	isZip := false
+	filter := &models.GalleryFilterType{
+		ParentFolder: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{strconv.Itoa(folderID)},
+			Modifier: models.CriterionModifierIncludes,
+		},
+		IsZip: &isZip,
+	}
+
+	perPage := 1
+	galleries, _, err := r.Query(filter, &models.FindFilterType{
+		PerPage: &perPage,
+	})
+	if err != nil || len(galleries) == 0 {
+		return nil, err
+	}
+
+	return galleries[0], nil
+}
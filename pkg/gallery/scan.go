@@ -110,6 +110,8 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retGallery *models.Galler
 	isNewGallery := false
 	isUpdatedGallery := false
 	var g *models.Gallery
+	var oldPath string
+	var movedImages []MovedImage
 
 	// grab a mutex on the checksum
 	done := make(chan struct{})
@@ -133,7 +135,8 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retGallery *models.Galler
 			if exists {
 				logger.Infof("%s already exists.  Duplicate of %s ", path, g.Path.String)
 			} else {
-				logger.Infof("%s already exists.  Updating path...", path)
+				oldPath = g.Path.String
+				logger.Infof("%s moved from %s.  Updating path...", path, oldPath)
 				g.Path = sql.NullString{
 					String: path,
 					Valid:  true,
@@ -143,6 +146,12 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retGallery *models.Galler
 					return err
 				}
 
+				// images within the zip file are moved with it
+				movedImages, err = MoveZipImages(r.Image(), g.ID, oldPath, path)
+				if err != nil {
+					return err
+				}
+
 				isUpdatedGallery = true
 			}
 		} else if scanner.hasImages(path) { // don't create gallery if it has no images
@@ -185,6 +194,11 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retGallery *models.Galler
 		scanner.PluginCache.ExecutePostHooks(scanner.Ctx, g.ID, plugin.GalleryCreatePost, nil, nil)
 	} else if isUpdatedGallery {
 		scanner.PluginCache.ExecutePostHooks(scanner.Ctx, g.ID, plugin.GalleryUpdatePost, nil, nil)
+		scanner.PluginCache.ExecuteMovePostHooks(scanner.Ctx, g.ID, plugin.GalleryMovePost, oldPath, path)
+
+		for _, i := range movedImages {
+			scanner.PluginCache.ExecuteMovePostHooks(scanner.Ctx, i.ID, plugin.ImageMovePost, i.OldPath, i.NewPath)
+		}
 	}
 
 	scanImages = isNewGallery
@@ -20,7 +20,10 @@ type SceneUpdatePostHookExecutor interface {
 }
 
 type ScraperSource struct {
-	Name       string
+	Name string
+	// SourceID is recorded as the source of the fields set from this source.
+	// It is the stash-box endpoint or the scraper id.
+	SourceID   string
 	Options    *models.IdentifyMetadataOptionsInput
 	Scraper    SceneScraper
 	RemoteSite string
@@ -93,6 +96,23 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 
 	fieldOptions := getFieldOptions(options)
 
+	skipUserEdited := false
+	for _, o := range options {
+		if o.SkipUserEdited != nil {
+			skipUserEdited = *o.SkipUserEdited
+			break
+		}
+	}
+
+	if skipUserEdited {
+		sources, err := repo.Scene().GetFieldSources(s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting scene field sources: %w", err)
+		}
+
+		fieldOptions = ignoreFields(fieldOptions, models.UserEditedFields(sources))
+	}
+
 	setOrganized := false
 	for _, o := range options {
 		if o.SetOrganized != nil {
@@ -185,6 +205,11 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, txnManager models.Tra
 			return fmt.Errorf("error updating scene: %w", err)
 		}
 
+		fieldSources := models.NewFieldSources(result.source.SourceID, getUpdatedFields(updater))
+		if err := repo.Scene().UpdateFieldSources(s.ID, fieldSources); err != nil {
+			return fmt.Errorf("error updating scene field sources: %w", err)
+		}
+
 		as := ""
 		title := updater.Partial.Title
 		if title != nil {
@@ -221,6 +246,59 @@ func getFieldOptions(options []models.IdentifyMetadataOptionsInput) map[string]*
 	return ret
 }
 
+// ignoreFields returns a copy of fieldOptions with the strategy of the
+// provided fields set to IGNORE.
+func ignoreFields(fieldOptions map[string]*models.IdentifyFieldOptionsInput, fields []string) map[string]*models.IdentifyFieldOptionsInput {
+	ret := make(map[string]*models.IdentifyFieldOptionsInput)
+	for k, v := range fieldOptions {
+		ret[k] = v
+	}
+
+	for _, f := range fields {
+		o := &models.IdentifyFieldOptionsInput{
+			Field:    f,
+			Strategy: models.IdentifyFieldStrategyIgnore,
+		}
+		if existing := ret[f]; existing != nil {
+			o.CreateMissing = existing.CreateMissing
+		}
+		ret[f] = o
+	}
+
+	return ret
+}
+
+// getUpdatedFields returns the names of the scene fields that are set by
+// the updater.
+func getUpdatedFields(u *scene.UpdateSet) []string {
+	var ret []string
+	p := u.Partial
+
+	if p.Title != nil {
+		ret = append(ret, "title")
+	}
+	if p.Date != nil {
+		ret = append(ret, "date")
+	}
+	if p.Details != nil {
+		ret = append(ret, "details")
+	}
+	if p.URL != nil {
+		ret = append(ret, "url")
+	}
+	if p.StudioID != nil {
+		ret = append(ret, "studio")
+	}
+	if u.PerformerIDs != nil {
+		ret = append(ret, "performers")
+	}
+	if u.TagIDs != nil {
+		ret = append(ret, "tags")
+	}
+
+	return ret
+}
+
 func getScenePartial(scene *models.Scene, scraped *models.ScrapedScene, fieldOptions map[string]*models.IdentifyFieldOptionsInput, setOrganized bool) models.ScenePartial {
 	partial := models.ScenePartial{
 		ID: scene.ID,
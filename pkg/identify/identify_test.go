@@ -76,6 +76,7 @@ func TestSceneIdentifier_Identify(t *testing.T) {
 	repo.Scene().(*mocks.SceneReaderWriter).On("Update", mock.MatchedBy(func(partial models.ScenePartial) bool {
 		return partial.ID == errUpdateID
 	})).Return(nil, errors.New("update error"))
+	repo.Scene().(*mocks.SceneReaderWriter).On("UpdateFieldSources", mock.Anything, mock.Anything).Return(nil)
 
 	tests := []struct {
 		name    string
@@ -235,6 +236,71 @@ func Test_getFieldOptions(t *testing.T) {
 	}
 }
 
+func Test_ignoreFields(t *testing.T) {
+	const (
+		title   = "title"
+		details = "details"
+		studio  = "studio"
+	)
+
+	createMissing := true
+
+	fieldOptions := map[string]*models.IdentifyFieldOptionsInput{
+		title: {
+			Field:    title,
+			Strategy: models.IdentifyFieldStrategyOverwrite,
+		},
+		studio: {
+			Field:         studio,
+			Strategy:      models.IdentifyFieldStrategyMerge,
+			CreateMissing: &createMissing,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   map[string]*models.IdentifyFieldOptionsInput
+	}{
+		{
+			"none",
+			nil,
+			fieldOptions,
+		},
+		{
+			"existing and new",
+			[]string{title, details, studio},
+			map[string]*models.IdentifyFieldOptionsInput{
+				title: {
+					Field:    title,
+					Strategy: models.IdentifyFieldStrategyIgnore,
+				},
+				details: {
+					Field:    details,
+					Strategy: models.IdentifyFieldStrategyIgnore,
+				},
+				studio: {
+					Field:         studio,
+					Strategy:      models.IdentifyFieldStrategyIgnore,
+					CreateMissing: &createMissing,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ignoreFields(fieldOptions, tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ignoreFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// ensure the original map was not modified
+	if fieldOptions[title].Strategy != models.IdentifyFieldStrategyOverwrite {
+		t.Errorf("ignoreFields() modified the original field options")
+	}
+}
+
 func Test_getScenePartial(t *testing.T) {
 	var (
 		originalTitle   = "originalTitle"
@@ -10,7 +10,29 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
-func getPerformerID(endpoint string, r models.Repository, p *models.ScrapedPerformer, createMissing bool) (*int, error) {
+// createdPerformerFields are the scraped performer fields that are set when
+// creating a missing performer.
+var createdPerformerFields = []string{
+	"name",
+	"birthdate",
+	"death_date",
+	"gender",
+	"ethnicity",
+	"country",
+	"eye_color",
+	"hair_color",
+	"height",
+	"measurements",
+	"fake_tits",
+	"career_length",
+	"tattoos",
+	"piercings",
+	"aliases",
+	"twitter",
+	"instagram",
+}
+
+func getPerformerID(source ScraperSource, r models.Repository, p *models.ScrapedPerformer, createMissing bool) (*int, error) {
 	if p.StoredID != nil {
 		// existing performer, just add it
 		performerID, err := strconv.Atoi(*p.StoredID)
@@ -20,18 +42,30 @@ func getPerformerID(endpoint string, r models.Repository, p *models.ScrapedPerfo
 
 		return &performerID, nil
 	} else if createMissing && p.Name != nil { // name is mandatory
-		return createMissingPerformer(endpoint, r, p)
+		return createMissingPerformer(source, r, p)
 	}
 
 	return nil, nil
 }
 
-func createMissingPerformer(endpoint string, r models.Repository, p *models.ScrapedPerformer) (*int, error) {
+func createMissingPerformer(source ScraperSource, r models.Repository, p *models.ScrapedPerformer) (*int, error) {
 	created, err := r.Performer().Create(scrapedToPerformerInput(p))
 	if err != nil {
 		return nil, fmt.Errorf("error creating performer: %w", err)
 	}
 
+	var fields []string
+	for _, f := range utils.NotNilFields(*p, "json") {
+		if utils.StrInclude(createdPerformerFields, f) {
+			fields = append(fields, f)
+		}
+	}
+
+	if err := r.Performer().UpdateFieldSources(created.ID, models.NewFieldSources(source.SourceID, fields)); err != nil {
+		return nil, fmt.Errorf("error setting performer field sources: %w", err)
+	}
+
+	endpoint := source.RemoteSite
 	if endpoint != "" && p.RemoteSiteID != nil {
 		if err := r.Performer().UpdateStashIDs(created.ID, []models.StashID{
 			{
@@ -27,6 +27,7 @@ func Test_getPerformerID(t *testing.T) {
 	repo.PerformerMock().On("Create", mock.Anything).Return(&models.Performer{
 		ID: validStoredID,
 	}, nil)
+	repo.PerformerMock().On("UpdateFieldSources", validStoredID, mock.Anything).Return(nil)
 
 	type args struct {
 		endpoint      string
@@ -110,7 +111,7 @@ func Test_getPerformerID(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getPerformerID(tt.args.endpoint, repo, tt.args.p, tt.args.createMissing)
+			got, err := getPerformerID(ScraperSource{RemoteSite: tt.args.endpoint}, repo, tt.args.p, tt.args.createMissing)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getPerformerID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -141,6 +142,8 @@ func Test_createMissingPerformer(t *testing.T) {
 		return p.Name.String == invalidName
 	})).Return(nil, errors.New("error creating performer"))
 
+	repo.PerformerMock().On("UpdateFieldSources", performerID, mock.Anything).Return(nil)
+
 	repo.PerformerMock().On("UpdateStashIDs", performerID, []models.StashID{
 		{
 			Endpoint: invalidEndpoint,
@@ -213,7 +216,7 @@ func Test_createMissingPerformer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createMissingPerformer(tt.args.endpoint, repo, tt.args.p)
+			got, err := createMissingPerformer(ScraperSource{RemoteSite: tt.args.endpoint}, repo, tt.args.p)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createMissingPerformer() error = %v, wantErr %v", err, tt.wantErr)
 				return
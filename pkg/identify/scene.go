@@ -25,7 +25,6 @@ func (g sceneRelationships) studio() (*int64, error) {
 	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
 
 	scraped := g.result.result.Studio
-	endpoint := g.result.source.RemoteSite
 
 	if scraped == nil || !shouldSetSingleValueField(fieldStrategy, existingID.Valid) {
 		return nil, nil
@@ -43,7 +42,7 @@ func (g sceneRelationships) studio() (*int64, error) {
 			return &studioID, nil
 		}
 	} else if createMissing {
-		return createMissingStudio(endpoint, g.repo, scraped)
+		return createMissingStudio(g.result.source, g.repo, scraped)
 	}
 
 	return nil, nil
@@ -65,7 +64,6 @@ func (g sceneRelationships) performers(ignoreMale bool) ([]int, error) {
 	}
 
 	repo := g.repo
-	endpoint := g.result.source.RemoteSite
 
 	var performerIDs []int
 	originalPerformerIDs, err := repo.Scene().GetPerformerIDs(g.scene.ID)
@@ -83,7 +81,7 @@ func (g sceneRelationships) performers(ignoreMale bool) ([]int, error) {
 			continue
 		}
 
-		performerID, err := getPerformerID(endpoint, repo, p, createMissing)
+		performerID, err := getPerformerID(g.result.source, repo, p, createMissing)
 		if err != nil {
 			return nil, err
 		}
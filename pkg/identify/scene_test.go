@@ -27,6 +27,7 @@ func Test_sceneRelationships_studio(t *testing.T) {
 	repo.StudioMock().On("Create", mock.Anything).Return(&models.Studio{
 		ID: int(validStoredIDInt),
 	}, nil)
+	repo.StudioMock().On("UpdateFieldSources", int(validStoredIDInt), mock.Anything).Return(nil)
 
 	tr := sceneRelationships{
 		repo:         repo,
@@ -9,12 +9,22 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
-func createMissingStudio(endpoint string, repo models.Repository, studio *models.ScrapedStudio) (*int64, error) {
+func createMissingStudio(source ScraperSource, repo models.Repository, studio *models.ScrapedStudio) (*int64, error) {
 	created, err := repo.Studio().Create(scrapedToStudioInput(studio))
 	if err != nil {
 		return nil, fmt.Errorf("error creating studio: %w", err)
 	}
 
+	fields := []string{"name"}
+	if studio.URL != nil {
+		fields = append(fields, "url")
+	}
+
+	if err := repo.Studio().UpdateFieldSources(created.ID, models.NewFieldSources(source.SourceID, fields)); err != nil {
+		return nil, fmt.Errorf("error setting studio field sources: %w", err)
+	}
+
+	endpoint := source.RemoteSite
 	if endpoint != "" && studio.RemoteSiteID != nil {
 		if err := repo.Studio().UpdateStashIDs(created.ID, []models.StashID{
 			{
@@ -30,6 +30,8 @@ func Test_createMissingStudio(t *testing.T) {
 		return p.Name.String == invalidName
 	})).Return(nil, errors.New("error creating performer"))
 
+	repo.StudioMock().On("UpdateFieldSources", createdID, mock.Anything).Return(nil)
+
 	repo.StudioMock().On("UpdateStashIDs", createdID, []models.StashID{
 		{
 			Endpoint: invalidEndpoint,
@@ -102,7 +104,7 @@ func Test_createMissingStudio(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createMissingStudio(tt.args.endpoint, repo, tt.args.studio)
+			got, err := createMissingStudio(ScraperSource{RemoteSite: tt.args.endpoint}, repo, tt.args.studio)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createMissingStudio() error = %v, wantErr %v", err, tt.wantErr)
 				return
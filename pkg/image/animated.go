@@ -0,0 +1,446 @@
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// imageContentTypes maps the extensions of image files to their content
+// types. Not all of these are known by the mime package on all systems.
+var imageContentTypes = map[string]string{
+	".gif":  "image/gif",
+	".jpeg": "image/jpeg",
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+}
+
+// clipContentTypes maps the extensions of video files that may be scanned
+// as video clips to their content types. Clips are played by the browser in
+// the image viewer, so other video formats are always scanned as scenes.
+var clipContentTypes = map[string]string{
+	".m4v":  "video/mp4",
+	".mkv":  "video/x-matroska",
+	".mov":  "video/quicktime",
+	".mp4":  "video/mp4",
+	".webm": "video/webm",
+}
+
+// ContentType returns the content type of the image file based on its
+// extension, or an empty string if unknown.
+func ContentType(path string) string {
+	_, fn := file.ZipFilePath(path)
+	ext := strings.ToLower(filepath.Ext(fn))
+	if ret, found := clipContentTypes[ext]; found {
+		return ret
+	}
+
+	return imageContentTypes[ext]
+}
+
+// IsClipExtension returns true if video files with the extension may be
+// scanned as video clips. The extension may omit the leading period.
+func IsClipExtension(ext string) bool {
+	_, found := clipContentTypes["."+strings.ToLower(strings.TrimPrefix(ext, "."))]
+	return found
+}
+
+// IsClip returns true if the image is a video clip.
+func IsClip(i *models.Image) bool {
+	_, fn := file.ZipFilePath(i.Path)
+	return IsClipExtension(filepath.Ext(fn))
+}
+
+// getAnimationDuration returns the duration in seconds of an animated GIF,
+// WebP or PNG image. Returns false if the image is not animated.
+func getAnimationDuration(r io.Reader, format string) (float64, bool) {
+	switch format {
+	case "gif":
+		return getGIFDuration(r)
+	case "webp":
+		return getWebPDuration(r)
+	case "png":
+		return getAPNGDuration(r)
+	}
+
+	return 0, false
+}
+
+// getGIFDuration sums the frame delays of the graphic control extensions of
+// an animated GIF image. The blocks are walked without decoding the frames.
+func getGIFDuration(r io.Reader) (float64, bool) {
+	br := bufio.NewReader(r)
+
+	// header and logical screen descriptor
+	header := make([]byte, 13)
+	if _, err := io.ReadFull(br, header); err != nil || string(header[0:3]) != "GIF" {
+		return 0, false
+	}
+
+	if err := skipGIFColorTable(br, header[10]); err != nil {
+		return 0, false
+	}
+
+	frames := 0
+	total := 0
+	for {
+		introducer, err := br.ReadByte()
+		if err != nil {
+			break
+		}
+
+		switch introducer {
+		case 0x21:
+			// extension
+			label, err := br.ReadByte()
+			if err != nil {
+				return 0, false
+			}
+
+			if label == 0xF9 {
+				// graphic control extension
+				data := make([]byte, 5)
+				if _, err := io.ReadFull(br, data); err != nil {
+					return 0, false
+				}
+				// delays are in hundredths of a second
+				total += int(binary.LittleEndian.Uint16(data[2:4]))
+			}
+
+			if err := skipGIFSubBlocks(br); err != nil {
+				return 0, false
+			}
+		case 0x2C:
+			// image descriptor
+			descriptor := make([]byte, 9)
+			if _, err := io.ReadFull(br, descriptor); err != nil {
+				return 0, false
+			}
+			if err := skipGIFColorTable(br, descriptor[8]); err != nil {
+				return 0, false
+			}
+
+			// lzw minimum code size followed by the image data
+			if _, err := br.ReadByte(); err != nil {
+				return 0, false
+			}
+			if err := skipGIFSubBlocks(br); err != nil {
+				return 0, false
+			}
+
+			frames++
+		default:
+			// trailer or invalid data
+			if frames < 2 {
+				return 0, false
+			}
+			return float64(total) / 100, true
+		}
+	}
+
+	if frames < 2 {
+		return 0, false
+	}
+
+	return float64(total) / 100, true
+}
+
+func skipGIFColorTable(br *bufio.Reader, flags byte) error {
+	if flags&0x80 == 0 {
+		return nil
+	}
+
+	size := int64(3 * (1 << ((flags & 0x07) + 1)))
+	_, err := io.CopyN(io.Discard, br, size)
+	return err
+}
+
+func skipGIFSubBlocks(br *bufio.Reader) error {
+	for {
+		size, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if size == 0 {
+			return nil
+		}
+
+		if _, err := io.CopyN(io.Discard, br, int64(size)); err != nil {
+			return err
+		}
+	}
+}
+
+// getWebPDuration sums the durations of the ANMF chunks of an animated WebP
+// image.
+func getWebPDuration(r io.Reader) (float64, bool) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(br, header); err != nil || string(header[0:4]) != "RIFF" || string(header[8:12]) != "WEBP" {
+		return 0, false
+	}
+
+	animated := false
+	frames := 0
+	totalMs := 0
+	chunkHeader := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(br, chunkHeader); err != nil {
+			break
+		}
+
+		chunkType := string(chunkHeader[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunkHeader[4:8]))
+		// chunks are padded to an even size
+		size += size & 1
+
+		switch chunkType {
+		case "VP8X":
+			flags, err := br.ReadByte()
+			if err != nil || size < 1 {
+				return 0, false
+			}
+			animated = flags&0x02 != 0
+			if !animated {
+				return 0, false
+			}
+			size--
+		case "VP8 ", "VP8L":
+			// simple formats are never animated
+			if !animated {
+				return 0, false
+			}
+		case "ANMF":
+			// frame x, y, width and height precede the 24-bit duration
+			data := make([]byte, 16)
+			if size < 16 {
+				return 0, false
+			}
+			if _, err := io.ReadFull(br, data); err != nil {
+				return 0, false
+			}
+			totalMs += int(data[12]) | int(data[13])<<8 | int(data[14])<<16
+			frames++
+			size -= 16
+		}
+
+		if _, err := io.CopyN(io.Discard, br, size); err != nil {
+			break
+		}
+	}
+
+	if !animated || frames < 2 {
+		return 0, false
+	}
+
+	return float64(totalMs) / 1000, true
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// getAPNGDuration sums the frame delays of the fcTL chunks of an animated
+// PNG image.
+func getAPNGDuration(r io.Reader) (float64, bool) {
+	br := bufio.NewReader(r)
+
+	signature := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(br, signature); err != nil || !bytes.Equal(signature, pngSignature) {
+		return 0, false
+	}
+
+	animated := false
+	frames := 0
+	total := 0.0
+	chunkHeader := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(br, chunkHeader); err != nil {
+			break
+		}
+
+		size := int64(binary.BigEndian.Uint32(chunkHeader[0:4]))
+		chunkType := string(chunkHeader[4:8])
+
+		switch chunkType {
+		case "acTL":
+			animated = true
+		case "fcTL":
+			// sequence number, width, height, x and y offsets precede the
+			// delay numerator and denominator
+			data := make([]byte, 24)
+			if size < 24 {
+				return 0, false
+			}
+			if _, err := io.ReadFull(br, data); err != nil {
+				return 0, false
+			}
+			num := float64(binary.BigEndian.Uint16(data[20:22]))
+			den := float64(binary.BigEndian.Uint16(data[22:24]))
+			// a zero denominator means hundredths of a second
+			if den == 0 {
+				den = 100
+			}
+			total += num / den
+			frames++
+			size -= 24
+		case "IDAT":
+			// the animation control chunk must precede the image data
+			if !animated {
+				return 0, false
+			}
+		case "IEND":
+			size = 0
+		}
+
+		// skip the remaining data and the crc
+		if _, err := io.CopyN(io.Discard, br, size+4); err != nil {
+			break
+		}
+
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	if !animated || frames < 2 {
+		return 0, false
+	}
+
+	return total, true
+}
+
+// mayBeAnimated returns true if the image may be an animated image that was
+// not checked for animation. Images scanned before animated images were
+// supported are checked once, on the next scan.
+func mayBeAnimated(i *models.Image) bool {
+	if i.AnimationChecked {
+		return false
+	}
+
+	contentType := ContentType(i.Path)
+	return contentType == "image/gif" || contentType == "image/webp"
+}
+
+// updateAnimationDetails checks images that may be animated, setting their
+// animation details. Returns true if the image is animated.
+func updateAnimationDetails(i *models.Image) (bool, error) {
+	format := strings.TrimPrefix(ContentType(i.Path), "image/")
+	if err := setAnimationDetails(i, format); err != nil {
+		return false, err
+	}
+
+	return i.IsAnimated, nil
+}
+
+// setAnimationDetails sets the animation details of an image with the
+// provided format.
+func setAnimationDetails(i *models.Image, format string) error {
+	f, err := openSourceImage(i.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	duration, animated := getAnimationDuration(f, format)
+	i.AnimationChecked = true
+	i.IsAnimated = animated
+	i.Duration = sql.NullFloat64{}
+	if animated {
+		i.Duration = sql.NullFloat64{Float64: duration, Valid: true}
+	}
+
+	return nil
+}
+
+type videoFileCreator interface {
+	NewVideoFile(path string, stripExt bool) (*ffmpeg.VideoFile, error)
+}
+
+// SetClipDetails sets the size, dimensions and duration of a video clip.
+func SetClipDetails(i *models.Image, ffprobe videoFileCreator) error {
+	f, err := stat(i.Path)
+	if err != nil {
+		return err
+	}
+
+	videoFile, err := probeClip(i.Path, ffprobe)
+	if err != nil {
+		return err
+	}
+
+	i.Width = sql.NullInt64{Int64: int64(videoFile.Width), Valid: true}
+	i.Height = sql.NullInt64{Int64: int64(videoFile.Height), Valid: true}
+	i.AnimationChecked = true
+	i.IsAnimated = true
+	i.Duration = sql.NullFloat64{Float64: videoFile.Duration, Valid: true}
+	i.Size = sql.NullInt64{Int64: f.Size(), Valid: true}
+
+	return nil
+}
+
+// GetClipDuration returns the duration in seconds of the video file at
+// path, which may be in a zip file.
+func GetClipDuration(path string, ffprobe videoFileCreator) (float64, error) {
+	videoFile, err := probeClip(path, ffprobe)
+	if err != nil {
+		return 0, err
+	}
+
+	return videoFile.Duration, nil
+}
+
+func probeClip(path string, ffprobe videoFileCreator) (*ffmpeg.VideoFile, error) {
+	var ret *ffmpeg.VideoFile
+	if err := withLocalFile(path, func(path string) error {
+		var err error
+		ret, err = ffprobe.NewVideoFile(path, false)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// withLocalFile calls fn with the path of the image file on the file
+// system. Files in zip files are extracted to a temporary file first, as
+// ffmpeg cannot reliably read video files from a pipe.
+func withLocalFile(path string, fn func(path string) error) error {
+	zipFilename, filename := file.ZipFilePath(path)
+	if zipFilename == "" {
+		return fn(filename)
+	}
+
+	src, err := openSourceImage(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp("", "stash-clip-*"+filepath.Ext(filename))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, src)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return fn(tmp.Name())
+}
@@ -0,0 +1,155 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeGIF(t *testing.T, delays ...int) []byte {
+	palette := color.Palette{color.Black, color.White}
+
+	g := &gif.GIF{}
+	for _, d := range delays {
+		g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, 2, 2), palette))
+		g.Delay = append(g.Delay, d)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatalf("error encoding gif: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func webpChunk(chunkType string, data []byte) []byte {
+	ret := []byte(chunkType)
+	ret = append(ret, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(ret[4:], uint32(len(data)))
+	ret = append(ret, data...)
+	if len(data)%2 == 1 {
+		ret = append(ret, 0)
+	}
+	return ret
+}
+
+func makeWebP(animated bool, durationsMs ...int) []byte {
+	var body []byte
+
+	flags := byte(0)
+	if animated {
+		flags = 0x02
+	}
+	body = append(body, webpChunk("VP8X", []byte{flags, 0, 0, 0, 1, 0, 0, 1, 0, 0})...)
+
+	for _, d := range durationsMs {
+		frame := make([]byte, 16)
+		frame[12] = byte(d)
+		frame[13] = byte(d >> 8)
+		frame[14] = byte(d >> 16)
+		body = append(body, webpChunk("ANMF", frame)...)
+	}
+
+	ret := []byte("RIFF")
+	ret = append(ret, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(ret[4:], uint32(len(body)+4))
+	ret = append(ret, []byte("WEBP")...)
+	return append(ret, body...)
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	ret := make([]byte, 4)
+	binary.BigEndian.PutUint32(ret, uint32(len(data)))
+	ret = append(ret, []byte(chunkType)...)
+	ret = append(ret, data...)
+	// crc is not checked
+	return append(ret, 0, 0, 0, 0)
+}
+
+func makeAPNG(animated bool, delays ...[2]uint16) []byte {
+	ret := append([]byte{}, pngSignature...)
+	ret = append(ret, pngChunk("IHDR", make([]byte, 13))...)
+
+	if animated {
+		ret = append(ret, pngChunk("acTL", make([]byte, 8))...)
+	}
+
+	for _, d := range delays {
+		fcTL := make([]byte, 26)
+		binary.BigEndian.PutUint16(fcTL[20:22], d[0])
+		binary.BigEndian.PutUint16(fcTL[22:24], d[1])
+		ret = append(ret, pngChunk("fcTL", fcTL)...)
+	}
+
+	ret = append(ret, pngChunk("IDAT", []byte{0})...)
+	return append(ret, pngChunk("IEND", nil)...)
+}
+
+func TestGetAnimationDuration(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             []byte
+		format           string
+		expectedDuration float64
+		expectedAnimated bool
+	}{
+		{"animated gif", makeGIF(t, 10, 20, 30), "gif", 0.6, true},
+		{"still gif", makeGIF(t, 0), "gif", 0, false},
+		{"animated webp", makeWebP(true, 100, 250), "webp", 0.35, true},
+		{"still extended webp", makeWebP(false), "webp", 0, false},
+		{"animated png", makeAPNG(true, [2]uint16{1, 10}, [2]uint16{5, 0}), "png", 0.15, true},
+		{"still png", makeAPNG(false), "png", 0, false},
+		{"invalid data", []byte("not an image"), "gif", 0, false},
+		{"unsupported format", makeGIF(t, 10, 20), "jpeg", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, animated := getAnimationDuration(bytes.NewReader(tt.data), tt.format)
+			assert.Equal(t, tt.expectedAnimated, animated)
+			assert.InDelta(t, tt.expectedDuration, duration, 0.0001)
+		})
+	}
+}
+
+func TestIsClip(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"clip.mp4", true},
+		{"clip.WEBM", true},
+		{"gallery.zip\x00clip.mov", true},
+		{"image.gif", false},
+		{"image.webp", false},
+		{"unknown.xyz", false},
+		// not played by browsers
+		{"clip.wmv", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsClip(&models.Image{Path: tt.path}), "path: %q", tt.path)
+	}
+}
+
+func TestIsClipExtension(t *testing.T) {
+	assert.True(t, IsClipExtension("mp4"))
+	assert.True(t, IsClipExtension(".MKV"))
+	assert.False(t, IsClipExtension("avi"))
+	assert.False(t, IsClipExtension("gif"))
+}
+
+func TestMayBeAnimated(t *testing.T) {
+	assert.True(t, mayBeAnimated(&models.Image{Path: "image.gif"}))
+	assert.True(t, mayBeAnimated(&models.Image{Path: "image.webp"}))
+	assert.False(t, mayBeAnimated(&models.Image{Path: "image.jpg"}))
+	// images are only checked once
+	assert.False(t, mayBeAnimated(&models.Image{Path: "image.gif", AnimationChecked: true}))
+}
@@ -4,7 +4,6 @@ import (
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/manager/paths"
 	"github.com/stashapp/stash/pkg/models"
-	"github.com/stashapp/stash/pkg/utils"
 )
 
 type Destroyer interface {
@@ -20,10 +19,9 @@ type FileDeleter struct {
 
 // MarkGeneratedFiles marks for deletion the generated files for the provided image.
 func (d *FileDeleter) MarkGeneratedFiles(image *models.Image) error {
-	thumbPath := d.Paths.Generated.GetThumbnailPath(image.Checksum, models.DefaultGthumbWidth)
-	exists, _ := utils.FileExists(thumbPath)
-	if exists {
-		return d.Files([]string{thumbPath})
+	thumbPaths := existingThumbnailPaths(d.Paths, image.Checksum)
+	if len(thumbPaths) > 0 {
+		return d.Files(thumbPaths)
 	}
 
 	return nil
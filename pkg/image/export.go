@@ -3,6 +3,7 @@ package image
 import (
 	"github.com/stashapp/stash/pkg/manager/jsonschema"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 // ToBasicJSON converts a image object into its JSON object equivalent. It
@@ -19,6 +20,10 @@ func ToBasicJSON(image *models.Image) *jsonschema.Image {
 		newImageJSON.Title = image.Title.String
 	}
 
+	if image.Date.Valid {
+		newImageJSON.Date = utils.GetYMDFromDatabaseDate(image.Date.String)
+	}
+
 	if image.Rating.Valid {
 		newImageJSON.Rating = int(image.Rating.Int64)
 	}
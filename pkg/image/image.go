@@ -129,22 +129,25 @@ func GetFileDetails(path string) (*models.Image, error) {
 		Path: path,
 	}
 
-	err := SetFileDetails(i)
-	if err != nil {
+	if _, err := SetFileDetails(i); err != nil {
 		return nil, err
 	}
 
 	return i, nil
 }
 
-func SetFileDetails(i *models.Image) error {
+// SetFileDetails sets the size, dimensions, animation details and embedded
+// metadata of the image. Returns the embedded metadata, or nil if the image
+// format does not support metadata.
+func SetFileDetails(i *models.Image) (*Metadata, error) {
 	f, err := stat(i.Path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	config, _, err := DecodeSourceImage(i)
+	config, format, err := DecodeSourceImage(i)
 
+	var metadata *Metadata
 	if err == nil {
 		i.Width = sql.NullInt64{
 			Int64: int64(config.Width),
@@ -154,6 +157,15 @@ func SetFileDetails(i *models.Image) error {
 			Int64: int64(config.Height),
 			Valid: true,
 		}
+
+		if err := setAnimationDetails(i, *format); err != nil {
+			return nil, err
+		}
+
+		metadata, err = setMetadata(i, *format)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	i.Size = sql.NullInt64{
@@ -161,7 +173,7 @@ func SetFileDetails(i *models.Image) error {
 		Valid: true,
 	}
 
-	return nil
+	return metadata, nil
 }
 
 // GetFileModTime gets the file modification time, handling files in zip files.
@@ -204,6 +216,12 @@ func stat(path string) (os.FileInfo, error) {
 func Serve(w http.ResponseWriter, r *http.Request, path string) {
 	zipFilename, _ := file.ZipFilePath(path)
 	w.Header().Add("Cache-Control", "max-age=604800000") // 1 Week
+
+	// the content type is otherwise sniffed from the data for files in zip
+	// files, and may not be known by the mime package for video clips
+	if contentType := ContentType(path); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
 	if zipFilename == "" {
 		http.ServeFile(w, r, path)
 	} else {
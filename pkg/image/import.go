@@ -58,6 +58,9 @@ func (i *Importer) imageJSONToImage(imageJSON jsonschema.Image) models.Image {
 	if imageJSON.Title != "" {
 		newImage.Title = sql.NullString{String: imageJSON.Title, Valid: true}
 	}
+	if imageJSON.Date != "" {
+		newImage.Date = models.SQLiteDate{String: imageJSON.Date, Valid: true}
+	}
 	if imageJSON.Rating != 0 {
 		newImage.Rating = sql.NullInt64{Int64: int64(imageJSON.Rating), Valid: true}
 	}
@@ -0,0 +1,668 @@
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// maxMetadataSegmentSize limits the size of metadata segments that are read
+// into memory.
+const maxMetadataSegmentSize = 4 << 20
+
+var errInvalidExif = errors.New("invalid exif data")
+
+// Metadata is the EXIF and XMP metadata embedded in an image file.
+type Metadata struct {
+	// Date is the date the image was captured.
+	Date *time.Time
+	// Orientation is the EXIF orientation, between 1 and 8. Zero if unset.
+	Orientation  int
+	CameraMake   string
+	CameraModel  string
+	Lens         string
+	FocalLength  float64
+	Aperture     float64
+	ExposureTime float64
+	ISO          int
+	Latitude     *float64
+	Longitude    *float64
+	// Keywords are the XMP subject keywords.
+	Keywords []string
+	// Rating is the XMP rating, between 1 and 5. Zero if unset or rejected.
+	Rating int
+}
+
+// SwapsDimensions returns true if the orientation rotates the image by 90
+// degrees.
+func (m *Metadata) SwapsDimensions() bool {
+	return m.Orientation >= 5 && m.Orientation <= 8
+}
+
+// metadataFormats are the image formats that may contain embedded metadata.
+var metadataFormats = map[string]bool{
+	"jpeg": true,
+	"png":  true,
+	"webp": true,
+}
+
+// metadataMissing returns true if the embedded metadata of the image has not
+// been read. The orientation is always set once the metadata has been read.
+// Images scanned before metadata was supported are read on the next scan.
+func metadataMissing(i *models.Image) bool {
+	if i.Orientation.Valid {
+		return false
+	}
+
+	format := strings.TrimPrefix(ContentType(i.Path), "image/")
+	return metadataFormats[format]
+}
+
+// updateMetadata reads the embedded metadata of an image that was scanned
+// before metadata was supported.
+func updateMetadata(i *models.Image) (*Metadata, error) {
+	format := strings.TrimPrefix(ContentType(i.Path), "image/")
+	return setMetadata(i, format)
+}
+
+// setMetadata reads the embedded metadata of the image with the provided
+// format and sets the corresponding image fields. The capture date is only
+// set if the image has no date. The width and height are swapped if the
+// orientation rotates the image. Invalid metadata is logged and ignored.
+func setMetadata(i *models.Image, format string) (*Metadata, error) {
+	if !metadataFormats[format] {
+		return nil, nil
+	}
+
+	f, err := openSourceImage(i.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := readMetadata(f, format)
+	if err != nil {
+		logger.Warnf("Error reading metadata of %s: %v", file.ZipPathDisplayName(i.Path), err)
+		m = &Metadata{}
+	}
+
+	orientation := m.Orientation
+	if orientation == 0 {
+		orientation = 1
+	}
+	i.Orientation = sql.NullInt64{Int64: int64(orientation), Valid: true}
+	if m.SwapsDimensions() {
+		i.Width, i.Height = i.Height, i.Width
+	}
+
+	if m.Date != nil && !i.Date.Valid {
+		i.Date = models.SQLiteDate{String: m.Date.Format("2006-01-02"), Valid: true}
+	}
+
+	i.CameraMake = nullString(m.CameraMake)
+	i.CameraModel = nullString(m.CameraModel)
+	i.Lens = nullString(m.Lens)
+	i.FocalLength = nullFloat64(m.FocalLength)
+	i.Aperture = nullFloat64(m.Aperture)
+	i.ExposureTime = nullFloat64(m.ExposureTime)
+	i.ISO = sql.NullInt64{Int64: int64(m.ISO), Valid: m.ISO > 0}
+
+	i.Latitude = sql.NullFloat64{}
+	i.Longitude = sql.NullFloat64{}
+	if m.Latitude != nil && m.Longitude != nil {
+		i.Latitude = sql.NullFloat64{Float64: *m.Latitude, Valid: true}
+		i.Longitude = sql.NullFloat64{Float64: *m.Longitude, Valid: true}
+	}
+
+	return m, nil
+}
+
+func nullString(v string) sql.NullString {
+	return sql.NullString{String: v, Valid: v != ""}
+}
+
+func nullFloat64(v float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: v, Valid: v > 0}
+}
+
+// readMetadata reads the EXIF and XMP metadata of a JPEG, PNG or WebP image.
+// Returns nil if the image format does not support metadata.
+func readMetadata(r io.Reader, format string) (*Metadata, error) {
+	var exifData, xmpData []byte
+	var err error
+
+	switch format {
+	case "jpeg":
+		exifData, xmpData, err = readJPEGMetadata(r)
+	case "png":
+		exifData, xmpData, err = readPNGMetadata(r)
+	case "webp":
+		exifData, xmpData, err = readWebPMetadata(r)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Metadata{}
+	if len(exifData) > 0 {
+		if err := parseExif(exifData, ret); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(xmpData) > 0 {
+		parseXMP(xmpData, ret)
+	}
+
+	return ret, nil
+}
+
+var (
+	exifHeader = []byte("Exif\x00\x00")
+	xmpHeader  = []byte("http://ns.adobe.com/xap/1.0/\x00")
+)
+
+func readSegment(r io.Reader, size int64) ([]byte, error) {
+	if size > maxMetadataSegmentSize {
+		_, err := io.CopyN(io.Discard, r, size)
+		return nil, err
+	}
+
+	ret := make([]byte, size)
+	_, err := io.ReadFull(r, ret)
+	return ret, err
+}
+
+// readJPEGMetadata returns the EXIF and XMP data of the APP1 segments of a
+// JPEG image.
+func readJPEGMetadata(r io.Reader) (exifData []byte, xmpData []byte, err error) {
+	br := bufio.NewReader(r)
+
+	soi := make([]byte, 2)
+	if _, err := io.ReadFull(br, soi); err != nil || soi[0] != 0xFF || soi[1] != 0xD8 {
+		return nil, nil, errors.New("invalid jpeg data")
+	}
+
+	marker := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(br, marker); err != nil {
+			// metadata is optional
+			return exifData, xmpData, nil
+		}
+
+		if marker[0] != 0xFF {
+			return exifData, xmpData, nil
+		}
+
+		// metadata precedes the start of scan
+		if marker[1] == 0xDA || marker[1] == 0xD9 {
+			return exifData, xmpData, nil
+		}
+
+		size := int64(binary.BigEndian.Uint16(marker[2:4])) - 2
+		if size < 0 {
+			return exifData, xmpData, nil
+		}
+
+		if marker[1] != 0xE1 {
+			if _, err := io.CopyN(io.Discard, br, size); err != nil {
+				return exifData, xmpData, nil
+			}
+			continue
+		}
+
+		data, err := readSegment(br, size)
+		if err != nil {
+			return exifData, xmpData, nil
+		}
+
+		switch {
+		case bytes.HasPrefix(data, exifHeader) && exifData == nil:
+			exifData = data[len(exifHeader):]
+		case bytes.HasPrefix(data, xmpHeader) && xmpData == nil:
+			xmpData = data[len(xmpHeader):]
+		}
+	}
+}
+
+const pngXMPKeyword = "XML:com.adobe.xmp"
+
+// readPNGMetadata returns the EXIF data of the eXIf chunk and the XMP data
+// of the iTXt chunk of a PNG image.
+func readPNGMetadata(r io.Reader) (exifData []byte, xmpData []byte, err error) {
+	br := bufio.NewReader(r)
+
+	signature := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(br, signature); err != nil || !bytes.Equal(signature, pngSignature) {
+		return nil, nil, errors.New("invalid png data")
+	}
+
+	chunkHeader := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(br, chunkHeader); err != nil {
+			return exifData, xmpData, nil
+		}
+
+		size := int64(binary.BigEndian.Uint32(chunkHeader[0:4]))
+		chunkType := string(chunkHeader[4:8])
+
+		switch chunkType {
+		case "eXIf", "iTXt":
+			data, err := readSegment(br, size)
+			if err != nil {
+				return exifData, xmpData, nil
+			}
+
+			if chunkType == "eXIf" {
+				exifData = data
+			} else if bytes.HasPrefix(data, []byte(pngXMPKeyword+"\x00")) {
+				xmpData = pngITXtText(data[len(pngXMPKeyword)+1:])
+			}
+		case "IEND":
+			return exifData, xmpData, nil
+		default:
+			if _, err := io.CopyN(io.Discard, br, size); err != nil {
+				return exifData, xmpData, nil
+			}
+		}
+
+		// skip the crc
+		if _, err := io.CopyN(io.Discard, br, 4); err != nil {
+			return exifData, xmpData, nil
+		}
+	}
+}
+
+// pngITXtText returns the text of an uncompressed iTXt chunk, following the
+// keyword. Compressed XMP data is not supported.
+func pngITXtText(data []byte) []byte {
+	// compression flag, compression method
+	if len(data) < 2 || data[0] != 0 {
+		return nil
+	}
+	data = data[2:]
+
+	// language tag and translated keyword are null terminated
+	for i := 0; i < 2; i++ {
+		idx := bytes.IndexByte(data, 0)
+		if idx == -1 {
+			return nil
+		}
+		data = data[idx+1:]
+	}
+
+	return data
+}
+
+// readWebPMetadata returns the EXIF and XMP data of the chunks of a WebP
+// image.
+func readWebPMetadata(r io.Reader) (exifData []byte, xmpData []byte, err error) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(br, header); err != nil || string(header[0:4]) != "RIFF" || string(header[8:12]) != "WEBP" {
+		return nil, nil, errors.New("invalid webp data")
+	}
+
+	chunkHeader := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(br, chunkHeader); err != nil {
+			return exifData, xmpData, nil
+		}
+
+		chunkType := string(chunkHeader[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunkHeader[4:8]))
+		padding := size & 1
+
+		switch chunkType {
+		case "EXIF", "XMP ":
+			data, err := readSegment(br, size)
+			if err != nil {
+				return exifData, xmpData, nil
+			}
+
+			if chunkType == "EXIF" {
+				// some encoders include the jpeg exif header
+				exifData = bytes.TrimPrefix(data, exifHeader)
+			} else {
+				xmpData = data
+			}
+		default:
+			padding += size
+		}
+
+		if _, err := io.CopyN(io.Discard, br, padding); err != nil {
+			return exifData, xmpData, nil
+		}
+	}
+}
+
+const (
+	exifTagMake              = 0x010F
+	exifTagModel             = 0x0110
+	exifTagOrientation       = 0x0112
+	exifTagExifIFD           = 0x8769
+	exifTagGPSIFD            = 0x8825
+	exifTagExposureTime      = 0x829A
+	exifTagFNumber           = 0x829D
+	exifTagISO               = 0x8827
+	exifTagDateTimeOriginal  = 0x9003
+	exifTagDateTimeDigitized = 0x9004
+	exifTagFocalLength       = 0x920A
+	exifTagLensModel         = 0xA434
+
+	gpsTagLatitudeRef  = 0x0001
+	gpsTagLatitude     = 0x0002
+	gpsTagLongitudeRef = 0x0003
+	gpsTagLongitude    = 0x0004
+)
+
+// exifTypeSizes are the sizes in bytes of the EXIF value types.
+var exifTypeSizes = map[uint16]int{
+	1:  1, // byte
+	2:  1, // ascii
+	3:  2, // short
+	4:  4, // long
+	5:  8, // rational
+	7:  1, // undefined
+	9:  4, // signed long
+	10: 8, // signed rational
+}
+
+type exifEntry struct {
+	typ   uint16
+	count int
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (e exifEntry) String() string {
+	if e.typ != 2 {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimRight(string(e.data), "\x00"))
+}
+
+// Uint returns the first value of a byte, short or long entry.
+func (e exifEntry) Uint() (uint32, bool) {
+	switch {
+	case e.typ == 1 && len(e.data) >= 1:
+		return uint32(e.data[0]), true
+	case e.typ == 3 && len(e.data) >= 2:
+		return uint32(e.order.Uint16(e.data)), true
+	case e.typ == 4 && len(e.data) >= 4:
+		return e.order.Uint32(e.data), true
+	}
+
+	return 0, false
+}
+
+// Rational returns the nth value of a rational entry.
+func (e exifEntry) Rational(n int) (float64, bool) {
+	if (e.typ != 5 && e.typ != 10) || len(e.data) < (n+1)*8 {
+		return 0, false
+	}
+
+	d := e.data[n*8:]
+	var num, den float64
+	if e.typ == 5 {
+		num = float64(e.order.Uint32(d[0:4]))
+		den = float64(e.order.Uint32(d[4:8]))
+	} else {
+		num = float64(int32(e.order.Uint32(d[0:4])))
+		den = float64(int32(e.order.Uint32(d[4:8])))
+	}
+
+	if den == 0 {
+		return 0, false
+	}
+
+	return num / den, true
+}
+
+type exifReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// readIFD returns the entries of the image file directory at the offset,
+// keyed by tag.
+func (r exifReader) readIFD(offset uint32) (map[uint16]exifEntry, error) {
+	if int(offset)+2 > len(r.data) {
+		return nil, errInvalidExif
+	}
+
+	count := int(r.order.Uint16(r.data[offset:]))
+	start := int(offset) + 2
+	if start+count*12 > len(r.data) {
+		return nil, errInvalidExif
+	}
+
+	ret := make(map[uint16]exifEntry)
+	for i := 0; i < count; i++ {
+		entry := r.data[start+i*12 : start+(i+1)*12]
+		tag := r.order.Uint16(entry[0:2])
+		typ := r.order.Uint16(entry[2:4])
+		n := int(r.order.Uint32(entry[4:8]))
+
+		typeSize, ok := exifTypeSizes[typ]
+		if !ok || n < 0 || n > maxMetadataSegmentSize {
+			continue
+		}
+
+		size := typeSize * n
+		var value []byte
+		if size <= 4 {
+			// the value is stored in the offset field
+			value = entry[8 : 8+size]
+		} else {
+			valueOffset := int(r.order.Uint32(entry[8:12]))
+			if valueOffset < 0 || valueOffset+size > len(r.data) {
+				continue
+			}
+			value = r.data[valueOffset : valueOffset+size]
+		}
+
+		ret[tag] = exifEntry{
+			typ:   typ,
+			count: n,
+			data:  value,
+			order: r.order,
+		}
+	}
+
+	return ret, nil
+}
+
+// parseExif sets the metadata fields from the TIFF-structured EXIF data.
+func parseExif(data []byte, m *Metadata) error {
+	if len(data) < 8 {
+		return errInvalidExif
+	}
+
+	r := exifReader{data: data}
+	switch string(data[0:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return errInvalidExif
+	}
+
+	if r.order.Uint16(data[2:4]) != 42 {
+		return errInvalidExif
+	}
+
+	ifd0, err := r.readIFD(r.order.Uint32(data[4:8]))
+	if err != nil {
+		return err
+	}
+
+	m.CameraMake = ifd0[exifTagMake].String()
+	m.CameraModel = ifd0[exifTagModel].String()
+	if v, ok := ifd0[exifTagOrientation].Uint(); ok && v >= 1 && v <= 8 {
+		m.Orientation = int(v)
+	}
+
+	if offset, ok := ifd0[exifTagExifIFD].Uint(); ok {
+		// ignore invalid sub-directories
+		if exifIFD, err := r.readIFD(offset); err == nil {
+			parseExifIFD(exifIFD, m)
+		}
+	}
+
+	if offset, ok := ifd0[exifTagGPSIFD].Uint(); ok {
+		if gpsIFD, err := r.readIFD(offset); err == nil {
+			parseGPSIFD(gpsIFD, m)
+		}
+	}
+
+	return nil
+}
+
+func parseExifIFD(ifd map[uint16]exifEntry, m *Metadata) {
+	for _, tag := range []uint16{exifTagDateTimeOriginal, exifTagDateTimeDigitized} {
+		if t, err := time.Parse("2006:01:02 15:04:05", ifd[tag].String()); err == nil {
+			m.Date = &t
+			break
+		}
+	}
+
+	m.Lens = ifd[exifTagLensModel].String()
+
+	if v, ok := ifd[exifTagExposureTime].Rational(0); ok {
+		m.ExposureTime = v
+	}
+	if v, ok := ifd[exifTagFNumber].Rational(0); ok {
+		m.Aperture = v
+	}
+	if v, ok := ifd[exifTagFocalLength].Rational(0); ok {
+		m.FocalLength = v
+	}
+	if v, ok := ifd[exifTagISO].Uint(); ok {
+		m.ISO = int(v)
+	}
+}
+
+func parseGPSIFD(ifd map[uint16]exifEntry, m *Metadata) {
+	lat, latOK := gpsCoordinate(ifd[gpsTagLatitude], ifd[gpsTagLatitudeRef].String(), "S")
+	lon, lonOK := gpsCoordinate(ifd[gpsTagLongitude], ifd[gpsTagLongitudeRef].String(), "W")
+	if latOK && lonOK {
+		m.Latitude = &lat
+		m.Longitude = &lon
+	}
+}
+
+// gpsCoordinate returns the decimal degrees of a degrees, minutes and
+// seconds GPS coordinate. The value is negated if ref equals negativeRef.
+func gpsCoordinate(e exifEntry, ref string, negativeRef string) (float64, bool) {
+	var parts [3]float64
+	for i := range parts {
+		v, ok := e.Rational(i)
+		if !ok {
+			return 0, false
+		}
+		parts[i] = v
+	}
+
+	ret := parts[0] + parts[1]/60 + parts[2]/3600
+	if strings.EqualFold(ref, negativeRef) {
+		ret = -ret
+	}
+
+	// round to avoid storing floating point noise
+	return math.Round(ret*1e7) / 1e7, true
+}
+
+const (
+	xmpNamespace       = "http://ns.adobe.com/xap/1.0/"
+	dcNamespace        = "http://purl.org/dc/elements/1.1/"
+	rdfNamespace       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	photoshopNamespace = "http://ns.adobe.com/photoshop/1.0/"
+)
+
+// xmpDateLayouts are the supported layouts of XMP dates.
+var xmpDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseXMP sets the keywords and rating, and the date if not set, from the
+// XMP packet. Invalid data is ignored.
+func parseXMP(data []byte, m *Metadata) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var stack []xml.Name
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name)
+
+			// properties may be stored as attributes of the description
+			for _, attr := range t.Attr {
+				setXMPProperty(attr.Name, attr.Value, m)
+			}
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			value := strings.TrimSpace(string(t))
+			if value == "" || len(stack) == 0 {
+				continue
+			}
+
+			current := stack[len(stack)-1]
+			if current.Space == rdfNamespace && current.Local == "li" {
+				// dc:subject is a bag of keywords
+				if len(stack) >= 3 && stack[len(stack)-3].Space == dcNamespace && stack[len(stack)-3].Local == "subject" {
+					m.Keywords = append(m.Keywords, value)
+				}
+				continue
+			}
+
+			setXMPProperty(current, value, m)
+		}
+	}
+}
+
+func setXMPProperty(name xml.Name, value string, m *Metadata) {
+	switch {
+	case name.Space == xmpNamespace && name.Local == "Rating":
+		// -1 indicates a rejected image
+		if v, err := strconv.ParseFloat(value, 64); err == nil && v >= 1 && v <= 5 {
+			m.Rating = int(math.Round(v))
+		}
+	case name.Space == photoshopNamespace && name.Local == "DateCreated" && m.Date == nil:
+		for _, layout := range xmpDateLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				m.Date = &t
+				return
+			}
+		}
+	}
+}
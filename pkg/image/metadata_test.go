@@ -0,0 +1,334 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+	// subIFD is the index of the directory the entry points to, if non-zero
+	subIFD int
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func tiffASCII(tag uint16, s string) tiffEntry {
+	v := append([]byte(s), 0)
+	return tiffEntry{tag: tag, typ: 2, count: uint32(len(v)), value: v}
+}
+
+func tiffShort(tag uint16, v uint16) tiffEntry {
+	b := make([]byte, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	return tiffEntry{tag: tag, typ: 3, count: 1, value: b}
+}
+
+func tiffRational(tag uint16, values ...[2]uint32) tiffEntry {
+	var b []byte
+	for _, v := range values {
+		b = appendUint32(b, v[0])
+		b = appendUint32(b, v[1])
+	}
+	return tiffEntry{tag: tag, typ: 5, count: uint32(len(values)), value: b}
+}
+
+func tiffPointer(tag uint16, subIFD int) tiffEntry {
+	return tiffEntry{tag: tag, typ: 4, count: 1, subIFD: subIFD}
+}
+
+func ifdSize(entries []tiffEntry) int {
+	ret := 2 + len(entries)*12 + 4
+	for _, e := range entries {
+		if len(e.value) > 4 {
+			ret += len(e.value) + len(e.value)%2
+		}
+	}
+	return ret
+}
+
+// makeTIFF returns little endian TIFF data with the provided directories.
+// The first directory is IFD0.
+func makeTIFF(ifds ...[]tiffEntry) []byte {
+	offsets := make([]int, len(ifds))
+	offset := 8
+	for i, ifd := range ifds {
+		offsets[i] = offset
+		offset += ifdSize(ifd)
+	}
+
+	ret := []byte("II")
+	ret = appendUint16(ret, 42)
+	ret = appendUint32(ret, 8)
+
+	for i, ifd := range ifds {
+		dataOffset := offsets[i] + 2 + len(ifd)*12 + 4
+		var data []byte
+
+		ret = appendUint16(ret, uint16(len(ifd)))
+		for _, e := range ifd {
+			ret = appendUint16(ret, e.tag)
+			ret = appendUint16(ret, e.typ)
+			ret = appendUint32(ret, e.count)
+
+			switch {
+			case e.subIFD != 0:
+				ret = appendUint32(ret, uint32(offsets[e.subIFD]))
+			case len(e.value) > 4:
+				ret = appendUint32(ret, uint32(dataOffset+len(data)))
+				data = append(data, e.value...)
+				if len(e.value)%2 == 1 {
+					data = append(data, 0)
+				}
+			default:
+				v := make([]byte, 4)
+				copy(v, e.value)
+				ret = append(ret, v...)
+			}
+		}
+
+		// no next directory
+		ret = appendUint32(ret, 0)
+		ret = append(ret, data...)
+	}
+
+	return ret
+}
+
+func testExif() []byte {
+	return makeTIFF(
+		[]tiffEntry{
+			tiffASCII(exifTagMake, "Canon"),
+			tiffASCII(exifTagModel, "Canon EOS 5D"),
+			tiffShort(exifTagOrientation, 6),
+			tiffPointer(exifTagExifIFD, 1),
+			tiffPointer(exifTagGPSIFD, 2),
+		},
+		[]tiffEntry{
+			tiffRational(exifTagExposureTime, [2]uint32{1, 250}),
+			tiffRational(exifTagFNumber, [2]uint32{28, 10}),
+			tiffShort(exifTagISO, 400),
+			tiffASCII(exifTagDateTimeOriginal, "2021:06:15 10:30:00"),
+			tiffRational(exifTagFocalLength, [2]uint32{50, 1}),
+			tiffASCII(exifTagLensModel, "EF50mm f/1.8"),
+		},
+		[]tiffEntry{
+			tiffASCII(gpsTagLatitudeRef, "N"),
+			tiffRational(gpsTagLatitude, [2]uint32{51, 1}, [2]uint32{30, 1}, [2]uint32{0, 1}),
+			tiffASCII(gpsTagLongitudeRef, "W"),
+			tiffRational(gpsTagLongitude, [2]uint32{0, 1}, [2]uint32{15, 1}, [2]uint32{0, 1}),
+		},
+	)
+}
+
+const testXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/" xmp:Rating="4">
+<dc:subject><rdf:Bag><rdf:li>beach</rdf:li><rdf:li>sunset</rdf:li></rdf:Bag></dc:subject>
+<photoshop:DateCreated>2020-01-02T03:04:05</photoshop:DateCreated>
+</rdf:Description>
+</rdf:RDF>
+</x:xmpmeta>`
+
+func jpegSegment(marker byte, data []byte) []byte {
+	ret := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(ret[2:], uint16(len(data)+2))
+	return append(ret, data...)
+}
+
+func makeMetadataJPEG(exifData []byte, xmpData []byte) []byte {
+	ret := []byte{0xFF, 0xD8}
+	ret = append(ret, jpegSegment(0xE0, []byte("JFIF\x00"))...)
+	if exifData != nil {
+		ret = append(ret, jpegSegment(0xE1, append(append([]byte{}, exifHeader...), exifData...))...)
+	}
+	if xmpData != nil {
+		ret = append(ret, jpegSegment(0xE1, append(append([]byte{}, xmpHeader...), xmpData...))...)
+	}
+	return append(ret, 0xFF, 0xDA, 0, 2)
+}
+
+func makeMetadataPNG(exifData []byte, xmpData []byte) []byte {
+	ret := append([]byte{}, pngSignature...)
+	ret = append(ret, pngChunk("IHDR", make([]byte, 13))...)
+	if exifData != nil {
+		ret = append(ret, pngChunk("eXIf", exifData)...)
+	}
+	if xmpData != nil {
+		iTXt := []byte(pngXMPKeyword + "\x00\x00\x00\x00\x00")
+		ret = append(ret, pngChunk("iTXt", append(iTXt, xmpData...))...)
+	}
+	ret = append(ret, pngChunk("IDAT", []byte{0})...)
+	return append(ret, pngChunk("IEND", nil)...)
+}
+
+func makeMetadataWebP(exifData []byte, xmpData []byte) []byte {
+	body := webpChunk("VP8X", make([]byte, 10))
+	if exifData != nil {
+		body = append(body, webpChunk("EXIF", exifData)...)
+	}
+	if xmpData != nil {
+		body = append(body, webpChunk("XMP ", xmpData)...)
+	}
+
+	ret := []byte("RIFF")
+	ret = appendUint32(ret, uint32(len(body)+4))
+	ret = append(ret, []byte("WEBP")...)
+	return append(ret, body...)
+}
+
+func TestReadMetadata(t *testing.T) {
+	exifData := testExif()
+	xmpData := []byte(testXMP)
+
+	tests := []struct {
+		name   string
+		data   []byte
+		format string
+	}{
+		{"jpeg", makeMetadataJPEG(exifData, xmpData), "jpeg"},
+		{"png", makeMetadataPNG(exifData, xmpData), "png"},
+		{"webp", makeMetadataWebP(exifData, xmpData), "webp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := readMetadata(bytes.NewReader(tt.data), tt.format)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, "Canon", m.CameraMake)
+			assert.Equal(t, "Canon EOS 5D", m.CameraModel)
+			assert.Equal(t, "EF50mm f/1.8", m.Lens)
+			assert.Equal(t, 6, m.Orientation)
+			assert.True(t, m.SwapsDimensions())
+			assert.InDelta(t, 0.004, m.ExposureTime, 0.00001)
+			assert.InDelta(t, 2.8, m.Aperture, 0.00001)
+			assert.InDelta(t, 50, m.FocalLength, 0.00001)
+			assert.Equal(t, 400, m.ISO)
+
+			// the exif date takes precedence
+			if assert.NotNil(t, m.Date) {
+				assert.Equal(t, time.Date(2021, 6, 15, 10, 30, 0, 0, time.UTC), *m.Date)
+			}
+
+			if assert.NotNil(t, m.Latitude) && assert.NotNil(t, m.Longitude) {
+				assert.InDelta(t, 51.5, *m.Latitude, 0.0000001)
+				assert.InDelta(t, -0.25, *m.Longitude, 0.0000001)
+			}
+
+			assert.Equal(t, []string{"beach", "sunset"}, m.Keywords)
+			assert.Equal(t, 4, m.Rating)
+		})
+	}
+}
+
+func TestReadMetadataMissing(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		format string
+	}{
+		{"jpeg", makeMetadataJPEG(nil, nil), "jpeg"},
+		{"png", makeMetadataPNG(nil, nil), "png"},
+		{"webp", makeMetadataWebP(nil, nil), "webp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := readMetadata(bytes.NewReader(tt.data), tt.format)
+			if assert.NoError(t, err) {
+				assert.Equal(t, &Metadata{}, m)
+			}
+		})
+	}
+}
+
+func TestReadMetadataInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		format string
+	}{
+		{"invalid jpeg", []byte("not an image"), "jpeg"},
+		{"invalid png", []byte("not an image"), "png"},
+		{"invalid webp", []byte("not an image"), "webp"},
+		{"invalid exif", makeMetadataJPEG([]byte("XX\x2a\x00\x08\x00\x00\x00"), nil), "jpeg"},
+		{"truncated exif", makeMetadataJPEG([]byte("II\x2a\x00\xff\x00\x00\x00"), nil), "jpeg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readMetadata(bytes.NewReader(tt.data), tt.format)
+			assert.Error(t, err)
+		})
+	}
+
+	m, err := readMetadata(bytes.NewReader(makeGIF(t, 0)), "gif")
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+}
+
+func TestParseXMP(t *testing.T) {
+	tests := []struct {
+		name     string
+		xmp      string
+		keywords []string
+		rating   int
+		date     *time.Time
+	}{
+		{
+			"element rating",
+			`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description xmlns:xmp="http://ns.adobe.com/xap/1.0/"><xmp:Rating>5</xmp:Rating></rdf:Description></rdf:RDF>`,
+			nil,
+			5,
+			nil,
+		},
+		{
+			"rejected",
+			`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="-1"/></rdf:RDF>`,
+			nil,
+			0,
+			nil,
+		},
+		{
+			"date only",
+			`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/" photoshop:DateCreated="2019-12-31"/></rdf:RDF>`,
+			nil,
+			0,
+			func() *time.Time { t := time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC); return &t }(),
+		},
+		{
+			"other bags ignored",
+			`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><rdf:Description xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:creator><rdf:Seq><rdf:li>someone</rdf:li></rdf:Seq></dc:creator><dc:subject><rdf:Bag><rdf:li> tag </rdf:li></rdf:Bag></dc:subject></rdf:Description></rdf:RDF>`,
+			[]string{"tag"},
+			0,
+			nil,
+		},
+		{"invalid", "<not xml", nil, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Metadata{}
+			parseXMP([]byte(tt.xmp), m)
+			assert.Equal(t, tt.keywords, m.Keywords)
+			assert.Equal(t, tt.rating, m.Rating)
+			assert.Equal(t, tt.date, m.Date)
+		})
+	}
+}
@@ -68,3 +68,17 @@ func CountByTagID(r models.ImageReader, id int) (int, error) {
 
 	return r.QueryCount(filter, nil)
 }
+
+// CountByFolderID returns the number of images in the folder. Images in
+// sub-folders up to the provided depth are included.
+func CountByFolderID(r models.ImageReader, id int, depth *int) (int, error) {
+	filter := &models.ImageFilterType{
+		ParentFolder: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{strconv.Itoa(id)},
+			Modifier: models.CriterionModifierIncludes,
+			Depth:    depth,
+		},
+	}
+
+	return r.QueryCount(filter, nil)
+}
@@ -2,8 +2,8 @@ package image
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -12,6 +12,7 @@ import (
 	"github.com/stashapp/stash/pkg/manager/paths"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -21,6 +22,16 @@ type Scanner struct {
 	file.Scanner
 
 	StripFileExtension bool
+	// CreateTagsFromKeywords adds tags matching the embedded keywords of
+	// images, creating missing tags.
+	CreateTagsFromKeywords bool
+	// SetRatingFromMetadata sets the rating of unrated images to their
+	// embedded rating.
+	SetRatingFromMetadata bool
+	// ClipExtensions are the extensions of video files scanned as video
+	// clips.
+	ClipExtensions   []string
+	VideoFileCreator videoFileCreator
 
 	Ctx             context.Context
 	CaseSensitiveFs bool
@@ -48,12 +59,14 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 	path := scanned.New.Path
 	oldChecksum := i.Checksum
 	changed := false
+	var metadata *Metadata
 
 	if scanned.ContentsChanged() {
 		logger.Infof("%s has been updated: rescanning", path)
 
 		// regenerate the file details as well
-		if err := SetFileDetails(i); err != nil {
+		metadata, err = scanner.setFileDetails(i)
+		if err != nil {
 			return nil, err
 		}
 
@@ -62,6 +75,36 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 		logger.Infof("Updated image file %s", path)
 
 		changed = true
+	} else {
+		if mayBeAnimated(i) {
+			animated, err := updateAnimationDetails(i)
+			if err != nil {
+				return nil, err
+			}
+
+			if animated {
+				logger.Infof("Detected animated image %s", path)
+				changed = true
+
+				// remove the still thumbnails - we'll regenerate them
+				DeleteThumbnails(scanner.Paths, i.Checksum)
+			}
+		}
+
+		if metadataMissing(i) {
+			metadata, err = updateMetadata(i)
+			if err != nil {
+				return nil, err
+			}
+
+			scanner.setMetadataRating(i, metadata)
+			changed = true
+
+			// rotated images need new thumbnails
+			if i.Orientation.Int64 != 1 {
+				DeleteThumbnails(scanner.Paths, i.Checksum)
+			}
+		}
 	}
 
 	if changed {
@@ -86,18 +129,18 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 			}
 
 			retImage, err = r.Image().UpdateFull(*i)
-			return err
+			if err != nil {
+				return err
+			}
+
+			return scanner.setKeywordTags(r, retImage.ID, metadata)
 		}); err != nil {
 			return nil, err
 		}
 
-		// remove the old thumbnail if the checksum changed - we'll regenerate it
+		// remove the old thumbnails if the checksum changed - we'll regenerate them
 		if oldChecksum != scanned.New.Checksum {
-			// remove cache dir of gallery
-			err = os.Remove(scanner.Paths.Generated.GetThumbnailPath(oldChecksum, models.DefaultGthumbWidth))
-			if err != nil {
-				logger.Errorf("Error deleting thumbnail image: %s", err)
-			}
+			DeleteThumbnails(scanner.Paths, oldChecksum)
 		}
 
 		scanner.PluginCache.ExecutePostHooks(scanner.Ctx, retImage.ID, plugin.ImageUpdatePost, nil, nil)
@@ -106,6 +149,93 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 	return
 }
 
+func (scanner *Scanner) setFileDetails(i *models.Image) (*Metadata, error) {
+	if utils.MatchExtension(i.Path, scanner.ClipExtensions) {
+		return nil, SetClipDetails(i, scanner.VideoFileCreator)
+	}
+
+	metadata, err := SetFileDetails(i)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner.setMetadataRating(i, metadata)
+	return metadata, nil
+}
+
+// setMetadataRating sets the rating of the image to the embedded rating if
+// enabled. Existing ratings are not overwritten.
+func (scanner *Scanner) setMetadataRating(i *models.Image, metadata *Metadata) {
+	if !scanner.SetRatingFromMetadata || metadata == nil || metadata.Rating == 0 || i.Rating.Valid {
+		return
+	}
+
+	i.Rating = sql.NullInt64{Int64: int64(metadata.Rating), Valid: true}
+}
+
+// setKeywordTags adds tags matching the embedded keywords to the image if
+// enabled. Missing tags are created.
+func (scanner *Scanner) setKeywordTags(r models.Repository, imageID int, metadata *Metadata) error {
+	if !scanner.CreateTagsFromKeywords || metadata == nil || len(metadata.Keywords) == 0 {
+		return nil
+	}
+
+	qb := r.Image()
+	originalTagIDs, err := qb.GetTagIDs(imageID)
+	if err != nil {
+		return fmt.Errorf("error getting image tags: %w", err)
+	}
+
+	tagIDs := originalTagIDs
+	for _, keyword := range metadata.Keywords {
+		t, err := getOrCreateTag(r.Tag(), keyword)
+		if err != nil {
+			return err
+		}
+
+		tagIDs = utils.IntAppendUnique(tagIDs, t.ID)
+	}
+
+	if len(tagIDs) == len(originalTagIDs) {
+		return nil
+	}
+
+	return qb.UpdateTags(imageID, tagIDs)
+}
+
+// getOrCreateTag returns the tag with the provided name or alias, creating it
+// if it does not exist.
+func getOrCreateTag(qb models.TagReaderWriter, name string) (*models.Tag, error) {
+	t, err := tag.ByName(qb, name)
+	if err != nil {
+		return nil, fmt.Errorf("error finding tag %s: %w", name, err)
+	}
+
+	if t == nil {
+		t, err = tag.ByAlias(qb, name)
+		if err != nil {
+			return nil, fmt.Errorf("error finding tag %s: %w", name, err)
+		}
+	}
+
+	if t != nil {
+		return t, nil
+	}
+
+	logger.Infof("Creating tag %s from image keyword", name)
+	now := time.Now()
+	t, err = qb.Create(models.Tag{
+		Name:      name,
+		CreatedAt: models.SQLiteTimestamp{Timestamp: now},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: now},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating tag %s: %w", name, err)
+	}
+
+	return t, nil
+}
+
 func (scanner *Scanner) ScanNew(f file.SourceFile) (retImage *models.Image, err error) {
 	scanned, err := scanner.Scanner.ScanNew(f)
 	if err != nil {
@@ -146,7 +276,8 @@ func (scanner *Scanner) ScanNew(f file.SourceFile) (retImage *models.Image, err
 			logger.Infof("%s already exists. Duplicate of %s ", pathDisplayName, file.ZipPathDisplayName(existingImage.Path))
 			return nil, nil
 		} else {
-			logger.Infof("%s already exists. Updating path...", pathDisplayName)
+			oldPath := existingImage.Path
+			logger.Infof("%s moved from %s. Updating path...", pathDisplayName, file.ZipPathDisplayName(oldPath))
 			imagePartial := models.ImagePartial{
 				ID:   existingImage.ID,
 				Path: &path,
@@ -160,6 +291,7 @@ func (scanner *Scanner) ScanNew(f file.SourceFile) (retImage *models.Image, err
 			}
 
 			scanner.PluginCache.ExecutePostHooks(scanner.Ctx, existingImage.ID, plugin.ImageUpdatePost, nil, nil)
+			scanner.PluginCache.ExecuteMovePostHooks(scanner.Ctx, existingImage.ID, plugin.ImageMovePost, oldPath, path)
 		}
 	} else {
 		logger.Infof("%s doesn't exist. Creating new item...", pathDisplayName)
@@ -172,7 +304,8 @@ func (scanner *Scanner) ScanNew(f file.SourceFile) (retImage *models.Image, err
 		newImage.Title.String = GetFilename(&newImage, scanner.StripFileExtension)
 		newImage.Title.Valid = true
 
-		if err := SetFileDetails(&newImage); err != nil {
+		metadata, err := scanner.setFileDetails(&newImage)
+		if err != nil {
 			logger.Error(err.Error())
 			return nil, err
 		}
@@ -180,7 +313,11 @@ func (scanner *Scanner) ScanNew(f file.SourceFile) (retImage *models.Image, err
 		if err := scanner.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
 			var err error
 			retImage, err = r.Image().Create(newImage)
-			return err
+			if err != nil {
+				return err
+			}
+
+			return scanner.setKeywordTags(r, retImage.ID, metadata)
 		}); err != nil {
 			return nil, err
 		}
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -16,6 +17,10 @@ var once sync.Once
 
 var ErrUnsupportedFormat = errors.New("unsupported image format")
 
+// maxAnimatedThumbnailDuration is the maximum duration in seconds of the
+// thumbnails of animated images and video clips.
+const maxAnimatedThumbnailDuration = 10
+
 type ThumbnailEncoder struct {
 	ffmpeg ffmpeg.Encoder
 	vips   *vipsEncoder
@@ -42,11 +47,55 @@ func NewThumbnailEncoder(ffmpegEncoder ffmpeg.Encoder) ThumbnailEncoder {
 	return ret
 }
 
+// animatedThumbnailFormat returns the format of the thumbnail of the image
+// with the provided max size if the thumbnail is animated. Returns false if
+// the thumbnail is a still image.
+func animatedThumbnailFormat(img *models.Image, maxSize int) (ThumbnailFormat, bool) {
+	if IsClip(img) {
+		return ThumbnailFormatWebP, true
+	}
+
+	if !img.IsAnimated {
+		return "", false
+	}
+
+	switch ContentType(img.Path) {
+	case "image/gif":
+		// animated gifs are converted to smaller animated webp images
+		if img.Width.Int64 > int64(maxSize) || img.Height.Int64 > int64(maxSize) {
+			return ThumbnailFormatWebP, true
+		}
+		return ThumbnailFormatGIF, true
+	case "image/webp":
+		return ThumbnailFormatWebP, true
+	}
+
+	// other animated images have still thumbnails
+	return "", false
+}
+
+// GetThumbnailFormat returns the format of the thumbnail of the image with
+// the provided max size when the provided format is requested. Thumbnails of
+// animated GIF and WebP images and of video clips are animated GIF or WebP
+// images, regardless of the requested format.
+func GetThumbnailFormat(img *models.Image, maxSize int, format ThumbnailFormat) ThumbnailFormat {
+	if animatedFormat, animated := animatedThumbnailFormat(img, maxSize); animated {
+		return animatedFormat
+	}
+
+	return format
+}
+
 // GetThumbnail returns the thumbnail image of the provided image resized to
-// the provided max size. It resizes based on the largest X/Y direction.
+// the provided max size. It resizes based on the largest X/Y direction. The
+// thumbnail is encoded in the format returned by GetThumbnailFormat.
 // It returns nil and an error if an error occurs reading, decoding or encoding
 // the image.
-func (e *ThumbnailEncoder) GetThumbnail(img *models.Image, maxSize int) ([]byte, error) {
+func (e *ThumbnailEncoder) GetThumbnail(img *models.Image, maxSize int, format ThumbnailFormat) ([]byte, error) {
+	if IsClip(img) {
+		return e.getClipThumbnail(img, maxSize)
+	}
+
 	reader, err := openSourceImage(img.Path)
 	if err != nil {
 		return nil, err
@@ -57,19 +106,78 @@ func (e *ThumbnailEncoder) GetThumbnail(img *models.Image, maxSize int) ([]byte,
 		return nil, err
 	}
 
-	_, format, err := DecodeSourceImage(img)
-	if err != nil {
-		return nil, err
-	}
+	if animatedFormat, animated := animatedThumbnailFormat(img, maxSize); animated {
+		if animatedFormat == ThumbnailFormatWebP && ContentType(img.Path) == "image/gif" {
+			return e.ffmpeg.AnimatedImageThumbnail(img.Path, buf, "gif", maxSize, maxAnimatedThumbnailDuration)
+		}
 
-	if format != nil && *format == "gif" {
+		// small animated gifs and animated webp images are used as is.
+		// ffmpeg cannot decode animated webp images.
 		return buf.Bytes(), nil
 	}
 
+	_, inputFormat, err := DecodeSourceImage(img)
+	if err != nil {
+		return nil, err
+	}
+
 	// vips has issues loading files from stdin on Windows
 	if e.vips != nil && runtime.GOOS != "windows" {
-		return e.vips.ImageThumbnail(buf, maxSize)
+		return e.vips.ImageThumbnail(buf, maxSize, format)
 	} else {
-		return e.ffmpeg.ImageThumbnail(buf, format, maxSize, img.Path)
+		return e.ffmpeg.ImageThumbnail(buf, inputFormat, string(format), maxSize, int(img.Orientation.Int64), img.Path)
+	}
+}
+
+// unsupportedFormats records the thumbnail formats that could not be encoded
+// for images that could be encoded as JPEG. The available encoders are
+// assumed not to support these formats.
+var unsupportedFormats sync.Map
+
+// IsThumbnailFormatSupported returns false if the thumbnail format is known
+// to be unsupported by the available encoders.
+func IsThumbnailFormatSupported(format ThumbnailFormat) bool {
+	_, unsupported := unsupportedFormats.Load(format)
+	return !unsupported
+}
+
+// GetThumbnailWithFallback returns the thumbnail of the image in the provided
+// format, falling back to JPEG if the format cannot be encoded. Returns the
+// format of the returned thumbnail, which is fixed for animated thumbnails.
+func (e *ThumbnailEncoder) GetThumbnailWithFallback(img *models.Image, maxSize int, format ThumbnailFormat) ([]byte, ThumbnailFormat, error) {
+	if animatedFormat, animated := animatedThumbnailFormat(img, maxSize); animated {
+		data, err := e.GetThumbnail(img, maxSize, animatedFormat)
+		return data, animatedFormat, err
 	}
+
+	if format != ThumbnailFormatJPEG && IsThumbnailFormatSupported(format) {
+		data, err := e.GetThumbnail(img, maxSize, format)
+		if err == nil {
+			return data, format, nil
+		}
+
+		logger.Warnf("error generating %s thumbnail for image %s: %v. Falling back to jpeg", format, img.Path, err)
+
+		data, err = e.GetThumbnail(img, maxSize, ThumbnailFormatJPEG)
+		if err == nil {
+			unsupportedFormats.Store(format, true)
+		}
+		return data, ThumbnailFormatJPEG, err
+	}
+
+	data, err := e.GetThumbnail(img, maxSize, ThumbnailFormatJPEG)
+	return data, ThumbnailFormatJPEG, err
+}
+
+// getClipThumbnail returns a looping animated webp thumbnail of the start of
+// the video clip.
+func (e *ThumbnailEncoder) getClipThumbnail(img *models.Image, maxSize int) ([]byte, error) {
+	var ret []byte
+	err := withLocalFile(img.Path, func(path string) error {
+		var err error
+		ret, err = e.ffmpeg.AnimatedImageThumbnail(path, nil, "", maxSize, maxAnimatedThumbnailDuration)
+		return err
+	})
+
+	return ret, err
 }
@@ -0,0 +1,128 @@
+package image
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/paths"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ThumbnailSizes are the maximum dimensions of the cached thumbnail variants,
+// in ascending order.
+var ThumbnailSizes = []int{320, models.DefaultGthumbWidth, 1280}
+
+// GetThumbnailSize returns the smallest thumbnail size that is at least as
+// large as the requested size. Returns the largest size if the requested size
+// is larger than all thumbnail sizes.
+func GetThumbnailSize(requested int) int {
+	for _, s := range ThumbnailSizes {
+		if s >= requested {
+			return s
+		}
+	}
+
+	return ThumbnailSizes[len(ThumbnailSizes)-1]
+}
+
+type ThumbnailFormat string
+
+const (
+	ThumbnailFormatJPEG ThumbnailFormat = "jpeg"
+	ThumbnailFormatWebP ThumbnailFormat = "webp"
+	ThumbnailFormatAVIF ThumbnailFormat = "avif"
+	// ThumbnailFormatGIF is only used for the thumbnails of animated GIF
+	// images
+	ThumbnailFormatGIF ThumbnailFormat = "gif"
+)
+
+// ThumbnailFormats are the supported thumbnail formats, in order of
+// preference.
+var ThumbnailFormats = []ThumbnailFormat{
+	ThumbnailFormatAVIF,
+	ThumbnailFormatWebP,
+	ThumbnailFormatJPEG,
+}
+
+// Extension returns the file extension of thumbnails in the format, without
+// the leading period.
+func (f ThumbnailFormat) Extension() string {
+	if f == ThumbnailFormatJPEG {
+		return "jpg"
+	}
+
+	return string(f)
+}
+
+// MimeType returns the content type of thumbnails in the format.
+func (f ThumbnailFormat) MimeType() string {
+	return "image/" + string(f)
+}
+
+// NegotiateThumbnailFormat returns the most preferred thumbnail format that is
+// accepted according to the provided Accept header value. Returns JPEG if no
+// other format is accepted.
+func NegotiateThumbnailFormat(accept string) ThumbnailFormat {
+	accepted := make(map[string]bool)
+	for _, mediaRange := range strings.Split(accept, ",") {
+		params := strings.Split(mediaRange, ";")
+		mimeType := strings.ToLower(strings.TrimSpace(params[0]))
+
+		// exclude media types with a quality of 0
+		rejected := false
+		for _, p := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+			if len(kv) == 2 && kv[0] == "q" {
+				if q, err := strconv.ParseFloat(kv[1], 64); err == nil && q == 0 {
+					rejected = true
+				}
+			}
+		}
+
+		if !rejected {
+			accepted[mimeType] = true
+		}
+	}
+
+	// only match explicitly listed formats - browsers send */* for images
+	// regardless of their support
+	for _, f := range ThumbnailFormats {
+		if accepted[f.MimeType()] {
+			return f
+		}
+	}
+
+	return ThumbnailFormatJPEG
+}
+
+// GetThumbnailPath returns the path of the cached thumbnail of the image with
+// the provided checksum, size and format.
+func GetThumbnailPath(p *paths.Paths, checksum string, size int, format ThumbnailFormat) string {
+	return p.Generated.GetThumbnailFormatPath(checksum, size, format.Extension())
+}
+
+// DeleteThumbnails removes all cached thumbnail variants of the image with the
+// provided checksum.
+func DeleteThumbnails(p *paths.Paths, checksum string) {
+	for _, path := range existingThumbnailPaths(p, checksum) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Errorf("Error deleting thumbnail image: %s", err)
+		}
+	}
+}
+
+func existingThumbnailPaths(p *paths.Paths, checksum string) []string {
+	var ret []string
+	for _, size := range ThumbnailSizes {
+		for _, format := range append(ThumbnailFormats, ThumbnailFormatGIF) {
+			path := GetThumbnailPath(p, checksum, size, format)
+			if _, err := os.Stat(path); err == nil {
+				ret = append(ret, path)
+			}
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,74 @@
+package image
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetThumbnailSize(t *testing.T) {
+	tests := []struct {
+		requested int
+		want      int
+	}{
+		{1, 320},
+		{320, 320},
+		{321, 640},
+		{640, 640},
+		{1000, 1280},
+		{5000, 1280},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, GetThumbnailSize(tt.requested), "requested: %d", tt.requested)
+	}
+}
+
+func TestNegotiateThumbnailFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   ThumbnailFormat
+	}{
+		{"empty", "", ThumbnailFormatJPEG},
+		{"wildcard", "image/*,*/*;q=0.8", ThumbnailFormatJPEG},
+		{"webp", "image/webp,image/apng,image/*,*/*;q=0.8", ThumbnailFormatWebP},
+		{"avif preferred", "image/avif,image/webp,image/apng,*/*;q=0.8", ThumbnailFormatAVIF},
+		{"avif rejected", "image/avif;q=0,image/webp", ThumbnailFormatWebP},
+		{"case and spacing", " Image/WebP ; q=0.5", ThumbnailFormatWebP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NegotiateThumbnailFormat(tt.accept))
+		})
+	}
+}
+
+func TestGetThumbnailFormat(t *testing.T) {
+	size := func(n int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: n, Valid: true}
+	}
+
+	tests := []struct {
+		name string
+		img  models.Image
+		want ThumbnailFormat
+	}{
+		{"still", models.Image{Path: "image.jpg"}, ThumbnailFormatAVIF},
+		{"static gif", models.Image{Path: "image.gif"}, ThumbnailFormatAVIF},
+		{"small animated gif", models.Image{Path: "image.gif", IsAnimated: true, Width: size(320), Height: size(200)}, ThumbnailFormatGIF},
+		{"large animated gif", models.Image{Path: "image.gif", IsAnimated: true, Width: size(1000), Height: size(200)}, ThumbnailFormatWebP},
+		{"animated webp", models.Image{Path: "image.webp", IsAnimated: true}, ThumbnailFormatWebP},
+		{"animated png", models.Image{Path: "image.png", IsAnimated: true}, ThumbnailFormatAVIF},
+		{"clip", models.Image{Path: "clip.mp4", IsAnimated: true}, ThumbnailFormatWebP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetThumbnailFormat(&tt.img, 320, ThumbnailFormatAVIF))
+		})
+	}
+}
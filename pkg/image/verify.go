@@ -0,0 +1,129 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// trailerLength is the number of bytes at the end of an image file that are
+// checked for the trailer of the image format.
+const trailerLength = 12
+
+var (
+	jpegTrailer = []byte{0xFF, 0xD9}
+	pngTrailer  = []byte{0, 0, 0, 0, 'I', 'E', 'N', 'D', 0xAE, 0x42, 0x60, 0x82}
+	gifTrailer  = []byte{0x3B}
+)
+
+// Verify checks that the header of the image file can be decoded, returning
+// an error if it cannot. Problems that may still allow the image to be
+// displayed, such as the missing trailer of a truncated file, are returned
+// as warnings.
+func Verify(i *models.Image) ([]string, error) {
+	f, err := openSourceImage(i.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tail := &tailWriter{}
+	r := io.TeeReader(f, tail)
+
+	_, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image header: %w", err)
+	}
+
+	if _, err := io.Copy(tail, f); err != nil {
+		return nil, fmt.Errorf("error reading image: %w", err)
+	}
+
+	if !hasTrailer(format, tail) {
+		return []string{fmt.Sprintf("%s image is truncated", format)}, nil
+	}
+
+	return nil, nil
+}
+
+// hasTrailer returns true if the image data ends with the trailer of the
+// image format, or if the format has no trailer.
+func hasTrailer(format string, tail *tailWriter) bool {
+	switch format {
+	case "jpeg":
+		return bytes.HasSuffix(tail.buf, jpegTrailer)
+	case "png":
+		return bytes.HasSuffix(tail.buf, pngTrailer)
+	case "gif":
+		return bytes.HasSuffix(tail.buf, gifTrailer)
+	case "webp":
+		// the RIFF header holds the size of the rest of the file
+		if len(tail.head) < 8 {
+			return false
+		}
+		return int64(binary.LittleEndian.Uint32(tail.head[4:8]))+8 <= tail.size
+	}
+
+	return true
+}
+
+// tailWriter records the first and last bytes written to it, and the
+// number of bytes written.
+type tailWriter struct {
+	head []byte
+	buf  []byte
+	size int64
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.size += int64(len(p))
+
+	if n := trailerLength - len(w.head); n > 0 {
+		if n > len(p) {
+			n = len(p)
+		}
+		w.head = append(w.head, p[:n]...)
+	}
+
+	if len(p) >= trailerLength {
+		w.buf = append(w.buf[:0], p[len(p)-trailerLength:]...)
+	} else {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) > trailerLength {
+			w.buf = append(w.buf[:0], w.buf[len(w.buf)-trailerLength:]...)
+		}
+	}
+
+	return len(p), nil
+}
+
+type clipVerifier interface {
+	Verify(ctx context.Context, probeResult ffmpeg.VideoFile, options ffmpeg.VerifyOptions) (*ffmpeg.VerifyResult, error)
+}
+
+// VerifyClip decodes the video clip. The clip is reported as failed if it
+// cannot be probed.
+func VerifyClip(ctx context.Context, i *models.Image, ffprobe videoFileCreator, encoder clipVerifier) (*ffmpeg.VerifyResult, error) {
+	var ret *ffmpeg.VerifyResult
+	err := withLocalFile(i.Path, func(path string) error {
+		videoFile, err := ffprobe.NewVideoFile(path, false)
+		if err != nil {
+			ret = &ffmpeg.VerifyResult{
+				Errors: []string{err.Error()},
+				Failed: true,
+			}
+			return nil
+		}
+
+		ret, err = encoder.Verify(ctx, *videoFile, ffmpeg.VerifyOptions{})
+		return err
+	})
+
+	return ret, err
+}
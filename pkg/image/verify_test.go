@@ -0,0 +1,70 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 64, 64))
+	for i := range src.Pix {
+		src.Pix[i] = uint8(i * 7)
+	}
+
+	var jpg bytes.Buffer
+	if err := jpeg.Encode(&jpg, src, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, src); err != nil {
+		t.Fatal(err)
+	}
+
+	gifData := makeGIF(t, 10, 10)
+
+	tests := []struct {
+		name         string
+		data         []byte
+		wantWarnings bool
+		wantErr      bool
+	}{
+		{"jpeg", jpg.Bytes(), false, false},
+		{"truncated jpeg", jpg.Bytes()[:jpg.Len()/2], true, false},
+		{"png", pngData.Bytes(), false, false},
+		{"truncated png", pngData.Bytes()[:pngData.Len()-4], true, false},
+		{"gif", gifData, false, false},
+		{"truncated gif", gifData[:len(gifData)-1], true, false},
+		{"webp", makeWebP(true, 100, 100), false, false},
+		{"truncated webp", makeWebP(true, 100, 100)[:40], true, false},
+		{"invalid header", []byte("not an image"), false, true},
+		{"empty", nil, false, true},
+	}
+
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name)
+			if err := os.WriteFile(path, tt.data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			warnings, err := Verify(&models.Image{Path: path})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantWarnings, len(warnings) > 0)
+		})
+	}
+}
@@ -12,11 +12,19 @@ import (
 
 type vipsEncoder string
 
-func (e *vipsEncoder) ImageThumbnail(image *bytes.Buffer, maxSize int) ([]byte, error) {
+var vipsOutputFormats = map[ThumbnailFormat]string{
+	ThumbnailFormatJPEG: ".jpg[Q=70,strip]",
+	ThumbnailFormatWebP: ".webp[Q=70,strip]",
+	ThumbnailFormatAVIF: ".avif[Q=50,strip]",
+}
+
+// ImageThumbnail returns a thumbnail of the image in the provided format.
+// vips rotates the image according to its EXIF orientation.
+func (e *vipsEncoder) ImageThumbnail(image *bytes.Buffer, maxSize int, format ThumbnailFormat) ([]byte, error) {
 	args := []string{
 		"thumbnail_source",
 		"[descriptor=0]",
-		".jpg[Q=70,strip]",
+		vipsOutputFormats[format],
 		fmt.Sprint(maxSize),
 		"--size", "down",
 	}
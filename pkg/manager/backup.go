@@ -0,0 +1,173 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stashapp/stash/pkg/database"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// how often the backup scheduler checks whether a backup is due
+const backupSchedulerPeriod = 10 * time.Minute
+
+// databaseIntegrityStatus is the result of the database integrity check
+// run on startup.
+type databaseIntegrityStatus struct {
+	// set if the database failed the integrity check
+	err error
+	// the name of the latest backup that passes the integrity check
+	latestGoodBackup string
+}
+
+// getDatabaseIntegrity returns the result of the startup integrity check.
+// The result is cleared when the database is restored.
+func (s *singleton) getDatabaseIntegrity() databaseIntegrityStatus {
+	ret, _ := s.databaseIntegrity.Load().(databaseIntegrityStatus)
+	return ret
+}
+
+// BackupDatabase creates a verified backup of the database in the
+// configured backup directory, removing old backups beyond the configured
+// count. Returns the path of the new backup.
+func (s *singleton) BackupDatabase() (string, error) {
+	return database.RotateBackup(s.Config.GetBackupDirectory(), s.Config.GetBackupCount())
+}
+
+// ListDatabaseBackups returns the backups in the configured backup
+// directory, newest first.
+func (s *singleton) ListDatabaseBackups() ([]*models.DatabaseBackup, error) {
+	backups, err := database.ListBackups(s.Config.GetBackupDirectory(), s.Config.GetDatabasePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ret []*models.DatabaseBackup
+	for _, b := range backups {
+		ret = append(ret, &models.DatabaseBackup{
+			Name:      filepath.Base(b.Path),
+			Size:      int(b.Size),
+			CreatedAt: b.ModTime,
+		})
+	}
+
+	return ret, nil
+}
+
+// RestoreDatabase replaces the database with the named backup from the
+// configured backup directory. Fails if any jobs are queued or running.
+func (s *singleton) RestoreDatabase(ctx context.Context, backup string) error {
+	if len(s.JobManager.GetQueue()) > 0 {
+		return errors.New("cannot restore database while jobs are running")
+	}
+
+	// only allow restoring from the backup directory
+	backupPath := filepath.Join(s.Config.GetBackupDirectory(), filepath.Base(backup))
+	if _, err := os.Stat(backupPath); err != nil {
+		return fmt.Errorf("backup %s not found: %w", backup, err)
+	}
+
+	if err := database.Restore(backupPath, s.Config.GetDatabasePath()); err != nil {
+		return err
+	}
+
+	s.databaseIntegrity.Store(databaseIntegrityStatus{})
+
+	s.PostMigrate(ctx)
+
+	logger.Infof("Restored database from backup %s", backupPath)
+	return nil
+}
+
+// checkDatabaseIntegrity checks the existing database file for corruption.
+// If the database is corrupt, then the latest backup that passes the
+// integrity check is recorded so that it can be offered for restore.
+// Returns false if the database is corrupt.
+func (s *singleton) checkDatabaseIntegrity() bool {
+	dbPath := s.Config.GetDatabasePath()
+	if exists, _ := utils.FileExists(dbPath); !exists {
+		return true
+	}
+
+	integrityErr := database.QuickCheck(dbPath)
+	if integrityErr == nil {
+		return true
+	}
+
+	logger.Errorf("Database %s is corrupt: %v", dbPath, integrityErr)
+
+	latest, err := database.LatestGoodBackup(s.Config.GetBackupDirectory(), dbPath)
+	switch {
+	case err != nil:
+		logger.Warnf("error finding database backups: %v", err)
+	case latest == nil:
+		logger.Error("No valid database backup found")
+	default:
+		logger.Errorf("Latest valid database backup is %s, created %s. Use restoreDatabase to restore it.", latest.Path, latest.ModTime.Format(time.RFC3339))
+	}
+
+	status := databaseIntegrityStatus{err: integrityErr}
+	if latest != nil {
+		status.latestGoodBackup = filepath.Base(latest.Path)
+	}
+	s.databaseIntegrity.Store(status)
+
+	return false
+}
+
+// backupDue returns true if the latest backup in the backup directory is
+// older than the configured backup interval.
+func (s *singleton) backupDue() bool {
+	interval := s.Config.GetBackupInterval()
+	if interval <= 0 {
+		return false
+	}
+
+	backups, err := database.ListBackups(s.Config.GetBackupDirectory(), s.Config.GetDatabasePath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("error listing database backups: %v", err)
+		return false
+	}
+
+	if len(backups) == 0 {
+		return true
+	}
+
+	return time.Since(backups[0].ModTime) >= time.Duration(interval)*time.Hour
+}
+
+// runBackupScheduler periodically creates database backups according to
+// the configured backup interval, until the context is cancelled.
+func (s *singleton) runBackupScheduler(ctx context.Context) {
+	ticker := time.NewTicker(backupSchedulerPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if s.getDatabaseIntegrity().err != nil || database.Ready() != nil || !s.backupDue() {
+			continue
+		}
+
+		backupPath, err := s.BackupDatabase()
+		if err != nil {
+			logger.Errorf("error running scheduled database backup: %v", err)
+			continue
+		}
+
+		logger.Infof("Scheduled database backup written to %s", backupPath)
+	}
+}
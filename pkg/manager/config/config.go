@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"sync"
 	// "github.com/sasha-s/go-deadlock" // if you have deadlock issues
@@ -38,6 +39,12 @@ const (
 
 	Database = "database"
 
+	// database backup options
+	BackupDirectory = "backup_directory"
+	BackupCount     = "backup_count"
+	// interval between scheduled backups, in hours
+	BackupInterval = "backup_interval"
+
 	Exclude      = "exclude"
 	ImageExclude = "image_exclude"
 
@@ -45,6 +52,9 @@ const (
 	ImageExtensions            = "image_extensions"
 	GalleryExtensions          = "gallery_extensions"
 	CreateGalleriesFromFolders = "create_galleries_from_folders"
+	CreateImageClipsFromVideos = "create_image_clips_from_videos"
+	CreateImageKeywordTags     = "create_image_keyword_tags"
+	SetImageMetadataRating     = "set_image_metadata_rating"
 
 	// CalculateMD5 is the config key used to determine if MD5 should be calculated
 	// for video files.
@@ -57,6 +67,15 @@ const (
 	MaxTranscodeSize          = "max_transcode_size"
 	MaxStreamingTranscodeSize = "max_streaming_transcode_size"
 
+	// TranscodeProfiles is the config key of the named transcode profiles
+	// used for streaming and generated transcodes. This should be manually
+	// configured only.
+	TranscodeProfiles = "transcode_profiles"
+
+	// GenerateTranscodeProfile is the name of the transcode profile used for
+	// generated transcodes. The built-in settings are used if empty.
+	GenerateTranscodeProfile = "generate_transcode_profile"
+
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
@@ -80,6 +99,14 @@ const (
 	WriteImageThumbnails        = "write_image_thumbnails"
 	writeImageThumbnailsDefault = true
 
+	ChapterDetectionThreshold        = "chapter_detection_threshold"
+	chapterDetectionThresholdDefault = 0.4
+
+	ChapterMinimumLength        = "chapter_minimum_length"
+	chapterMinimumLengthDefault = 60
+
+	ChapterPrimaryTag = "chapter_primary_tag"
+
 	Host        = "host"
 	hostDefault = "0.0.0.0"
 
@@ -153,6 +180,14 @@ const (
 	HandyKey        = "handy_key"
 	FunscriptOffset = "funscript_offset"
 
+	// time of the last incremental export of each export target, written by
+	// the application
+	LastIncrementalExport = "export.last_incremental"
+
+	// DefaultExportTarget is the export target used for incremental exports
+	// when one is not provided
+	DefaultExportTarget = "default"
+
 	// Security
 	dangerousAllowPublicWithoutAuth                   = "dangerous_allow_public_without_auth"
 	dangerousAllowPublicWithoutAuthDefault            = "false"
@@ -204,6 +239,44 @@ var (
 	defaultMenuItems         = []string{"scenes", "images", "movies", "markers", "galleries", "performers", "studios", "tags"}
 )
 
+// Settings that are included in metadata exports. Keys that hold secrets or
+// paths specific to the local system must not be added to these lists.
+var (
+	ExportableInterfaceSettings = []string{
+		Language,
+		MenuItems,
+		SoundOnPreview,
+		WallShowTitle,
+		MaximumLoopDuration,
+		ShowSceneDeoVRButton,
+		AutostartVideo,
+		AutostartVideoOnPlaySelected,
+		ContinuePlaylistDefault,
+		ShowStudioAsText,
+		CSSEnabled,
+		ShowScrubber,
+		WallPlayback,
+		SlideshowDelay,
+		DisableDropdownCreatePerformer,
+		DisableDropdownCreateStudio,
+		DisableDropdownCreateTag,
+		FunscriptOffset,
+	}
+
+	ExportableScrapingSettings = []string{
+		ScraperUserAgent,
+		ScraperCertCheck,
+		ScraperExcludeTagPatterns,
+	}
+
+	ExportableDLNASettings = []string{
+		DLNAServerName,
+		DLNADefaultEnabled,
+		DLNADefaultIPWhitelist,
+		DLNAInterfaces,
+	}
+)
+
 type MissingConfigError struct {
 	missingFields []string
 }
@@ -362,6 +435,30 @@ func (i *Instance) viper(key string) *viper.Viper {
 	return v
 }
 
+// IsSet returns true if the key has a value in the config file.
+func (i *Instance) IsSet(key string) bool {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.main.IsSet(key)
+}
+
+// GetSettings returns the values of the provided keys from the config
+// file. Keys without a value are omitted.
+func (i *Instance) GetSettings(keys []string) map[string]interface{} {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := make(map[string]interface{})
+	for _, key := range keys {
+		if i.main.IsSet(key) {
+			ret[key] = i.main.Get(key)
+		}
+	}
+
+	return ret
+}
+
 func (i *Instance) HasOverride(key string) bool {
 	i.RLock()
 	defer i.RUnlock()
@@ -478,6 +575,30 @@ func (i *Instance) GetDatabasePath() string {
 	return i.getString(Database)
 }
 
+// GetBackupDirectory returns the directory that database backups are
+// written to. Defaults to the directory containing the database.
+func (i *Instance) GetBackupDirectory() string {
+	ret := i.getString(BackupDirectory)
+	if ret == "" {
+		ret = filepath.Dir(i.GetDatabasePath())
+	}
+
+	return ret
+}
+
+// GetBackupCount returns the number of database backups to keep. Older
+// backups are deleted when a new backup is created. Returns 0 if all
+// backups should be kept.
+func (i *Instance) GetBackupCount() int {
+	return i.getInt(BackupCount)
+}
+
+// GetBackupInterval returns the number of hours between scheduled database
+// backups. Returns 0 if scheduled backups are disabled.
+func (i *Instance) GetBackupInterval() int {
+	return i.getInt(BackupInterval)
+}
+
 func (i *Instance) GetJWTSignKey() []byte {
 	return []byte(i.getString(JWTSignKey))
 }
@@ -529,6 +650,22 @@ func (i *Instance) GetCreateGalleriesFromFolders() bool {
 	return i.getBool(CreateGalleriesFromFolders)
 }
 
+func (i *Instance) GetCreateImageClipsFromVideos() bool {
+	return i.getBool(CreateImageClipsFromVideos)
+}
+
+// GetCreateImageKeywordTags returns true if tags should be added to images
+// from their embedded keywords.
+func (i *Instance) GetCreateImageKeywordTags() bool {
+	return i.getBool(CreateImageKeywordTags)
+}
+
+// GetSetImageMetadataRating returns true if unrated images should be given
+// their embedded rating.
+func (i *Instance) GetSetImageMetadataRating() bool {
+	return i.getBool(SetImageMetadataRating)
+}
+
 func (i *Instance) GetLanguage() string {
 	ret := i.getString(Language)
 
@@ -673,6 +810,24 @@ func (i *Instance) GetPreviewExcludeEnd() string {
 	return i.getString(PreviewExcludeEnd)
 }
 
+// GetChapterDetectionThreshold returns the minimum scene change score, between
+// 0 and 1, of the shot changes used to detect chapters.
+func (i *Instance) GetChapterDetectionThreshold() float64 {
+	return i.getFloat64(ChapterDetectionThreshold)
+}
+
+// GetChapterMinimumLength returns the minimum length of detected chapters, in
+// seconds.
+func (i *Instance) GetChapterMinimumLength() int {
+	return i.getInt(ChapterMinimumLength)
+}
+
+// GetChapterPrimaryTag returns the name of the default primary tag of
+// detected chapters. Returns an empty string if not set.
+func (i *Instance) GetChapterPrimaryTag() string {
+	return i.getString(ChapterPrimaryTag)
+}
+
 // GetPreviewPreset returns the preset when generating previews. Defaults to
 // Slow.
 func (i *Instance) GetPreviewPreset() models.PreviewPreset {
@@ -708,6 +863,66 @@ func (i *Instance) GetMaxStreamingTranscodeSize() models.StreamingResolutionEnum
 	return models.StreamingResolutionEnum(ret)
 }
 
+// GetTranscodeProfiles returns the valid configured transcode profiles.
+// Invalid profiles are ignored, and are reported by
+// ValidateTranscodeProfiles.
+func (i *Instance) GetTranscodeProfiles() models.TranscodeProfiles {
+	var ret models.TranscodeProfiles
+	if err := i.unmarshalKey(TranscodeProfiles, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret.Valid()
+}
+
+// GetGenerateTranscodeProfile returns the transcode profile used for
+// generated transcodes, or nil if not set or invalid.
+func (i *Instance) GetGenerateTranscodeProfile() *models.TranscodeProfile {
+	name := i.getString(GenerateTranscodeProfile)
+	if name == "" {
+		return nil
+	}
+
+	// generated transcodes are served as mp4 files
+	p := i.GetTranscodeProfiles().Find(name)
+	if p == nil || p.Container != "mp4" {
+		return nil
+	}
+
+	return p
+}
+
+// ValidateTranscodeProfiles returns an error if the configured transcode
+// profiles or the generate transcode profile are invalid.
+func (i *Instance) ValidateTranscodeProfiles() error {
+	i.RLock()
+	defer i.RUnlock()
+
+	var profiles models.TranscodeProfiles
+	if err := i.viper(TranscodeProfiles).UnmarshalKey(TranscodeProfiles, &profiles); err != nil {
+		return fmt.Errorf("invalid %s: %w", TranscodeProfiles, err)
+	}
+
+	if err := profiles.Validate(); err != nil {
+		return fmt.Errorf("invalid %s: %w", TranscodeProfiles, err)
+	}
+
+	generateProfile := i.viper(GenerateTranscodeProfile).GetString(GenerateTranscodeProfile)
+	if generateProfile != "" {
+		p := profiles.Find(generateProfile)
+		if p == nil {
+			return fmt.Errorf("invalid %s: transcode profile %q not found", GenerateTranscodeProfile, generateProfile)
+		}
+
+		// generated transcodes are served as mp4 files
+		if p.Container != "mp4" {
+			return fmt.Errorf("invalid %s: transcode profile %q must use the mp4 container", GenerateTranscodeProfile, generateProfile)
+		}
+	}
+
+	return nil
+}
+
 // IsWriteImageThumbnails returns true if image thumbnails should be written
 // to disk after generating on the fly.
 func (i *Instance) IsWriteImageThumbnails() bool {
@@ -1127,6 +1342,51 @@ func (i *Instance) ActivatePublicAccessTripwire(requestIP string) error {
 	return i.Write()
 }
 
+// GetLastIncrementalExports returns the time that the last incremental
+// export of each export target was started, keyed by target.
+func (i *Instance) GetLastIncrementalExports() map[string]time.Time {
+	ret := make(map[string]time.Time)
+	for target, v := range i.getStringMapString(LastIncrementalExport) {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			continue
+		}
+		ret[target] = t
+	}
+
+	return ret
+}
+
+// GetLastIncrementalExport returns the time that the last incremental export
+// to target was started. Returns the zero time if an incremental export has
+// not been run for the target.
+func (i *Instance) GetLastIncrementalExport(target string) time.Time {
+	return i.GetLastIncrementalExports()[normaliseExportTarget(target)]
+}
+
+// SetLastIncrementalExport sets the time that the last incremental export
+// to target was started and writes the config file.
+func (i *Instance) SetLastIncrementalExport(target string, t time.Time) error {
+	m := i.getStringMapString(LastIncrementalExport)
+	if m == nil {
+		m = make(map[string]string)
+	}
+	m[normaliseExportTarget(target)] = t.Format(time.RFC3339Nano)
+
+	i.Set(LastIncrementalExport, m)
+	return i.Write()
+}
+
+// normaliseExportTarget returns the key used to store the last incremental
+// export time of target. Config keys are case-insensitive.
+func normaliseExportTarget(target string) string {
+	if target == "" {
+		return DefaultExportTarget
+	}
+
+	return strings.ToLower(target)
+}
+
 func (i *Instance) Validate() error {
 	i.RLock()
 	defer i.RUnlock()
@@ -1182,6 +1442,8 @@ func (i *Instance) setDefaultValues(write bool) error {
 	i.main.SetDefault(SoundOnPreview, false)
 
 	i.main.SetDefault(WriteImageThumbnails, writeImageThumbnailsDefault)
+	i.main.SetDefault(ChapterDetectionThreshold, chapterDetectionThresholdDefault)
+	i.main.SetDefault(ChapterMinimumLength, chapterMinimumLengthDefault)
 
 	i.main.SetDefault(Database, defaultDatabaseFilePath)
 
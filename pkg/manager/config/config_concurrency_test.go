@@ -28,6 +28,7 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.GetDefaultDatabaseFilePath()
 				i.GetStashPaths()
 				_ = i.ValidateStashBoxes(nil)
+				_ = i.ValidateTranscodeProfiles()
 				_ = i.Validate()
 				_ = i.ActivatePublicAccessTripwire("")
 				i.Set(Cache, i.GetCachePath())
@@ -101,6 +102,10 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(DeleteFileDefault, i.GetDeleteFileDefault())
 				i.Set(dangerousAllowPublicWithoutAuth, i.GetDangerousAllowPublicWithoutAuth())
 				i.Set(SecurityTripwireAccessedFromPublicInternet, i.GetSecurityTripwireAccessedFromPublicInternet())
+				i.Set(BackupDirectory, i.GetBackupDirectory())
+				i.Set(BackupCount, i.GetBackupCount())
+				i.Set(BackupInterval, i.GetBackupInterval())
+				i.Set(LastIncrementalExport, i.getStringMapString(LastIncrementalExport))
 				i.Set(DisableDropdownCreatePerformer, i.GetDisableDropdownCreate().Performer)
 				i.Set(DisableDropdownCreateStudio, i.GetDisableDropdownCreate().Studio)
 				i.Set(DisableDropdownCreateTag, i.GetDisableDropdownCreate().Tag)
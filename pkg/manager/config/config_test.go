@@ -0,0 +1,47 @@
+package config
+
+import "testing"
+
+// nonExportableSettings are settings that hold secrets or paths specific to
+// the local system.
+var nonExportableSettings = []string{
+	Stash,
+	Cache,
+	Generated,
+	Metadata,
+	Downloads,
+	ApiKey,
+	Username,
+	Password,
+	Database,
+	BackupDirectory,
+	JWTSignKey,
+	SessionStoreKey,
+	ScrapersPath,
+	ScraperCDPPath,
+	StashBoxes,
+	PluginsPath,
+	CustomServedFolders,
+	CustomUILocation,
+	CustomPerformerImageLocation,
+	HandyKey,
+	LogFile,
+}
+
+func TestExportableSettings(t *testing.T) {
+	exportable := [][]string{
+		ExportableInterfaceSettings,
+		ExportableScrapingSettings,
+		ExportableDLNASettings,
+	}
+
+	for _, settings := range exportable {
+		for _, key := range settings {
+			for _, excluded := range nonExportableSettings {
+				if key == excluded {
+					t.Errorf("setting %q must not be exportable", key)
+				}
+			}
+		}
+	}
+}
@@ -121,17 +121,28 @@ func initConfig(instance *Instance, flags flagStruct) error {
 func initFlags() flagStruct {
 	flags := flagStruct{}
 
-	pflag.IP("host", net.IPv4(0, 0, 0, 0), "ip address for the host")
-	pflag.Int("port", 9999, "port to serve from")
-	pflag.StringVarP(&flags.configFilePath, "config", "c", "", "config file to use")
-	pflag.StringVar(&flags.cpuProfilePath, "cpuprofile", "", "write cpu profile to file")
-	pflag.BoolVar(&flags.nobrowser, "nobrowser", false, "Don't open a browser window after launch")
-
+	registerFlags(pflag.CommandLine, &flags)
 	pflag.Parse()
 
 	return flags
 }
 
+func registerFlags(fs *pflag.FlagSet, flags *flagStruct) {
+	fs.IP("host", net.IPv4(0, 0, 0, 0), "ip address for the host")
+	fs.Int("port", 9999, "port to serve from")
+	fs.StringVarP(&flags.configFilePath, "config", "c", "", "config file to use")
+	fs.StringVar(&flags.cpuProfilePath, "cpuprofile", "", "write cpu profile to file")
+	fs.BoolVar(&flags.nobrowser, "nobrowser", false, "Don't open a browser window after launch")
+}
+
+// FlagSet returns a new flag set with the global command-line flags. The
+// returned flag set is not used to configure the instance.
+func FlagSet() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("stash", pflag.ContinueOnError)
+	registerFlags(fs, &flagStruct{})
+	return fs
+}
+
 func initEnvs(viper *viper.Viper) {
 	viper.SetEnvPrefix("stash")     // will be uppercased automatically
 	bindEnv(viper, "host")          // STASH_HOST
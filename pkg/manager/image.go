@@ -27,7 +27,7 @@ func walkGalleryZip(path string, walkFunc func(file *zip.File) error) error {
 			continue
 		}
 
-		if !isImage(f.Name) {
+		if !isImageOrClip(f.Name) {
 			continue
 		}
 
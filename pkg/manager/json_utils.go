@@ -25,6 +25,30 @@ func (jp *jsonUtils) saveScaped(scraped []jsonschema.ScrapedItem) error {
 	return jsonschema.SaveScrapedFile(jp.json.ScrapedFile, scraped)
 }
 
+func (jp *jsonUtils) getTombstones() ([]jsonschema.Tombstone, error) {
+	return jsonschema.LoadTombstonesFile(jp.json.TombstonesFile)
+}
+
+func (jp *jsonUtils) saveTombstones(tombstones []jsonschema.Tombstone) error {
+	return jsonschema.SaveTombstonesFile(jp.json.TombstonesFile, tombstones)
+}
+
+func (jp *jsonUtils) getSavedFilters() ([]jsonschema.SavedFilter, error) {
+	return jsonschema.LoadSavedFiltersFile(jp.json.SavedFiltersFile)
+}
+
+func (jp *jsonUtils) saveSavedFilters(savedFilters []jsonschema.SavedFilter) error {
+	return jsonschema.SaveSavedFiltersFile(jp.json.SavedFiltersFile, savedFilters)
+}
+
+func (jp *jsonUtils) getConfig() (*jsonschema.Config, error) {
+	return jsonschema.LoadConfigFile(jp.json.ConfigFile)
+}
+
+func (jp *jsonUtils) saveConfig(config *jsonschema.Config) error {
+	return jsonschema.SaveConfigFile(jp.json.ConfigFile, config)
+}
+
 func (jp *jsonUtils) getPerformer(checksum string) (*jsonschema.Performer, error) {
 	return jsonschema.LoadPerformerFile(jp.json.PerformerJSONPath(checksum))
 }
@@ -0,0 +1,39 @@
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Config contains the exportable configuration settings, keyed by config
+// key. It must not contain secrets or system-specific paths.
+type Config struct {
+	Interface map[string]interface{} `json:"interface,omitempty"`
+	Scraping  map[string]interface{} `json:"scraping,omitempty"`
+	DLNA      map[string]interface{} `json:"dlna,omitempty"`
+}
+
+func LoadConfigFile(filePath string) (*Config, error) {
+	var config Config
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func SaveConfigFile(filePath string, config *Config) error {
+	if config == nil {
+		return fmt.Errorf("config must not be nil")
+	}
+	return marshalToFile(filePath, config)
+}
@@ -19,6 +19,7 @@ type Image struct {
 	Title      string          `json:"title,omitempty"`
 	Checksum   string          `json:"checksum,omitempty"`
 	Studio     string          `json:"studio,omitempty"`
+	Date       string          `json:"date,omitempty"`
 	Rating     int             `json:"rating,omitempty"`
 	Organized  bool            `json:"organized,omitempty"`
 	OCounter   int             `json:"o_counter,omitempty"`
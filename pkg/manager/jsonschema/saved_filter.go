@@ -0,0 +1,38 @@
+package jsonschema
+
+import (
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type SavedFilter struct {
+	Mode models.FilterMode `json:"mode"`
+	// empty for the default filter of the mode
+	Name   string `json:"name,omitempty"`
+	Filter string `json:"filter"`
+}
+
+func LoadSavedFiltersFile(filePath string) ([]SavedFilter, error) {
+	var savedFilters []SavedFilter
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&savedFilters)
+	if err != nil {
+		return nil, err
+	}
+	return savedFilters, nil
+}
+
+func SaveSavedFiltersFile(filePath string, savedFilters []SavedFilter) error {
+	if savedFilters == nil {
+		savedFilters = []SavedFilter{}
+	}
+	return marshalToFile(filePath, savedFilters)
+}
@@ -18,6 +18,11 @@ type SceneMarker struct {
 }
 
 type SceneFile struct {
+	// Path and fingerprints are only set for the additional files of a scene
+	Path       string          `json:"path,omitempty"`
+	Checksum   string          `json:"checksum,omitempty"`
+	OSHash     string          `json:"oshash,omitempty"`
+	Phash      string          `json:"phash,omitempty"`
 	ModTime    models.JSONTime `json:"mod_time,omitempty"`
 	Size       string          `json:"size"`
 	Duration   string          `json:"duration"`
@@ -53,6 +58,7 @@ type Scene struct {
 	Tags       []string         `json:"tags,omitempty"`
 	Markers    []SceneMarker    `json:"markers,omitempty"`
 	File       *SceneFile       `json:"file,omitempty"`
+	Files      []SceneFile      `json:"files,omitempty"`
 	Cover      string           `json:"cover,omitempty"`
 	CreatedAt  models.JSONTime  `json:"created_at,omitempty"`
 	UpdatedAt  models.JSONTime  `json:"updated_at,omitempty"`
@@ -0,0 +1,40 @@
+package jsonschema
+
+import (
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Tombstone records the deletion of an object in an incremental export.
+type Tombstone struct {
+	ObjectType string          `json:"object_type"`
+	Checksum   string          `json:"checksum,omitempty"`
+	OSHash     string          `json:"oshash,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	DeletedAt  models.JSONTime `json:"deleted_at"`
+}
+
+func LoadTombstonesFile(filePath string) ([]Tombstone, error) {
+	var tombstones []Tombstone
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&tombstones)
+	if err != nil {
+		return nil, err
+	}
+	return tombstones, nil
+}
+
+func SaveTombstonesFile(filePath string, tombstones []Tombstone) error {
+	if tombstones == nil {
+		tombstones = []Tombstone{}
+	}
+	return marshalToFile(filePath, tombstones)
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"runtime/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/stashapp/stash/pkg/database"
@@ -47,6 +48,9 @@ type singleton struct {
 	TxnManager models.TransactionManager
 
 	scanSubs *subscriptionManager
+
+	// databaseIntegrityStatus of the database on startup
+	databaseIntegrity atomic.Value
 }
 
 var instance *singleton
@@ -60,71 +64,92 @@ func GetInstance() *singleton {
 func Initialize() *singleton {
 	once.Do(func() {
 		ctx := context.TODO()
-		cfg, err := config.Initialize()
+		if err := initialize(ctx); err != nil {
+			panic(err)
+		}
 
-		if err != nil {
-			panic(fmt.Sprintf("error initializing configuration: %s", err.Error()))
+		// if DLNA is enabled, start it now
+		if instance.Config.GetDLNADefaultEnabled() {
+			if err := instance.DLNAService.Start(nil); err != nil {
+				logger.Warnf("could not start DLNA service: %v", err)
+			}
 		}
 
-		initLog()
-		initProfiling(cfg.GetCPUProfilePath())
+		go instance.runBackupScheduler(ctx)
+	})
+
+	return instance
+}
+
+// InitializeHeadless initializes the configuration, paths and database
+// without starting the DLNA service or the backup scheduler. It is used to
+// run tasks from the command line. Returns an error instead of panicking
+// if initialization fails.
+func InitializeHeadless() (*singleton, error) {
+	var err error
+	once.Do(func() {
+		err = initialize(context.TODO())
+	})
 
-		instance = &singleton{
-			Config:        cfg,
-			JobManager:    job.NewManager(),
-			DownloadStore: NewDownloadStore(),
-			PluginCache:   plugin.NewCache(cfg),
+	return instance, err
+}
 
-			TxnManager: sqlite.NewTransactionManager(),
+func initialize(ctx context.Context) error {
+	cfg, err := config.Initialize()
 
-			scanSubs: &subscriptionManager{},
-		}
+	if err != nil {
+		return fmt.Errorf("error initializing configuration: %w", err)
+	}
 
-		sceneServer := SceneServer{
-			TXNManager: instance.TxnManager,
-		}
-		instance.DLNAService = dlna.NewService(instance.TxnManager, instance.Config, &sceneServer)
+	initLog()
+	initProfiling(cfg.GetCPUProfilePath())
 
-		if !cfg.IsNewSystem() {
-			logger.Infof("using config file: %s", cfg.GetConfigFile())
+	instance = &singleton{
+		Config:        cfg,
+		JobManager:    job.NewManager(),
+		DownloadStore: NewDownloadStore(),
+		PluginCache:   plugin.NewCache(cfg),
 
-			if err == nil {
-				err = cfg.Validate()
-			}
+		TxnManager: sqlite.NewTransactionManager(),
 
-			if err != nil {
-				panic(fmt.Sprintf("error initializing configuration: %s", err.Error()))
-			} else if err := instance.PostInit(ctx); err != nil {
-				panic(err)
-			}
+		scanSubs: &subscriptionManager{},
+	}
 
-			initSecurity(cfg)
-		} else {
-			cfgFile := cfg.GetConfigFile()
-			if cfgFile != "" {
-				cfgFile += " "
-			}
+	sceneServer := SceneServer{
+		TXNManager: instance.TxnManager,
+	}
+	instance.DLNAService = dlna.NewService(instance.TxnManager, instance.Config, &sceneServer)
 
-			// create temporary session store - this will be re-initialised
-			// after config is complete
-			instance.SessionStore = session.NewStore(cfg)
+	if !cfg.IsNewSystem() {
+		logger.Infof("using config file: %s", cfg.GetConfigFile())
 
-			logger.Warnf("config file %snot found. Assuming new system...", cfgFile)
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("error initializing configuration: %w", err)
 		}
 
-		if err = initFFMPEG(); err != nil {
-			logger.Warnf("could not initialize FFMPEG subsystem: %v", err)
+		if err := instance.PostInit(ctx); err != nil {
+			return err
 		}
 
-		// if DLNA is enabled, start it now
-		if instance.Config.GetDLNADefaultEnabled() {
-			if err := instance.DLNAService.Start(nil); err != nil {
-				logger.Warnf("could not start DLNA service: %v", err)
-			}
+		initSecurity(cfg)
+	} else {
+		cfgFile := cfg.GetConfigFile()
+		if cfgFile != "" {
+			cfgFile += " "
 		}
-	})
 
-	return instance
+		// create temporary session store - this will be re-initialised
+		// after config is complete
+		instance.SessionStore = session.NewStore(cfg)
+
+		logger.Warnf("config file %snot found. Assuming new system...", cfgFile)
+	}
+
+	if err := initFFMPEG(); err != nil {
+		logger.Warnf("could not initialize FFMPEG subsystem: %v", err)
+	}
+
+	return nil
 }
 
 func initSecurity(cfg *config.Instance) {
@@ -232,6 +257,13 @@ func (s *singleton) PostInit(ctx context.Context) error {
 		})
 	}
 
+	// a corrupt database may not open, so it is left uninitialized until
+	// it is restored from a backup
+	if !s.checkDatabaseIntegrity() {
+		logger.Error("Database not initialized. Restore a backup with restoreDatabase, or replace the database file and restart.")
+		return nil
+	}
+
 	if err := database.Initialize(s.Config.GetDatabasePath()); err != nil {
 		return err
 	}
@@ -258,6 +290,11 @@ func (s *singleton) RefreshConfig() {
 	s.Paths = paths.NewPaths(s.Config.GetGeneratedPath())
 	config := s.Config
 	if config.Validate() == nil {
+		// invalid transcode profiles are ignored
+		if err := config.ValidateTranscodeProfiles(); err != nil {
+			logger.Errorf("%v. Invalid transcode profiles will not be used.", err)
+		}
+
 		if err := utils.EnsureDir(s.Paths.Generated.Screenshots); err != nil {
 			logger.Warnf("could not create directory for Screenshots: %v", err)
 		}
@@ -417,13 +454,23 @@ func (s *singleton) GetSystemStatus() *models.SystemStatus {
 		status = models.SystemStatusEnumNeedsMigration
 	}
 
-	return &models.SystemStatus{
+	ret := &models.SystemStatus{
 		DatabaseSchema: &dbSchema,
 		DatabasePath:   &dbPath,
 		AppSchema:      appSchema,
 		Status:         status,
 		ConfigPath:     &configFile,
 	}
+
+	if integrity := s.getDatabaseIntegrity(); integrity.err != nil {
+		errStr := integrity.err.Error()
+		ret.DatabaseIntegrityError = &errStr
+		if integrity.latestGoodBackup != "" {
+			ret.LatestGoodBackup = &integrity.latestGoodBackup
+		}
+	}
+
+	return ret
 }
 
 // Shutdown gracefully stops the manager
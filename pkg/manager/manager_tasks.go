@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"sync"
 
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager/config"
@@ -29,6 +31,47 @@ func isImage(pathname string) bool {
 	return utils.MatchExtension(pathname, imgExt)
 }
 
+// getImageClipExtensions returns the extensions of video files that may be
+// scanned as image clips. Returns nil if image clips are disabled.
+func getImageClipExtensions() []string {
+	c := config.GetInstance()
+	if !c.GetCreateImageClipsFromVideos() {
+		return nil
+	}
+
+	var ret []string
+	for _, ext := range c.GetVideoExtensions() {
+		if image.IsClipExtension(ext) {
+			ret = append(ret, ext)
+		}
+	}
+
+	return ret
+}
+
+// isImageOrClip returns true if the file is an image, or a video file that
+// may be scanned as an image clip.
+func isImageOrClip(pathname string) bool {
+	return isImage(pathname) || utils.MatchExtension(pathname, getImageClipExtensions())
+}
+
+// dirContainsImages returns true if the directory directly contains at least
+// one image file.
+func dirContainsImages(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+
+	for _, e := range entries {
+		if !e.IsDir() && isImage(e.Name()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getScanPaths(inputPaths []string) []*models.StashConfig {
 	if len(inputPaths) == 0 {
 		return config.GetInstance().GetStashPaths()
@@ -235,6 +278,33 @@ func (s *singleton) Clean(ctx context.Context, input models.CleanMetadataInput)
 	return s.JobManager.Add(ctx, "Cleaning...", &j)
 }
 
+func (s *singleton) Organize(ctx context.Context, input models.OrganizeInput) int {
+	j := organizeJob{
+		txnManager: s.TxnManager,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Organizing...", &j)
+}
+
+func (s *singleton) ExportNfo(ctx context.Context, input models.ExportNfoInput) int {
+	j := exportNfoJob{
+		txnManager: s.TxnManager,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Exporting NFO files...", &j)
+}
+
+func (s *singleton) Verify(ctx context.Context, input models.VerifyMetadataInput) int {
+	j := verifyJob{
+		txnManager: s.TxnManager,
+		input:      input,
+	}
+
+	return s.JobManager.Add(ctx, "Verifying files...", &j)
+}
+
 func (s *singleton) MigrateHash(ctx context.Context) int {
 	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
 		fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
@@ -69,6 +69,12 @@ func (gp *generatedPaths) TempDir(pattern string) (string, error) {
 }
 
 func (gp *generatedPaths) GetThumbnailPath(checksum string, width int) string {
-	fname := fmt.Sprintf("%s_%d.jpg", checksum, width)
+	return gp.GetThumbnailFormatPath(checksum, width, "jpg")
+}
+
+// GetThumbnailFormatPath returns the path of the thumbnail with the provided
+// width and file extension.
+func (gp *generatedPaths) GetThumbnailFormatPath(checksum string, width int, ext string) string {
+	fname := fmt.Sprintf("%s_%d.%s", checksum, width, ext)
 	return filepath.Join(gp.Thumbnails, utils.GetIntraDir(checksum, thumbDirDepth, thumbDirLength), fname)
 }
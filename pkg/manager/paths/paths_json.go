@@ -10,8 +10,11 @@ import (
 type JSONPaths struct {
 	Metadata string
 
-	MappingsFile string
-	ScrapedFile  string
+	MappingsFile     string
+	ScrapedFile      string
+	TombstonesFile   string
+	SavedFiltersFile string
+	ConfigFile       string
 
 	Performers string
 	Scenes     string
@@ -27,6 +30,9 @@ func newJSONPaths(baseDir string) *JSONPaths {
 	jp.Metadata = baseDir
 	jp.MappingsFile = filepath.Join(baseDir, "mappings.json")
 	jp.ScrapedFile = filepath.Join(baseDir, "scraped.json")
+	jp.TombstonesFile = filepath.Join(baseDir, "tombstones.json")
+	jp.SavedFiltersFile = filepath.Join(baseDir, "saved_filters.json")
+	jp.ConfigFile = filepath.Join(baseDir, "config.json")
 	jp.Performers = filepath.Join(baseDir, "performers")
 	jp.Scenes = filepath.Join(baseDir, "scenes")
 	jp.Images = filepath.Join(baseDir, "images")
@@ -2,6 +2,7 @@ package manager
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/manager/config"
@@ -58,6 +59,46 @@ func makeStreamEndpoint(streamURL string, streamingResolution models.StreamingRe
 	}
 }
 
+// getProfileStreamEndpoints returns a stream endpoint for each of the
+// transcode profiles. The client chooses from these by the mime types it
+// supports.
+func getProfileStreamEndpoints(profiles models.TranscodeProfiles, directStreamURL string, container ffmpeg.Container) []*models.SceneStreamEndpoint {
+	var ret []*models.SceneStreamEndpoint
+	for _, p := range profiles {
+		var streamURL, mimeType string
+		switch ffmpeg.Container(p.Container) {
+		case ffmpeg.Mp4:
+			streamURL = directStreamURL + ".mp4"
+			mimeType = ffmpeg.MimeMp4
+		case ffmpeg.Webm:
+			streamURL = directStreamURL + ".webm"
+			mimeType = ffmpeg.MimeWebm
+		case ffmpeg.Mpegts:
+			streamURL = directStreamURL + ".m3u8"
+			mimeType = ffmpeg.MimeHLS
+		case ffmpeg.Matroska:
+			// only available if the scene container is an mkv already
+			if container != ffmpeg.Matroska {
+				continue
+			}
+			streamURL = directStreamURL + ".mkv"
+			// set mkv to mp4 to trick the client, as for the mkv endpoint
+			mimeType = ffmpeg.MimeMp4
+		default:
+			continue
+		}
+
+		label := p.Name
+		ret = append(ret, &models.SceneStreamEndpoint{
+			URL:      fmt.Sprintf("%s?profile=%s", streamURL, url.QueryEscape(p.Name)),
+			MimeType: &mimeType,
+			Label:    &label,
+		})
+	}
+
+	return ret
+}
+
 func GetSceneStreamPaths(scene *models.Scene, directStreamURL string, maxStreamingTranscodeSize models.StreamingResolutionEnum) ([]*models.SceneStreamEndpoint, error) {
 	if scene == nil {
 		return nil, fmt.Errorf("nil scene")
@@ -100,6 +141,9 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL string, maxStreami
 		})
 	}
 
+	// configured transcode profiles, in order of preference
+	ret = append(ret, getProfileStreamEndpoints(config.GetInstance().GetTranscodeProfiles(), directStreamURL, container)...)
+
 	hls := models.SceneStreamEndpoint{
 		URL:      directStreamURL + ".m3u8",
 		MimeType: &mimeHLS,
@@ -0,0 +1,28 @@
+package manager
+
+import (
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// GetSceneCoverCandidates returns count evenly spaced frames of the scene,
+// scored for use as the cover image.
+func GetSceneCoverCandidates(s *models.Scene, count int) ([]scene.CoverCandidate, error) {
+	probeResult, err := instance.FFProbe.NewVideoFile(s.Path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return scene.GetCoverCandidates(&instance.FFMPEG, *probeResult, count)
+}
+
+// GetSceneFrameCover returns the JPEG encoded frame of the scene at the
+// provided time, for use as the cover image.
+func GetSceneFrameCover(s *models.Scene, at float64) ([]byte, error) {
+	probeResult, err := instance.FFProbe.NewVideoFile(s.Path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return scene.GetFrameCover(&instance.FFMPEG, *probeResult, at)
+}
@@ -41,7 +41,7 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) {
 			return nil
 		}
 
-		if err := j.processScenes(ctx, progress, r.Scene()); err != nil {
+		if err := j.processScenes(ctx, progress, r.Scene(), r.SceneFile()); err != nil {
 			return fmt.Errorf("error cleaning scenes: %w", err)
 		}
 		if err := j.processImages(ctx, progress, r.Image()); err != nil {
@@ -50,6 +50,9 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) {
 		if err := j.processGalleries(ctx, progress, r.Gallery(), r.Image()); err != nil {
 			return fmt.Errorf("error cleaning galleries: %w", err)
 		}
+		if err := j.processFolders(ctx, progress, r.Folder()); err != nil {
+			return fmt.Errorf("error cleaning folders: %w", err)
+		}
 
 		return nil
 	}); err != nil {
@@ -91,7 +94,7 @@ func (j *cleanJob) getCount(r models.ReaderRepository) (int, error) {
 	return sceneResult.Count + imageCount + galleryCount, nil
 }
 
-func (j *cleanJob) processScenes(ctx context.Context, progress *job.Progress, qb models.SceneReader) error {
+func (j *cleanJob) processScenes(ctx context.Context, progress *job.Progress, qb models.SceneReader, fqb models.SceneFileReader) error {
 	batchSize := 1000
 
 	findFilter := models.BatchFindFilter(batchSize)
@@ -100,6 +103,7 @@ func (j *cleanJob) processScenes(ctx context.Context, progress *job.Progress, qb
 	findFilter.Sort = &sort
 
 	var toDelete []int
+	var filesToRemove []*models.SceneFile
 
 	more := true
 	for more {
@@ -113,10 +117,35 @@ func (j *cleanJob) processScenes(ctx context.Context, progress *job.Progress, qb
 		}
 
 		for _, scene := range scenes {
+			files, err := fqb.FindBySceneID(scene.ID)
+			if err != nil {
+				return fmt.Errorf("error getting files for scene %s: %w", scene.Path, err)
+			}
+
 			progress.ExecuteTask(fmt.Sprintf("Assessing scene %s for clean", scene.Path), func() {
-				if j.shouldCleanScene(scene) {
+				if len(files) == 0 {
+					// scene without file records
+					if j.shouldCleanSceneFile(scene.Path) {
+						toDelete = append(toDelete, scene.ID)
+					} else {
+						progress.Increment()
+					}
+					return
+				}
+
+				var missing []*models.SceneFile
+				for _, f := range files {
+					if j.shouldCleanSceneFile(f.Path) {
+						missing = append(missing, f)
+					}
+				}
+
+				// only delete the scene if all of its files are to be cleaned
+				if len(missing) == len(files) {
 					toDelete = append(toDelete, scene.ID)
 				} else {
+					filesToRemove = append(filesToRemove, missing...)
+
 					// increment progress, no further processing
 					progress.Increment()
 				}
@@ -151,6 +180,18 @@ func (j *cleanJob) processScenes(ctx context.Context, progress *job.Progress, qb
 		})
 	}
 
+	if !j.input.DryRun && len(filesToRemove) > 0 {
+		progress.ExecuteTask(fmt.Sprintf("Cleaning %d scene files", len(filesToRemove)), func() {
+			for _, f := range filesToRemove {
+				if job.IsCancelled(ctx) {
+					return
+				}
+
+				j.removeSceneFile(ctx, fileNamingAlgorithm, f)
+			}
+		})
+	}
+
 	return nil
 }
 
@@ -279,6 +320,68 @@ func (j *cleanJob) processImages(ctx context.Context, progress *job.Progress, qb
 	return nil
 }
 
+// processFolders removes the folders that no longer exist, such as the
+// previous folders of moved or renamed folders.
+func (j *cleanJob) processFolders(ctx context.Context, progress *job.Progress, qb models.FolderReader) error {
+	// folders are sorted by path, so parent folders precede their children
+	folders, err := qb.All()
+	if err != nil {
+		return fmt.Errorf("error querying for folders: %w", err)
+	}
+
+	var toDelete []int
+	deleted := make(map[int64]bool)
+	for _, f := range folders {
+		if !j.isInPaths(f.Path) {
+			continue
+		}
+
+		// sub-folders are removed with their parent folder
+		if f.ParentFolderID.Valid && deleted[f.ParentFolderID.Int64] {
+			deleted[int64(f.ID)] = true
+			continue
+		}
+
+		if exists, _ := utils.DirExists(f.Path); !exists {
+			logger.Infof("Folder not found. Marking to clean: \"%s\"", f.Path)
+			toDelete = append(toDelete, f.ID)
+			deleted[int64(f.ID)] = true
+		}
+	}
+
+	if !j.input.DryRun && len(toDelete) > 0 {
+		progress.ExecuteTask(fmt.Sprintf("Cleaning %d folders", len(toDelete)), func() {
+			for _, folderID := range toDelete {
+				if job.IsCancelled(ctx) {
+					return
+				}
+
+				if err := j.txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+					return r.Folder().Destroy(folderID)
+				}); err != nil {
+					logger.Errorf("Error deleting folder from database: %s", err.Error())
+				}
+			}
+		})
+	}
+
+	return nil
+}
+
+func (j *cleanJob) isInPaths(path string) bool {
+	if len(j.input.Paths) == 0 {
+		return true
+	}
+
+	for _, p := range j.input.Paths {
+		if utils.IsPathInDir(p, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (j *cleanJob) shouldClean(path string) bool {
 	// use image.FileExists for zip file checking
 	fileExists := image.FileExists(path)
@@ -293,25 +396,25 @@ func (j *cleanJob) shouldClean(path string) bool {
 	return false
 }
 
-func (j *cleanJob) shouldCleanScene(s *models.Scene) bool {
-	if j.shouldClean(s.Path) {
+func (j *cleanJob) shouldCleanSceneFile(path string) bool {
+	if j.shouldClean(path) {
 		return true
 	}
 
-	stash := getStashFromPath(s.Path)
+	stash := getStashFromPath(path)
 	if stash.ExcludeVideo {
-		logger.Infof("File in stash library that excludes video. Marking to clean: \"%s\"", s.Path)
+		logger.Infof("File in stash library that excludes video. Marking to clean: \"%s\"", path)
 		return true
 	}
 
 	config := config.GetInstance()
-	if !utils.MatchExtension(s.Path, config.GetVideoExtensions()) {
-		logger.Infof("File extension does not match video extensions. Marking to clean: \"%s\"", s.Path)
+	if !utils.MatchExtension(path, config.GetVideoExtensions()) {
+		logger.Infof("File extension does not match video extensions. Marking to clean: \"%s\"", path)
 		return true
 	}
 
-	if matchFile(s.Path, config.GetExcludes()) {
-		logger.Infof("File matched regex. Marking to clean: \"%s\"", s.Path)
+	if matchFile(path, config.GetExcludes()) {
+		logger.Infof("File matched regex. Marking to clean: \"%s\"", path)
 		return true
 	}
 
@@ -379,7 +482,7 @@ func (j *cleanJob) shouldCleanImage(s *models.Image) bool {
 	}
 
 	config := config.GetInstance()
-	if !utils.MatchExtension(s.Path, config.GetImageExtensions()) {
+	if !isImageOrClip(s.Path) {
 		logger.Infof("File extension does not match image extensions. Marking to clean: \"%s\"", s.Path)
 		return true
 	}
@@ -428,6 +531,40 @@ func (j *cleanJob) deleteScene(ctx context.Context, fileNamingAlgorithm models.H
 	}, nil)
 }
 
+// removeSceneFile removes the file from its scene. Another file of the scene
+// becomes the primary file if the removed file was the primary file.
+func (j *cleanJob) removeSceneFile(ctx context.Context, fileNamingAlgorithm models.HashAlgorithm, f *models.SceneFile) {
+	var s *models.Scene
+	var oldHash string
+	if err := j.txnManager.WithTxn(context.TODO(), func(repo models.Repository) error {
+		qb := repo.Scene()
+
+		var err error
+		s, err = qb.Find(f.SceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("scene %d not found", f.SceneID)
+		}
+
+		oldHash = s.GetHash(fileNamingAlgorithm)
+		_, err = scene.RemoveFile(qb, repo.SceneFile(), s, f)
+		return err
+	}); err != nil {
+		logger.Errorf("Error removing scene file %s from database: %s", f.Path, err.Error())
+		return
+	}
+
+	// migrate generated files if the primary file has changed
+	if newHash := s.GetHash(fileNamingAlgorithm); newHash != oldHash {
+		scene.MigrateHash(GetInstance().Paths, oldHash, newHash)
+	}
+
+	GetInstance().PluginCache.ExecutePostHooks(ctx, s.ID, plugin.SceneUpdatePost, nil, nil)
+}
+
 func (j *cleanJob) deleteGallery(ctx context.Context, galleryID int) {
 	var g *models.Gallery
 
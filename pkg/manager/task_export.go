@@ -20,6 +20,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/movie"
 	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/studio"
 	"github.com/stashapp/stash/pkg/tag"
@@ -45,6 +46,14 @@ type ExportTask struct {
 	galleries  *exportSpec
 
 	includeDependencies bool
+	savedFilters        bool
+	config              bool
+
+	// if set, only objects updated after this time are exported, along
+	// with tombstones for objects deleted after this time
+	since *time.Time
+	// the export target that since was read from
+	target string
 
 	DownloadHash string
 }
@@ -78,18 +87,69 @@ func CreateExportTask(a models.HashAlgorithm, input models.ExportObjectsInput) *
 		includeDeps = *input.IncludeDependencies
 	}
 
-	return &ExportTask{
+	var ret *ExportTask
+	if input.Incremental != nil && *input.Incremental {
+		target := ""
+		if input.IncrementalTarget != nil {
+			target = *input.IncrementalTarget
+		}
+		ret = createIncrementalExportTask(a, target, includeDeps)
+	} else {
+		ret = &ExportTask{
+			txnManager:          GetInstance().TxnManager,
+			fileNamingAlgorithm: a,
+			scenes:              newExportSpec(input.Scenes),
+			images:              newExportSpec(input.Images),
+			performers:          newExportSpec(input.Performers),
+			movies:              newExportSpec(input.Movies),
+			tags:                newExportSpec(input.Tags),
+			studios:             newExportSpec(input.Studios),
+			galleries:           newExportSpec(input.Galleries),
+			includeDependencies: includeDeps,
+		}
+	}
+
+	if input.SavedFilters != nil {
+		ret.savedFilters = *input.SavedFilters
+	}
+	if input.Config != nil {
+		ret.config = *input.Config
+	}
+
+	return ret
+}
+
+// createIncrementalExportTask creates an export task that includes all
+// objects modified since the last incremental export to target.
+func createIncrementalExportTask(a models.HashAlgorithm, target string, includeDeps bool) *ExportTask {
+	all := &exportSpec{all: true}
+
+	ret := &ExportTask{
 		txnManager:          GetInstance().TxnManager,
 		fileNamingAlgorithm: a,
-		scenes:              newExportSpec(input.Scenes),
-		images:              newExportSpec(input.Images),
-		performers:          newExportSpec(input.Performers),
-		movies:              newExportSpec(input.Movies),
-		tags:                newExportSpec(input.Tags),
-		studios:             newExportSpec(input.Studios),
-		galleries:           newExportSpec(input.Galleries),
+		scenes:              all,
+		images:              all,
+		performers:          all,
+		movies:              all,
+		tags:                all,
+		studios:             all,
+		galleries:           all,
 		includeDependencies: includeDeps,
+		target:              target,
 	}
+
+	since := config.GetInstance().GetLastIncrementalExport(target)
+	ret.since = &since
+
+	return ret
+}
+
+// isModified returns true if an object with the provided updated time
+// should be included in the export.
+func (t *ExportTask) isModified(updatedAt models.SQLiteTimestamp) bool {
+	// updated times have second precision, so objects updated within the
+	// same second as the last export are included again
+	return t.since == nil || !updatedAt.Timestamp.Before(t.since.Truncate(time.Second))
 }
 
 func (t *ExportTask) Start(wg *sync.WaitGroup) {
@@ -151,6 +211,14 @@ func (t *ExportTask) Start(wg *sync.WaitGroup) {
 			t.ExportScrapedItems(r)
 		}
 
+		if t.full || t.savedFilters {
+			t.ExportSavedFilters(r)
+		}
+
+		if t.since != nil {
+			return t.ExportTombstones(r)
+		}
+
 		return nil
 	})
 	if txnErr != nil {
@@ -161,6 +229,10 @@ func (t *ExportTask) Start(wg *sync.WaitGroup) {
 		logger.Errorf("[mappings] failed to save json: %s", err.Error())
 	}
 
+	if t.full || t.config {
+		t.ExportConfig()
+	}
+
 	if !t.full {
 		err := t.generateDownload()
 		if err != nil {
@@ -168,9 +240,48 @@ func (t *ExportTask) Start(wg *sync.WaitGroup) {
 			return
 		}
 	}
+
+	if t.since != nil && txnErr == nil {
+		// only objects modified from the start of this export need to be
+		// included in the next incremental export
+		if err := config.GetInstance().SetLastIncrementalExport(t.target, startTime); err != nil {
+			logger.Errorf("error setting last incremental export time: %s", err.Error())
+		} else {
+			t.pruneTombstones()
+		}
+	}
+
 	logger.Infof("Export complete in %s.", time.Since(startTime))
 }
 
+// pruneTombstones removes the tombstones that have been exported to every
+// export target.
+func (t *ExportTask) pruneTombstones() {
+	before := earliestExport(config.GetInstance().GetLastIncrementalExports())
+	if before.IsZero() {
+		return
+	}
+
+	if err := t.txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		return r.Tombstone().DestroyBefore(before)
+	}); err != nil {
+		logger.Errorf("error pruning tombstones: %s", err.Error())
+	}
+}
+
+// earliestExport returns the earliest of the provided export times, or the
+// zero time if there are none.
+func earliestExport(exports map[string]time.Time) time.Time {
+	var ret time.Time
+	for _, t := range exports {
+		if ret.IsZero() || t.Before(ret) {
+			ret = t
+		}
+	}
+
+	return ret
+}
+
 func (t *ExportTask) generateDownload() error {
 	// zip the files and register a download link
 	if err := utils.EnsureDir(instance.Paths.Generated.Downloads); err != nil {
@@ -214,6 +325,24 @@ func (t *ExportTask) zipFiles(w io.Writer) error {
 	walkWarn(t.json.json.Scenes, t.zipWalkFunc(u.json.Scenes, z))
 	walkWarn(t.json.json.Images, t.zipWalkFunc(u.json.Images, z))
 
+	if t.since != nil {
+		if err := t.zipFile(t.json.json.TombstonesFile, "", z); err != nil {
+			return err
+		}
+	}
+
+	if t.savedFilters {
+		if err := t.zipFile(t.json.json.SavedFiltersFile, "", z); err != nil {
+			return err
+		}
+	}
+
+	if t.config {
+		if err := t.zipFile(t.json.json.ConfigFile, "", z); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -349,6 +478,10 @@ func (t *ExportTask) ExportScenes(workers int, repo models.ReaderRepository) {
 	}
 
 	for i, scene := range scenes {
+		if !t.isModified(scene.UpdatedAt) {
+			continue
+		}
+
 		index := i + 1
 
 		if (i % 100) == 0 { // make progress easier to read
@@ -373,6 +506,7 @@ func exportScene(wg *sync.WaitGroup, jobChan <-chan *models.Scene, repo models.R
 	performerReader := repo.Performer()
 	tagReader := repo.Tag()
 	sceneMarkerReader := repo.SceneMarker()
+	sceneFileReader := repo.SceneFile()
 
 	for s := range jobChan {
 		sceneHash := s.GetHash(t.fileNamingAlgorithm)
@@ -417,6 +551,12 @@ func exportScene(wg *sync.WaitGroup, jobChan <-chan *models.Scene, repo models.R
 			continue
 		}
 
+		newSceneJSON.Files, err = scene.GetSceneFilesJSON(sceneFileReader, s)
+		if err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene files JSON: %s", sceneHash, err.Error())
+			continue
+		}
+
 		newSceneJSON.Movies, err = scene.GetSceneMoviesJSON(movieReader, sceneReader, s)
 		if err != nil {
 			logger.Errorf("[scenes] <%s> error getting scene movies JSON: %s", sceneHash, err.Error())
@@ -487,6 +627,10 @@ func (t *ExportTask) ExportImages(workers int, repo models.ReaderRepository) {
 	}
 
 	for i, image := range images {
+		if !t.isModified(image.UpdatedAt) {
+			continue
+		}
+
 		index := i + 1
 
 		if (i % 100) == 0 { // make progress easier to read
@@ -602,6 +746,10 @@ func (t *ExportTask) ExportGalleries(workers int, repo models.ReaderRepository)
 	}
 
 	for i, gallery := range galleries {
+		if !t.isModified(gallery.UpdatedAt) {
+			continue
+		}
+
 		index := i + 1
 
 		if (i % 100) == 0 { // make progress easier to read
@@ -706,6 +854,10 @@ func (t *ExportTask) ExportPerformers(workers int, repo models.ReaderRepository)
 	}
 
 	for i, performer := range performers {
+		if !t.isModified(performer.UpdatedAt) {
+			continue
+		}
+
 		index := i + 1
 		logger.Progressf("[performers] %d of %d", index, len(performers))
 
@@ -785,6 +937,10 @@ func (t *ExportTask) ExportStudios(workers int, repo models.ReaderRepository) {
 	}
 
 	for i, studio := range studios {
+		if !t.isModified(studio.UpdatedAt) {
+			continue
+		}
+
 		index := i + 1
 		logger.Progressf("[studios] %d of %d", index, len(studios))
 
@@ -850,6 +1006,10 @@ func (t *ExportTask) ExportTags(workers int, repo models.ReaderRepository) {
 	}
 
 	for i, tag := range tags {
+		if !t.isModified(tag.UpdatedAt) {
+			continue
+		}
+
 		index := i + 1
 		logger.Progressf("[tags] %d of %d", index, len(tags))
 
@@ -921,6 +1081,10 @@ func (t *ExportTask) ExportMovies(workers int, repo models.ReaderRepository) {
 	}
 
 	for i, movie := range movies {
+		if !t.isModified(movie.UpdatedAt) {
+			continue
+		}
+
 		index := i + 1
 		logger.Progressf("[movies] %d of %d", index, len(movies))
 
@@ -1049,3 +1213,69 @@ func (t *ExportTask) ExportScrapedItems(repo models.ReaderRepository) {
 
 	logger.Infof("[scraped sites] export complete")
 }
+
+func (t *ExportTask) ExportTombstones(repo models.ReaderRepository) error {
+	logger.Info("[tombstones] exporting")
+
+	tombstones, err := repo.Tombstone().FindSince(*t.since)
+	if err != nil {
+		return fmt.Errorf("error fetching tombstones: %v", err)
+	}
+
+	var ret []jsonschema.Tombstone
+	for _, ts := range tombstones {
+		ret = append(ret, jsonschema.Tombstone{
+			ObjectType: ts.ObjectType,
+			Checksum:   ts.Checksum.String,
+			OSHash:     ts.OSHash.String,
+			Name:       ts.Name.String,
+			DeletedAt:  models.JSONTime{Time: ts.DeletedAt.Timestamp},
+		})
+	}
+
+	if err := t.json.saveTombstones(ret); err != nil {
+		return fmt.Errorf("failed to save json: %v", err)
+	}
+
+	logger.Infof("[tombstones] %d tombstones exported", len(ret))
+	return nil
+}
+
+func (t *ExportTask) ExportSavedFilters(repo models.ReaderRepository) {
+	logger.Info("[saved filters] exporting")
+
+	savedFilters, err := repo.SavedFilter().All()
+	if err != nil {
+		logger.Errorf("[saved filters] failed to fetch saved filters: %s", err.Error())
+		return
+	}
+
+	var ret []jsonschema.SavedFilter
+	for _, f := range savedFilters {
+		ret = append(ret, *savedfilter.ToJSON(f))
+	}
+
+	if err := t.json.saveSavedFilters(ret); err != nil {
+		logger.Errorf("[saved filters] failed to save json: %s", err.Error())
+		return
+	}
+
+	logger.Infof("[saved filters] %d saved filters exported", len(ret))
+}
+
+// ExportConfig exports the configuration settings that are safe to share
+// between instances.
+func (t *ExportTask) ExportConfig() {
+	logger.Info("[config] exporting")
+
+	c := config.GetInstance()
+	configJSON := &jsonschema.Config{
+		Interface: c.GetSettings(config.ExportableInterfaceSettings),
+		Scraping:  c.GetSettings(config.ExportableScrapingSettings),
+		DLNA:      c.GetSettings(config.ExportableDLNASettings),
+	}
+
+	if err := t.json.saveConfig(configJSON); err != nil {
+		logger.Errorf("[config] failed to save json: %s", err.Error())
+	}
+}
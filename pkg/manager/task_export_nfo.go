@@ -0,0 +1,214 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/movie"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type exportNfoJob struct {
+	txnManager models.TransactionManager
+	input      models.ExportNfoInput
+}
+
+func (j *exportNfoJob) Execute(ctx context.Context, progress *job.Progress) {
+	var scenes []*models.Scene
+	var movies []*models.Movie
+	if err := j.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		scenes, err = j.getScenes(r.Scene())
+		if err != nil {
+			return fmt.Errorf("error getting scenes: %w", err)
+		}
+
+		if j.input.MoviesPath != nil && *j.input.MoviesPath != "" {
+			movies, err = j.getMovies(r.Movie())
+			if err != nil {
+				return fmt.Errorf("error getting movies: %w", err)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	progress.SetTotal(len(scenes) + len(movies))
+
+	for _, s := range scenes {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Exporting NFO for %s", s.Path), func() {
+			if err := j.exportScene(ctx, s); err != nil {
+				logger.Errorf("error exporting NFO for %s: %v", s.Path, err)
+			}
+		})
+
+		progress.Increment()
+	}
+
+	// movie directory names are made unique, since movie names may not be
+	// unique once invalid characters are removed
+	dirNames := make(map[string]bool)
+	for _, m := range movies {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		dirName := nfo.DirName(m.Name.String)
+		if dirName == "" || dirNames[strings.ToLower(dirName)] {
+			dirName = strings.TrimSpace(fmt.Sprintf("%s (%d)", dirName, m.ID))
+		}
+		dirNames[strings.ToLower(dirName)] = true
+
+		dir := filepath.Join(*j.input.MoviesPath, dirName)
+		progress.ExecuteTask(fmt.Sprintf("Exporting NFO for movie %s", m.Name.String), func() {
+			if err := j.exportMovie(ctx, m, dir); err != nil {
+				logger.Errorf("error exporting NFO for movie %s: %v", m.Name.String, err)
+			}
+		})
+
+		progress.Increment()
+	}
+
+	logger.Info("Finished exporting NFO files")
+}
+
+func (j *exportNfoJob) getScenes(qb models.SceneReader) ([]*models.Scene, error) {
+	if j.input.SceneIds == nil {
+		return qb.All()
+	}
+
+	ids, err := utils.StringSliceToIntSlice(j.input.SceneIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return qb.FindMany(ids)
+}
+
+func (j *exportNfoJob) getMovies(qb models.MovieReader) ([]*models.Movie, error) {
+	if j.input.MovieIds == nil {
+		return qb.All()
+	}
+
+	ids, err := utils.StringSliceToIntSlice(j.input.MovieIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return qb.FindMany(ids)
+}
+
+// readExistingNFO reads the NFO file at the provided path, returning nil if
+// the file does not exist. An error is returned if the file exists but
+// cannot be read, so that it is not overwritten.
+func readExistingNFO(path string) (*nfo.Movie, error) {
+	if exists, _ := utils.FileExists(path); !exists {
+		return nil, nil
+	}
+
+	return nfo.Read(path)
+}
+
+func (j *exportNfoJob) exportScene(ctx context.Context, s *models.Scene) error {
+	path := nfo.Path(s.Path)
+	existing, err := readExistingNFO(path)
+	if err != nil {
+		return err
+	}
+
+	var n *nfo.Movie
+	var cover []byte
+	if err := j.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		n, err = scene.ToNFO(r, s, existing)
+		if err != nil {
+			return err
+		}
+
+		cover, err = r.Scene().GetCover(s.ID)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := nfo.Write(path, n); err != nil {
+		return err
+	}
+
+	if len(cover) > 0 {
+		if err := nfo.WriteFile(nfo.PosterPath(s.Path, cover), cover); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j *exportNfoJob) exportMovie(ctx context.Context, m *models.Movie, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, nfo.MovieFilename)
+	existing, err := readExistingNFO(path)
+	if err != nil {
+		return err
+	}
+
+	var n *nfo.Movie
+	var frontImage []byte
+	var backImage []byte
+	if err := j.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		n, err = movie.ToNFO(r.Studio(), m, existing)
+		if err != nil {
+			return err
+		}
+
+		qb := r.Movie()
+		frontImage, err = qb.GetFrontImage(m.ID)
+		if err != nil {
+			return err
+		}
+
+		backImage, err = qb.GetBackImage(m.ID)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := nfo.Write(path, n); err != nil {
+		return err
+	}
+
+	if len(frontImage) > 0 {
+		if err := nfo.WriteFile(filepath.Join(dir, "poster"+nfo.ImageExt(frontImage)), frontImage); err != nil {
+			return err
+		}
+	}
+
+	if len(backImage) > 0 {
+		if err := nfo.WriteFile(filepath.Join(dir, "back"+nfo.ImageExt(backImage)), backImage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarliestExport(t *testing.T) {
+	t1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, earliestExport(nil).IsZero())
+	assert.Equal(t, t1, earliestExport(map[string]time.Time{"a": t2, "b": t1}))
+	assert.Equal(t, t2, earliestExport(map[string]time.Time{"a": t2}))
+}
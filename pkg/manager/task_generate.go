@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager/config"
@@ -33,6 +34,9 @@ type totalsGenerate struct {
 	transcodes               int64
 	phashes                  int64
 	interactiveHeatmapSpeeds int64
+	imageThumbnails          int64
+	covers                   int64
+	chapters                 int64
 
 	tasks int
 }
@@ -95,7 +99,7 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 			return
 		}
 
-		logger.Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes %d phashes %d heatmaps & speeds", totals.sprites, totals.previews, totals.imagePreviews, totals.markers, totals.transcodes, totals.phashes, totals.interactiveHeatmapSpeeds)
+		logger.Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes %d phashes %d heatmaps & speeds %d image thumbnails %d cover checks %d chapter detections", totals.sprites, totals.previews, totals.imagePreviews, totals.markers, totals.transcodes, totals.phashes, totals.interactiveHeatmapSpeeds, totals.imageThumbnails, totals.covers, totals.chapters)
 
 		progress.SetTotal(int(totals.tasks))
 	}()
@@ -174,6 +178,10 @@ func (j *GenerateJob) queueTasks(ctx context.Context, queue chan<- Task) totalsG
 			}
 		}
 
+		if utils.IsTrue(j.input.ImageThumbnails) {
+			return j.queueImageJobs(ctx, r.Image(), queue, &totals)
+		}
+
 		return nil
 	}); err != nil {
 		if !errors.Is(err, context.Canceled) {
@@ -184,6 +192,48 @@ func (j *GenerateJob) queueTasks(ctx context.Context, queue chan<- Task) totalsG
 	return totals
 }
 
+func (j *GenerateJob) queueImageJobs(ctx context.Context, qb models.ImageReader, queue chan<- Task, totals *totalsGenerate) error {
+	const batchSize = 1000
+
+	findFilter := models.BatchFindFilter(batchSize)
+
+	for more := true; more; {
+		if job.IsCancelled(ctx) {
+			return context.Canceled
+		}
+
+		images, err := image.Query(qb, nil, findFilter)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range images {
+			if job.IsCancelled(ctx) {
+				return context.Canceled
+			}
+
+			task := &GenerateImageThumbnailsTask{
+				Image:     *i,
+				Overwrite: j.overwrite,
+			}
+
+			if task.required() {
+				totals.imageThumbnails++
+				totals.tasks++
+				queue <- task
+			}
+		}
+
+		if len(images) != batchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return nil
+}
+
 func (j *GenerateJob) queueSceneJobs(scene *models.Scene, queue chan<- Task, totals *totalsGenerate) {
 	if utils.IsTrue(j.input.Sprites) {
 		task := &GenerateSpriteTask{
@@ -295,6 +345,29 @@ func (j *GenerateJob) queueSceneJobs(scene *models.Scene, queue chan<- Task, tot
 			queue <- task
 		}
 	}
+
+	if utils.IsTrue(j.input.RepickCovers) {
+		task := &GenerateCoverTask{
+			Scene:      *scene,
+			txnManager: j.txnManager,
+		}
+
+		totals.covers++
+		totals.tasks++
+		queue <- task
+	}
+
+	if utils.IsTrue(j.input.Chapters) {
+		task := &GenerateChaptersTask{
+			Scene:      *scene,
+			Overwrite:  j.overwrite,
+			txnManager: j.txnManager,
+		}
+
+		totals.chapters++
+		totals.tasks++
+		queue <- task
+	}
 }
 
 func (j *GenerateJob) queueMarkerJob(marker *models.SceneMarker, queue chan<- Task, totals *totalsGenerate) {
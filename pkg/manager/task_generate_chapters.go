@@ -0,0 +1,121 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/tag"
+)
+
+// GenerateChaptersTask detects the chapters of a scene from its shot changes
+// and stores them as pending scene marker suggestions. Scenes are only
+// detected once unless overwriting, and rejected suggestions are not
+// suggested again.
+type GenerateChaptersTask struct {
+	Scene      models.Scene
+	Overwrite  bool
+	txnManager models.TransactionManager
+}
+
+func (t *GenerateChaptersTask) GetDescription() string {
+	return fmt.Sprintf("Detecting chapters for %s", t.Scene.Path)
+}
+
+func (t *GenerateChaptersTask) Start(ctx context.Context) {
+	var markers []*models.SceneMarker
+	var rejected []float64
+	var primaryTagID *int
+	skip := false
+	if err := t.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		qb := r.SceneMarker()
+		var err error
+		if !t.Overwrite {
+			// don't replace suggestions that are pending review
+			skip, err = qb.ChaptersDetected(t.Scene.ID)
+			if err != nil || skip {
+				return err
+			}
+		}
+
+		markers, err = qb.FindBySceneID(t.Scene.ID)
+		if err != nil {
+			return err
+		}
+
+		rejected, err = qb.GetRejectedSuggestionSeconds(t.Scene.ID)
+		if err != nil {
+			return err
+		}
+
+		primaryTagID, err = chapterPrimaryTagID(r.Tag())
+		return err
+	}); err != nil {
+		logger.Errorf("error getting markers for scene %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	if skip {
+		return
+	}
+
+	probeResult, err := instance.FFProbe.NewVideoFile(t.Scene.Path, false)
+	if err != nil {
+		logger.Errorf("error reading video file %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	c := config.GetInstance()
+	chapters, err := scene.DetectChapters(&instance.FFMPEG, *probeResult, c.GetChapterDetectionThreshold(), float64(c.GetChapterMinimumLength()))
+	if err != nil {
+		logger.Errorf("error detecting chapters of scene %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	suggestions := scene.ChapterSuggestions(chapters, markers, rejected, primaryTagID)
+
+	if err := t.txnManager.WithTxn(ctx, func(r models.Repository) error {
+		qb := r.SceneMarker()
+		if err := qb.UpdateSuggestions(t.Scene.ID, suggestions); err != nil {
+			return err
+		}
+
+		return qb.SetChaptersDetected(t.Scene.ID)
+	}); err != nil {
+		logger.Errorf("error saving chapters of scene %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	logger.Debugf("Detected %d chapters for scene %s", len(suggestions), t.Scene.Path)
+}
+
+// chapterPrimaryTagID returns the id of the configured default primary tag
+// of detected chapters. Returns nil if the tag is not set or does not exist.
+func chapterPrimaryTagID(qb models.TagReader) (*int, error) {
+	name := config.GetInstance().GetChapterPrimaryTag()
+	if name == "" {
+		return nil, nil
+	}
+
+	t, err := tag.ByName(qb, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		t, err = tag.ByAlias(qb, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if t == nil {
+		logger.Warnf("Chapter primary tag %s not found", name)
+		return nil, nil
+	}
+
+	return &t.ID, nil
+}
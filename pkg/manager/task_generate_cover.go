@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// GenerateCoverTask replaces the cover of a scene with the best scoring
+// frame of the scene if the current cover is black, fading or blurry.
+type GenerateCoverTask struct {
+	Scene      models.Scene
+	txnManager models.TransactionManager
+}
+
+func (t *GenerateCoverTask) GetDescription() string {
+	return fmt.Sprintf("Checking cover for %s", t.Scene.Path)
+}
+
+func (t *GenerateCoverTask) Start(ctx context.Context) {
+	var cover []byte
+	if err := t.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		cover, err = r.Scene().GetCover(t.Scene.ID)
+		return err
+	}); err != nil {
+		logger.Errorf("error getting cover for scene %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	var current *scene.FrameScore
+	if len(cover) > 0 {
+		var err error
+		current, err = scene.ScoreCover(cover)
+		if err != nil {
+			logger.Warnf("error reading cover for scene %s: %v", t.Scene.Path, err)
+		} else if !current.IsPoor() {
+			return
+		}
+	}
+
+	candidates, err := GetSceneCoverCandidates(&t.Scene, scene.DefaultCoverCandidates)
+	if err != nil {
+		logger.Errorf("error getting cover candidates for scene %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	best := scene.BestCoverCandidate(candidates)
+	if best == nil || (current != nil && best.Score <= current.Score) {
+		logger.Infof("No better cover found for scene %s", t.Scene.Path)
+		return
+	}
+
+	coverImageData, err := GetSceneFrameCover(&t.Scene, best.At)
+	if err != nil {
+		logger.Errorf("error extracting cover for scene %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	updater := scene.UpdateSet{
+		ID:         t.Scene.ID,
+		CoverImage: coverImageData,
+	}
+
+	if err := t.txnManager.WithTxn(ctx, func(r models.Repository) error {
+		_, err := updater.Update(r.Scene(), &scene.PathsScreenshotSetter{
+			Paths:               instance.Paths,
+			FileNamingAlgorithm: config.GetInstance().GetVideoFileNamingAlgorithm(),
+		})
+		return err
+	}); err != nil {
+		logger.Errorf("error setting cover for scene %s: %v", t.Scene.Path, err)
+		return
+	}
+
+	logger.Infof("Replaced cover of scene %s with frame at %.2fs", t.Scene.Path, best.At)
+}
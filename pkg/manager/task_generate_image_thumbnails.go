@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// generatedThumbnailFormats are the thumbnail formats built by the generate
+// task. Other formats are encoded on demand.
+var generatedThumbnailFormats = []image.ThumbnailFormat{
+	image.ThumbnailFormatJPEG,
+	image.ThumbnailFormatWebP,
+}
+
+type GenerateImageThumbnailsTask struct {
+	Image     models.Image
+	Overwrite bool
+}
+
+func (t *GenerateImageThumbnailsTask) GetDescription() string {
+	return fmt.Sprintf("Generating thumbnails for %s", t.Image.Path)
+}
+
+func (t *GenerateImageThumbnailsTask) Start(ctx context.Context) {
+	encoder := image.NewThumbnailEncoder(instance.FFMPEG)
+
+	for _, size := range image.ThumbnailSizes {
+		for _, format := range t.formats() {
+			format = image.GetThumbnailFormat(&t.Image, size, format)
+			thumbPath := image.GetThumbnailPath(instance.Paths, t.Image.Checksum, size, format)
+			if !t.Overwrite {
+				if exists, _ := utils.FileExists(thumbPath); exists {
+					continue
+				}
+			}
+
+			data, encodedFormat, err := encoder.GetThumbnailWithFallback(&t.Image, size, format)
+			if err != nil {
+				// don't retry the other thumbnails if the image cannot be read
+				logger.Errorf("error generating thumbnail for image %s: %s", t.Image.Path, err.Error())
+				return
+			}
+
+			if encodedFormat != format {
+				// the format is not supported by the encoders
+				continue
+			}
+
+			if err := utils.WriteFile(thumbPath, data); err != nil {
+				logger.Errorf("error writing thumbnail for image %s: %s", t.Image.Path, err)
+			}
+		}
+	}
+}
+
+// formats returns the thumbnail formats to generate. Animated images have a
+// single thumbnail format, returned by image.GetThumbnailFormat.
+func (t *GenerateImageThumbnailsTask) formats() []image.ThumbnailFormat {
+	if t.Image.IsAnimated {
+		return generatedThumbnailFormats[:1]
+	}
+
+	var ret []image.ThumbnailFormat
+	for _, f := range generatedThumbnailFormats {
+		if image.IsThumbnailFormatSupported(f) {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret
+}
+
+func (t *GenerateImageThumbnailsTask) required() bool {
+	if t.Overwrite {
+		return true
+	}
+
+	for _, size := range image.ThumbnailSizes {
+		for _, format := range t.formats() {
+			format = image.GetThumbnailFormat(&t.Image, size, format)
+			if exists, _ := utils.FileExists(image.GetThumbnailPath(instance.Paths, t.Image.Checksum, size, format)); !exists {
+				return true
+			}
+		}
+	}
+
+	return false
+}
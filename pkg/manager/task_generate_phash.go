@@ -52,7 +52,19 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 			ID:    t.Scene.ID,
 			Phash: &hashValue,
 		}
-		_, err := qb.Update(scenePartial)
+		if _, err := qb.Update(scenePartial); err != nil {
+			return err
+		}
+
+		// keep the primary file in sync with the scene
+		fqb := r.SceneFile()
+		f, err := fqb.FindByPath(t.Scene.Path)
+		if err != nil || f == nil {
+			return err
+		}
+
+		f.Phash = hashValue
+		_, err = fqb.UpdateFull(*f)
 		return err
 	}); err != nil {
 		logger.Error(err.Error())
@@ -164,7 +164,8 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 		var src identify.ScraperSource
 		if stashBox != nil {
 			src = identify.ScraperSource{
-				Name: "stash-box: " + stashBox.Endpoint,
+				Name:     "stash-box: " + stashBox.Endpoint,
+				SourceID: stashBox.Endpoint,
 				Scraper: stashboxSource{
 					stashbox.NewClient(*stashBox, j.txnManager),
 					stashBox.Endpoint,
@@ -178,7 +179,8 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 				return nil, fmt.Errorf("%w: scraper with id %q", models.ErrNotFound, scraperID)
 			}
 			src = identify.ScraperSource{
-				Name: s.Name,
+				Name:     s.Name,
+				SourceID: scraperID,
 				Scraper: scraperSource{
 					cache:     instance.ScraperCache,
 					scraperID: scraperID,
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/database"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
@@ -21,6 +22,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/movie"
 	"github.com/stashapp/stash/pkg/performer"
+	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/studio"
 	"github.com/stashapp/stash/pkg/tag"
@@ -126,6 +128,9 @@ func (t *ImportTask) Start(ctx context.Context) {
 		}
 	}
 
+	// apply deletions first, so that objects re-created since are imported
+	t.ImportTombstones(ctx)
+
 	t.ImportTags(ctx)
 	t.ImportPerformers(ctx)
 	t.ImportStudios(ctx)
@@ -135,6 +140,9 @@ func (t *ImportTask) Start(ctx context.Context) {
 	t.ImportScrapedItems(ctx)
 	t.ImportScenes(ctx)
 	t.ImportImages(ctx)
+
+	t.ImportSavedFilters(ctx)
+	t.ImportConfig()
 }
 
 func (t *ImportTask) unzipFile() error {
@@ -190,6 +198,180 @@ func (t *ImportTask) unzipFile() error {
 	return nil
 }
 
+// ImportTombstones deletes the objects recorded as deleted in an incremental
+// export. Objects that do not exist are ignored, so that applying the same
+// export more than once has no further effect.
+func (t *ImportTask) ImportTombstones(ctx context.Context) {
+	tombstones, err := t.json.getTombstones()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Errorf("[tombstones] failed to read json: %s", err.Error())
+		}
+		return
+	}
+
+	logger.Info("[tombstones] importing")
+
+	for i, ts := range tombstones {
+		index := i + 1
+		logger.Progressf("[tombstones] %d of %d", index, len(tombstones))
+
+		if err := t.importTombstone(ts); err != nil {
+			logger.Errorf("[tombstones] <%s %s> failed to apply: %s", ts.ObjectType, tombstoneDescriptor(ts), err.Error())
+		}
+	}
+
+	logger.Info("[tombstones] import complete")
+}
+
+func tombstoneDescriptor(ts jsonschema.Tombstone) string {
+	switch {
+	case ts.Name != "":
+		return ts.Name
+	case ts.Checksum != "":
+		return ts.Checksum
+	default:
+		return ts.OSHash
+	}
+}
+
+func (t *ImportTask) importTombstone(ts jsonschema.Tombstone) error {
+	switch ts.ObjectType {
+	case models.TombstoneObjectTypeScene:
+		return t.importSceneTombstone(ts)
+	case models.TombstoneObjectTypeImage:
+		return t.importImageTombstone(ts)
+	}
+
+	return t.txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		switch ts.ObjectType {
+		case models.TombstoneObjectTypeGallery:
+			qb := r.Gallery()
+			g, err := qb.FindByChecksum(ts.Checksum)
+			if err != nil || g == nil {
+				return err
+			}
+			return qb.Destroy(g.ID)
+		case models.TombstoneObjectTypePerformer:
+			qb := r.Performer()
+			performers, err := qb.FindByNames([]string{ts.Name}, false)
+			if err != nil {
+				return err
+			}
+			p, err := matchPerformerTombstone(performers, ts)
+			if err != nil || p == nil {
+				return err
+			}
+			return qb.Destroy(p.ID)
+		case models.TombstoneObjectTypeStudio:
+			qb := r.Studio()
+			s, err := qb.FindByName(ts.Name, false)
+			if err != nil || s == nil {
+				return err
+			}
+			return qb.Destroy(s.ID)
+		case models.TombstoneObjectTypeMovie:
+			qb := r.Movie()
+			m, err := qb.FindByName(ts.Name, false)
+			if err != nil || m == nil {
+				return err
+			}
+			return qb.Destroy(m.ID)
+		case models.TombstoneObjectTypeTag:
+			qb := r.Tag()
+			tag, err := qb.FindByName(ts.Name, false)
+			if err != nil || tag == nil {
+				return err
+			}
+			return qb.Destroy(tag.ID)
+		}
+
+		return fmt.Errorf("unknown object type %q", ts.ObjectType)
+	})
+}
+
+// matchPerformerTombstone returns the performer deleted by ts from the
+// performers with the same name. Performer names are not unique, so the
+// checksum is used to identify the performer where present. Returns an error
+// if the performer cannot be identified unambiguously.
+func matchPerformerTombstone(performers []*models.Performer, ts jsonschema.Tombstone) (*models.Performer, error) {
+	if ts.Checksum != "" {
+		for _, p := range performers {
+			if p.Checksum == ts.Checksum {
+				return p, nil
+			}
+		}
+		return nil, nil
+	}
+
+	switch len(performers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return performers[0], nil
+	}
+
+	return nil, fmt.Errorf("%d performers match name, skipping", len(performers))
+}
+
+func (t *ImportTask) importSceneTombstone(ts jsonschema.Tombstone) error {
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        *file.NewDeleter(),
+		FileNamingAlgo: t.fileNamingAlgorithm,
+		Paths:          GetInstance().Paths,
+	}
+
+	if err := t.txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		qb := r.Scene()
+
+		var s *models.Scene
+		var err error
+		if ts.Checksum != "" {
+			s, err = qb.FindByChecksum(ts.Checksum)
+		}
+		if err == nil && s == nil && ts.OSHash != "" {
+			s, err = qb.FindByOSHash(ts.OSHash)
+		}
+		if err != nil || s == nil {
+			return err
+		}
+
+		return scene.Destroy(s, r, fileDeleter, true, false)
+	}); err != nil {
+		fileDeleter.Rollback()
+		return err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+	return nil
+}
+
+func (t *ImportTask) importImageTombstone(ts jsonschema.Tombstone) error {
+	fileDeleter := &image.FileDeleter{
+		Deleter: *file.NewDeleter(),
+		Paths:   GetInstance().Paths,
+	}
+
+	if err := t.txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		qb := r.Image()
+
+		i, err := qb.FindByChecksum(ts.Checksum)
+		if err != nil || i == nil {
+			return err
+		}
+
+		return image.Destroy(i, qb, fileDeleter, true, false)
+	}); err != nil {
+		fileDeleter.Rollback()
+		return err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+	return nil
+}
+
 func (t *ImportTask) ImportPerformers(ctx context.Context) {
 	logger.Info("[performers] importing")
 
@@ -448,6 +630,87 @@ func (t *ImportTask) ImportTag(tagJSON *jsonschema.Tag, pendingParent map[string
 	return nil
 }
 
+func (t *ImportTask) ImportSavedFilters(ctx context.Context) {
+	savedFilters, err := t.json.getSavedFilters()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Errorf("[saved filters] failed to read json: %s", err.Error())
+		}
+		return
+	}
+
+	logger.Info("[saved filters] importing")
+
+	for i, savedFilterJSON := range savedFilters {
+		index := i + 1
+		logger.Progressf("[saved filters] %d of %d", index, len(savedFilters))
+
+		importer := &savedfilter.Importer{
+			Input: savedFilterJSON,
+		}
+
+		if err := t.txnManager.WithTxn(ctx, func(r models.Repository) error {
+			importer.ReaderWriter = r.SavedFilter()
+			return performImport(importer, t.DuplicateBehaviour)
+		}); err != nil {
+			logger.Errorf("[saved filters] <%s> failed to import: %s", importer.Name(), err.Error())
+			continue
+		}
+	}
+
+	logger.Info("[saved filters] import complete")
+}
+
+// ImportConfig applies the exported configuration settings. Settings that
+// are not exportable are ignored, so that secrets cannot be set by an import.
+func (t *ImportTask) ImportConfig() {
+	configJSON, err := t.json.getConfig()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Errorf("[config] failed to read json: %s", err.Error())
+		}
+		return
+	}
+
+	logger.Info("[config] importing")
+
+	c := config.GetInstance()
+	changed := false
+	importSettings := func(settings map[string]interface{}, exportable []string) {
+		for key, value := range settings {
+			if !utils.StrInclude(exportable, key) {
+				logger.Warnf("[config] <%s> ignoring setting that cannot be imported", key)
+				continue
+			}
+
+			if c.IsSet(key) {
+				if t.DuplicateBehaviour == models.ImportDuplicateEnumFail {
+					logger.Errorf("[config] <%s> failed to import: existing setting", key)
+					continue
+				} else if t.DuplicateBehaviour == models.ImportDuplicateEnumIgnore {
+					continue
+				}
+			}
+
+			c.Set(key, value)
+			changed = true
+		}
+	}
+
+	importSettings(configJSON.Interface, config.ExportableInterfaceSettings)
+	importSettings(configJSON.Scraping, config.ExportableScrapingSettings)
+	importSettings(configJSON.DLNA, config.ExportableDLNASettings)
+
+	if changed {
+		if err := c.Write(); err != nil {
+			logger.Errorf("[config] failed to write configuration: %s", err.Error())
+			return
+		}
+	}
+
+	logger.Info("[config] import complete")
+}
+
 func (t *ImportTask) ImportScrapedItems(ctx context.Context) {
 	if err := t.txnManager.WithTxn(ctx, func(r models.Repository) error {
 		logger.Info("[scraped sites] importing")
@@ -522,9 +785,11 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 			performerWriter := r.Performer()
 			studioWriter := r.Studio()
 			markerWriter := r.SceneMarker()
+			fileWriter := r.SceneFile()
 
 			sceneImporter := &scene.Importer{
 				ReaderWriter: readerWriter,
+				FileWriter:   fileWriter,
 				Input:        *sceneJSON,
 				Path:         mappingJSON.Path,
 
@@ -0,0 +1,39 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/manager/jsonschema"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPerformerTombstone(t *testing.T) {
+	p1 := &models.Performer{ID: 1, Checksum: "checksum1"}
+	p2 := &models.Performer{ID: 2, Checksum: "checksum2"}
+
+	tests := []struct {
+		name       string
+		performers []*models.Performer
+		ts         jsonschema.Tombstone
+		want       *models.Performer
+		wantErr    bool
+	}{
+		{"checksum match", []*models.Performer{p1, p2}, jsonschema.Tombstone{Checksum: "checksum2"}, p2, false},
+		{"checksum no match", []*models.Performer{p1, p2}, jsonschema.Tombstone{Checksum: "checksum3"}, nil, false},
+		{"no checksum single", []*models.Performer{p1}, jsonschema.Tombstone{}, p1, false},
+		{"no checksum none", nil, jsonschema.Tombstone{}, nil, false},
+		{"no checksum ambiguous", []*models.Performer{p1, p2}, jsonschema.Tombstone{}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchPerformerTombstone(tt.performers, tt.ts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("matchPerformerTombstone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
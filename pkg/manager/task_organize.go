@@ -0,0 +1,258 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/folder"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type organizeJob struct {
+	txnManager models.TransactionManager
+	input      models.OrganizeInput
+}
+
+func (j *organizeJob) Execute(ctx context.Context, progress *job.Progress) {
+	var operations []*models.OrganizeOperation
+	if err := j.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		operations, err = GetOrganizeOperations(ctx, r, j.input)
+		return err
+	}); err != nil {
+		logger.Errorf("error getting files to organize: %v", err)
+		return
+	}
+
+	progress.SetTotal(len(operations))
+
+	moved := 0
+	for _, op := range operations {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		if op.Conflict != nil {
+			logger.Warnf("Not moving %s: %s", op.From, *op.Conflict)
+			progress.Increment()
+			continue
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Moving %s", op.From), func() {
+			if err := j.moveScene(ctx, op); err != nil {
+				logger.Errorf("error moving %s to %s: %v", op.From, op.To, err)
+				return
+			}
+
+			moved++
+		})
+
+		progress.Increment()
+	}
+
+	logger.Infof("Finished organizing. Moved %d of %d files", moved, len(operations))
+}
+
+func (j *organizeJob) moveScene(ctx context.Context, op *models.OrganizeOperation) error {
+	sceneID, err := strconv.Atoi(op.SceneID)
+	if err != nil {
+		return err
+	}
+
+	libraryPaths := getLibraryPaths()
+	mover := file.NewMover()
+
+	if err := j.txnManager.WithTxn(ctx, func(r models.Repository) error {
+		qb := r.Scene()
+		s, err := qb.Find(sceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil || s.Path != op.From {
+			return fmt.Errorf("scene %s has changed since the files to organize were determined", op.SceneID)
+		}
+
+		if err := scene.MovePrimaryFile(mover, qb, r.SceneFile(), s, op.To); err != nil {
+			return err
+		}
+
+		// keep the folder of the scene up to date
+		f, err := folder.GetOrCreate(r.Folder(), filepath.Dir(op.To), libraryPaths)
+		if err != nil {
+			return err
+		}
+
+		folderID := sql.NullInt64{Int64: int64(f.ID), Valid: true}
+		_, err = qb.Update(models.ScenePartial{
+			ID:       sceneID,
+			FolderID: &folderID,
+		})
+		return err
+	}); err != nil {
+		mover.Rollback()
+		return err
+	}
+
+	mover.Commit()
+
+	logger.Infof("Moved %s to %s", op.From, op.To)
+
+	pluginCache := GetInstance().PluginCache
+	pluginCache.ExecutePostHooks(ctx, sceneID, plugin.SceneUpdatePost, nil, nil)
+	pluginCache.ExecuteMovePostHooks(ctx, sceneID, plugin.SceneMovePost, op.From, op.To)
+
+	return nil
+}
+
+func getLibraryPaths() []string {
+	var ret []string
+	for _, s := range config.GetInstance().GetStashPaths() {
+		ret = append(ret, s.Path)
+	}
+
+	return ret
+}
+
+// GetOrganizeOperations returns the file moves that organizing the scenes
+// would perform, including the conflicts that prevent files from being
+// moved.
+func GetOrganizeOperations(ctx context.Context, r models.ReaderRepository, input models.OrganizeInput) ([]*models.OrganizeOperation, error) {
+	var ret []*models.OrganizeOperation
+
+	// destinations of the files to be moved, to detect files being moved to
+	// the same path
+	destinations := make(map[string]*models.OrganizeOperation)
+
+	addOperation := func(s *models.Scene) {
+		stash := getStashFromPath(s.Path)
+		if stash == nil || stash.OrganizeTemplate == nil || *stash.OrganizeTemplate == "" {
+			return
+		}
+
+		op := &models.OrganizeOperation{
+			SceneID: strconv.Itoa(s.ID),
+			From:    s.Path,
+		}
+
+		relPath, err := scene.OrganizePath(*stash.OrganizeTemplate, s, r.Studio(), r.Performer())
+		if err != nil {
+			conflict := err.Error()
+			op.To = s.Path
+			op.Conflict = &conflict
+			ret = append(ret, op)
+			return
+		}
+
+		op.To = filepath.Join(stash.Path, relPath)
+		if op.To == op.From {
+			return
+		}
+
+		// destinations are compared case-insensitively, since the
+		// filesystem may be case-insensitive
+		key := strings.ToLower(op.To)
+		if other := destinations[key]; other != nil {
+			conflict := fmt.Sprintf("destination is the same as that of scene %s", other.SceneID)
+			op.Conflict = &conflict
+		} else {
+			destinations[key] = op
+			op.Conflict = getOrganizeConflict(op.From, op.To)
+		}
+
+		ret = append(ret, op)
+	}
+
+	if input.SceneIds != nil {
+		ids, err := utils.StringSliceToIntSlice(input.SceneIds)
+		if err != nil {
+			return nil, err
+		}
+
+		scenes, err := r.Scene().FindMany(ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range scenes {
+			addOperation(s)
+		}
+
+		return ret, nil
+	}
+
+	var paths []string
+	for _, s := range config.GetInstance().GetStashPaths() {
+		if s.OrganizeTemplate != nil && *s.OrganizeTemplate != "" {
+			paths = append(paths, s.Path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	batchSize := 1000
+	findFilter := models.BatchFindFilter(batchSize)
+	sceneFilter := scene.PathsFilter(paths)
+	sort := "path"
+	findFilter.Sort = &sort
+
+	more := true
+	for more {
+		if job.IsCancelled(ctx) {
+			return nil, nil
+		}
+
+		scenes, err := scene.Query(r.Scene(), sceneFilter, findFilter)
+		if err != nil {
+			return nil, fmt.Errorf("error querying for scenes: %w", err)
+		}
+
+		for _, s := range scenes {
+			addOperation(s)
+		}
+
+		if len(scenes) != batchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return ret, nil
+}
+
+// getOrganizeConflict returns the reason the file cannot be moved to the
+// destination, or nil if it can be moved.
+func getOrganizeConflict(from string, to string) *string {
+	var conflict string
+
+	// a case-only rename refers to the same file on case-insensitive
+	// filesystems
+	if exists, _ := utils.FileExists(to); exists && !strings.EqualFold(from, to) {
+		conflict = "destination file already exists"
+	} else if exists, _ := utils.FileExists(utils.GetFunscriptPath(from)); exists {
+		if exists, _ := utils.FileExists(utils.GetFunscriptPath(to)); exists && !strings.EqualFold(from, to) {
+			conflict = "destination funscript file already exists"
+		}
+	}
+
+	if conflict == "" {
+		return nil
+	}
+
+	return &conflict
+}
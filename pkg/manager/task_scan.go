@@ -11,6 +11,7 @@ import (
 	"github.com/remeh/sizedwaitgroup"
 
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager/config"
@@ -20,6 +21,10 @@ import (
 
 const scanQueueSize = 200000
 
+// maxImageClipDuration is the maximum duration in seconds of a video file
+// scanned as an image clip. Longer video files are scanned as scenes.
+const maxImageClipDuration = 60
+
 type ScanJob struct {
 	txnManager    models.TransactionManager
 	input         models.ScanMetadataInput
@@ -67,6 +72,7 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) {
 	var galleries []string
 
 	mutexManager := utils.NewMutexManager()
+	folders := newFolderCache(config.GetStashPaths())
 
 	for f := range fileQueue {
 		if job.IsCancelled(ctx) {
@@ -86,6 +92,7 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) {
 			TxnManager:           j.txnManager,
 			file:                 file.FSFile(f.path, f.info),
 			UseFileMetadata:      utils.IsTrue(input.UseFileMetadata),
+			UseNfoMetadata:       utils.IsTrue(input.UseNfoMetadata),
 			StripFileExtension:   utils.IsTrue(input.StripFileExtension),
 			fileNamingAlgorithm:  fileNamingAlgo,
 			calculateMD5:         calculateMD5,
@@ -98,6 +105,7 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) {
 			CaseSensitiveFs:      f.caseSensitiveFs,
 			ctx:                  ctx,
 			mutexManager:         mutexManager,
+			folders:              folders,
 		}
 
 		go func() {
@@ -226,8 +234,15 @@ func (j *ScanJob) doesPathExist(path string) bool {
 				ret = true
 			}
 		case utils.MatchExtension(path, vidExt):
-			s, _ := r.Scene().FindByPath(path)
-			if s != nil {
+			f, _ := r.SceneFile().FindByPath(path)
+			if f != nil {
+				ret = true
+				break
+			}
+
+			// the video file may have been scanned as an image clip
+			i, _ := r.Image().FindByPath(path)
+			if i != nil {
 				ret = true
 			}
 		case utils.MatchExtension(path, imgExt):
@@ -251,6 +266,7 @@ type ScanTask struct {
 	TxnManager           models.TransactionManager
 	file                 file.SourceFile
 	UseFileMetadata      bool
+	UseNfoMetadata       bool
 	StripFileExtension   bool
 	calculateMD5         bool
 	fileNamingAlgorithm  models.HashAlgorithm
@@ -264,6 +280,7 @@ type ScanTask struct {
 	CaseSensitiveFs      bool
 
 	mutexManager *utils.MutexManager
+	folders      *folderCache
 }
 
 func (t *ScanTask) Start(ctx context.Context) {
@@ -273,11 +290,20 @@ func (t *ScanTask) Start(ctx context.Context) {
 		switch {
 		case isGallery(path):
 			t.scanGallery(ctx)
+		case isVideo(path) && t.isImageClip():
+			t.scanImage()
+		case isVideo(path) && t.zipGallery != nil:
+			// scenes cannot be created from files in zip files
+			logger.Infof("Skipping %s: video file is not an image clip", file.ZipPathDisplayName(path))
 		case isVideo(path):
 			s = t.scanScene()
 		case isImage(path):
 			t.scanImage()
 		}
+
+		if err := t.setFolder(); err != nil {
+			logger.Warnf("error setting folder for %s: %v", path, err)
+		}
 	})
 
 	if s == nil {
@@ -350,6 +376,54 @@ func (t *ScanTask) Start(ctx context.Context) {
 	iwg.Wait()
 }
 
+// isImageClip returns true if the video file should be scanned as an image
+// clip. Short video files in zip galleries or in folders containing images
+// are image clips when enabled, unless they were previously scanned as
+// scenes.
+func (t *ScanTask) isImageClip() bool {
+	path := t.file.Path()
+	if !utils.MatchExtension(path, getImageClipExtensions()) {
+		return false
+	}
+
+	if t.zipGallery == nil && !dirContainsImages(filepath.Dir(path)) {
+		return false
+	}
+
+	var sceneFile *models.SceneFile
+	var existing *models.Image
+	if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		sceneFile, err = r.SceneFile().FindByPath(path)
+		if err != nil {
+			return err
+		}
+
+		existing, err = r.Image().FindByPath(path)
+		return err
+	}); err != nil {
+		logger.Warnf("error checking if %s is a scene file: %v", path, err)
+		return false
+	}
+
+	if sceneFile != nil {
+		return false
+	}
+
+	// files previously scanned as image clips remain image clips
+	if existing != nil {
+		return true
+	}
+
+	duration, err := image.GetClipDuration(path, &instance.FFProbe)
+	if err != nil {
+		logger.Warnf("error reading video file %s: %v", path, err)
+		return false
+	}
+
+	return duration <= maxImageClipDuration
+}
+
 func walkFilesToScan(s *models.StashConfig, f filepath.WalkFunc) error {
 	config := config.GetInstance()
 	vidExt := config.GetVideoExtensions()
@@ -0,0 +1,136 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/folder"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// folderCache caches the folders created or found during a scan, so that
+// each folder is only looked up once.
+type folderCache struct {
+	mutex        sync.Mutex
+	ids          map[string]int
+	libraryPaths []string
+}
+
+func newFolderCache(stashes []*models.StashConfig) *folderCache {
+	ret := &folderCache{
+		ids: make(map[string]int),
+	}
+
+	for _, s := range stashes {
+		ret.libraryPaths = append(ret.libraryPaths, s.Path)
+	}
+
+	return ret
+}
+
+func (c *folderCache) getFolderID(txnManager models.TransactionManager, path string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if id, found := c.ids[path]; found {
+		return id, nil
+	}
+
+	var f *models.Folder
+	if err := txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		var err error
+		f, err = folder.GetOrCreate(r.Folder(), path, c.libraryPaths)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	c.ids[path] = f.ID
+	return f.ID, nil
+}
+
+// setFolder sets the folder of the scanned object, creating the folder if
+// needed.
+func (t *ScanTask) setFolder() error {
+	if t.folders == nil {
+		return nil
+	}
+
+	path := t.file.Path()
+	folderID, err := t.folders.getFolderID(t.TxnManager, folder.ObjectFolderPath(path))
+	if err != nil {
+		return err
+	}
+
+	folderIDValue := sql.NullInt64{Int64: int64(folderID), Valid: true}
+
+	var id int
+	var current sql.NullInt64
+	if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		id, current, err = findObjectFolderID(r, path)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if id == 0 || current == folderIDValue {
+		return nil
+	}
+
+	return t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		return updateObjectFolderID(r, path, id, folderIDValue)
+	})
+}
+
+// findObjectFolderID returns the id and folder id of the object with the
+// provided path. Returns a zero id if the object is not found.
+func findObjectFolderID(r models.ReaderRepository, path string) (int, sql.NullInt64, error) {
+	switch {
+	case isGallery(path):
+		g, err := r.Gallery().FindByPath(path)
+		if err != nil || g == nil {
+			return 0, sql.NullInt64{}, err
+		}
+		return g.ID, g.FolderID, nil
+	case isVideo(path):
+		// only the primary file of a scene determines its folder
+		s, err := r.Scene().FindByPath(path)
+		if err != nil || s == nil {
+			return 0, sql.NullInt64{}, err
+		}
+		return s.ID, s.FolderID, nil
+	case isImage(path):
+		i, err := r.Image().FindByPath(path)
+		if err != nil || i == nil {
+			return 0, sql.NullInt64{}, err
+		}
+		return i.ID, i.FolderID, nil
+	}
+
+	return 0, sql.NullInt64{}, nil
+}
+
+func updateObjectFolderID(r models.Repository, path string, id int, folderID sql.NullInt64) error {
+	var err error
+	switch {
+	case isGallery(path):
+		_, err = r.Gallery().UpdatePartial(models.GalleryPartial{
+			ID:       id,
+			FolderID: &folderID,
+		})
+	case isVideo(path):
+		_, err = r.Scene().Update(models.ScenePartial{
+			ID:       id,
+			FolderID: &folderID,
+		})
+	case isImage(path):
+		_, err = r.Image().Update(models.ImagePartial{
+			ID:       id,
+			FolderID: &folderID,
+		})
+	}
+
+	return err
+}
@@ -40,7 +40,7 @@ func (t *ScanTask) scanGallery(ctx context.Context) {
 
 	scanner := gallery.Scanner{
 		Scanner:            gallery.FileScanner(&file.FSHasher{}),
-		ImageExtensions:    instance.Config.GetImageExtensions(),
+		ImageExtensions:    append(instance.Config.GetImageExtensions(), getImageClipExtensions()...),
 		StripFileExtension: t.StripFileExtension,
 		Ctx:                t.ctx,
 		CaseSensitiveFs:    t.CaseSensitiveFs,
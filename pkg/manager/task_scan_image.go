@@ -30,13 +30,17 @@ func (t *ScanTask) scanImage() {
 	}
 
 	scanner := image.Scanner{
-		Scanner:            image.FileScanner(&file.FSHasher{}),
-		StripFileExtension: t.StripFileExtension,
-		Ctx:                t.ctx,
-		TxnManager:         t.TxnManager,
-		Paths:              GetInstance().Paths,
-		PluginCache:        instance.PluginCache,
-		MutexManager:       t.mutexManager,
+		Scanner:                image.FileScanner(&file.FSHasher{}),
+		StripFileExtension:     t.StripFileExtension,
+		CreateTagsFromKeywords: config.GetInstance().GetCreateImageKeywordTags(),
+		SetRatingFromMetadata:  config.GetInstance().GetSetImageMetadataRating(),
+		ClipExtensions:         getImageClipExtensions(),
+		VideoFileCreator:       &instance.FFProbe,
+		Ctx:                    t.ctx,
+		TxnManager:             t.TxnManager,
+		Paths:                  GetInstance().Paths,
+		PluginCache:            instance.PluginCache,
+		MutexManager:           t.mutexManager,
 	}
 
 	var err error
@@ -65,11 +69,18 @@ func (t *ScanTask) scanImage() {
 			} else if config.GetInstance().GetCreateGalleriesFromFolders() {
 				// create gallery from folder or associate with existing gallery
 				logger.Infof("Associating image %s with folder gallery", i.Path)
+				folderID, err := t.getGalleryFolderID()
+				if err != nil {
+					logger.Error(err.Error())
+					return
+				}
+
 				var galleryID int
 				var isNewGallery bool
+				var movedFrom string
 				if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
 					var err error
-					galleryID, isNewGallery, err = t.associateImageWithFolderGallery(i.ID, r.Gallery())
+					galleryID, isNewGallery, movedFrom, err = t.associateImageWithFolderGallery(i.ID, folderID, r.Gallery())
 					return err
 				}); err != nil {
 					logger.Error(err.Error())
@@ -78,6 +89,10 @@ func (t *ScanTask) scanImage() {
 
 				if isNewGallery {
 					GetInstance().PluginCache.ExecutePostHooks(t.ctx, galleryID, plugin.GalleryCreatePost, nil, nil)
+				} else if movedFrom != "" {
+					pluginCache := GetInstance().PluginCache
+					pluginCache.ExecutePostHooks(t.ctx, galleryID, plugin.GalleryUpdatePost, nil, nil)
+					pluginCache.ExecuteMovePostHooks(t.ctx, galleryID, plugin.GalleryMovePost, movedFrom, filepath.Dir(i.Path))
 				}
 			}
 		}
@@ -88,13 +103,53 @@ func (t *ScanTask) scanImage() {
 	}
 }
 
-func (t *ScanTask) associateImageWithFolderGallery(imageID int, qb models.GalleryReaderWriter) (galleryID int, isNew bool, err error) {
-	// find a gallery with the path specified
+func (t *ScanTask) associateImageWithFolderGallery(imageID int, folderID sql.NullInt64, qb models.GalleryReaderWriter) (galleryID int, isNew bool, movedFrom string, err error) {
 	path := filepath.Dir(t.file.Path())
+
+	// find the gallery linked to the folder
 	var g *models.Gallery
-	g, err = qb.FindByPath(path)
-	if err != nil {
-		return
+	if folderID.Valid {
+		g, err = gallery.FindByFolderID(qb, int(folderID.Int64))
+		if err != nil {
+			return
+		}
+	}
+
+	if g == nil {
+		// fall back to a gallery with the path specified
+		g, err = qb.FindByPath(path)
+		if err != nil {
+			return
+		}
+
+		// link the existing gallery to the folder
+		if g != nil && folderID.Valid {
+			g, err = qb.UpdatePartial(models.GalleryPartial{
+				ID:       g.ID,
+				FolderID: &folderID,
+			})
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if g == nil {
+		// the folder of the image gallery may have been moved or renamed
+		var moved *models.Gallery
+		moved, err = gallery.FindMovedFolderGallery(qb, imageID, path, t.CaseSensitiveFs)
+		if err != nil {
+			return
+		}
+
+		if moved != nil {
+			movedFrom = moved.Path.String
+			logger.Infof("%s moved from %s. Updating gallery path...", path, movedFrom)
+			g, err = gallery.MoveFolderGallery(qb, moved.ID, path, folderID)
+			if err != nil {
+				return
+			}
+		}
 	}
 
 	if g == nil {
@@ -115,12 +170,13 @@ func (t *ScanTask) associateImageWithFolderGallery(imageID int, qb models.Galler
 				String: utils.GetNameFromPath(path, false),
 				Valid:  true,
 			},
+			FolderID: folderID,
 		}
 
 		logger.Infof("Creating gallery for folder %s", path)
 		g, err = qb.Create(newGallery)
 		if err != nil {
-			return 0, false, err
+			return 0, false, "", err
 		}
 
 		isNew = true
@@ -132,26 +188,49 @@ func (t *ScanTask) associateImageWithFolderGallery(imageID int, qb models.Galler
 	return
 }
 
+// getGalleryFolderID returns the id of the folder containing the scanned
+// image, creating the folder if needed.
+func (t *ScanTask) getGalleryFolderID() (sql.NullInt64, error) {
+	if t.folders == nil {
+		return sql.NullInt64{}, nil
+	}
+
+	id, err := t.folders.getFolderID(t.TxnManager, filepath.Dir(t.file.Path()))
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	return sql.NullInt64{Int64: int64(id), Valid: true}, nil
+}
+
 func (t *ScanTask) generateThumbnail(i *models.Image) {
 	if !t.GenerateThumbnails {
 		return
 	}
 
-	thumbPath := GetInstance().Paths.Generated.GetThumbnailPath(i.Checksum, models.DefaultGthumbWidth)
+	// thumbnails of animated images have a fixed format
+	format := image.GetThumbnailFormat(i, models.DefaultGthumbWidth, image.ThumbnailFormatJPEG)
+	thumbPath := image.GetThumbnailPath(GetInstance().Paths, i.Checksum, models.DefaultGthumbWidth, format)
 	exists, _ := utils.FileExists(thumbPath)
 	if exists {
 		return
 	}
 
-	config, _, err := image.DecodeSourceImage(i)
-	if err != nil {
-		logger.Errorf("error reading image %s: %s", i.Path, err.Error())
-		return
+	// video clips cannot be decoded and always need a thumbnail
+	generate := image.IsClip(i)
+	if !generate {
+		config, _, err := image.DecodeSourceImage(i)
+		if err != nil {
+			logger.Errorf("error reading image %s: %s", i.Path, err.Error())
+			return
+		}
+
+		generate = config.Height > models.DefaultGthumbWidth || config.Width > models.DefaultGthumbWidth
 	}
 
-	if config.Height > models.DefaultGthumbWidth || config.Width > models.DefaultGthumbWidth {
+	if generate {
 		encoder := image.NewThumbnailEncoder(instance.FFMPEG)
-		data, err := encoder.GetThumbnail(i, models.DefaultGthumbWidth)
+		data, err := encoder.GetThumbnail(i, models.DefaultGthumbWidth, format)
 
 		if err != nil {
 			logger.Errorf("error getting thumbnail for image %s: %s", i.Path, err.Error())
@@ -16,11 +16,11 @@ func (t *ScanTask) scanScene() *models.Scene {
 	}
 
 	var retScene *models.Scene
-	var s *models.Scene
+	var f *models.SceneFile
 
 	if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
 		var err error
-		s, err = r.Scene().FindByPath(t.file.Path())
+		f, err = r.SceneFile().FindByPath(t.file.Path())
 		return err
 	}); err != nil {
 		logger.Error(err.Error())
@@ -39,10 +39,11 @@ func (t *ScanTask) scanScene() *models.Scene {
 		PluginCache:         instance.PluginCache,
 		MutexManager:        t.mutexManager,
 		UseFileMetadata:     t.UseFileMetadata,
+		UseNfoMetadata:      t.UseNfoMetadata,
 	}
 
-	if s != nil {
-		if err := scanner.ScanExisting(s, t.file); err != nil {
+	if f != nil {
+		if err := scanner.ScanExisting(f, t.file); err != nil {
 			return logError(err)
 		}
 
@@ -65,13 +65,21 @@ func (t *GenerateTranscodeTask) Start(ctc context.Context) {
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
 	outputPath := instance.Paths.Generated.GetTmpPath(sceneHash + ".mp4")
 	transcodeSize := config.GetInstance().GetMaxTranscodeSize()
+	profile := config.GetInstance().GetGenerateTranscodeProfile()
+	if profile != nil && profile.MaxResolution != "" {
+		transcodeSize = profile.MaxResolution
+	}
+
 	options := ffmpeg.TranscodeOptions{
 		OutputPath:       outputPath,
 		MaxTranscodeSize: transcodeSize,
 	}
 	encoder := instance.FFMPEG
 
-	if videoCodec == ffmpeg.H264 { // for non supported h264 files stream copy the video part
+	if profile != nil {
+		// ffmpeg fails if it trys to transcode an unsupported audio codec
+		encoder.TranscodeWithProfile(*videoFile, *profile, options, audioCodec == ffmpeg.MissingUnsupported)
+	} else if videoCodec == ffmpeg.H264 { // for non supported h264 files stream copy the video part
 		if audioCodec == ffmpeg.MissingUnsupported {
 			encoder.CopyVideo(*videoFile, options)
 		} else {
@@ -0,0 +1,348 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// trimmedSuffix is appended to the name of trimmed files when the original
+// file is kept.
+const trimmedSuffix = ".trimmed"
+
+type trimJob struct {
+	txnManager models.TransactionManager
+	sceneID    int
+	removed    []ffmpeg.TrimRange
+	mode       models.SceneTrimMode
+}
+
+// Trim queues a job that removes the ranges from the primary file of the
+// scene.
+func (s *singleton) Trim(ctx context.Context, sceneID int, removed []ffmpeg.TrimRange, mode models.SceneTrimMode) int {
+	j := trimJob{
+		txnManager: s.TxnManager,
+		sceneID:    sceneID,
+		removed:    removed,
+		mode:       mode,
+	}
+
+	return s.JobManager.Add(ctx, fmt.Sprintf("Trimming scene id %d", sceneID), &j)
+}
+
+func (j *trimJob) Execute(ctx context.Context, progress *job.Progress) {
+	if err := j.trim(ctx, progress); err != nil {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		logger.Errorf("error trimming scene %d: %v", j.sceneID, err)
+	}
+}
+
+func (j *trimJob) trim(ctx context.Context, progress *job.Progress) error {
+	var s *models.Scene
+	var captions []*models.SceneCaption
+	if err := j.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		s, err = r.Scene().Find(j.sceneID)
+		if err != nil || s == nil {
+			return err
+		}
+
+		captions, err = r.Scene().GetCaptions(j.sceneID)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if s == nil {
+		return fmt.Errorf("scene with id %d not found", j.sceneID)
+	}
+
+	probeResult, err := instance.FFProbe.NewVideoFile(s.Path, false)
+	if err != nil {
+		return err
+	}
+
+	kept, err := scene.KeptRanges(j.removed, probeResult.Duration)
+	if err != nil {
+		return err
+	}
+
+	destination := s.Path
+	if j.mode == models.SceneTrimModeKeepOriginal {
+		ext := filepath.Ext(s.Path)
+		destination = strings.TrimSuffix(s.Path, ext) + trimmedSuffix + ext
+		if exists, _ := utils.FileExists(destination); exists {
+			return fmt.Errorf("destination file %s already exists", destination)
+		}
+	}
+
+	progress.Indefinite()
+
+	streamCopy := false
+	progress.ExecuteTask(fmt.Sprintf("Reading keyframes of %s", s.Path), func() {
+		keyframes, err := instance.FFProbe.KeyFrames(ctx, s.Path)
+		if err != nil {
+			logger.Warnf("error reading keyframes of %s, re-encoding: %v", s.Path, err)
+			return
+		}
+
+		streamCopy = scene.CanStreamCopy(kept, keyframes)
+	})
+
+	if err := instance.Paths.Generated.EnsureTmpDir(); err != nil {
+		return err
+	}
+
+	tmpPath := instance.Paths.Generated.GetTmpPath(fmt.Sprintf("trim-%d%s", s.ID, filepath.Ext(s.Path)))
+	defer func() {
+		if exists, _ := utils.FileExists(tmpPath); exists {
+			if err := os.Remove(tmpPath); err != nil {
+				logger.Warnf("error removing %s: %v", tmpPath, err)
+			}
+		}
+	}()
+
+	encoder := instance.FFMPEG
+	progress.ExecuteTask(fmt.Sprintf("Trimming %s", s.Path), func() {
+		err = encoder.Trim(ctx, *probeResult, ffmpeg.TrimOptions{
+			OutputPath: tmpPath,
+			Ranges:     kept,
+			StreamCopy: streamCopy,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if job.IsCancelled(ctx) {
+		return errors.New("cancelled")
+	}
+
+	trimmed, err := j.newFile(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	videoFile, err := instance.FFProbe.NewVideoFile(tmpPath, false)
+	if err != nil {
+		return err
+	}
+
+	fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
+	fileDeleter := &scene.FileDeleter{
+		Deleter:        *file.NewDeleter(),
+		FileNamingAlgo: fileNamingAlgo,
+		Paths:          instance.Paths,
+	}
+
+	// move the trimmed files into place before updating the database, and
+	// restore the original files if anything fails
+	var written []string
+	rollback := func() {
+		for _, p := range written {
+			if err := os.Remove(p); err != nil {
+				logger.Warnf("error removing %s: %v", p, err)
+			}
+		}
+		fileDeleter.Rollback()
+	}
+
+	funscript, err := j.trimFunscript(s.Path, kept)
+	if err != nil {
+		logger.Warnf("error trimming funscript of %s, leaving it unchanged: %v", s.Path, err)
+	}
+
+	trimmedCaptions, captionFiles := j.trimCaptions(captions, s.Path, destination, kept)
+
+	if j.mode == models.SceneTrimModeReplaceOriginal {
+		KillRunningStreams(s, fileNamingAlgo)
+
+		if err := fileDeleter.Files([]string{s.Path}); err != nil {
+			rollback()
+			return err
+		}
+	}
+
+	if err := utils.SafeMove(tmpPath, destination); err != nil {
+		rollback()
+		return err
+	}
+	written = append(written, destination)
+
+	if funscript != nil {
+		funscriptPath := utils.GetFunscriptPath(destination)
+		if exists, _ := utils.FileExists(funscriptPath); exists {
+			if err := fileDeleter.Files([]string{funscriptPath}); err != nil {
+				rollback()
+				return err
+			}
+		}
+
+		if err := utils.WriteFile(funscriptPath, funscript); err != nil {
+			rollback()
+			return err
+		}
+		written = append(written, funscriptPath)
+	}
+
+	for _, c := range captionFiles {
+		if exists, _ := utils.FileExists(c.path); exists {
+			if err := fileDeleter.Files([]string{c.path}); err != nil {
+				rollback()
+				return err
+			}
+		}
+
+		if err := utils.WriteFile(c.path, c.data); err != nil {
+			rollback()
+			return err
+		}
+		written = append(written, c.path)
+	}
+
+	info, err := os.Stat(destination)
+	if err != nil {
+		rollback()
+		return err
+	}
+	trimmed.Path = destination
+	trimmed.FileModTime = models.NullSQLiteTimestamp{
+		// truncate to seconds, since we don't store beyond that in the database
+		Timestamp: info.ModTime().Truncate(time.Second),
+		Valid:     true,
+	}
+
+	if err := j.txnManager.WithTxn(ctx, func(r models.Repository) error {
+		if j.mode == models.SceneTrimModeReplaceOriginal {
+			f, err := r.SceneFile().FindByPath(s.Path)
+			if err != nil {
+				return err
+			}
+
+			if f == nil || f.SceneID != s.ID {
+				return fmt.Errorf("primary file of scene %s not found", s.Path)
+			}
+
+			trimmed.ID = f.ID
+			trimmed.CreatedAt = f.CreatedAt
+			trimmed.Primary = f.Primary
+		}
+
+		return scene.ApplyTrim(r, s, trimmed, videoFile, kept, trimmedCaptions, fileDeleter)
+	}); err != nil {
+		rollback()
+		return err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+
+	method := "re-encoded"
+	if streamCopy {
+		method = "stream copied"
+	}
+	logger.Infof("Trimmed %s to %s (%s)", probeResult.Path, destination, method)
+
+	GetInstance().PluginCache.ExecutePostHooks(ctx, s.ID, plugin.SceneUpdatePost, nil, nil)
+
+	return nil
+}
+
+// newFile returns a scene file with the hashes of the file at the provided
+// path.
+func (j *trimJob) newFile(path string) (*models.SceneFile, error) {
+	c := config.GetInstance()
+
+	oshash, err := utils.OSHashFromFilePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.SceneFile{
+		OSHash: models.NullString(oshash),
+	}
+
+	if c.GetVideoFileNamingAlgorithm() == models.HashAlgorithmMd5 || c.IsCalculateMD5() {
+		checksum, err := utils.MD5FromFilePath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.Checksum = models.NullString(checksum)
+	}
+
+	return ret, nil
+}
+
+// trimFunscript returns the trimmed funscript of the video, or nil if the
+// video has no funscript.
+func (j *trimJob) trimFunscript(videoPath string, kept []ffmpeg.TrimRange) ([]byte, error) {
+	funscriptPath := utils.GetFunscriptPath(videoPath)
+	if exists, _ := utils.FileExists(funscriptPath); !exists {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(funscriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return scene.TrimFunscript(data, kept)
+}
+
+// captionFile is a trimmed caption file to write.
+type captionFile struct {
+	path string
+	data []byte
+}
+
+// trimCaptions returns the captions of the trimmed video and the trimmed
+// caption files to write next to it. Caption files that cannot be trimmed are
+// removed from the scene. Embedded captions are removed, since subtitle
+// streams are not retained when trimming.
+func (j *trimJob) trimCaptions(captions []*models.SceneCaption, videoPath string, destination string, kept []ffmpeg.TrimRange) ([]models.SceneCaption, []captionFile) {
+	var ret []models.SceneCaption
+	var files []captionFile
+	for _, c := range captions {
+		if c.Source != models.CaptionSourceFile || !c.Path.Valid {
+			continue
+		}
+
+		data, err := os.ReadFile(c.Path.String)
+		if err == nil {
+			data, err = scene.TrimCaption(data, c.CaptionType, kept)
+		}
+		if err != nil {
+			logger.Warnf("error trimming caption %s, removing it from the scene: %v", c.Path.String, err)
+			continue
+		}
+
+		trimmed := *c
+		trimmed.Path = models.NullString(scene.CaptionPath(c.Path.String, videoPath, destination))
+		ret = append(ret, trimmed)
+		files = append(files, captionFile{
+			path: trimmed.Path.String,
+			data: data,
+		})
+	}
+
+	return ret, files
+}
@@ -0,0 +1,393 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+const (
+	// verifySamples is the number of sections of each video decoded when
+	// sampling.
+	verifySamples = 5
+	// verifySampleDuration is the length in seconds of each sampled section.
+	verifySampleDuration = 10
+
+	// maxVerifyErrors is the maximum number of distinct errors kept in the
+	// error summary of a file.
+	maxVerifyErrors = 10
+)
+
+// ffmpegAddressRE matches the context addresses that ffmpeg prefixes its
+// errors with, such as [h264 @ 0x55d5c3a8e2c0].
+var ffmpegAddressRE = regexp.MustCompile(` @ 0x[0-9a-f]+\]`)
+
+// verifySeverity orders the file statuses from best to worst.
+var verifySeverity = map[models.FileStatusEnum]int{
+	models.FileStatusEnumOk:      0,
+	models.FileStatusEnumWarning: 1,
+	models.FileStatusEnumCorrupt: 2,
+}
+
+type verifyJob struct {
+	txnManager models.TransactionManager
+	input      models.VerifyMetadataInput
+}
+
+// verifyResult is the outcome of verifying a file.
+type verifyResult struct {
+	status models.FileStatusEnum
+	errors []string
+}
+
+func newVerifyResult(errors []string, failed bool) verifyResult {
+	ret := verifyResult{
+		status: models.FileStatusEnumOk,
+		errors: errors,
+	}
+
+	switch {
+	case failed:
+		ret.status = models.FileStatusEnumCorrupt
+	case len(errors) > 0:
+		ret.status = models.FileStatusEnumWarning
+	}
+
+	return ret
+}
+
+func (j *verifyJob) Execute(ctx context.Context, progress *job.Progress) {
+	var scenes []*models.Scene
+	var images []*models.Image
+	scenePaths := make(map[int][]string)
+	if err := j.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		scenes, err = j.getScenes(r.Scene())
+		if err != nil {
+			return fmt.Errorf("error getting scenes: %w", err)
+		}
+
+		for _, s := range scenes {
+			scenePaths[s.ID], err = j.getScenePaths(r.SceneFile(), s)
+			if err != nil {
+				return fmt.Errorf("error getting files of scene %d: %w", s.ID, err)
+			}
+		}
+
+		images, err = j.getImages(r.Image())
+		if err != nil {
+			return fmt.Errorf("error getting images: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+		return
+	}
+
+	progress.SetTotal(len(scenes) + len(images))
+
+	counts := make(map[models.FileStatusEnum]int)
+	skipped := 0
+
+	for _, s := range scenes {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		paths := scenePaths[s.ID]
+		if j.skip(paths, s.FileCheckedAt, j.sceneModTime) {
+			skipped++
+			progress.Increment()
+			continue
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Verifying %s", s.Path), func() {
+			result, err := j.verifyScene(ctx, paths)
+			if err == nil {
+				err = j.updateScene(ctx, s.ID, result)
+			}
+
+			if err != nil {
+				if !job.IsCancelled(ctx) {
+					logger.Errorf("error verifying %s: %v", s.Path, err)
+				}
+				return
+			}
+
+			counts[result.status]++
+		})
+
+		progress.Increment()
+	}
+
+	for _, i := range images {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
+		if j.skip([]string{i.Path}, i.FileCheckedAt, image.GetFileModTime) {
+			skipped++
+			progress.Increment()
+			continue
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Verifying %s", i.Path), func() {
+			result, err := j.verifyImage(ctx, i)
+			if err == nil {
+				err = j.updateImage(ctx, i.ID, result)
+			}
+
+			if err != nil {
+				if !job.IsCancelled(ctx) {
+					logger.Errorf("error verifying %s: %v", i.Path, err)
+				}
+				return
+			}
+
+			counts[result.status]++
+		})
+
+		progress.Increment()
+	}
+
+	logger.Infof("Finished verifying files: %d ok, %d with warnings, %d corrupt, %d unchanged",
+		counts[models.FileStatusEnumOk], counts[models.FileStatusEnumWarning], counts[models.FileStatusEnumCorrupt], skipped)
+}
+
+func (j *verifyJob) getScenes(qb models.SceneReader) ([]*models.Scene, error) {
+	if j.input.SceneIds == nil {
+		if j.input.ImageIds != nil {
+			return nil, nil
+		}
+		return qb.All()
+	}
+
+	ids, err := utils.StringSliceToIntSlice(j.input.SceneIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return qb.FindMany(ids)
+}
+
+// getScenePaths returns the paths of the files of the scene, primary file
+// first.
+func (j *verifyJob) getScenePaths(fqb models.SceneFileReader, s *models.Scene) ([]string, error) {
+	files, err := fqb.FindBySceneID(s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{s.Path}
+	for _, f := range files {
+		if f.Path != s.Path {
+			paths = append(paths, f.Path)
+		}
+	}
+
+	return paths, nil
+}
+
+func (j *verifyJob) getImages(qb models.ImageReader) ([]*models.Image, error) {
+	if j.input.ImageIds == nil {
+		if j.input.SceneIds != nil {
+			return nil, nil
+		}
+		return qb.All()
+	}
+
+	ids, err := utils.StringSliceToIntSlice(j.input.ImageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return qb.FindMany(ids)
+}
+
+// skip returns true if unchanged files are skipped and none of the files
+// have been modified since they were last checked.
+func (j *verifyJob) skip(paths []string, checkedAt models.NullSQLiteTimestamp, modTime func(path string) (time.Time, error)) bool {
+	if !utils.IsTrue(j.input.SkipUnchanged) || !checkedAt.Valid {
+		return false
+	}
+
+	for _, path := range paths {
+		t, err := modTime(path)
+		if err != nil {
+			// let the check report the error
+			return false
+		}
+
+		if t.After(checkedAt.Timestamp) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (j *verifyJob) sceneModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// truncate to seconds, since we don't store beyond that in the database
+	return info.ModTime().Truncate(time.Second), nil
+}
+
+// verifyScene verifies each of the files of a scene. The scene is given the
+// worst status of its files, and the errors of all of its files. Errors are
+// prefixed with the file name when the scene has more than one file.
+func (j *verifyJob) verifyScene(ctx context.Context, paths []string) (verifyResult, error) {
+	ret := newVerifyResult(nil, false)
+	for _, path := range paths {
+		result, err := j.verifySceneFile(ctx, path)
+		if err != nil {
+			return verifyResult{}, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if verifySeverity[result.status] > verifySeverity[ret.status] {
+			ret.status = result.status
+		}
+
+		for _, e := range result.errors {
+			if len(paths) > 1 {
+				e = filepath.Base(path) + ": " + e
+			}
+			ret.errors = append(ret.errors, e)
+		}
+	}
+
+	return ret, nil
+}
+
+func (j *verifyJob) verifySceneFile(ctx context.Context, path string) (verifyResult, error) {
+	if _, err := os.Stat(path); err != nil {
+		return verifyResult{}, err
+	}
+
+	// files that cannot be probed are usually missing their headers
+	videoFile, err := instance.FFProbe.NewVideoFile(path, false)
+	if err != nil {
+		return newVerifyResult([]string{err.Error()}, true), nil
+	}
+
+	var options ffmpeg.VerifyOptions
+	if utils.IsTrue(j.input.Sample) {
+		options = ffmpeg.VerifyOptions{
+			Samples:        verifySamples,
+			SampleDuration: verifySampleDuration,
+		}
+	}
+
+	result, err := instance.FFMPEG.Verify(ctx, *videoFile, options)
+	if err != nil {
+		return verifyResult{}, err
+	}
+
+	return newVerifyResult(result.Errors, result.Failed), nil
+}
+
+func (j *verifyJob) verifyImage(ctx context.Context, i *models.Image) (verifyResult, error) {
+	if !image.FileExists(i.Path) {
+		return verifyResult{}, fmt.Errorf("file not found")
+	}
+
+	if image.IsClip(i) {
+		result, err := image.VerifyClip(ctx, i, &instance.FFProbe, &instance.FFMPEG)
+		if err != nil {
+			return verifyResult{}, err
+		}
+
+		return newVerifyResult(result.Errors, result.Failed), nil
+	}
+
+	warnings, err := image.Verify(i)
+	if err != nil {
+		return newVerifyResult([]string{err.Error()}, true), nil
+	}
+
+	return newVerifyResult(warnings, false), nil
+}
+
+func (j *verifyJob) updateScene(ctx context.Context, id int, result verifyResult) error {
+	status, summary, checkedAt := result.fields()
+	return j.txnManager.WithTxn(ctx, func(r models.Repository) error {
+		_, err := r.Scene().Update(models.ScenePartial{
+			ID:              id,
+			FileStatus:      &status,
+			FileStatusError: &summary,
+			FileCheckedAt:   &checkedAt,
+		})
+		return err
+	})
+}
+
+func (j *verifyJob) updateImage(ctx context.Context, id int, result verifyResult) error {
+	status, summary, checkedAt := result.fields()
+	return j.txnManager.WithTxn(ctx, func(r models.Repository) error {
+		_, err := r.Image().Update(models.ImagePartial{
+			ID:              id,
+			FileStatus:      &status,
+			FileStatusError: &summary,
+			FileCheckedAt:   &checkedAt,
+		})
+		return err
+	})
+}
+
+// fields returns the values of the file status columns for the result.
+func (r verifyResult) fields() (status sql.NullString, summary sql.NullString, checkedAt models.NullSQLiteTimestamp) {
+	status = sql.NullString{String: r.status.String(), Valid: true}
+	if s := summarizeErrors(r.errors); s != "" {
+		summary = sql.NullString{String: s, Valid: true}
+	}
+	checkedAt = models.NullSQLiteTimestamp{Timestamp: time.Now(), Valid: true}
+	return
+}
+
+// summarizeErrors returns the distinct errors, one per line, with the
+// number of occurrences of repeated errors. At most maxVerifyErrors errors
+// are included.
+func summarizeErrors(errors []string) string {
+	var distinct []string
+	counts := make(map[string]int)
+	for _, e := range errors {
+		e = ffmpegAddressRE.ReplaceAllString(e, "]")
+		if counts[e] == 0 {
+			distinct = append(distinct, e)
+		}
+		counts[e]++
+	}
+
+	var lines []string
+	for i, e := range distinct {
+		if i == maxVerifyErrors {
+			lines = append(lines, fmt.Sprintf("and %d more errors", len(distinct)-maxVerifyErrors))
+			break
+		}
+
+		if counts[e] > 1 {
+			e = fmt.Sprintf("%s (x%d)", e, counts[e])
+		}
+		lines = append(lines, e)
+	}
+
+	return strings.Join(lines, "\n")
+}
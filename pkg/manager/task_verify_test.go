@@ -0,0 +1,65 @@
+package manager
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerifyResult(t *testing.T) {
+	assert.Equal(t, models.FileStatusEnumOk, newVerifyResult(nil, false).status)
+	assert.Equal(t, models.FileStatusEnumWarning, newVerifyResult([]string{"error"}, false).status)
+	assert.Equal(t, models.FileStatusEnumCorrupt, newVerifyResult(nil, true).status)
+	assert.Equal(t, models.FileStatusEnumCorrupt, newVerifyResult([]string{"error"}, true).status)
+}
+
+func TestSummarizeErrors(t *testing.T) {
+	assert.Equal(t, "", summarizeErrors(nil))
+
+	errors := []string{
+		"[h264 @ 0x55d5c3a8e2c0] error while decoding MB 1 2",
+		"[h264 @ 0x55d5c3a8f000] error while decoding MB 1 2",
+		"[aac @ 0x55d5c3a90000] Input buffer exhausted before END element found",
+	}
+	assert.Equal(t, "[h264] error while decoding MB 1 2 (x2)\n[aac] Input buffer exhausted before END element found", summarizeErrors(errors))
+
+	errors = nil
+	for i := 0; i < maxVerifyErrors+5; i++ {
+		errors = append(errors, strings.Repeat("x", i+1))
+	}
+	lines := strings.Split(summarizeErrors(errors), "\n")
+	assert.Len(t, lines, maxVerifyErrors+1)
+	assert.Equal(t, "and 5 more errors", lines[maxVerifyErrors])
+}
+
+func TestVerifySkip(t *testing.T) {
+	checkedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	modTimes := map[string]time.Time{
+		"unchanged": checkedAt,
+		"changed":   checkedAt.Add(time.Second),
+	}
+	modTime := func(path string) (time.Time, error) {
+		t, ok := modTimes[path]
+		if !ok {
+			return time.Time{}, os.ErrNotExist
+		}
+		return t, nil
+	}
+
+	checked := models.NullSQLiteTimestamp{Timestamp: checkedAt, Valid: true}
+	skipUnchanged := true
+	j := &verifyJob{input: models.VerifyMetadataInput{SkipUnchanged: &skipUnchanged}}
+
+	assert.True(t, j.skip([]string{"unchanged"}, checked, modTime))
+	assert.False(t, j.skip([]string{"changed"}, checked, modTime))
+	assert.False(t, j.skip([]string{"unchanged", "changed"}, checked, modTime))
+	assert.False(t, j.skip([]string{"unchanged", "missing"}, checked, modTime))
+	assert.False(t, j.skip([]string{"unchanged"}, models.NullSQLiteTimestamp{}, modTime))
+
+	j.input.SkipUnchanged = nil
+	assert.False(t, j.skip([]string{"unchanged"}, checked, modTime))
+}
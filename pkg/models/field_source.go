@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// FieldSourceUser is the source recorded against fields that were set by
+// the user, rather than from a scraper or stash-box instance.
+const FieldSourceUser = "user"
+
+// FieldSource records where the current value of an object field was
+// sourced from, and when it was set.
+type FieldSource struct {
+	Field     string          `db:"field" json:"field"`
+	Source    string          `db:"source" json:"source"`
+	UpdatedAt SQLiteTimestamp `db:"updated_at" json:"updated_at"`
+}
+
+// NewFieldSources returns a FieldSource for each of the provided fields,
+// attributed to source and timestamped with the current time.
+func NewFieldSources(source string, fields []string) []FieldSource {
+	now := SQLiteTimestamp{Timestamp: time.Now()}
+
+	var ret []FieldSource
+	for _, f := range fields {
+		ret = append(ret, FieldSource{
+			Field:     f,
+			Source:    source,
+			UpdatedAt: now,
+		})
+	}
+
+	return ret
+}
+
+// UserEditedFields returns the fields whose current value was set by the user.
+func UserEditedFields(sources []*FieldSource) []string {
+	var ret []string
+	for _, s := range sources {
+		if s.Source == FieldSourceUser {
+			ret = append(ret, s.Field)
+		}
+	}
+
+	return ret
+}
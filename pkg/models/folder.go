@@ -0,0 +1,24 @@
+package models
+
+type FolderReader interface {
+	Find(id int) (*Folder, error)
+	FindByPath(path string) (*Folder, error)
+	// FindByParentFolderID returns the child folders of the folder, ordered
+	// by path.
+	FindByParentFolderID(parentFolderID int) ([]*Folder, error)
+	// Query returns the child folders of the provided parent folder. Root
+	// folders are returned if parentFolderID is nil.
+	Query(parentFolderID *int, findFilter *FindFilterType) ([]*Folder, int, error)
+	All() ([]*Folder, error)
+}
+
+type FolderWriter interface {
+	Create(newFolder Folder) (*Folder, error)
+	UpdateFull(updatedFolder Folder) (*Folder, error)
+	Destroy(id int) error
+}
+
+type FolderReaderWriter interface {
+	FolderReader
+	FolderWriter
+}
@@ -0,0 +1,195 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FolderReaderWriter is an autogenerated mock type for the FolderReaderWriter type
+type FolderReaderWriter struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields:
+func (_m *FolderReaderWriter) All() ([]*models.Folder, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Folder
+	if rf, ok := ret.Get(0).(func() []*models.Folder); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: newFolder
+func (_m *FolderReaderWriter) Create(newFolder models.Folder) (*models.Folder, error) {
+	ret := _m.Called(newFolder)
+
+	var r0 *models.Folder
+	if rf, ok := ret.Get(0).(func(models.Folder) *models.Folder); ok {
+		r0 = rf(newFolder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.Folder) error); ok {
+		r1 = rf(newFolder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Destroy provides a mock function with given fields: id
+func (_m *FolderReaderWriter) Destroy(id int) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: id
+func (_m *FolderReaderWriter) Find(id int) (*models.Folder, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Folder
+	if rf, ok := ret.Get(0).(func(int) *models.Folder); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByParentFolderID provides a mock function with given fields: parentFolderID
+func (_m *FolderReaderWriter) FindByParentFolderID(parentFolderID int) ([]*models.Folder, error) {
+	ret := _m.Called(parentFolderID)
+
+	var r0 []*models.Folder
+	if rf, ok := ret.Get(0).(func(int) []*models.Folder); ok {
+		r0 = rf(parentFolderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(parentFolderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByPath provides a mock function with given fields: path
+func (_m *FolderReaderWriter) FindByPath(path string) (*models.Folder, error) {
+	ret := _m.Called(path)
+
+	var r0 *models.Folder
+	if rf, ok := ret.Get(0).(func(string) *models.Folder); ok {
+		r0 = rf(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Query provides a mock function with given fields: parentFolderID, findFilter
+func (_m *FolderReaderWriter) Query(parentFolderID *int, findFilter *models.FindFilterType) ([]*models.Folder, int, error) {
+	ret := _m.Called(parentFolderID, findFilter)
+
+	var r0 []*models.Folder
+	if rf, ok := ret.Get(0).(func(*int, *models.FindFilterType) []*models.Folder); ok {
+		r0 = rf(parentFolderID, findFilter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Folder)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*int, *models.FindFilterType) int); ok {
+		r1 = rf(parentFolderID, findFilter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*int, *models.FindFilterType) error); ok {
+		r2 = rf(parentFolderID, findFilter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateFull provides a mock function with given fields: updatedFolder
+func (_m *FolderReaderWriter) UpdateFull(updatedFolder models.Folder) (*models.Folder, error) {
+	ret := _m.Called(updatedFolder)
+
+	var r0 *models.Folder
+	if rf, ok := ret.Get(0).(func(models.Folder) *models.Folder); ok {
+		r0 = rf(updatedFolder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Folder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.Folder) error); ok {
+		r1 = rf(updatedFolder)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
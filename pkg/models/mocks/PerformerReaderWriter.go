@@ -335,6 +335,29 @@ func (_m *PerformerReaderWriter) FindNamesBySceneID(sceneID int) ([]*models.Perf
 	return r0, r1
 }
 
+// GetFieldSources provides a mock function with given fields: performerID
+func (_m *PerformerReaderWriter) GetFieldSources(performerID int) ([]*models.FieldSource, error) {
+	ret := _m.Called(performerID)
+
+	var r0 []*models.FieldSource
+	if rf, ok := ret.Get(0).(func(int) []*models.FieldSource); ok {
+		r0 = rf(performerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FieldSource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(performerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: performerID
 func (_m *PerformerReaderWriter) GetImage(performerID int) ([]byte, error) {
 	ret := _m.Called(performerID)
@@ -480,6 +503,20 @@ func (_m *PerformerReaderWriter) Update(updatedPerformer models.PerformerPartial
 	return r0, r1
 }
 
+// UpdateFieldSources provides a mock function with given fields: performerID, sources
+func (_m *PerformerReaderWriter) UpdateFieldSources(performerID int, sources []models.FieldSource) error {
+	ret := _m.Called(performerID, sources)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []models.FieldSource) error); ok {
+		r0 = rf(performerID, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateFull provides a mock function with given fields: updatedPerformer
 func (_m *PerformerReaderWriter) UpdateFull(updatedPerformer models.Performer) (*models.Performer, error) {
 	ret := _m.Called(updatedPerformer)
@@ -0,0 +1,223 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PlaylistReaderWriter is an autogenerated mock type for the PlaylistReaderWriter type
+type PlaylistReaderWriter struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields:
+func (_m *PlaylistReaderWriter) All() ([]*models.Playlist, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Playlist
+	if rf, ok := ret.Get(0).(func() []*models.Playlist); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Playlist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Count provides a mock function with given fields:
+func (_m *PlaylistReaderWriter) Count() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: newPlaylist
+func (_m *PlaylistReaderWriter) Create(newPlaylist models.Playlist) (*models.Playlist, error) {
+	ret := _m.Called(newPlaylist)
+
+	var r0 *models.Playlist
+	if rf, ok := ret.Get(0).(func(models.Playlist) *models.Playlist); ok {
+		r0 = rf(newPlaylist)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Playlist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.Playlist) error); ok {
+		r1 = rf(newPlaylist)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Destroy provides a mock function with given fields: id
+func (_m *PlaylistReaderWriter) Destroy(id int) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: id
+func (_m *PlaylistReaderWriter) Find(id int) (*models.Playlist, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Playlist
+	if rf, ok := ret.Get(0).(func(int) *models.Playlist); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Playlist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByName provides a mock function with given fields: name
+func (_m *PlaylistReaderWriter) FindByName(name string) (*models.Playlist, error) {
+	ret := _m.Called(name)
+
+	var r0 *models.Playlist
+	if rf, ok := ret.Get(0).(func(string) *models.Playlist); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Playlist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMany provides a mock function with given fields: ids
+func (_m *PlaylistReaderWriter) FindMany(ids []int) ([]*models.Playlist, error) {
+	ret := _m.Called(ids)
+
+	var r0 []*models.Playlist
+	if rf, ok := ret.Get(0).(func([]int) []*models.Playlist); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Playlist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSceneIDs provides a mock function with given fields: playlistID
+func (_m *PlaylistReaderWriter) GetSceneIDs(playlistID int) ([]int, error) {
+	ret := _m.Called(playlistID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(int) []int); ok {
+		r0 = rf(playlistID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(playlistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: updatedPlaylist
+func (_m *PlaylistReaderWriter) Update(updatedPlaylist models.PlaylistPartial) (*models.Playlist, error) {
+	ret := _m.Called(updatedPlaylist)
+
+	var r0 *models.Playlist
+	if rf, ok := ret.Get(0).(func(models.PlaylistPartial) *models.Playlist); ok {
+		r0 = rf(updatedPlaylist)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Playlist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.PlaylistPartial) error); ok {
+		r1 = rf(updatedPlaylist)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateScenes provides a mock function with given fields: playlistID, sceneIDs
+func (_m *PlaylistReaderWriter) UpdateScenes(playlistID int, sceneIDs []int) error {
+	ret := _m.Called(playlistID, sceneIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []int) error); ok {
+		r0 = rf(playlistID, sceneIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
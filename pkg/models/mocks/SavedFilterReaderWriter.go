@@ -12,6 +12,29 @@ type SavedFilterReaderWriter struct {
 	mock.Mock
 }
 
+// All provides a mock function with given fields:
+func (_m *SavedFilterReaderWriter) All() ([]*models.SavedFilter, error) {
+	ret := _m.Called()
+
+	var r0 []*models.SavedFilter
+	if rf, ok := ret.Get(0).(func() []*models.SavedFilter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SavedFilter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: obj
 func (_m *SavedFilterReaderWriter) Create(obj models.SavedFilter) (*models.SavedFilter, error) {
 	ret := _m.Called(obj)
@@ -95,6 +118,29 @@ func (_m *SavedFilterReaderWriter) FindByMode(mode models.FilterMode) ([]*models
 	return r0, r1
 }
 
+// FindByName provides a mock function with given fields: mode, name
+func (_m *SavedFilterReaderWriter) FindByName(mode models.FilterMode, name string) (*models.SavedFilter, error) {
+	ret := _m.Called(mode, name)
+
+	var r0 *models.SavedFilter
+	if rf, ok := ret.Get(0).(func(models.FilterMode, string) *models.SavedFilter); ok {
+		r0 = rf(mode, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SavedFilter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.FilterMode, string) error); ok {
+		r1 = rf(mode, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindDefault provides a mock function with given fields: mode
 func (_m *SavedFilterReaderWriter) FindDefault(mode models.FilterMode) (*models.SavedFilter, error) {
 	ret := _m.Called(mode)
@@ -0,0 +1,188 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SceneFileReaderWriter is an autogenerated mock type for the SceneFileReaderWriter type
+type SceneFileReaderWriter struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: newFile
+func (_m *SceneFileReaderWriter) Create(newFile models.SceneFile) (*models.SceneFile, error) {
+	ret := _m.Called(newFile)
+
+	var r0 *models.SceneFile
+	if rf, ok := ret.Get(0).(func(models.SceneFile) *models.SceneFile); ok {
+		r0 = rf(newFile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SceneFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.SceneFile) error); ok {
+		r1 = rf(newFile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Destroy provides a mock function with given fields: id
+func (_m *SceneFileReaderWriter) Destroy(id int) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: id
+func (_m *SceneFileReaderWriter) Find(id int) (*models.SceneFile, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.SceneFile
+	if rf, ok := ret.Get(0).(func(int) *models.SceneFile); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SceneFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByChecksum provides a mock function with given fields: checksum
+func (_m *SceneFileReaderWriter) FindByChecksum(checksum string) (*models.SceneFile, error) {
+	ret := _m.Called(checksum)
+
+	var r0 *models.SceneFile
+	if rf, ok := ret.Get(0).(func(string) *models.SceneFile); ok {
+		r0 = rf(checksum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SceneFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(checksum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByOSHash provides a mock function with given fields: oshash
+func (_m *SceneFileReaderWriter) FindByOSHash(oshash string) (*models.SceneFile, error) {
+	ret := _m.Called(oshash)
+
+	var r0 *models.SceneFile
+	if rf, ok := ret.Get(0).(func(string) *models.SceneFile); ok {
+		r0 = rf(oshash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SceneFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(oshash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByPath provides a mock function with given fields: path
+func (_m *SceneFileReaderWriter) FindByPath(path string) (*models.SceneFile, error) {
+	ret := _m.Called(path)
+
+	var r0 *models.SceneFile
+	if rf, ok := ret.Get(0).(func(string) *models.SceneFile); ok {
+		r0 = rf(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SceneFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindBySceneID provides a mock function with given fields: sceneID
+func (_m *SceneFileReaderWriter) FindBySceneID(sceneID int) ([]*models.SceneFile, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 []*models.SceneFile
+	if rf, ok := ret.Get(0).(func(int) []*models.SceneFile); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateFull provides a mock function with given fields: updatedFile
+func (_m *SceneFileReaderWriter) UpdateFull(updatedFile models.SceneFile) (*models.SceneFile, error) {
+	ret := _m.Called(updatedFile)
+
+	var r0 *models.SceneFile
+	if rf, ok := ret.Get(0).(func(models.SceneFile) *models.SceneFile); ok {
+		r0 = rf(updatedFile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SceneFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(models.SceneFile) error); ok {
+		r1 = rf(updatedFile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -12,6 +12,27 @@ type SceneMarkerReaderWriter struct {
 	mock.Mock
 }
 
+// ChaptersDetected provides a mock function with given fields: sceneID
+func (_m *SceneMarkerReaderWriter) ChaptersDetected(sceneID int) (bool, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(sceneID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountByTagID provides a mock function with given fields: tagID
 func (_m *SceneMarkerReaderWriter) CountByTagID(tagID int) (int, error) {
 	ret := _m.Called(tagID)
@@ -70,6 +91,20 @@ func (_m *SceneMarkerReaderWriter) Destroy(id int) error {
 	return r0
 }
 
+// DestroySuggestions provides a mock function with given fields: ids
+func (_m *SceneMarkerReaderWriter) DestroySuggestions(ids []int) error {
+	ret := _m.Called(ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]int) error); ok {
+		r0 = rf(ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Find provides a mock function with given fields: id
 func (_m *SceneMarkerReaderWriter) Find(id int) (*models.SceneMarker, error) {
 	ret := _m.Called(id)
@@ -139,6 +174,29 @@ func (_m *SceneMarkerReaderWriter) FindMany(ids []int) ([]*models.SceneMarker, e
 	return r0, r1
 }
 
+// FindSuggestions provides a mock function with given fields: ids
+func (_m *SceneMarkerReaderWriter) FindSuggestions(ids []int) ([]*models.SceneMarkerSuggestion, error) {
+	ret := _m.Called(ids)
+
+	var r0 []*models.SceneMarkerSuggestion
+	if rf, ok := ret.Get(0).(func([]int) []*models.SceneMarkerSuggestion); ok {
+		r0 = rf(ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneMarkerSuggestion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMarkerStrings provides a mock function with given fields: q, sort
 func (_m *SceneMarkerReaderWriter) GetMarkerStrings(q *string, sort *string) ([]*models.MarkerStringsResultType, error) {
 	ret := _m.Called(q, sort)
@@ -162,6 +220,52 @@ func (_m *SceneMarkerReaderWriter) GetMarkerStrings(q *string, sort *string) ([]
 	return r0, r1
 }
 
+// GetRejectedSuggestionSeconds provides a mock function with given fields: sceneID
+func (_m *SceneMarkerReaderWriter) GetRejectedSuggestionSeconds(sceneID int) ([]float64, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 []float64
+	if rf, ok := ret.Get(0).(func(int) []float64); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]float64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSuggestions provides a mock function with given fields: sceneID
+func (_m *SceneMarkerReaderWriter) GetSuggestions(sceneID *int) ([]*models.SceneMarkerSuggestion, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 []*models.SceneMarkerSuggestion
+	if rf, ok := ret.Get(0).(func(*int) []*models.SceneMarkerSuggestion); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneMarkerSuggestion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTagIDs provides a mock function with given fields: imageID
 func (_m *SceneMarkerReaderWriter) GetTagIDs(imageID int) ([]int, error) {
 	ret := _m.Called(imageID)
@@ -215,6 +319,48 @@ func (_m *SceneMarkerReaderWriter) Query(sceneMarkerFilter *models.SceneMarkerFi
 	return r0, r1, r2
 }
 
+// RejectSuggestions provides a mock function with given fields: ids
+func (_m *SceneMarkerReaderWriter) RejectSuggestions(ids []int) error {
+	ret := _m.Called(ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]int) error); ok {
+		r0 = rf(ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetChapterDetection provides a mock function with given fields: sceneID
+func (_m *SceneMarkerReaderWriter) ResetChapterDetection(sceneID int) error {
+	ret := _m.Called(sceneID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(sceneID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetChaptersDetected provides a mock function with given fields: sceneID
+func (_m *SceneMarkerReaderWriter) SetChaptersDetected(sceneID int) error {
+	ret := _m.Called(sceneID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(sceneID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: updatedSceneMarker
 func (_m *SceneMarkerReaderWriter) Update(updatedSceneMarker models.SceneMarker) (*models.SceneMarker, error) {
 	ret := _m.Called(updatedSceneMarker)
@@ -238,6 +384,20 @@ func (_m *SceneMarkerReaderWriter) Update(updatedSceneMarker models.SceneMarker)
 	return r0, r1
 }
 
+// UpdateSuggestions provides a mock function with given fields: sceneID, suggestions
+func (_m *SceneMarkerReaderWriter) UpdateSuggestions(sceneID int, suggestions []models.SceneMarkerSuggestion) error {
+	ret := _m.Called(sceneID, suggestions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []models.SceneMarkerSuggestion) error); ok {
+		r0 = rf(sceneID, suggestions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTags provides a mock function with given fields: markerID, tagIDs
 func (_m *SceneMarkerReaderWriter) UpdateTags(markerID int, tagIDs []int) error {
 	ret := _m.Called(markerID, tagIDs)
@@ -482,6 +482,52 @@ func (_m *SceneReaderWriter) FindMany(ids []int) ([]*models.Scene, error) {
 	return r0, r1
 }
 
+// GetAudioStreams provides a mock function with given fields: sceneID
+func (_m *SceneReaderWriter) GetAudioStreams(sceneID int) ([]*models.SceneAudioStream, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 []*models.SceneAudioStream
+	if rf, ok := ret.Get(0).(func(int) []*models.SceneAudioStream); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneAudioStream)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCaptions provides a mock function with given fields: sceneID
+func (_m *SceneReaderWriter) GetCaptions(sceneID int) ([]*models.SceneCaption, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 []*models.SceneCaption
+	if rf, ok := ret.Get(0).(func(int) []*models.SceneCaption); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SceneCaption)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCover provides a mock function with given fields: sceneID
 func (_m *SceneReaderWriter) GetCover(sceneID int) ([]byte, error) {
 	ret := _m.Called(sceneID)
@@ -505,6 +551,29 @@ func (_m *SceneReaderWriter) GetCover(sceneID int) ([]byte, error) {
 	return r0, r1
 }
 
+// GetFieldSources provides a mock function with given fields: sceneID
+func (_m *SceneReaderWriter) GetFieldSources(sceneID int) ([]*models.FieldSource, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 []*models.FieldSource
+	if rf, ok := ret.Get(0).(func(int) []*models.FieldSource); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FieldSource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGalleryIDs provides a mock function with given fields: sceneID
 func (_m *SceneReaderWriter) GetGalleryIDs(sceneID int) ([]int, error) {
 	ret := _m.Called(sceneID)
@@ -729,6 +798,34 @@ func (_m *SceneReaderWriter) Update(updatedScene models.ScenePartial) (*models.S
 	return r0, r1
 }
 
+// UpdateAudioStreams provides a mock function with given fields: sceneID, streams
+func (_m *SceneReaderWriter) UpdateAudioStreams(sceneID int, streams []models.SceneAudioStream) error {
+	ret := _m.Called(sceneID, streams)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []models.SceneAudioStream) error); ok {
+		r0 = rf(sceneID, streams)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCaptions provides a mock function with given fields: sceneID, captions
+func (_m *SceneReaderWriter) UpdateCaptions(sceneID int, captions []models.SceneCaption) error {
+	ret := _m.Called(sceneID, captions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []models.SceneCaption) error); ok {
+		r0 = rf(sceneID, captions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateCover provides a mock function with given fields: sceneID, cover
 func (_m *SceneReaderWriter) UpdateCover(sceneID int, cover []byte) error {
 	ret := _m.Called(sceneID, cover)
@@ -743,6 +840,20 @@ func (_m *SceneReaderWriter) UpdateCover(sceneID int, cover []byte) error {
 	return r0
 }
 
+// UpdateFieldSources provides a mock function with given fields: sceneID, sources
+func (_m *SceneReaderWriter) UpdateFieldSources(sceneID int, sources []models.FieldSource) error {
+	ret := _m.Called(sceneID, sources)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []models.FieldSource) error); ok {
+		r0 = rf(sceneID, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateFileModTime provides a mock function with given fields: id, modTime
 func (_m *SceneReaderWriter) UpdateFileModTime(id int, modTime models.NullSQLiteTimestamp) error {
 	ret := _m.Called(id, modTime)
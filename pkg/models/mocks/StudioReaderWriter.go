@@ -245,6 +245,29 @@ func (_m *StudioReaderWriter) GetAliases(studioID int) ([]string, error) {
 	return r0, r1
 }
 
+// GetFieldSources provides a mock function with given fields: studioID
+func (_m *StudioReaderWriter) GetFieldSources(studioID int) ([]*models.FieldSource, error) {
+	ret := _m.Called(studioID)
+
+	var r0 []*models.FieldSource
+	if rf, ok := ret.Get(0).(func(int) []*models.FieldSource); ok {
+		r0 = rf(studioID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FieldSource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(studioID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: studioID
 func (_m *StudioReaderWriter) GetImage(studioID int) ([]byte, error) {
 	ret := _m.Called(studioID)
@@ -402,6 +425,20 @@ func (_m *StudioReaderWriter) UpdateAliases(studioID int, aliases []string) erro
 	return r0
 }
 
+// UpdateFieldSources provides a mock function with given fields: studioID, sources
+func (_m *StudioReaderWriter) UpdateFieldSources(studioID int, sources []models.FieldSource) error {
+	ret := _m.Called(studioID, sources)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []models.FieldSource) error); ok {
+		r0 = rf(studioID, sources)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateFull provides a mock function with given fields: updatedStudio
 func (_m *StudioReaderWriter) UpdateFull(updatedStudio models.Studio) (*models.Studio, error) {
 	ret := _m.Called(updatedStudio)
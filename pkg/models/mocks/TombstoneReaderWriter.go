@@ -0,0 +1,52 @@
+// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TombstoneReaderWriter is an autogenerated mock type for the TombstoneReaderWriter type
+type TombstoneReaderWriter struct {
+	mock.Mock
+}
+
+// DestroyBefore provides a mock function with given fields: t
+func (_m *TombstoneReaderWriter) DestroyBefore(t time.Time) error {
+	ret := _m.Called(t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindSince provides a mock function with given fields: t
+func (_m *TombstoneReaderWriter) FindSince(t time.Time) ([]*models.Tombstone, error) {
+	ret := _m.Called(t)
+
+	var r0 []*models.Tombstone
+	if rf, ok := ret.Get(0).(func(time.Time) []*models.Tombstone); ok {
+		r0 = rf(t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Tombstone)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -7,30 +7,38 @@ import (
 )
 
 type TransactionManager struct {
+	folder      *FolderReaderWriter
 	gallery     *GalleryReaderWriter
 	image       *ImageReaderWriter
 	movie       *MovieReaderWriter
 	performer   *PerformerReaderWriter
 	scene       *SceneReaderWriter
+	sceneFile   *SceneFileReaderWriter
 	sceneMarker *SceneMarkerReaderWriter
 	scrapedItem *ScrapedItemReaderWriter
 	studio      *StudioReaderWriter
 	tag         *TagReaderWriter
 	savedFilter *SavedFilterReaderWriter
+	playlist    *PlaylistReaderWriter
+	tombstone   *TombstoneReaderWriter
 }
 
 func NewTransactionManager() *TransactionManager {
 	return &TransactionManager{
+		folder:      &FolderReaderWriter{},
 		gallery:     &GalleryReaderWriter{},
 		image:       &ImageReaderWriter{},
 		movie:       &MovieReaderWriter{},
 		performer:   &PerformerReaderWriter{},
 		scene:       &SceneReaderWriter{},
+		sceneFile:   &SceneFileReaderWriter{},
 		sceneMarker: &SceneMarkerReaderWriter{},
 		scrapedItem: &ScrapedItemReaderWriter{},
 		studio:      &StudioReaderWriter{},
 		tag:         &TagReaderWriter{},
 		savedFilter: &SavedFilterReaderWriter{},
+		playlist:    &PlaylistReaderWriter{},
+		tombstone:   &TombstoneReaderWriter{},
 	}
 }
 
@@ -38,6 +46,10 @@ func (t *TransactionManager) WithTxn(ctx context.Context, fn func(r models.Repos
 	return fn(t)
 }
 
+func (t *TransactionManager) FolderMock() *FolderReaderWriter {
+	return t.folder
+}
+
 func (t *TransactionManager) GalleryMock() *GalleryReaderWriter {
 	return t.gallery
 }
@@ -62,6 +74,10 @@ func (t *TransactionManager) SceneMock() *SceneReaderWriter {
 	return t.scene
 }
 
+func (t *TransactionManager) SceneFileMock() *SceneFileReaderWriter {
+	return t.sceneFile
+}
+
 func (t *TransactionManager) ScrapedItemMock() *ScrapedItemReaderWriter {
 	return t.scrapedItem
 }
@@ -78,6 +94,14 @@ func (t *TransactionManager) SavedFilterMock() *SavedFilterReaderWriter {
 	return t.savedFilter
 }
 
+func (t *TransactionManager) PlaylistMock() *PlaylistReaderWriter {
+	return t.playlist
+}
+
+func (t *TransactionManager) TombstoneMock() *TombstoneReaderWriter {
+	return t.tombstone
+}
+
 func (t *TransactionManager) Gallery() models.GalleryReaderWriter {
 	return t.GalleryMock()
 }
@@ -98,10 +122,18 @@ func (t *TransactionManager) SceneMarker() models.SceneMarkerReaderWriter {
 	return t.SceneMarkerMock()
 }
 
+func (t *TransactionManager) Folder() models.FolderReaderWriter {
+	return t.FolderMock()
+}
+
 func (t *TransactionManager) Scene() models.SceneReaderWriter {
 	return t.SceneMock()
 }
 
+func (t *TransactionManager) SceneFile() models.SceneFileReaderWriter {
+	return t.SceneFileMock()
+}
+
 func (t *TransactionManager) ScrapedItem() models.ScrapedItemReaderWriter {
 	return t.ScrapedItemMock()
 }
@@ -118,6 +150,14 @@ func (t *TransactionManager) SavedFilter() models.SavedFilterReaderWriter {
 	return t.SavedFilterMock()
 }
 
+func (t *TransactionManager) Playlist() models.PlaylistReaderWriter {
+	return t.PlaylistMock()
+}
+
+func (t *TransactionManager) Tombstone() models.TombstoneReaderWriter {
+	return t.TombstoneMock()
+}
+
 type ReadTransaction struct {
 	*TransactionManager
 }
@@ -146,10 +186,18 @@ func (r *ReadTransaction) SceneMarker() models.SceneMarkerReader {
 	return r.SceneMarkerMock()
 }
 
+func (r *ReadTransaction) Folder() models.FolderReader {
+	return r.FolderMock()
+}
+
 func (r *ReadTransaction) Scene() models.SceneReader {
 	return r.SceneMock()
 }
 
+func (r *ReadTransaction) SceneFile() models.SceneFileReader {
+	return r.SceneFileMock()
+}
+
 func (r *ReadTransaction) ScrapedItem() models.ScrapedItemReader {
 	return r.ScrapedItemMock()
 }
@@ -165,3 +213,11 @@ func (r *ReadTransaction) Tag() models.TagReader {
 func (r *ReadTransaction) SavedFilter() models.SavedFilterReader {
 	return r.SavedFilterMock()
 }
+
+func (r *ReadTransaction) Playlist() models.PlaylistReader {
+	return r.PlaylistMock()
+}
+
+func (r *ReadTransaction) Tombstone() models.TombstoneReader {
+	return r.TombstoneMock()
+}
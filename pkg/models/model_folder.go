@@ -0,0 +1,43 @@
+package models
+
+import (
+	"database/sql"
+	"path/filepath"
+	"time"
+)
+
+// Folder is a directory containing scanned files. Folders are linked to
+// their parent folder, up to the root of the library path they were scanned
+// from.
+type Folder struct {
+	ID             int                 `db:"id" json:"id"`
+	Path           string              `db:"path" json:"path"`
+	ParentFolderID sql.NullInt64       `db:"parent_folder_id,omitempty" json:"parent_folder_id"`
+	ModTime        NullSQLiteTimestamp `db:"mod_time" json:"mod_time"`
+	CreatedAt      SQLiteTimestamp     `db:"created_at" json:"created_at"`
+	UpdatedAt      SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
+}
+
+func NewFolder(path string) *Folder {
+	currentTime := time.Now()
+	return &Folder{
+		Path:      path,
+		CreatedAt: SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: SQLiteTimestamp{Timestamp: currentTime},
+	}
+}
+
+// Basename returns the base name of the folder.
+func (f Folder) Basename() string {
+	return filepath.Base(f.Path)
+}
+
+type Folders []*Folder
+
+func (f *Folders) Append(o interface{}) {
+	*f = append(*f, o.(*Folder))
+}
+
+func (f *Folders) New() interface{} {
+	return &Folder{}
+}
@@ -9,38 +9,65 @@ import (
 
 // Image stores the metadata for a single image.
 type Image struct {
-	ID          int                 `db:"id" json:"id"`
-	Checksum    string              `db:"checksum" json:"checksum"`
-	Path        string              `db:"path" json:"path"`
-	Title       sql.NullString      `db:"title" json:"title"`
-	Rating      sql.NullInt64       `db:"rating" json:"rating"`
-	Organized   bool                `db:"organized" json:"organized"`
-	OCounter    int                 `db:"o_counter" json:"o_counter"`
-	Size        sql.NullInt64       `db:"size" json:"size"`
-	Width       sql.NullInt64       `db:"width" json:"width"`
-	Height      sql.NullInt64       `db:"height" json:"height"`
-	StudioID    sql.NullInt64       `db:"studio_id,omitempty" json:"studio_id"`
-	FileModTime NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
-	CreatedAt   SQLiteTimestamp     `db:"created_at" json:"created_at"`
-	UpdatedAt   SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
+	ID         int             `db:"id" json:"id"`
+	Checksum   string          `db:"checksum" json:"checksum"`
+	Path       string          `db:"path" json:"path"`
+	Title      sql.NullString  `db:"title" json:"title"`
+	Rating     sql.NullInt64   `db:"rating" json:"rating"`
+	Organized  bool            `db:"organized" json:"organized"`
+	OCounter   int             `db:"o_counter" json:"o_counter"`
+	Size       sql.NullInt64   `db:"size" json:"size"`
+	Width      sql.NullInt64   `db:"width" json:"width"`
+	Height     sql.NullInt64   `db:"height" json:"height"`
+	IsAnimated bool            `db:"is_animated" json:"is_animated"`
+	Duration   sql.NullFloat64 `db:"duration" json:"duration"`
+	// AnimationChecked is true if the image file has been checked for
+	// animation.
+	AnimationChecked bool                `db:"animation_checked" json:"animation_checked"`
+	Date             SQLiteDate          `db:"date" json:"date"`
+	Orientation      sql.NullInt64       `db:"orientation" json:"orientation"`
+	CameraMake       sql.NullString      `db:"camera_make" json:"camera_make"`
+	CameraModel      sql.NullString      `db:"camera_model" json:"camera_model"`
+	Lens             sql.NullString      `db:"lens" json:"lens"`
+	FocalLength      sql.NullFloat64     `db:"focal_length" json:"focal_length"`
+	Aperture         sql.NullFloat64     `db:"aperture" json:"aperture"`
+	ExposureTime     sql.NullFloat64     `db:"exposure_time" json:"exposure_time"`
+	ISO              sql.NullInt64       `db:"iso" json:"iso"`
+	Latitude         sql.NullFloat64     `db:"latitude" json:"latitude"`
+	Longitude        sql.NullFloat64     `db:"longitude" json:"longitude"`
+	StudioID         sql.NullInt64       `db:"studio_id,omitempty" json:"studio_id"`
+	FolderID         sql.NullInt64       `db:"folder_id,omitempty" json:"folder_id"`
+	FileModTime      NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
+	FileStatus       sql.NullString      `db:"file_status" json:"file_status"`
+	FileStatusError  sql.NullString      `db:"file_status_error" json:"file_status_error"`
+	FileCheckedAt    NullSQLiteTimestamp `db:"file_checked_at" json:"file_checked_at"`
+	CreatedAt        SQLiteTimestamp     `db:"created_at" json:"created_at"`
+	UpdatedAt        SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
 }
 
 // ImagePartial represents part of a Image object. It is used to update
 // the database entry. Only non-nil fields will be updated.
 type ImagePartial struct {
-	ID          int                  `db:"id" json:"id"`
-	Checksum    *string              `db:"checksum" json:"checksum"`
-	Path        *string              `db:"path" json:"path"`
-	Title       *sql.NullString      `db:"title" json:"title"`
-	Rating      *sql.NullInt64       `db:"rating" json:"rating"`
-	Organized   *bool                `db:"organized" json:"organized"`
-	Size        *sql.NullInt64       `db:"size" json:"size"`
-	Width       *sql.NullInt64       `db:"width" json:"width"`
-	Height      *sql.NullInt64       `db:"height" json:"height"`
-	StudioID    *sql.NullInt64       `db:"studio_id,omitempty" json:"studio_id"`
-	FileModTime *NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
-	CreatedAt   *SQLiteTimestamp     `db:"created_at" json:"created_at"`
-	UpdatedAt   *SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
+	ID              int                  `db:"id" json:"id"`
+	Checksum        *string              `db:"checksum" json:"checksum"`
+	Path            *string              `db:"path" json:"path"`
+	Title           *sql.NullString      `db:"title" json:"title"`
+	Rating          *sql.NullInt64       `db:"rating" json:"rating"`
+	Organized       *bool                `db:"organized" json:"organized"`
+	Size            *sql.NullInt64       `db:"size" json:"size"`
+	Width           *sql.NullInt64       `db:"width" json:"width"`
+	Height          *sql.NullInt64       `db:"height" json:"height"`
+	IsAnimated      *bool                `db:"is_animated" json:"is_animated"`
+	Duration        *sql.NullFloat64     `db:"duration" json:"duration"`
+	Date            *SQLiteDate          `db:"date" json:"date"`
+	StudioID        *sql.NullInt64       `db:"studio_id,omitempty" json:"studio_id"`
+	FolderID        *sql.NullInt64       `db:"folder_id,omitempty" json:"folder_id"`
+	FileModTime     *NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
+	FileStatus      *sql.NullString      `db:"file_status" json:"file_status"`
+	FileStatusError *sql.NullString      `db:"file_status_error" json:"file_status_error"`
+	FileCheckedAt   *NullSQLiteTimestamp `db:"file_checked_at" json:"file_checked_at"`
+	CreatedAt       *SQLiteTimestamp     `db:"created_at" json:"created_at"`
+	UpdatedAt       *SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
 }
 
 func (i *Image) File() File {
@@ -96,9 +123,21 @@ func (i *Image) GetTitle() string {
 
 // ImageFileType represents the file metadata for an image.
 type ImageFileType struct {
-	Size   *int `graphql:"size" json:"size"`
-	Width  *int `graphql:"width" json:"width"`
-	Height *int `graphql:"height" json:"height"`
+	Size     *int     `graphql:"size" json:"size"`
+	Width    *int     `graphql:"width" json:"width"`
+	Height   *int     `graphql:"height" json:"height"`
+	Duration *float64 `graphql:"duration" json:"duration"`
+
+	Orientation  *int     `graphql:"orientation" json:"orientation"`
+	CameraMake   *string  `graphql:"camera_make" json:"camera_make"`
+	CameraModel  *string  `graphql:"camera_model" json:"camera_model"`
+	Lens         *string  `graphql:"lens" json:"lens"`
+	FocalLength  *float64 `graphql:"focal_length" json:"focal_length"`
+	Aperture     *float64 `graphql:"aperture" json:"aperture"`
+	ExposureTime *float64 `graphql:"exposure_time" json:"exposure_time"`
+	ISO          *int     `graphql:"iso" json:"iso"`
+	Latitude     *float64 `graphql:"latitude" json:"latitude"`
+	Longitude    *float64 `graphql:"longitude" json:"longitude"`
 }
 
 type Images []*Image
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Playlist is an ordered list of scenes. A manual playlist contains the
+// scenes added to it. A smart playlist contains the scenes matching its
+// saved filter, optionally with a different sort and a limit.
+type Playlist struct {
+	ID            int             `db:"id" json:"id"`
+	Name          string          `db:"name" json:"name"`
+	SavedFilterID sql.NullInt64   `db:"saved_filter_id,omitempty" json:"saved_filter_id"`
+	Sort          sql.NullString  `db:"sort" json:"sort"`
+	SortDirection sql.NullString  `db:"sort_direction" json:"sort_direction"`
+	SceneLimit    sql.NullInt64   `db:"scene_limit" json:"scene_limit"`
+	CreatedAt     SQLiteTimestamp `db:"created_at" json:"created_at"`
+	UpdatedAt     SQLiteTimestamp `db:"updated_at" json:"updated_at"`
+}
+
+type PlaylistPartial struct {
+	ID            int              `db:"id" json:"id"`
+	Name          *string          `db:"name" json:"name"`
+	SavedFilterID *sql.NullInt64   `db:"saved_filter_id,omitempty" json:"saved_filter_id"`
+	Sort          *sql.NullString  `db:"sort" json:"sort"`
+	SortDirection *sql.NullString  `db:"sort_direction" json:"sort_direction"`
+	SceneLimit    *sql.NullInt64   `db:"scene_limit" json:"scene_limit"`
+	CreatedAt     *SQLiteTimestamp `db:"created_at" json:"created_at"`
+	UpdatedAt     *SQLiteTimestamp `db:"updated_at" json:"updated_at"`
+}
+
+func NewPlaylist(name string) *Playlist {
+	currentTime := time.Now()
+	return &Playlist{
+		Name:      name,
+		CreatedAt: SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: SQLiteTimestamp{Timestamp: currentTime},
+	}
+}
+
+// IsSmart returns true if the playlist scenes are selected by a saved
+// filter.
+func (p Playlist) IsSmart() bool {
+	return p.SavedFilterID.Valid
+}
+
+type Playlists []*Playlist
+
+func (p *Playlists) Append(o interface{}) {
+	*p = append(*p, o.(*Playlist))
+}
+
+func (p *Playlists) New() interface{} {
+	return &Playlist{}
+}
@@ -30,12 +30,16 @@ type Scene struct {
 	Framerate        sql.NullFloat64     `db:"framerate" json:"framerate"`
 	Bitrate          sql.NullInt64       `db:"bitrate" json:"bitrate"`
 	StudioID         sql.NullInt64       `db:"studio_id,omitempty" json:"studio_id"`
+	FolderID         sql.NullInt64       `db:"folder_id,omitempty" json:"folder_id"`
 	FileModTime      NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
 	Phash            sql.NullInt64       `db:"phash,omitempty" json:"phash"`
 	CreatedAt        SQLiteTimestamp     `db:"created_at" json:"created_at"`
 	UpdatedAt        SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
 	Interactive      bool                `db:"interactive" json:"interactive"`
 	InteractiveSpeed sql.NullInt64       `db:"interactive_speed" json:"interactive_speed"`
+	FileStatus       sql.NullString      `db:"file_status" json:"file_status"`
+	FileStatusError  sql.NullString      `db:"file_status_error" json:"file_status_error"`
+	FileCheckedAt    NullSQLiteTimestamp `db:"file_checked_at" json:"file_checked_at"`
 }
 
 func (s *Scene) File() File {
@@ -113,6 +117,7 @@ type ScenePartial struct {
 	Framerate        *sql.NullFloat64     `db:"framerate" json:"framerate"`
 	Bitrate          *sql.NullInt64       `db:"bitrate" json:"bitrate"`
 	StudioID         *sql.NullInt64       `db:"studio_id,omitempty" json:"studio_id"`
+	FolderID         *sql.NullInt64       `db:"folder_id,omitempty" json:"folder_id"`
 	MovieID          *sql.NullInt64       `db:"movie_id,omitempty" json:"movie_id"`
 	FileModTime      *NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
 	Phash            *sql.NullInt64       `db:"phash,omitempty" json:"phash"`
@@ -120,6 +125,9 @@ type ScenePartial struct {
 	UpdatedAt        *SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
 	Interactive      *bool                `db:"interactive" json:"interactive"`
 	InteractiveSpeed *sql.NullInt64       `db:"interactive_speed" json:"interactive_speed"`
+	FileStatus       *sql.NullString      `db:"file_status" json:"file_status"`
+	FileStatusError  *sql.NullString      `db:"file_status_error" json:"file_status_error"`
+	FileCheckedAt    *NullSQLiteTimestamp `db:"file_checked_at" json:"file_checked_at"`
 }
 
 // UpdateInput constructs a SceneUpdateInput using the populated fields in the ScenePartial object.
@@ -0,0 +1,15 @@
+package models
+
+// SceneAudioStream is an audio stream of the primary file of a scene.
+type SceneAudioStream struct {
+	SceneID int `db:"scene_id" json:"scene_id"`
+	// StreamIndex is the index of the stream in the video file.
+	StreamIndex int    `db:"stream_index" json:"stream_index" gqlgen:"index"`
+	Codec       string `db:"codec" json:"codec"`
+	// LanguageCode is empty if the language is unknown.
+	LanguageCode string `db:"language_code" json:"language_code"`
+	Channels     int    `db:"channels" json:"channels"`
+	Title        string `db:"title" json:"title"`
+	// Default is true for the stream that is played by default.
+	Default bool `db:"is_default" json:"default"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "database/sql"
+
+const (
+	CaptionTypeSRT = "srt"
+	CaptionTypeVTT = "vtt"
+	CaptionTypeASS = "ass"
+)
+
+// SceneCaption is a caption track of a scene. Captions are either caption
+// files next to the primary file of the scene, or subtitle streams embedded
+// in the primary file.
+type SceneCaption struct {
+	ID      int `db:"id" json:"id"`
+	SceneID int `db:"scene_id" json:"scene_id"`
+	// LanguageCode is empty if the language is unknown.
+	LanguageCode string        `db:"language_code" json:"language_code"`
+	CaptionType  string        `db:"caption_type" json:"caption_type"`
+	Source       CaptionSource `db:"source" json:"source"`
+	// Path is the path of the caption file. Only set for file captions.
+	Path sql.NullString `db:"path" json:"path"`
+	// StreamIndex is the index of the subtitle stream in the video file.
+	// Only set for embedded captions.
+	StreamIndex sql.NullInt64 `db:"stream_index" json:"stream_index"`
+}
+
+// Equal returns true if the caption refers to the same caption track as
+// other, ignoring the ids.
+func (c SceneCaption) Equal(other SceneCaption) bool {
+	return c.LanguageCode == other.LanguageCode &&
+		c.CaptionType == other.CaptionType &&
+		c.Source == other.Source &&
+		c.Path == other.Path &&
+		c.StreamIndex == other.StreamIndex
+}
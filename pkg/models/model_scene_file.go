@@ -0,0 +1,150 @@
+package models
+
+import (
+	"database/sql"
+	"path/filepath"
+	"time"
+)
+
+// SceneFile stores the metadata of a single video file of a scene. A scene
+// may have multiple files, such as different encodes of the same video,
+// exactly one of which is the primary file. The file fields of Scene hold a
+// copy of the primary file.
+type SceneFile struct {
+	ID          int                 `db:"id" json:"id"`
+	SceneID     int                 `db:"scene_id" json:"scene_id"`
+	Path        string              `db:"path" json:"path"`
+	Checksum    sql.NullString      `db:"checksum" json:"checksum"`
+	OSHash      sql.NullString      `db:"oshash" json:"oshash"`
+	Phash       sql.NullInt64       `db:"phash,omitempty" json:"phash"`
+	Size        sql.NullString      `db:"size" json:"size"`
+	Duration    sql.NullFloat64     `db:"duration" json:"duration"`
+	VideoCodec  sql.NullString      `db:"video_codec" json:"video_codec"`
+	Format      sql.NullString      `db:"format" json:"format_name"`
+	AudioCodec  sql.NullString      `db:"audio_codec" json:"audio_codec"`
+	Width       sql.NullInt64       `db:"width" json:"width"`
+	Height      sql.NullInt64       `db:"height" json:"height"`
+	Framerate   sql.NullFloat64     `db:"framerate" json:"framerate"`
+	Bitrate     sql.NullInt64       `db:"bitrate" json:"bitrate"`
+	FileModTime NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
+	Primary     bool                `db:"is_primary" json:"primary"`
+	CreatedAt   SQLiteTimestamp     `db:"created_at" json:"created_at"`
+	UpdatedAt   SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
+}
+
+// NewSceneFile returns a primary scene file with the file fields of the
+// provided scene.
+func NewSceneFile(s *Scene) *SceneFile {
+	currentTime := time.Now()
+	return &SceneFile{
+		SceneID:     s.ID,
+		Path:        s.Path,
+		Checksum:    s.Checksum,
+		OSHash:      s.OSHash,
+		Phash:       s.Phash,
+		Size:        s.Size,
+		Duration:    s.Duration,
+		VideoCodec:  s.VideoCodec,
+		Format:      s.Format,
+		AudioCodec:  s.AudioCodec,
+		Width:       s.Width,
+		Height:      s.Height,
+		Framerate:   s.Framerate,
+		Bitrate:     s.Bitrate,
+		FileModTime: s.FileModTime,
+		Primary:     true,
+		CreatedAt:   SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt:   SQLiteTimestamp{Timestamp: currentTime},
+	}
+}
+
+func (f *SceneFile) File() File {
+	ret := File{
+		Path: f.Path,
+	}
+
+	if f.Checksum.Valid {
+		ret.Checksum = f.Checksum.String
+	}
+	if f.OSHash.Valid {
+		ret.OSHash = f.OSHash.String
+	}
+	if f.FileModTime.Valid {
+		ret.FileModTime = f.FileModTime.Timestamp
+	}
+	if f.Size.Valid {
+		ret.Size = f.Size.String
+	}
+
+	return ret
+}
+
+func (f *SceneFile) SetFile(file File) {
+	f.Path = file.Path
+
+	if file.Checksum != "" {
+		f.Checksum = sql.NullString{
+			String: file.Checksum,
+			Valid:  true,
+		}
+	}
+	if file.OSHash != "" {
+		f.OSHash = sql.NullString{
+			String: file.OSHash,
+			Valid:  true,
+		}
+	}
+	zeroTime := time.Time{}
+	if file.FileModTime != zeroTime {
+		f.FileModTime = NullSQLiteTimestamp{
+			Timestamp: file.FileModTime,
+			Valid:     true,
+		}
+	}
+	if file.Size != "" {
+		f.Size = sql.NullString{
+			String: file.Size,
+			Valid:  true,
+		}
+	}
+}
+
+// GetHash returns the hash of the file, based on the hash algorithm
+// provided.
+func (f SceneFile) GetHash(hashAlgorithm HashAlgorithm) string {
+	return f.File().GetHash(hashAlgorithm)
+}
+
+// Basename returns the base filename of the file.
+func (f SceneFile) Basename() string {
+	return filepath.Base(f.Path)
+}
+
+// SetPrimaryFile sets the file fields of the scene to those of the provided
+// file.
+func (s *Scene) SetPrimaryFile(f SceneFile) {
+	s.Path = f.Path
+	s.Checksum = f.Checksum
+	s.OSHash = f.OSHash
+	s.Phash = f.Phash
+	s.Size = f.Size
+	s.Duration = f.Duration
+	s.VideoCodec = f.VideoCodec
+	s.Format = f.Format
+	s.AudioCodec = f.AudioCodec
+	s.Width = f.Width
+	s.Height = f.Height
+	s.Framerate = f.Framerate
+	s.Bitrate = f.Bitrate
+	s.FileModTime = f.FileModTime
+}
+
+type SceneFiles []*SceneFile
+
+func (f *SceneFiles) Append(o interface{}) {
+	*f = append(*f, o.(*SceneFile))
+}
+
+func (f *SceneFiles) New() interface{} {
+	return &SceneFile{}
+}
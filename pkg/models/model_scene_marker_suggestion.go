@@ -0,0 +1,27 @@
+package models
+
+import "database/sql"
+
+// SceneMarkerSuggestion is a pending scene marker that was detected
+// automatically. Suggestions are converted to scene markers when accepted.
+type SceneMarkerSuggestion struct {
+	ID      int     `db:"id" json:"id"`
+	SceneID int     `db:"scene_id" json:"scene_id"`
+	Title   string  `db:"title" json:"title"`
+	Seconds float64 `db:"seconds" json:"seconds"`
+	// EndSeconds is the end of the detected chapter.
+	EndSeconds float64 `db:"end_seconds" json:"end_seconds"`
+	// PrimaryTagID is the default primary tag of the marker, if set.
+	PrimaryTagID sql.NullInt64   `db:"primary_tag_id" json:"primary_tag_id"`
+	CreatedAt    SQLiteTimestamp `db:"created_at" json:"created_at"`
+}
+
+type SceneMarkerSuggestions []*SceneMarkerSuggestion
+
+func (m *SceneMarkerSuggestions) Append(o interface{}) {
+	*m = append(*m, o.(*SceneMarkerSuggestion))
+}
+
+func (m *SceneMarkerSuggestions) New() interface{} {
+	return &SceneMarkerSuggestion{}
+}
@@ -0,0 +1,34 @@
+package models
+
+import "database/sql"
+
+const (
+	TombstoneObjectTypeScene     = "scene"
+	TombstoneObjectTypeImage     = "image"
+	TombstoneObjectTypeGallery   = "gallery"
+	TombstoneObjectTypePerformer = "performer"
+	TombstoneObjectTypeStudio    = "studio"
+	TombstoneObjectTypeMovie     = "movie"
+	TombstoneObjectTypeTag       = "tag"
+)
+
+// Tombstone records the identifying fields of a deleted object, so that the
+// deletion can be included in incremental exports.
+type Tombstone struct {
+	ID         int             `db:"id" json:"id"`
+	ObjectType string          `db:"object_type" json:"object_type"`
+	Checksum   sql.NullString  `db:"checksum" json:"checksum"`
+	OSHash     sql.NullString  `db:"oshash" json:"oshash"`
+	Name       sql.NullString  `db:"name" json:"name"`
+	DeletedAt  SQLiteTimestamp `db:"deleted_at" json:"deleted_at"`
+}
+
+type Tombstones []*Tombstone
+
+func (t *Tombstones) Append(o interface{}) {
+	*t = append(*t, o.(*Tombstone))
+}
+
+func (t *Tombstones) New() interface{} {
+	return &Tombstone{}
+}
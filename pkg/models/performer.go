@@ -19,6 +19,7 @@ type PerformerReader interface {
 	Query(performerFilter *PerformerFilterType, findFilter *FindFilterType) ([]*Performer, int, error)
 	GetImage(performerID int) ([]byte, error)
 	GetStashIDs(performerID int) ([]*StashID, error)
+	GetFieldSources(performerID int) ([]*FieldSource, error)
 	GetTagIDs(performerID int) ([]int, error)
 }
 
@@ -30,6 +31,7 @@ type PerformerWriter interface {
 	UpdateImage(performerID int, image []byte) error
 	DestroyImage(performerID int) error
 	UpdateStashIDs(performerID int, stashIDs []StashID) error
+	UpdateFieldSources(performerID int, sources []FieldSource) error
 	UpdateTags(performerID int, tagIDs []int) error
 }
 
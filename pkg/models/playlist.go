@@ -0,0 +1,26 @@
+package models
+
+type PlaylistReader interface {
+	Find(id int) (*Playlist, error)
+	FindMany(ids []int) ([]*Playlist, error)
+	FindByName(name string) (*Playlist, error)
+	All() ([]*Playlist, error)
+	Count() (int, error)
+	// GetSceneIDs returns the ids of the scenes of a manual playlist, in
+	// playlist order.
+	GetSceneIDs(playlistID int) ([]int, error)
+}
+
+type PlaylistWriter interface {
+	Create(newPlaylist Playlist) (*Playlist, error)
+	Update(updatedPlaylist PlaylistPartial) (*Playlist, error)
+	Destroy(id int) error
+	// UpdateScenes replaces the scenes of a manual playlist with the
+	// provided scenes, in order.
+	UpdateScenes(playlistID int, sceneIDs []int) error
+}
+
+type PlaylistReaderWriter interface {
+	PlaylistReader
+	PlaylistWriter
+}
@@ -1,27 +1,35 @@
 package models
 
 type Repository interface {
+	Folder() FolderReaderWriter
 	Gallery() GalleryReaderWriter
 	Image() ImageReaderWriter
 	Movie() MovieReaderWriter
 	Performer() PerformerReaderWriter
 	Scene() SceneReaderWriter
+	SceneFile() SceneFileReaderWriter
 	SceneMarker() SceneMarkerReaderWriter
 	ScrapedItem() ScrapedItemReaderWriter
 	Studio() StudioReaderWriter
 	Tag() TagReaderWriter
 	SavedFilter() SavedFilterReaderWriter
+	Playlist() PlaylistReaderWriter
+	Tombstone() TombstoneReaderWriter
 }
 
 type ReaderRepository interface {
+	Folder() FolderReader
 	Gallery() GalleryReader
 	Image() ImageReader
 	Movie() MovieReader
 	Performer() PerformerReader
 	Scene() SceneReader
+	SceneFile() SceneFileReader
 	SceneMarker() SceneMarkerReader
 	ScrapedItem() ScrapedItemReader
 	Studio() StudioReader
 	Tag() TagReader
 	SavedFilter() SavedFilterReader
+	Playlist() PlaylistReader
+	Tombstone() TombstoneReader
 }
@@ -4,6 +4,8 @@ type SavedFilterReader interface {
 	Find(id int) (*SavedFilter, error)
 	FindByMode(mode FilterMode) ([]*SavedFilter, error)
 	FindDefault(mode FilterMode) (*SavedFilter, error)
+	FindByName(mode FilterMode, name string) (*SavedFilter, error)
+	All() ([]*SavedFilter, error)
 }
 
 type SavedFilterWriter interface {
@@ -68,6 +68,9 @@ type SceneReader interface {
 	GetGalleryIDs(sceneID int) ([]int, error)
 	GetPerformerIDs(sceneID int) ([]int, error)
 	GetStashIDs(sceneID int) ([]*StashID, error)
+	GetFieldSources(sceneID int) ([]*FieldSource, error)
+	GetCaptions(sceneID int) ([]*SceneCaption, error)
+	GetAudioStreams(sceneID int) ([]*SceneAudioStream, error)
 }
 
 type SceneWriter interface {
@@ -86,6 +89,9 @@ type SceneWriter interface {
 	UpdateGalleries(sceneID int, galleryIDs []int) error
 	UpdateMovies(sceneID int, movies []MoviesScenes) error
 	UpdateStashIDs(sceneID int, stashIDs []StashID) error
+	UpdateFieldSources(sceneID int, sources []FieldSource) error
+	UpdateCaptions(sceneID int, captions []SceneCaption) error
+	UpdateAudioStreams(sceneID int, streams []SceneAudioStream) error
 }
 
 type SceneReaderWriter interface {
@@ -0,0 +1,21 @@
+package models
+
+type SceneFileReader interface {
+	Find(id int) (*SceneFile, error)
+	FindByPath(path string) (*SceneFile, error)
+	FindByChecksum(checksum string) (*SceneFile, error)
+	FindByOSHash(oshash string) (*SceneFile, error)
+	// FindBySceneID returns the files of the scene, primary file first.
+	FindBySceneID(sceneID int) ([]*SceneFile, error)
+}
+
+type SceneFileWriter interface {
+	Create(newFile SceneFile) (*SceneFile, error)
+	UpdateFull(updatedFile SceneFile) (*SceneFile, error)
+	Destroy(id int) error
+}
+
+type SceneFileReaderWriter interface {
+	SceneFileReader
+	SceneFileWriter
+}
@@ -9,6 +9,16 @@ type SceneMarkerReader interface {
 	Wall(q *string) ([]*SceneMarker, error)
 	Query(sceneMarkerFilter *SceneMarkerFilterType, findFilter *FindFilterType) ([]*SceneMarker, int, error)
 	GetTagIDs(imageID int) ([]int, error)
+	FindSuggestions(ids []int) ([]*SceneMarkerSuggestion, error)
+	// GetSuggestions returns the pending suggestions of the scene. Returns
+	// the suggestions of all scenes if sceneID is nil.
+	GetSuggestions(sceneID *int) ([]*SceneMarkerSuggestion, error)
+	// ChaptersDetected returns true if chapters have been detected for the
+	// scene.
+	ChaptersDetected(sceneID int) (bool, error)
+	// GetRejectedSuggestionSeconds returns the start times of the rejected
+	// suggestions of the scene.
+	GetRejectedSuggestionSeconds(sceneID int) ([]float64, error)
 }
 
 type SceneMarkerWriter interface {
@@ -16,6 +26,18 @@ type SceneMarkerWriter interface {
 	Update(updatedSceneMarker SceneMarker) (*SceneMarker, error)
 	Destroy(id int) error
 	UpdateTags(markerID int, tagIDs []int) error
+	// UpdateSuggestions replaces the pending suggestions of the scene.
+	UpdateSuggestions(sceneID int, suggestions []SceneMarkerSuggestion) error
+	DestroySuggestions(ids []int) error
+	// RejectSuggestions destroys the suggestions, recording their start
+	// times so that they are not suggested again.
+	RejectSuggestions(ids []int) error
+	// SetChaptersDetected records that chapters have been detected for the
+	// scene.
+	SetChaptersDetected(sceneID int) error
+	// ResetChapterDetection removes the chapter detection record and the
+	// rejected suggestions of the scene.
+	ResetChapterDetection(sceneID int) error
 }
 
 type SceneMarkerReaderWriter interface {
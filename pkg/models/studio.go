@@ -15,6 +15,7 @@ type StudioReader interface {
 	GetImage(studioID int) ([]byte, error)
 	HasImage(studioID int) (bool, error)
 	GetStashIDs(studioID int) ([]*StashID, error)
+	GetFieldSources(studioID int) ([]*FieldSource, error)
 	GetAliases(studioID int) ([]string, error)
 }
 
@@ -26,6 +27,7 @@ type StudioWriter interface {
 	UpdateImage(studioID int, image []byte) error
 	DestroyImage(studioID int) error
 	UpdateStashIDs(studioID int, stashIDs []StashID) error
+	UpdateFieldSources(studioID int, sources []FieldSource) error
 	UpdateAliases(studioID int, aliases []string) error
 }
 
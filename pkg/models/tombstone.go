@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type TombstoneReader interface {
+	// FindSince returns the tombstones of objects deleted after t.
+	FindSince(t time.Time) ([]*Tombstone, error)
+}
+
+type TombstoneWriter interface {
+	// DestroyBefore removes the tombstones of objects deleted before t.
+	DestroyBefore(t time.Time) error
+}
+
+type TombstoneReaderWriter interface {
+	TombstoneReader
+	TombstoneWriter
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+)
+
+// transcodeProfileContainers are the output formats supported by transcode
+// profiles.
+var transcodeProfileContainers = []string{"mp4", "webm", "mpegts", "matroska"}
+
+// TranscodeProfile is a named set of transcoding options. Profiles are
+// configured in the configuration file and are used for streaming and for
+// generated transcodes.
+type TranscodeProfile struct {
+	Name string `mapstructure:"name"`
+	// Container is the output format of the profile. One of mp4, webm,
+	// mpegts or matroska.
+	Container string `mapstructure:"container"`
+	// VideoCodec is the ffmpeg video encoder, such as libx264, or copy.
+	VideoCodec string `mapstructure:"video_codec"`
+	// AudioCodec is the ffmpeg audio encoder, such as aac, or copy. The
+	// ffmpeg default for the container is used if empty.
+	AudioCodec string `mapstructure:"audio_codec"`
+	// CRF is the constant rate factor of the video encoder. Not set if zero.
+	CRF          int    `mapstructure:"crf"`
+	VideoBitrate string `mapstructure:"video_bitrate"`
+	AudioBitrate string `mapstructure:"audio_bitrate"`
+	Preset       string `mapstructure:"preset"`
+	// InputArgs are passed to ffmpeg before the input file.
+	InputArgs []string `mapstructure:"input_args"`
+	// OutputArgs are passed to ffmpeg after the codec arguments.
+	OutputArgs []string `mapstructure:"output_args"`
+	// MaxResolution overrides the configured maximum transcode size if set.
+	MaxResolution StreamingResolutionEnum `mapstructure:"max_resolution"`
+}
+
+// Validate returns an error if the profile is invalid.
+func (p TranscodeProfile) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("transcode profile name is required")
+	}
+
+	validContainer := false
+	for _, c := range transcodeProfileContainers {
+		if p.Container == c {
+			validContainer = true
+			break
+		}
+	}
+	if !validContainer {
+		return fmt.Errorf("transcode profile %q: unsupported container %q", p.Name, p.Container)
+	}
+
+	if p.VideoCodec == "" {
+		return fmt.Errorf("transcode profile %q: video codec is required", p.Name)
+	}
+
+	if p.CRF < 0 || p.CRF > 63 {
+		return fmt.Errorf("transcode profile %q: crf must be between 0 and 63", p.Name)
+	}
+
+	if p.MaxResolution != "" && !p.MaxResolution.IsValid() {
+		return fmt.Errorf("transcode profile %q: invalid max resolution %q", p.Name, p.MaxResolution)
+	}
+
+	return nil
+}
+
+type TranscodeProfiles []TranscodeProfile
+
+// Validate returns an error if any of the profiles is invalid or if profile
+// names are not unique.
+func (tp TranscodeProfiles) Validate() error {
+	names := make(map[string]bool)
+	for _, p := range tp {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+
+		if names[p.Name] {
+			return fmt.Errorf("duplicate transcode profile name %q", p.Name)
+		}
+		names[p.Name] = true
+	}
+
+	return nil
+}
+
+// Valid returns the valid profiles. Invalid profiles, and profiles with the
+// same name as an earlier profile, are omitted.
+func (tp TranscodeProfiles) Valid() TranscodeProfiles {
+	var ret TranscodeProfiles
+	names := make(map[string]bool)
+	for _, p := range tp {
+		if p.Validate() != nil || names[p.Name] {
+			continue
+		}
+
+		names[p.Name] = true
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+// Find returns the profile with the provided name, or nil if not found.
+func (tp TranscodeProfiles) Find(name string) *TranscodeProfile {
+	for i := range tp {
+		if tp[i].Name == name {
+			return &tp[i]
+		}
+	}
+
+	return nil
+}
+
+// FindByContainer returns the first profile with the provided container, or
+// nil if not found.
+func (tp TranscodeProfiles) FindByContainer(container string) *TranscodeProfile {
+	for i := range tp {
+		if tp[i].Container == container {
+			return &tp[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscodeProfilesValidate(t *testing.T) {
+	valid := TranscodeProfile{
+		Name:          "h264",
+		Container:     "mp4",
+		VideoCodec:    "libx264",
+		CRF:           23,
+		MaxResolution: StreamingResolutionEnumFullHd,
+	}
+
+	assert.Nil(t, TranscodeProfiles{valid}.Validate())
+	assert.NotNil(t, TranscodeProfiles{valid, valid}.Validate(), "duplicate name")
+
+	tests := []struct {
+		name   string
+		modify func(p *TranscodeProfile)
+	}{
+		{"missing name", func(p *TranscodeProfile) { p.Name = "" }},
+		{"invalid container", func(p *TranscodeProfile) { p.Container = "avi" }},
+		{"missing video codec", func(p *TranscodeProfile) { p.VideoCodec = "" }},
+		{"invalid crf", func(p *TranscodeProfile) { p.CRF = 64 }},
+		{"invalid max resolution", func(p *TranscodeProfile) { p.MaxResolution = "HUGE" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.modify(&p)
+			assert.NotNil(t, TranscodeProfiles{p}.Validate())
+		})
+	}
+}
+
+func TestTranscodeProfilesValid(t *testing.T) {
+	profiles := TranscodeProfiles{
+		{Name: "h264", Container: "mp4", VideoCodec: "libx264"},
+		{Name: "avi", Container: "avi", VideoCodec: "mpeg4"},
+		{Name: "h264", Container: "webm", VideoCodec: "libvpx-vp9"},
+		{Name: "vp9", Container: "webm", VideoCodec: "libvpx-vp9"},
+	}
+
+	assert.Equal(t, TranscodeProfiles{profiles[0], profiles[3]}, profiles.Valid())
+}
+
+func TestTranscodeProfilesFind(t *testing.T) {
+	profiles := TranscodeProfiles{
+		{Name: "webm", Container: "webm"},
+		{Name: "h264", Container: "mp4"},
+		{Name: "hevc", Container: "mp4"},
+	}
+
+	assert.Equal(t, "hevc", profiles.Find("hevc").Name)
+	assert.Nil(t, profiles.Find("missing"))
+	assert.Equal(t, "h264", profiles.FindByContainer("mp4").Name)
+	assert.Nil(t, profiles.FindByContainer("mpegts"))
+}
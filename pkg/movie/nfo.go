@@ -0,0 +1,60 @@
+package movie
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
+)
+
+// ToNFO returns the NFO of the movie. If existing is not nil, then the
+// existing NFO is updated with the movie metadata, retaining the elements
+// that are not managed by stash.
+func ToNFO(studioReader models.StudioReader, movie *models.Movie, existing *nfo.Movie) (*nfo.Movie, error) {
+	ret := existing
+	if ret == nil {
+		ret = &nfo.Movie{}
+	}
+
+	ret.Title = movie.Name.String
+	ret.OriginalTitle = movie.Aliases.String
+	ret.Plot = movie.Synopsis.String
+
+	ret.Premiered = ""
+	ret.Year = ""
+	if movie.Date.Valid {
+		ret.Premiered = movie.Date.String
+		if len(movie.Date.String) >= 4 {
+			ret.Year = movie.Date.String[0:4]
+		}
+	}
+
+	ret.Runtime = 0
+	if movie.Duration.Valid {
+		ret.Runtime = int(math.Round(float64(movie.Duration.Int64) / 60))
+	}
+
+	ret.SetRating(int(movie.Rating.Int64))
+	ret.SetUniqueID(nfo.UniqueIDTypeStash, strconv.Itoa(movie.ID))
+
+	ret.Directors = nil
+	if movie.Director.String != "" {
+		ret.Directors = []string{movie.Director.String}
+	}
+
+	ret.Studios = nil
+	if movie.StudioID.Valid {
+		studio, err := studioReader.Find(int(movie.StudioID.Int64))
+		if err != nil {
+			return nil, fmt.Errorf("error getting movie studio: %w", err)
+		}
+
+		if studio != nil {
+			ret.Studios = []string{studio.Name.String}
+		}
+	}
+
+	return ret, nil
+}
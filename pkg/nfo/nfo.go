@@ -0,0 +1,284 @@
+// Package nfo reads and writes Kodi-compatible NFO sidecar files.
+package nfo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// UniqueIDTypeStash is the unique id type of stash object ids.
+	UniqueIDTypeStash = "stash"
+
+	// MovieFilename is the filename of movie NFO files.
+	MovieFilename = "movie.nfo"
+
+	nfoExt = ".nfo"
+)
+
+// invalidPathCharsRE matches characters that are invalid in filenames on
+// common filesystems.
+var invalidPathCharsRE = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]`)
+
+// Movie is the root element of a movie NFO file. Elements that are not
+// recognised are retained, so that updating an existing file does not lose
+// information written by other applications.
+type Movie struct {
+	XMLName       xml.Name   `xml:"movie"`
+	Title         string     `xml:"title,omitempty"`
+	OriginalTitle string     `xml:"originaltitle,omitempty"`
+	Plot          string     `xml:"plot,omitempty"`
+	Premiered     string     `xml:"premiered,omitempty"`
+	Aired         string     `xml:"aired,omitempty"`
+	Year          string     `xml:"year,omitempty"`
+	Runtime       int        `xml:"runtime,omitempty"`
+	UserRating    float64    `xml:"userrating,omitempty"`
+	Studios       []string   `xml:"studio"`
+	Directors     []string   `xml:"director"`
+	Actors        []Actor    `xml:"actor"`
+	Tags          []string   `xml:"tag"`
+	Genres        []string   `xml:"genre"`
+	UniqueIDs     []UniqueID `xml:"uniqueid"`
+	Set           *Set       `xml:"set"`
+
+	Other []Element `xml:",any"`
+}
+
+type Actor struct {
+	Name  string `xml:"name"`
+	Role  string `xml:"role,omitempty"`
+	Order int    `xml:"order"`
+}
+
+type UniqueID struct {
+	Type    string `xml:"type,attr,omitempty"`
+	Default bool   `xml:"default,attr,omitempty"`
+	Value   string `xml:",chardata"`
+}
+
+type Set struct {
+	Name     string `xml:"name"`
+	Overview string `xml:"overview,omitempty"`
+}
+
+// Element is an element that is not recognised.
+type Element struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Content string     `xml:",innerxml"`
+}
+
+// Date returns the release date of the movie, or an empty string if not
+// set.
+func (m Movie) Date() string {
+	if m.Premiered != "" {
+		return m.Premiered
+	}
+
+	return m.Aired
+}
+
+// Rating returns the user rating of the movie, converted from the 0-10
+// scale to the 1-5 scale. Returns 0 if the movie is not rated.
+func (m Movie) Rating() int {
+	if m.UserRating <= 0 {
+		return 0
+	}
+
+	ret := int(math.Round(m.UserRating / 2))
+	if ret < 1 {
+		ret = 1
+	}
+	if ret > 5 {
+		ret = 5
+	}
+
+	return ret
+}
+
+// SetRating sets the user rating of the movie from a rating on the 1-5
+// scale. A rating of 0 clears the user rating.
+func (m *Movie) SetRating(rating int) {
+	m.UserRating = float64(rating * 2)
+}
+
+// ActorNames returns the unique names of the actors.
+func (m Movie) ActorNames() []string {
+	var names []string
+	for _, a := range m.Actors {
+		names = append(names, a.Name)
+	}
+
+	return uniqueNames(names)
+}
+
+// TagNames returns the unique names of the tags and genres.
+func (m Movie) TagNames() []string {
+	var names []string
+	names = append(names, m.Tags...)
+	names = append(names, m.Genres...)
+
+	return uniqueNames(names)
+}
+
+// uniqueNames returns the trimmed, non-empty names, omitting names that
+// differ only by case from an earlier name.
+func uniqueNames(names []string) []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		key := strings.ToLower(n)
+		if n == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		ret = append(ret, n)
+	}
+
+	return ret
+}
+
+// SetUniqueID sets the unique id of the provided type, replacing any
+// existing unique id of the same type.
+func (m *Movie) SetUniqueID(idType string, value string) {
+	for i, id := range m.UniqueIDs {
+		if id.Type == idType {
+			m.UniqueIDs[i].Value = value
+			return
+		}
+	}
+
+	m.UniqueIDs = append(m.UniqueIDs, UniqueID{
+		Type:    idType,
+		Default: len(m.UniqueIDs) == 0,
+		Value:   value,
+	})
+}
+
+// Path returns the path of the NFO file of the video file with the provided
+// path.
+func Path(videoPath string) string {
+	return strings.TrimSuffix(videoPath, filepath.Ext(videoPath)) + nfoExt
+}
+
+// PosterPath returns the path of the poster image of the video file with
+// the provided path. The extension is determined from the image data.
+func PosterPath(videoPath string, image []byte) string {
+	return strings.TrimSuffix(videoPath, filepath.Ext(videoPath)) + "-poster" + ImageExt(image)
+}
+
+// PosterPaths returns the possible paths of the poster image of the video
+// file with the provided path, one for each image extension.
+func PosterPaths(videoPath string) []string {
+	var ret []string
+	for _, ext := range imageExts {
+		ret = append(ret, strings.TrimSuffix(videoPath, filepath.Ext(videoPath))+"-poster"+ext)
+	}
+
+	return ret
+}
+
+// imageExts are the file extensions returned by ImageExt.
+var imageExts = []string{".jpg", ".png", ".gif", ".webp"}
+
+// ImageExt returns the file extension of the image data.
+func ImageExt(image []byte) string {
+	switch http.DetectContentType(image) {
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".jpg"
+	}
+}
+
+// DirName returns the name with the characters that are not valid in
+// directory names removed.
+func DirName(name string) string {
+	return strings.Trim(invalidPathCharsRE.ReplaceAllString(name, ""), " .")
+}
+
+// Exists returns true if the NFO file of the video file exists.
+func Exists(videoPath string) bool {
+	info, err := os.Stat(Path(videoPath))
+	return err == nil && !info.IsDir()
+}
+
+// Read reads the NFO file with the provided path.
+func Read(path string) (*Movie, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// NFO files may be URL files, which are not supported
+	if !bytes.Contains(data, []byte("<movie")) {
+		return nil, fmt.Errorf("%s is not a movie NFO file", path)
+	}
+
+	var ret Movie
+	if err := xml.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+
+	return &ret, nil
+}
+
+// Write writes the NFO file to the provided path. The file is written to a
+// temporary file first, so that an existing file is not left incomplete if
+// writing fails.
+func Write(path string, m *Movie) error {
+	data, err := xml.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error generating NFO: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.Write(data)
+	buf.WriteString("\n")
+
+	return WriteFile(path, buf.Bytes())
+}
+
+// WriteFile writes the data to the provided path, using a temporary file
+// that replaces the existing file once written.
+func WriteFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(tmpPath, 0644)
+	}
+
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	return nil
+}
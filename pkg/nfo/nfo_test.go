@@ -0,0 +1,127 @@
+package nfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const existingNFO = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<movie>
+  <title>Original</title>
+  <plot>Plot &amp; more</plot>
+  <premiered>2021-01-02</premiered>
+  <userrating>7</userrating>
+  <studio>Studio</studio>
+  <actor>
+    <name>Actor</name>
+    <role>Role</role>
+    <order>0</order>
+  </actor>
+  <actor>
+    <name>actor</name>
+  </actor>
+  <tag>Tag</tag>
+  <genre>Genre</genre>
+  <genre>tag</genre>
+  <uniqueid type="imdb" default="true">tt0000001</uniqueid>
+  <fileinfo>
+    <streamdetails>
+      <video><codec>h264</codec></video>
+    </streamdetails>
+  </fileinfo>
+  <thumb aspect="poster">poster.jpg</thumb>
+</movie>
+`
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.nfo")
+	if err := os.WriteFile(path, []byte(existingNFO), 0644); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	m, err := Read(path)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Original", m.Title)
+	assert.Equal(t, "Plot & more", m.Plot)
+	assert.Equal(t, "2021-01-02", m.Date())
+	assert.Equal(t, 4, m.Rating())
+	assert.Equal(t, []string{"Studio"}, m.Studios)
+	assert.Equal(t, []string{"Actor"}, m.ActorNames())
+	assert.Equal(t, []string{"Tag", "Genre"}, m.TagNames())
+
+	urlPath := filepath.Join(dir, "url.nfo")
+	if err := os.WriteFile(urlPath, []byte("https://example.com/movie/1"), 0644); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	_, err = Read(urlPath)
+	assert.NotNil(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.nfo")
+	if err := os.WriteFile(path, []byte(existingNFO), 0644); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	m, err := Read(path)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	const title = `Title <with> "special" & 'characters'`
+	m.Title = title
+	m.SetRating(5)
+	m.SetUniqueID(UniqueIDTypeStash, "1")
+
+	if !assert.Nil(t, Write(path, m)) {
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// unrecognised elements are retained
+	assert.True(t, strings.Contains(string(data), "<codec>h264</codec>"))
+	assert.True(t, strings.Contains(string(data), `<thumb aspect="poster">poster.jpg</thumb>`))
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+
+	got, err := Read(path)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, title, got.Title)
+	assert.Equal(t, 5, got.Rating())
+	assert.Equal(t, []UniqueID{
+		{Type: "imdb", Default: true, Value: "tt0000001"},
+		{Type: UniqueIDTypeStash, Value: "1"},
+	}, got.UniqueIDs)
+	assert.Len(t, got.Other, 2)
+}
+
+func TestPaths(t *testing.T) {
+	videoPath := filepath.Join("dir", "file.name.mp4")
+	png := []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+	assert.Equal(t, filepath.Join("dir", "file.name.nfo"), Path(videoPath))
+	assert.Equal(t, filepath.Join("dir", "file.name-poster.png"), PosterPath(videoPath, png))
+	assert.Equal(t, filepath.Join("dir", "file.name-poster.jpg"), PosterPath(videoPath, []byte{0xFF, 0xD8, 0xFF}))
+	assert.Contains(t, PosterPaths(videoPath), PosterPath(videoPath, png))
+	assert.Equal(t, "Movie Name", DirName(` Movie: Name?. `))
+}
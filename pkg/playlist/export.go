@@ -0,0 +1,94 @@
+package playlist
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Entry is a single item of an exported playlist.
+type Entry struct {
+	Title string
+	// Duration in seconds. Zero if unknown.
+	Duration float64
+	// URL of the media to play.
+	URL string
+	// ImageURL is the URL of an image representing the item. Optional.
+	ImageURL string
+}
+
+// WriteM3U writes the entries to w as an extended M3U playlist.
+func WriteM3U(w io.Writer, entries []Entry) error {
+	bw := bufio.NewWriter(w)
+
+	if _, err := bw.WriteString("#EXTM3U\n"); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		duration := -1
+		if e.Duration > 0 {
+			duration = int(math.Round(e.Duration))
+		}
+
+		// the title ends at the end of the line
+		title := strings.NewReplacer("\r", " ", "\n", " ").Replace(e.Title)
+
+		if _, err := fmt.Fprintf(bw, "#EXTINF:%d,%s\n%s\n", duration, title, e.URL); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+type xspfPlaylist struct {
+	XMLName   xml.Name    `xml:"playlist"`
+	Version   string      `xml:"version,attr"`
+	Namespace string      `xml:"xmlns,attr"`
+	Title     string      `xml:"title,omitempty"`
+	Tracks    []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location string `xml:"location"`
+	Title    string `xml:"title,omitempty"`
+	Image    string `xml:"image,omitempty"`
+	// duration in milliseconds
+	Duration int64 `xml:"duration,omitempty"`
+}
+
+// WriteXSPF writes the entries to w as an XSPF playlist with the provided
+// title.
+func WriteXSPF(w io.Writer, title string, entries []Entry) error {
+	p := xspfPlaylist{
+		Version:   "1",
+		Namespace: "http://xspf.org/ns/0/",
+		Title:     title,
+	}
+
+	for _, e := range entries {
+		p.Tracks = append(p.Tracks, xspfTrack{
+			Location: e.URL,
+			Title:    e.Title,
+			Image:    e.ImageURL,
+			Duration: int64(math.Round(e.Duration * 1000)),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(p); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
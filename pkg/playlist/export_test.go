@@ -0,0 +1,62 @@
+package playlist
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testEntries = []Entry{
+	{
+		Title:    "first & title",
+		Duration: 61.6,
+		URL:      "http://localhost/scene/1/stream",
+		ImageURL: "http://localhost/scene/1/screenshot",
+	},
+	{
+		Title: "second\ntitle",
+		URL:   "http://localhost/scene/2/stream",
+	},
+}
+
+func TestWriteM3U(t *testing.T) {
+	var buf bytes.Buffer
+	if !assert.Nil(t, WriteM3U(&buf, testEntries)) {
+		return
+	}
+
+	const expected = `#EXTM3U
+#EXTINF:62,first & title
+http://localhost/scene/1/stream
+#EXTINF:-1,second title
+http://localhost/scene/2/stream
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteXSPF(t *testing.T) {
+	var buf bytes.Buffer
+	if !assert.Nil(t, WriteXSPF(&buf, "playlist", testEntries)) {
+		return
+	}
+
+	const expected = `<?xml version="1.0" encoding="UTF-8"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <title>playlist</title>
+  <trackList>
+    <track>
+      <location>http://localhost/scene/1/stream</location>
+      <title>first &amp; title</title>
+      <image>http://localhost/scene/1/screenshot</image>
+      <duration>61600</duration>
+    </track>
+    <track>
+      <location>http://localhost/scene/2/stream</location>
+      <title>second&#xA;title</title>
+    </track>
+  </trackList>
+</playlist>
+`
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,75 @@
+// Package playlist provides the evaluation and export of scene playlists.
+package playlist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/savedfilter"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// ErrSavedFilterNotFound is returned when the saved filter of a smart
+// playlist does not exist.
+var ErrSavedFilterNotFound = errors.New("saved filter not found")
+
+// Scenes returns the scenes of the playlist, in playlist order. The scenes
+// of a smart playlist are those matching its saved filter, sorted and
+// limited by the playlist settings.
+func Scenes(r models.ReaderRepository, p *models.Playlist) ([]*models.Scene, error) {
+	if !p.IsSmart() {
+		sceneIDs, err := r.Playlist().GetSceneIDs(p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting scene ids of playlist %q: %w", p.Name, err)
+		}
+
+		return r.Scene().FindMany(sceneIDs)
+	}
+
+	sceneFilter, findFilter, err := Filter(r.SavedFilter(), p)
+	if err != nil {
+		return nil, err
+	}
+
+	return scene.Query(r.Scene(), sceneFilter, findFilter)
+}
+
+// Filter returns the scene filter and find filter that select the scenes of
+// a smart playlist.
+func Filter(r models.SavedFilterReader, p *models.Playlist) (*models.SceneFilterType, *models.FindFilterType, error) {
+	savedFilter, err := r.Find(int(p.SavedFilterID.Int64))
+	if err != nil {
+		return nil, nil, fmt.Errorf("finding saved filter of playlist %q: %w", p.Name, err)
+	}
+
+	if savedFilter == nil {
+		return nil, nil, fmt.Errorf("%w: playlist %q", ErrSavedFilterNotFound, p.Name)
+	}
+
+	sceneFilter, findFilter, err := savedfilter.SceneFilter(savedFilter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if p.Sort.Valid {
+		sort := p.Sort.String
+		findFilter.Sort = &sort
+	}
+
+	if p.SortDirection.Valid {
+		direction := models.SortDirectionEnum(p.SortDirection.String)
+		findFilter.Direction = &direction
+	}
+
+	// the playlist contains all matching scenes unless limited
+	page := 1
+	perPage := -1
+	if p.SceneLimit.Valid {
+		perPage = int(p.SceneLimit.Int64)
+	}
+	findFilter.Page = &page
+	findFilter.PerPage = &perPage
+
+	return sceneFilter, findFilter, nil
+}
@@ -0,0 +1,63 @@
+package playlist
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	savedFilterID        = 1
+	missingSavedFilterID = 2
+)
+
+func TestFilter(t *testing.T) {
+	mockSavedFilterReader := &mocks.SavedFilterReaderWriter{}
+	mockSavedFilterReader.On("Find", savedFilterID).Return(&models.SavedFilter{
+		ID:     savedFilterID,
+		Mode:   models.FilterModeScenes,
+		Filter: `{"perPage":40,"sortby":"date","sortdir":"desc","c":["{\"type\":\"organized\",\"value\":\"true\",\"modifier\":\"EQUALS\"}"]}`,
+	}, nil)
+	mockSavedFilterReader.On("Find", missingSavedFilterID).Return(nil, nil)
+
+	p := &models.Playlist{
+		Name:          "smart",
+		SavedFilterID: sql.NullInt64{Int64: savedFilterID, Valid: true},
+	}
+
+	// saved filter sort with all matching scenes
+	sceneFilter, findFilter, err := Filter(mockSavedFilterReader, p)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.True(t, *sceneFilter.Organized)
+	assert.Equal(t, "date", *findFilter.Sort)
+	assert.Equal(t, models.SortDirectionEnumDesc, *findFilter.Direction)
+	assert.Equal(t, 1, *findFilter.Page)
+	assert.Equal(t, -1, *findFilter.PerPage)
+
+	// playlist sort and limit
+	p.Sort = sql.NullString{String: "random", Valid: true}
+	p.SortDirection = sql.NullString{String: models.SortDirectionEnumAsc.String(), Valid: true}
+	p.SceneLimit = sql.NullInt64{Int64: 10, Valid: true}
+
+	_, findFilter, err = Filter(mockSavedFilterReader, p)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "random", *findFilter.Sort)
+	assert.Equal(t, models.SortDirectionEnumAsc, *findFilter.Direction)
+	assert.Equal(t, 10, *findFilter.PerPage)
+
+	p.SavedFilterID.Int64 = missingSavedFilterID
+	_, _, err = Filter(mockSavedFilterReader, p)
+	assert.True(t, errors.Is(err, ErrSavedFilterNotFound))
+
+	mockSavedFilterReader.AssertExpectations(t)
+}
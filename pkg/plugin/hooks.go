@@ -18,14 +18,17 @@ const (
 	SceneCreatePost  HookTriggerEnum = "Scene.Create.Post"
 	SceneUpdatePost  HookTriggerEnum = "Scene.Update.Post"
 	SceneDestroyPost HookTriggerEnum = "Scene.Destroy.Post"
+	SceneMovePost    HookTriggerEnum = "Scene.Move.Post"
 
 	ImageCreatePost  HookTriggerEnum = "Image.Create.Post"
 	ImageUpdatePost  HookTriggerEnum = "Image.Update.Post"
 	ImageDestroyPost HookTriggerEnum = "Image.Destroy.Post"
+	ImageMovePost    HookTriggerEnum = "Image.Move.Post"
 
 	GalleryCreatePost  HookTriggerEnum = "Gallery.Create.Post"
 	GalleryUpdatePost  HookTriggerEnum = "Gallery.Update.Post"
 	GalleryDestroyPost HookTriggerEnum = "Gallery.Destroy.Post"
+	GalleryMovePost    HookTriggerEnum = "Gallery.Move.Post"
 
 	MovieCreatePost  HookTriggerEnum = "Movie.Create.Post"
 	MovieUpdatePost  HookTriggerEnum = "Movie.Update.Post"
@@ -53,14 +56,17 @@ var AllHookTriggerEnum = []HookTriggerEnum{
 	SceneCreatePost,
 	SceneUpdatePost,
 	SceneDestroyPost,
+	SceneMovePost,
 
 	ImageCreatePost,
 	ImageUpdatePost,
 	ImageDestroyPost,
+	ImageMovePost,
 
 	GalleryCreatePost,
 	GalleryUpdatePost,
 	GalleryDestroyPost,
+	GalleryMovePost,
 
 	MovieCreatePost,
 	MovieUpdatePost,
@@ -90,14 +96,17 @@ func (e HookTriggerEnum) IsValid() bool {
 		SceneCreatePost,
 		SceneUpdatePost,
 		SceneDestroyPost,
+		SceneMovePost,
 
 		ImageCreatePost,
 		ImageUpdatePost,
 		ImageDestroyPost,
+		ImageMovePost,
 
 		GalleryCreatePost,
 		GalleryUpdatePost,
 		GalleryDestroyPost,
+		GalleryMovePost,
 
 		MovieCreatePost,
 		MovieUpdatePost,
@@ -159,3 +168,10 @@ type ImagesDestroyInput struct {
 	Checksum string `json:"checksum"`
 	Path     string `json:"path"`
 }
+
+// MoveInput is the input for move hooks, which are executed when a scan
+// detects that a file has been moved or renamed.
+type MoveInput struct {
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+}
@@ -189,6 +189,15 @@ func (c Cache) ExecuteSceneUpdatePostHooks(ctx context.Context, input models.Sce
 	c.ExecutePostHooks(ctx, id, SceneUpdatePost, input, inputFields)
 }
 
+// ExecuteMovePostHooks executes the move hooks of the provided type for the
+// object with the provided id.
+func (c Cache) ExecuteMovePostHooks(ctx context.Context, id int, hookType HookTriggerEnum, oldPath string, newPath string) {
+	c.ExecutePostHooks(ctx, id, hookType, MoveInput{
+		OldPath: oldPath,
+		NewPath: newPath,
+	}, nil)
+}
+
 func (c Cache) executePostHooks(ctx context.Context, hookType HookTriggerEnum, hookContext common.HookContext) error {
 	visitedPlugins := session.GetVisitedPlugins(ctx)
 
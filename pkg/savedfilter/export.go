@@ -0,0 +1,15 @@
+package savedfilter
+
+import (
+	"github.com/stashapp/stash/pkg/manager/jsonschema"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ToJSON converts a SavedFilter object into its JSON equivalent.
+func ToJSON(savedFilter *models.SavedFilter) *jsonschema.SavedFilter {
+	return &jsonschema.SavedFilter{
+		Mode:   savedFilter.Mode,
+		Name:   savedFilter.Name,
+		Filter: savedFilter.Filter,
+	}
+}
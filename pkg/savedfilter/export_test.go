@@ -0,0 +1,32 @@
+package savedfilter
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/manager/jsonschema"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	savedFilterID   = 1
+	savedFilterName = "testFilter"
+	filter          = `{"sortby":"date"}`
+)
+
+func TestToJSON(t *testing.T) {
+	savedFilter := models.SavedFilter{
+		ID:     savedFilterID,
+		Mode:   models.FilterModeScenes,
+		Name:   savedFilterName,
+		Filter: filter,
+	}
+
+	expected := &jsonschema.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Name:   savedFilterName,
+		Filter: filter,
+	}
+
+	assert.Equal(t, expected, ToJSON(&savedFilter))
+}
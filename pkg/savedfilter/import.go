@@ -0,0 +1,94 @@
+package savedfilter
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/manager/jsonschema"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type Importer struct {
+	ReaderWriter models.SavedFilterReaderWriter
+	Input        jsonschema.SavedFilter
+
+	savedFilter models.SavedFilter
+}
+
+func (i *Importer) PreImport() error {
+	if !i.Input.Mode.IsValid() {
+		return fmt.Errorf("invalid filter mode: %s", i.Input.Mode)
+	}
+
+	i.savedFilter = models.SavedFilter{
+		Mode:   i.Input.Mode,
+		Name:   i.Input.Name,
+		Filter: i.Input.Filter,
+	}
+
+	return nil
+}
+
+func (i *Importer) PostImport(id int) error {
+	return nil
+}
+
+func (i *Importer) isDefault() bool {
+	return i.Input.Name == ""
+}
+
+func (i *Importer) Name() string {
+	if i.isDefault() {
+		return fmt.Sprintf("%s (default)", i.Input.Mode)
+	}
+
+	return fmt.Sprintf("%s (%s)", i.Input.Name, i.Input.Mode)
+}
+
+func (i *Importer) FindExistingID() (*int, error) {
+	var existing *models.SavedFilter
+	var err error
+	if i.isDefault() {
+		existing, err = i.ReaderWriter.FindDefault(i.Input.Mode)
+	} else {
+		existing, err = i.ReaderWriter.FindByName(i.Input.Mode, i.Input.Name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		id := existing.ID
+		return &id, nil
+	}
+
+	return nil, nil
+}
+
+func (i *Importer) Create() (*int, error) {
+	var created *models.SavedFilter
+	var err error
+	if i.isDefault() {
+		created, err = i.ReaderWriter.SetDefault(i.savedFilter)
+	} else {
+		created, err = i.ReaderWriter.Create(i.savedFilter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating saved filter: %v", err)
+	}
+
+	id := created.ID
+	return &id, nil
+}
+
+func (i *Importer) Update(id int) error {
+	savedFilter := i.savedFilter
+	savedFilter.ID = id
+	_, err := i.ReaderWriter.Update(savedFilter)
+	if err != nil {
+		return fmt.Errorf("error updating existing saved filter: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package savedfilter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/manager/jsonschema"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	savedFilterNameErr      = "savedFilterNameErr"
+	existingSavedFilterName = "existingSavedFilterName"
+
+	existingSavedFilterID = 100
+	existingDefaultID     = 101
+)
+
+func TestImporterName(t *testing.T) {
+	i := Importer{
+		Input: jsonschema.SavedFilter{
+			Mode: models.FilterModeScenes,
+			Name: savedFilterName,
+		},
+	}
+
+	assert.Equal(t, "testFilter (SCENES)", i.Name())
+
+	i.Input.Name = ""
+	assert.Equal(t, "SCENES (default)", i.Name())
+}
+
+func TestImporterPreImport(t *testing.T) {
+	i := Importer{
+		Input: jsonschema.SavedFilter{
+			Mode:   "invalid",
+			Name:   savedFilterName,
+			Filter: filter,
+		},
+	}
+
+	err := i.PreImport()
+	assert.NotNil(t, err)
+
+	i.Input.Mode = models.FilterModeScenes
+
+	err = i.PreImport()
+	assert.Nil(t, err)
+	assert.Equal(t, models.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Name:   savedFilterName,
+		Filter: filter,
+	}, i.savedFilter)
+}
+
+func TestImporterFindExistingID(t *testing.T) {
+	readerWriter := &mocks.SavedFilterReaderWriter{}
+
+	i := Importer{
+		ReaderWriter: readerWriter,
+		Input: jsonschema.SavedFilter{
+			Mode: models.FilterModeScenes,
+			Name: savedFilterName,
+		},
+	}
+
+	errFindByName := errors.New("FindByName error")
+	readerWriter.On("FindByName", models.FilterModeScenes, savedFilterName).Return(nil, nil).Once()
+	readerWriter.On("FindByName", models.FilterModeScenes, existingSavedFilterName).Return(&models.SavedFilter{
+		ID: existingSavedFilterID,
+	}, nil).Once()
+	readerWriter.On("FindByName", models.FilterModeScenes, savedFilterNameErr).Return(nil, errFindByName).Once()
+	readerWriter.On("FindDefault", models.FilterModeScenes).Return(&models.SavedFilter{
+		ID: existingDefaultID,
+	}, nil).Once()
+
+	id, err := i.FindExistingID()
+	assert.Nil(t, id)
+	assert.Nil(t, err)
+
+	i.Input.Name = existingSavedFilterName
+	id, err = i.FindExistingID()
+	assert.Equal(t, existingSavedFilterID, *id)
+	assert.Nil(t, err)
+
+	i.Input.Name = savedFilterNameErr
+	id, err = i.FindExistingID()
+	assert.Nil(t, id)
+	assert.NotNil(t, err)
+
+	i.Input.Name = ""
+	id, err = i.FindExistingID()
+	assert.Equal(t, existingDefaultID, *id)
+	assert.Nil(t, err)
+
+	readerWriter.AssertExpectations(t)
+}
+
+func TestCreate(t *testing.T) {
+	readerWriter := &mocks.SavedFilterReaderWriter{}
+
+	savedFilter := models.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Name:   savedFilterName,
+		Filter: filter,
+	}
+
+	defaultFilter := models.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Filter: filter,
+	}
+
+	savedFilterErr := models.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Name:   savedFilterNameErr,
+		Filter: filter,
+	}
+
+	i := Importer{
+		ReaderWriter: readerWriter,
+		savedFilter:  savedFilter,
+		Input: jsonschema.SavedFilter{
+			Mode: models.FilterModeScenes,
+			Name: savedFilterName,
+		},
+	}
+
+	errCreate := errors.New("Create error")
+	readerWriter.On("Create", savedFilter).Return(&models.SavedFilter{
+		ID: savedFilterID,
+	}, nil).Once()
+	readerWriter.On("Create", savedFilterErr).Return(nil, errCreate).Once()
+	readerWriter.On("SetDefault", defaultFilter).Return(&models.SavedFilter{
+		ID: existingDefaultID,
+	}, nil).Once()
+
+	id, err := i.Create()
+	assert.Equal(t, savedFilterID, *id)
+	assert.Nil(t, err)
+
+	i.savedFilter = savedFilterErr
+	i.Input.Name = savedFilterNameErr
+	id, err = i.Create()
+	assert.Nil(t, id)
+	assert.NotNil(t, err)
+
+	i.savedFilter = defaultFilter
+	i.Input.Name = ""
+	id, err = i.Create()
+	assert.Equal(t, existingDefaultID, *id)
+	assert.Nil(t, err)
+
+	readerWriter.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	readerWriter := &mocks.SavedFilterReaderWriter{}
+
+	savedFilter := models.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Name:   savedFilterName,
+		Filter: filter,
+	}
+
+	i := Importer{
+		ReaderWriter: readerWriter,
+		savedFilter:  savedFilter,
+	}
+
+	errUpdate := errors.New("Update error")
+
+	// id needs to be set for the mock input
+	savedFilter.ID = existingSavedFilterID
+	readerWriter.On("Update", savedFilter).Return(nil, nil).Once()
+
+	err := i.Update(existingSavedFilterID)
+	assert.Nil(t, err)
+
+	savedFilter.ID = savedFilterID
+	readerWriter.On("Update", mock.Anything).Return(nil, errUpdate).Once()
+
+	err = i.Update(savedFilterID)
+	assert.NotNil(t, err)
+
+	readerWriter.AssertExpectations(t)
+}
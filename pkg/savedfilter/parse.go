@@ -0,0 +1,644 @@
+package savedfilter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ErrWrongMode is returned when a saved filter is parsed as a filter for a
+// different type of object.
+var ErrWrongMode = errors.New("saved filter mode does not match")
+
+// queryParameters is the structure of the JSON-encoded filter string of a
+// saved filter, as written by the UI.
+type queryParameters struct {
+	PerPage json.Number     `json:"perPage"`
+	SortBy  string          `json:"sortby"`
+	SortDir string          `json:"sortdir"`
+	Q       string          `json:"q"`
+	C       json.RawMessage `json:"c"`
+}
+
+// encodedCriterion is a single criterion of a saved filter.
+type encodedCriterion struct {
+	Type     string                   `json:"type"`
+	Modifier models.CriterionModifier `json:"modifier"`
+	Value    json.RawMessage          `json:"value"`
+}
+
+type criterionKind int
+
+const (
+	stringCriterion criterionKind = iota
+	numberCriterion
+	floatCriterion
+	multiCriterion
+	hierarchicalCriterion
+	boolCriterion
+	// the value is used as the criterion input without a modifier
+	valueCriterion
+	resolutionCriterion
+	genderCriterion
+	duplicatedCriterion
+	fileStatusCriterion
+	timestampCriterion
+)
+
+type criterionOption struct {
+	parameterName string
+	kind          criterionKind
+}
+
+// criterionOptions maps the criterion types of the UI to the filter field
+// and the way the criterion value is encoded.
+var criterionOptions = map[string]criterionOption{
+	"name":               {"name", stringCriterion},
+	"path":               {"path", stringCriterion},
+	"checksum":           {"checksum", stringCriterion},
+	"sceneChecksum":      {"checksum", stringCriterion},
+	"galleryChecksum":    {"checksum", stringCriterion},
+	"oshash":             {"oshash", stringCriterion},
+	"phash":              {"phash", stringCriterion},
+	"ethnicity":          {"ethnicity", stringCriterion},
+	"country":            {"country", stringCriterion},
+	"hair_color":         {"hair_color", stringCriterion},
+	"eye_color":          {"eye_color", stringCriterion},
+	"height":             {"height", stringCriterion},
+	"measurements":       {"measurements", stringCriterion},
+	"fake_tits":          {"fake_tits", stringCriterion},
+	"career_length":      {"career_length", stringCriterion},
+	"tattoos":            {"tattoos", stringCriterion},
+	"piercings":          {"piercings", stringCriterion},
+	"aliases":            {"aliases", stringCriterion},
+	"url":                {"url", stringCriterion},
+	"stash_id":           {"stash_id", stringCriterion},
+	"details":            {"details", stringCriterion},
+	"title":              {"title", stringCriterion},
+	"director":           {"director", stringCriterion},
+	"synopsis":           {"synopsis", stringCriterion},
+	"captions":           {"captions", stringCriterion},
+	"camera_make":        {"camera_make", stringCriterion},
+	"camera_model":       {"camera_model", stringCriterion},
+	"lens":               {"lens", stringCriterion},
+	"video_codec":        {"video_codec", stringCriterion},
+	"audio_codec":        {"audio_codec", stringCriterion},
+	"format":             {"format", stringCriterion},
+	"rating":             {"rating", numberCriterion},
+	"o_counter":          {"o_counter", numberCriterion},
+	"interactive_speed":  {"interactive_speed", numberCriterion},
+	"scene_count":        {"scene_count", numberCriterion},
+	"marker_count":       {"marker_count", numberCriterion},
+	"image_count":        {"image_count", numberCriterion},
+	"gallery_count":      {"gallery_count", numberCriterion},
+	"performer_count":    {"performer_count", numberCriterion},
+	"performer_age":      {"performer_age", numberCriterion},
+	"tag_count":          {"tag_count", numberCriterion},
+	"duration":           {"duration", numberCriterion},
+	"birth_year":         {"birth_year", numberCriterion},
+	"death_year":         {"death_year", numberCriterion},
+	"weight":             {"weight", numberCriterion},
+	"age":                {"age", numberCriterion},
+	"parent_tag_count":   {"parent_count", numberCriterion},
+	"child_tag_count":    {"child_count", numberCriterion},
+	"bitrate":            {"bitrate", numberCriterion},
+	"width":              {"width", numberCriterion},
+	"framerate":          {"framerate", floatCriterion},
+	"size":               {"size", floatCriterion},
+	"performers":         {"performers", multiCriterion},
+	"movies":             {"movies", multiCriterion},
+	"galleries":          {"galleries", multiCriterion},
+	"parent_studios":     {"parents", multiCriterion},
+	"tags":               {"tags", hierarchicalCriterion},
+	"sceneTags":          {"scene_tags", hierarchicalCriterion},
+	"performerTags":      {"performer_tags", hierarchicalCriterion},
+	"parentTags":         {"parents", hierarchicalCriterion},
+	"childTags":          {"children", hierarchicalCriterion},
+	"studios":            {"studios", hierarchicalCriterion},
+	"organized":          {"organized", boolCriterion},
+	"favorite":           {"filter_favorites", boolCriterion},
+	"performer_favorite": {"performer_favorite", boolCriterion},
+	"interactive":        {"interactive", boolCriterion},
+	"is_animated":        {"is_animated", boolCriterion},
+	"hasMarkers":         {"has_markers", valueCriterion},
+	"sceneIsMissing":     {"is_missing", valueCriterion},
+	"imageIsMissing":     {"is_missing", valueCriterion},
+	"performerIsMissing": {"is_missing", valueCriterion},
+	"galleryIsMissing":   {"is_missing", valueCriterion},
+	"tagIsMissing":       {"is_missing", valueCriterion},
+	"studioIsMissing":    {"is_missing", valueCriterion},
+	"movieIsMissing":     {"is_missing", valueCriterion},
+	"resolution":         {"resolution", resolutionCriterion},
+	"average_resolution": {"average_resolution", resolutionCriterion},
+	"gender":             {"gender", genderCriterion},
+	"duplicated":         {"duplicated", duplicatedCriterion},
+	"file_status":        {"file_status", fileStatusCriterion},
+	"date":               {"date", timestampCriterion},
+	"created_at":         {"created_at", timestampCriterion},
+	"updated_at":         {"updated_at", timestampCriterion},
+	"file_mod_time":      {"file_mod_time", timestampCriterion},
+}
+
+// modeCriterionOptions overrides criterionOptions for criterion types that
+// are encoded differently for a filter mode.
+var modeCriterionOptions = map[models.FilterMode]map[string]criterionOption{
+	// the height of performers is a string
+	models.FilterModeImages: {
+		"height": {"height", numberCriterion},
+	},
+}
+
+// getCriterionOption returns the criterion option of the criterion type for
+// the filter mode.
+func getCriterionOption(mode models.FilterMode, criterionType string) (criterionOption, bool) {
+	if option, found := modeCriterionOptions[mode][criterionType]; found {
+		return option, true
+	}
+
+	option, found := criterionOptions[criterionType]
+	return option, found
+}
+
+// resolution values as displayed in the UI
+var resolutionStrings = map[string]models.ResolutionEnum{
+	"144p":  models.ResolutionEnumVeryLow,
+	"240p":  models.ResolutionEnumLow,
+	"360p":  models.ResolutionEnumR360p,
+	"480p":  models.ResolutionEnumStandard,
+	"540p":  models.ResolutionEnumWebHd,
+	"720p":  models.ResolutionEnumStandardHd,
+	"1080p": models.ResolutionEnumFullHd,
+	"1440p": models.ResolutionEnumQuadHd,
+	"1920p": models.ResolutionEnumVrHd,
+	"4k":    models.ResolutionEnumFourK,
+	"5k":    models.ResolutionEnumFiveK,
+	"6k":    models.ResolutionEnumSixK,
+	"8k":    models.ResolutionEnumEightK,
+}
+
+// gender values as displayed in the UI
+var genderStrings = map[string]models.GenderEnum{
+	"Male":               models.GenderEnumMale,
+	"Female":             models.GenderEnumFemale,
+	"Transgender Male":   models.GenderEnumTransgenderMale,
+	"Transgender Female": models.GenderEnumTransgenderFemale,
+	"Intersex":           models.GenderEnumIntersex,
+	"Non-Binary":         models.GenderEnumNonBinary,
+}
+
+// SceneFilter returns the scene filter and find filter of a saved filter.
+// Returns ErrWrongMode if the saved filter is not a scene filter.
+func SceneFilter(savedFilter *models.SavedFilter) (*models.SceneFilterType, *models.FindFilterType, error) {
+	var ret models.SceneFilterType
+	findFilter, err := parse(savedFilter, models.FilterModeScenes, &ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ret, findFilter, nil
+}
+
+// ImageFilter returns the image filter and find filter of a saved filter.
+// Returns ErrWrongMode if the saved filter is not an image filter.
+func ImageFilter(savedFilter *models.SavedFilter) (*models.ImageFilterType, *models.FindFilterType, error) {
+	var ret models.ImageFilterType
+	findFilter, err := parse(savedFilter, models.FilterModeImages, &ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ret, findFilter, nil
+}
+
+// GalleryFilter returns the gallery filter and find filter of a saved
+// filter. Returns ErrWrongMode if the saved filter is not a gallery filter.
+func GalleryFilter(savedFilter *models.SavedFilter) (*models.GalleryFilterType, *models.FindFilterType, error) {
+	var ret models.GalleryFilterType
+	findFilter, err := parse(savedFilter, models.FilterModeGalleries, &ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ret, findFilter, nil
+}
+
+// PerformerFilter returns the performer filter and find filter of a saved
+// filter. Returns ErrWrongMode if the saved filter is not a performer filter.
+func PerformerFilter(savedFilter *models.SavedFilter) (*models.PerformerFilterType, *models.FindFilterType, error) {
+	var ret models.PerformerFilterType
+	findFilter, err := parse(savedFilter, models.FilterModePerformers, &ret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ret, findFilter, nil
+}
+
+// parse decodes the filter string of the saved filter into objectFilter,
+// and returns the find filter of the saved filter.
+func parse(savedFilter *models.SavedFilter, mode models.FilterMode, objectFilter interface{}) (*models.FindFilterType, error) {
+	if savedFilter.Mode != mode {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrWrongMode, mode, savedFilter.Mode)
+	}
+
+	var params queryParameters
+	if err := json.Unmarshal([]byte(savedFilter.Filter), &params); err != nil {
+		return nil, fmt.Errorf("decoding saved filter %q: %w", savedFilter.Name, err)
+	}
+
+	findFilter, err := params.findFilter()
+	if err != nil {
+		return nil, fmt.Errorf("decoding saved filter %q: %w", savedFilter.Name, err)
+	}
+
+	criteria, err := params.criteria()
+	if err != nil {
+		return nil, fmt.Errorf("decoding saved filter %q: %w", savedFilter.Name, err)
+	}
+
+	filterMap := make(map[string]interface{})
+	for _, c := range criteria {
+		name, input, err := c.toCriterionInput(mode)
+		if err != nil {
+			return nil, fmt.Errorf("decoding saved filter %q: criterion %q: %w", savedFilter.Name, c.Type, err)
+		}
+
+		if name != "" {
+			filterMap[name] = input
+		}
+	}
+
+	// the criterion inputs have the same structure as the GraphQL input
+	// types. Fields that do not exist for the filter mode are rejected.
+	data, err := json.Marshal(filterMap)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(objectFilter); err != nil {
+		return nil, fmt.Errorf("decoding saved filter %q: %w", savedFilter.Name, err)
+	}
+
+	return findFilter, nil
+}
+
+func (p queryParameters) findFilter() (*models.FindFilterType, error) {
+	ret := &models.FindFilterType{}
+
+	if p.Q != "" {
+		q := strings.TrimSpace(p.Q)
+		ret.Q = &q
+	}
+
+	if p.PerPage != "" {
+		perPage, err := p.PerPage.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid perPage value: %w", err)
+		}
+		v := int(perPage)
+		ret.PerPage = &v
+	}
+
+	if p.SortBy != "" {
+		sort := p.SortBy
+		ret.Sort = &sort
+	}
+
+	direction := models.SortDirectionEnumAsc
+	if p.SortDir == "desc" {
+		direction = models.SortDirectionEnumDesc
+	}
+	ret.Direction = &direction
+
+	return ret, nil
+}
+
+func (p queryParameters) criteria() ([]encodedCriterion, error) {
+	if len(p.C) == 0 {
+		return nil, nil
+	}
+
+	// a single criterion may be encoded as a string instead of a list
+	var encoded []string
+	if err := json.Unmarshal(p.C, &encoded); err != nil {
+		var single string
+		if err := json.Unmarshal(p.C, &single); err != nil {
+			return nil, fmt.Errorf("invalid criteria: %w", err)
+		}
+		encoded = []string{single}
+	}
+
+	var ret []encodedCriterion
+	for _, e := range encoded {
+		var c encodedCriterion
+		if err := json.Unmarshal([]byte(e), &c); err != nil {
+			return nil, fmt.Errorf("invalid criterion %q: %w", e, err)
+		}
+
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+// toCriterionInput returns the filter field name and input value of the
+// criterion for the filter mode. Returns an empty name for criteria that are
+// ignored.
+func (c encodedCriterion) toCriterionInput(mode models.FilterMode) (string, interface{}, error) {
+	if c.Type == "none" {
+		return "", nil, nil
+	}
+
+	option, found := getCriterionOption(mode, c.Type)
+	if !found {
+		return "", nil, errors.New("unsupported criterion type")
+	}
+
+	var input interface{}
+	var err error
+	switch option.kind {
+	case stringCriterion:
+		input, err = c.stringInput()
+	case numberCriterion:
+		input, err = c.numberInput()
+	case floatCriterion:
+		input, err = c.floatInput()
+	case multiCriterion:
+		input, err = c.multiInput()
+	case hierarchicalCriterion:
+		input, err = c.hierarchicalInput()
+	case boolCriterion:
+		input, err = c.boolInput()
+	case valueCriterion:
+		input, err = c.stringValue()
+	case resolutionCriterion:
+		input, err = c.resolutionInput()
+	case genderCriterion:
+		input, err = c.genderInput()
+	case duplicatedCriterion:
+		input, err = c.duplicatedInput()
+	case fileStatusCriterion:
+		input, err = c.fileStatusInput()
+	case timestampCriterion:
+		input, err = c.timestampInput()
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return option.parameterName, input, nil
+}
+
+func (c encodedCriterion) stringValue() (string, error) {
+	var ret string
+	if len(c.Value) == 0 {
+		return ret, nil
+	}
+
+	if err := json.Unmarshal(c.Value, &ret); err != nil {
+		return "", fmt.Errorf("invalid value: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (c encodedCriterion) stringInput() (*models.StringCriterionInput, error) {
+	v, err := c.stringValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// the UI encodes these characters so that they survive in the URL
+	v = strings.NewReplacer("%26", "&", "%2B", "+").Replace(v)
+
+	return &models.StringCriterionInput{
+		Value:    v,
+		Modifier: c.Modifier,
+	}, nil
+}
+
+func (c encodedCriterion) numberInput() (*models.IntCriterionInput, error) {
+	ret := &models.IntCriterionInput{
+		Modifier: c.Modifier,
+	}
+
+	if len(c.Value) == 0 {
+		return ret, nil
+	}
+
+	// older filters encode the value as a plain number
+	var value float64
+	if err := json.Unmarshal(c.Value, &value); err == nil {
+		ret.Value = int(value)
+		return ret, nil
+	}
+
+	var v struct {
+		Value  *float64 `json:"value"`
+		Value2 *float64 `json:"value2"`
+	}
+	if err := json.Unmarshal(c.Value, &v); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+
+	if v.Value != nil {
+		ret.Value = int(*v.Value)
+	}
+	if v.Value2 != nil {
+		value2 := int(*v.Value2)
+		ret.Value2 = &value2
+	}
+
+	return ret, nil
+}
+
+func (c encodedCriterion) floatInput() (*models.FloatCriterionInput, error) {
+	ret := &models.FloatCriterionInput{
+		Modifier: c.Modifier,
+	}
+
+	if len(c.Value) == 0 {
+		return ret, nil
+	}
+
+	// a single value may be encoded as a plain number
+	if err := json.Unmarshal(c.Value, &ret.Value); err == nil {
+		return ret, nil
+	}
+
+	var v struct {
+		Value  float64  `json:"value"`
+		Value2 *float64 `json:"value2"`
+	}
+	if err := json.Unmarshal(c.Value, &v); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+
+	ret.Value = v.Value
+	ret.Value2 = v.Value2
+
+	return ret, nil
+}
+
+type labeledID struct {
+	ID string `json:"id"`
+}
+
+func labeledIDs(items []labeledID) []string {
+	ret := []string{}
+	for _, i := range items {
+		ret = append(ret, i.ID)
+	}
+
+	return ret
+}
+
+func (c encodedCriterion) multiInput() (*models.MultiCriterionInput, error) {
+	var items []labeledID
+	if len(c.Value) > 0 {
+		if err := json.Unmarshal(c.Value, &items); err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+	}
+
+	return &models.MultiCriterionInput{
+		Value:    labeledIDs(items),
+		Modifier: c.Modifier,
+	}, nil
+}
+
+func (c encodedCriterion) hierarchicalInput() (*models.HierarchicalMultiCriterionInput, error) {
+	var v struct {
+		Items []labeledID `json:"items"`
+		Depth *int        `json:"depth"`
+	}
+
+	if len(c.Value) > 0 {
+		// older filters encode the value as a list of items
+		if err := json.Unmarshal(c.Value, &v.Items); err != nil {
+			if err := json.Unmarshal(c.Value, &v); err != nil {
+				return nil, fmt.Errorf("invalid value: %w", err)
+			}
+		}
+	}
+
+	return &models.HierarchicalMultiCriterionInput{
+		Value:    labeledIDs(v.Items),
+		Modifier: c.Modifier,
+		Depth:    v.Depth,
+	}, nil
+}
+
+func (c encodedCriterion) boolInput() (bool, error) {
+	v, err := c.stringValue()
+	if err != nil {
+		return false, err
+	}
+
+	return v == "true", nil
+}
+
+func (c encodedCriterion) resolutionInput() (*models.ResolutionCriterionInput, error) {
+	v, err := c.stringValue()
+	if err != nil {
+		return nil, err
+	}
+
+	resolution, found := resolutionStrings[v]
+	if !found {
+		return nil, fmt.Errorf("invalid resolution %q", v)
+	}
+
+	return &models.ResolutionCriterionInput{
+		Value:    resolution,
+		Modifier: c.Modifier,
+	}, nil
+}
+
+func (c encodedCriterion) genderInput() (*models.GenderCriterionInput, error) {
+	v, err := c.stringValue()
+	if err != nil {
+		return nil, err
+	}
+
+	gender, found := genderStrings[v]
+	if !found {
+		gender = models.GenderEnum(v)
+		if !gender.IsValid() {
+			return nil, fmt.Errorf("invalid gender %q", v)
+		}
+	}
+
+	return &models.GenderCriterionInput{
+		Value:    &gender,
+		Modifier: c.Modifier,
+	}, nil
+}
+
+func (c encodedCriterion) duplicatedInput() (*models.PHashDuplicationCriterionInput, error) {
+	v, err := c.boolInput()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PHashDuplicationCriterionInput{
+		Duplicated: &v,
+	}, nil
+}
+
+func (c encodedCriterion) fileStatusInput() (*models.FileStatusCriterionInput, error) {
+	v, err := c.stringValue()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.FileStatusCriterionInput{
+		Modifier: c.Modifier,
+	}
+
+	// the value is empty for the IS_NULL and NOT_NULL modifiers
+	if v != "" {
+		status := models.FileStatusEnum(v)
+		if !status.IsValid() {
+			return nil, fmt.Errorf("invalid file status %q", v)
+		}
+		ret.Value = &status
+	}
+
+	return ret, nil
+}
+
+func (c encodedCriterion) timestampInput() (*models.TimestampCriterionInput, error) {
+	ret := &models.TimestampCriterionInput{
+		Modifier: c.Modifier,
+	}
+
+	if len(c.Value) == 0 {
+		return ret, nil
+	}
+
+	// a single value may be encoded as a plain string
+	if err := json.Unmarshal(c.Value, &ret.Value); err == nil {
+		return ret, nil
+	}
+
+	var v struct {
+		Value  string  `json:"value"`
+		Value2 *string `json:"value2"`
+	}
+	if err := json.Unmarshal(c.Value, &v); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+
+	ret.Value = v.Value
+	ret.Value2 = v.Value2
+
+	return ret, nil
+}
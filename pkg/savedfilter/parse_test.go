@@ -0,0 +1,300 @@
+package savedfilter
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneFilter(t *testing.T) {
+	const savedFilterString = `{
+		"perPage": 40,
+		"sortby": "date",
+		"sortdir": "desc",
+		"q": " search ",
+		"c": [
+			"{\"type\":\"rating\",\"value\":{\"value\":3},\"modifier\":\"GREATER_THAN\"}",
+			"{\"type\":\"duration\",\"value\":{\"value\":60,\"value2\":120},\"modifier\":\"BETWEEN\"}",
+			"{\"type\":\"title\",\"value\":\"a %26 b\",\"modifier\":\"INCLUDES\"}",
+			"{\"type\":\"tags\",\"value\":{\"items\":[{\"id\":\"1\",\"label\":\"one\"},{\"id\":\"2\",\"label\":\"two\"}],\"depth\":-1},\"modifier\":\"INCLUDES_ALL\"}",
+			"{\"type\":\"performers\",\"value\":[{\"id\":\"3\",\"label\":\"three\"}],\"modifier\":\"INCLUDES\"}",
+			"{\"type\":\"organized\",\"value\":\"true\",\"modifier\":\"EQUALS\"}",
+			"{\"type\":\"resolution\",\"value\":\"1080p\",\"modifier\":\"GREATER_THAN\"}",
+			"{\"type\":\"sceneIsMissing\",\"value\":\"studio\",\"modifier\":\"EQUALS\"}",
+			"{\"type\":\"video_codec\",\"value\":\"hevc\",\"modifier\":\"EQUALS\"}",
+			"{\"type\":\"bitrate\",\"value\":{\"value\":8000000},\"modifier\":\"GREATER_THAN\"}",
+			"{\"type\":\"framerate\",\"value\":{\"value\":29.97,\"value2\":60},\"modifier\":\"BETWEEN\"}",
+			"{\"type\":\"created_at\",\"value\":{\"value\":\"2021-01-01\"},\"modifier\":\"GREATER_THAN\"}"
+		]
+	}`
+
+	sceneFilter, findFilter, err := SceneFilter(&models.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Filter: savedFilterString,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	perPage := 40
+	sort := "date"
+	direction := models.SortDirectionEnumDesc
+	q := "search"
+	assert.Equal(t, &models.FindFilterType{
+		PerPage:   &perPage,
+		Sort:      &sort,
+		Direction: &direction,
+		Q:         &q,
+	}, findFilter)
+
+	value2 := 120
+	depth := -1
+	organized := true
+	isMissing := "studio"
+	framerate2 := 60.0
+	assert.Equal(t, &models.SceneFilterType{
+		Rating: &models.IntCriterionInput{
+			Value:    3,
+			Modifier: models.CriterionModifierGreaterThan,
+		},
+		Duration: &models.IntCriterionInput{
+			Value:    60,
+			Value2:   &value2,
+			Modifier: models.CriterionModifierBetween,
+		},
+		Title: &models.StringCriterionInput{
+			Value:    "a & b",
+			Modifier: models.CriterionModifierIncludes,
+		},
+		Tags: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{"1", "2"},
+			Modifier: models.CriterionModifierIncludesAll,
+			Depth:    &depth,
+		},
+		Performers: &models.MultiCriterionInput{
+			Value:    []string{"3"},
+			Modifier: models.CriterionModifierIncludes,
+		},
+		Organized: &organized,
+		Resolution: &models.ResolutionCriterionInput{
+			Value:    models.ResolutionEnumFullHd,
+			Modifier: models.CriterionModifierGreaterThan,
+		},
+		IsMissing: &isMissing,
+		VideoCodec: &models.StringCriterionInput{
+			Value:    "hevc",
+			Modifier: models.CriterionModifierEquals,
+		},
+		Bitrate: &models.IntCriterionInput{
+			Value:    8000000,
+			Modifier: models.CriterionModifierGreaterThan,
+		},
+		Framerate: &models.FloatCriterionInput{
+			Value:    29.97,
+			Value2:   &framerate2,
+			Modifier: models.CriterionModifierBetween,
+		},
+		CreatedAt: &models.TimestampCriterionInput{
+			Value:    "2021-01-01",
+			Modifier: models.CriterionModifierGreaterThan,
+		},
+	}, sceneFilter)
+}
+
+func TestSceneFilterLegacyValues(t *testing.T) {
+	const savedFilterString = `{
+		"c": "{\"type\":\"tags\",\"value\":[{\"id\":\"1\",\"label\":\"one\"}],\"modifier\":\"INCLUDES\"}"
+	}`
+
+	sceneFilter, findFilter, err := SceneFilter(&models.SavedFilter{
+		Mode:   models.FilterModeScenes,
+		Filter: savedFilterString,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Nil(t, findFilter.Sort)
+	assert.Equal(t, models.SortDirectionEnumAsc, *findFilter.Direction)
+	assert.Equal(t, &models.HierarchicalMultiCriterionInput{
+		Value:    []string{"1"},
+		Modifier: models.CriterionModifierIncludes,
+	}, sceneFilter.Tags)
+}
+
+func TestPerformerFilter(t *testing.T) {
+	const savedFilterString = `{
+		"c": [
+			"{\"type\":\"gender\",\"value\":\"Transgender Female\",\"modifier\":\"EQUALS\"}",
+			"{\"type\":\"favorite\",\"value\":\"false\",\"modifier\":\"EQUALS\"}"
+		]
+	}`
+
+	performerFilter, _, err := PerformerFilter(&models.SavedFilter{
+		Mode:   models.FilterModePerformers,
+		Filter: savedFilterString,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	gender := models.GenderEnumTransgenderFemale
+	favorite := false
+	assert.Equal(t, &models.PerformerFilterType{
+		Gender: &models.GenderCriterionInput{
+			Value:    &gender,
+			Modifier: models.CriterionModifierEquals,
+		},
+		FilterFavorites: &favorite,
+	}, performerFilter)
+}
+
+func TestImageFilter(t *testing.T) {
+	const savedFilterString = `{
+		"c": [
+			"{\"type\":\"is_animated\",\"value\":\"true\",\"modifier\":\"EQUALS\"}",
+			"{\"type\":\"camera_make\",\"value\":\"Canon\",\"modifier\":\"EQUALS\"}",
+			"{\"type\":\"lens\",\"value\":\"50mm\",\"modifier\":\"INCLUDES\"}",
+			"{\"type\":\"date\",\"value\":{\"value\":\"2001-01-01\",\"value2\":\"2001-12-31\"},\"modifier\":\"BETWEEN\"}",
+			"{\"type\":\"file_status\",\"value\":\"CORRUPT\",\"modifier\":\"EQUALS\"}",
+			"{\"type\":\"height\",\"value\":{\"value\":1080},\"modifier\":\"GREATER_THAN\"}",
+			"{\"type\":\"size\",\"value\":{\"value\":1048576},\"modifier\":\"LESS_THAN\"}"
+		]
+	}`
+
+	imageFilter, _, err := ImageFilter(&models.SavedFilter{
+		Mode:   models.FilterModeImages,
+		Filter: savedFilterString,
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	isAnimated := true
+	value2 := "2001-12-31"
+	status := models.FileStatusEnumCorrupt
+	assert.Equal(t, &models.ImageFilterType{
+		IsAnimated: &isAnimated,
+		CameraMake: &models.StringCriterionInput{
+			Value:    "Canon",
+			Modifier: models.CriterionModifierEquals,
+		},
+		Lens: &models.StringCriterionInput{
+			Value:    "50mm",
+			Modifier: models.CriterionModifierIncludes,
+		},
+		Date: &models.TimestampCriterionInput{
+			Value:    "2001-01-01",
+			Value2:   &value2,
+			Modifier: models.CriterionModifierBetween,
+		},
+		FileStatus: &models.FileStatusCriterionInput{
+			Value:    &status,
+			Modifier: models.CriterionModifierEquals,
+		},
+		Height: &models.IntCriterionInput{
+			Value:    1080,
+			Modifier: models.CriterionModifierGreaterThan,
+		},
+		Size: &models.FloatCriterionInput{
+			Value:    1048576,
+			Modifier: models.CriterionModifierLessThan,
+		},
+	}, imageFilter)
+}
+
+// filter fields that the UI has no criterion for
+var unsupportedFilterFields = []string{
+	"is_zip",
+	"parent_folder",
+}
+
+func TestCriterionOptionsCoverFilterFields(t *testing.T) {
+	parameterNames := make(map[string]bool)
+	for _, o := range criterionOptions {
+		parameterNames[o.parameterName] = true
+	}
+
+	unsupported := make(map[string]bool)
+	for _, f := range unsupportedFilterFields {
+		unsupported[f] = true
+	}
+
+	filterTypes := []interface{}{
+		models.SceneFilterType{},
+		models.ImageFilterType{},
+		models.GalleryFilterType{},
+		models.PerformerFilterType{},
+	}
+
+	for _, filterType := range filterTypes {
+		typ := reflect.TypeOf(filterType)
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+			// sub-filters are not criteria
+			if strings.HasSuffix(field.Type.String(), "FilterType") {
+				continue
+			}
+
+			if !parameterNames[name] && !unsupported[name] {
+				t.Errorf("%s field %s has no criterion", typ.Name(), name)
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   models.FilterMode
+		filter string
+	}{
+		{
+			"wrong mode",
+			models.FilterModeImages,
+			`{}`,
+		},
+		{
+			"invalid json",
+			models.FilterModeScenes,
+			`{`,
+		},
+		{
+			"unsupported criterion",
+			models.FilterModeScenes,
+			`{"c":["{\"type\":\"unknown\",\"value\":\"\",\"modifier\":\"EQUALS\"}"]}`,
+		},
+		{
+			"criterion of another mode",
+			models.FilterModeScenes,
+			`{"c":["{\"type\":\"scene_count\",\"value\":{\"value\":1},\"modifier\":\"EQUALS\"}"]}`,
+		},
+		{
+			"invalid resolution",
+			models.FilterModeScenes,
+			`{"c":["{\"type\":\"resolution\",\"value\":\"huge\",\"modifier\":\"EQUALS\"}"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := SceneFilter(&models.SavedFilter{
+				Mode:   tt.mode,
+				Filter: tt.filter,
+			})
+			assert.NotNil(t, err)
+		})
+	}
+
+	_, _, err := SceneFilter(&models.SavedFilter{
+		Mode:   models.FilterModeImages,
+		Filter: `{}`,
+	})
+	assert.True(t, errors.Is(err, ErrWrongMode))
+}
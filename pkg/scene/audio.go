@@ -0,0 +1,61 @@
+package scene
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// GetAudioStreams returns the audio streams of the video file.
+func GetAudioStreams(videoFile *ffmpeg.VideoFile) []models.SceneAudioStream {
+	defaultStream := videoFile.DefaultAudioStream()
+
+	var ret []models.SceneAudioStream
+	for _, s := range videoFile.AudioStreams {
+		language := strings.ToLower(s.Tags.Language)
+		if language == undeterminedLanguage {
+			language = ""
+		}
+
+		ret = append(ret, models.SceneAudioStream{
+			StreamIndex:  s.Index,
+			Codec:        s.CodecName,
+			LanguageCode: language,
+			Channels:     s.Channels,
+			Title:        s.Tags.Title,
+			Default:      s == defaultStream,
+		})
+	}
+
+	return ret
+}
+
+// audioStreamsMissing returns true if the scene has audio but no audio
+// streams recorded. This is the case for scenes scanned before audio streams
+// were recorded.
+func (scanner *Scanner) audioStreamsMissing(s *models.Scene) (bool, error) {
+	if s.AudioCodec.String == "" {
+		return false, nil
+	}
+
+	var streams []*models.SceneAudioStream
+	if err := scanner.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		streams, err = r.Scene().GetAudioStreams(s.ID)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	return len(streams) == 0, nil
+}
+
+// updateAudioStreams replaces the audio streams of the scene with the audio
+// streams of the video file.
+func (scanner *Scanner) updateAudioStreams(sceneID int, videoFile *ffmpeg.VideoFile) error {
+	return scanner.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		return r.Scene().UpdateAudioStreams(sceneID, GetAudioStreams(videoFile))
+	})
+}
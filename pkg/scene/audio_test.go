@@ -0,0 +1,48 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAudioStreams(t *testing.T) {
+	videoFile := &ffmpeg.VideoFile{}
+
+	streams := []ffmpeg.FFProbeStream{
+		{Index: 1, CodecName: "aac", Channels: 2},
+		{Index: 2, CodecName: "ac3", Channels: 6},
+	}
+	streams[0].Tags.Language = "und"
+	streams[1].Tags.Language = "ENG"
+	streams[1].Tags.Title = "Surround"
+	streams[1].Disposition.Default = 1
+
+	for i := range streams {
+		videoFile.AudioStreams = append(videoFile.AudioStreams, &streams[i])
+	}
+
+	assert.Equal(t, []models.SceneAudioStream{
+		{
+			StreamIndex: 1,
+			Codec:       "aac",
+			Channels:    2,
+		},
+		{
+			StreamIndex:  2,
+			Codec:        "ac3",
+			LanguageCode: "eng",
+			Channels:     6,
+			Title:        "Surround",
+			Default:      true,
+		},
+	}, GetAudioStreams(videoFile))
+
+	// the first stream is the default if no stream has the default disposition
+	streams[1].Disposition.Default = 0
+	got := GetAudioStreams(videoFile)
+	assert.True(t, got[0].Default)
+	assert.False(t, got[1].Default)
+}
@@ -0,0 +1,177 @@
+package scene
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// captionFileTypes maps the extensions of caption files to their caption
+// types.
+var captionFileTypes = map[string]string{
+	".srt": models.CaptionTypeSRT,
+	".vtt": models.CaptionTypeVTT,
+	".ass": models.CaptionTypeASS,
+	".ssa": models.CaptionTypeASS,
+}
+
+// languageCodeRE matches ISO 639 language codes, optionally followed by a
+// region, such as en, eng or pt-BR.
+var languageCodeRE = regexp.MustCompile(`^[a-z]{2,3}([-_][a-z0-9]{2,4})?$`)
+
+// undeterminedLanguage is the language code used by ffprobe for streams of
+// unknown language.
+const undeterminedLanguage = "und"
+
+// GetCaptionFiles returns the captions of the caption files next to the
+// video file. A caption file has the same basename as the video file,
+// optionally followed by a language code. For example, the caption files of
+// video.mp4 may be named video.srt or video.en.srt.
+func GetCaptionFiles(videoPath string) []models.SceneCaption {
+	dir := filepath.Dir(videoPath)
+	base := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logger.Warnf("error reading directory %s: %v", dir, err)
+		return nil
+	}
+
+	var ret []models.SceneCaption
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		name := e.Name()
+		ext := filepath.Ext(name)
+		captionType, found := captionFileTypes[strings.ToLower(ext)]
+		if !found {
+			continue
+		}
+
+		stem := strings.TrimSuffix(name, ext)
+		language := ""
+		if stem != base {
+			if !strings.HasPrefix(stem, base+".") {
+				continue
+			}
+
+			language = strings.ToLower(strings.TrimPrefix(stem, base+"."))
+			if !languageCodeRE.MatchString(language) {
+				continue
+			}
+		}
+
+		ret = append(ret, models.SceneCaption{
+			LanguageCode: language,
+			CaptionType:  captionType,
+			Source:       models.CaptionSourceFile,
+			Path:         models.NullString(filepath.Join(dir, name)),
+		})
+	}
+
+	return ret
+}
+
+// CaptionPath returns the path of the caption file of the video file at
+// videoPath for the video file at newVideoPath. The language code of the
+// caption file is retained, so that video.en.srt becomes title.en.srt for
+// title.mp4.
+func CaptionPath(captionPath string, videoPath string, newVideoPath string) string {
+	base := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath))
+	newBase := strings.TrimSuffix(newVideoPath, filepath.Ext(newVideoPath))
+	return newBase + strings.TrimPrefix(filepath.Base(captionPath), base)
+}
+
+// GetEmbeddedCaptions returns the captions of the text-based subtitle
+// streams of the video file.
+func GetEmbeddedCaptions(videoFile *ffmpeg.VideoFile) []models.SceneCaption {
+	var ret []models.SceneCaption
+	for _, s := range videoFile.SubtitleStreams {
+		captionType := ffmpeg.SubtitleCaptionType(s.CodecName)
+		if captionType == "" {
+			continue
+		}
+
+		language := strings.ToLower(s.Tags.Language)
+		if language == undeterminedLanguage {
+			language = ""
+		}
+
+		ret = append(ret, models.SceneCaption{
+			LanguageCode: language,
+			CaptionType:  captionType,
+			Source:       models.CaptionSourceEmbedded,
+			StreamIndex:  sql.NullInt64{Int64: int64(s.Index), Valid: true},
+		})
+	}
+
+	return ret
+}
+
+// captionsChanged returns true if the captions are different from the
+// existing captions.
+func captionsChanged(existing []*models.SceneCaption, captions []models.SceneCaption) bool {
+	if len(existing) != len(captions) {
+		return true
+	}
+
+	for _, c := range captions {
+		found := false
+		for _, e := range existing {
+			if e.Equal(c) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateCaptions updates the captions of the scene with the caption files
+// next to the video file. If videoFile is not nil, the embedded captions
+// are updated from its subtitle streams. Otherwise, the existing embedded
+// captions are retained.
+func (scanner *Scanner) updateCaptions(sceneID int, path string, videoFile *ffmpeg.VideoFile) error {
+	var existing []*models.SceneCaption
+	if err := scanner.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		existing, err = r.Scene().GetCaptions(sceneID)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	captions := GetCaptionFiles(path)
+	if videoFile != nil {
+		captions = append(captions, GetEmbeddedCaptions(videoFile)...)
+	} else {
+		for _, c := range existing {
+			if c.Source == models.CaptionSourceEmbedded {
+				captions = append(captions, *c)
+			}
+		}
+	}
+
+	if !captionsChanged(existing, captions) {
+		return nil
+	}
+
+	logger.Infof("Updating captions of %s", path)
+	return scanner.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		return r.Scene().UpdateCaptions(sceneID, captions)
+	})
+}
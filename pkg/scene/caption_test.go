@@ -0,0 +1,125 @@
+package scene
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCaptionFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{
+		"video.mp4",
+		"video.srt",
+		"video.en.srt",
+		"video.pt-BR.VTT",
+		"video.ssa",
+		"video.part2.srt",
+		"video.en.txt",
+		"other.en.srt",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte{}, 0644); err != nil {
+			t.Fatalf("error creating file: %v", err)
+		}
+	}
+
+	got := GetCaptionFiles(filepath.Join(dir, "video.mp4"))
+
+	assert.ElementsMatch(t, []models.SceneCaption{
+		{
+			LanguageCode: "",
+			CaptionType:  models.CaptionTypeSRT,
+			Source:       models.CaptionSourceFile,
+			Path:         models.NullString(filepath.Join(dir, "video.srt")),
+		},
+		{
+			LanguageCode: "en",
+			CaptionType:  models.CaptionTypeSRT,
+			Source:       models.CaptionSourceFile,
+			Path:         models.NullString(filepath.Join(dir, "video.en.srt")),
+		},
+		{
+			LanguageCode: "pt-br",
+			CaptionType:  models.CaptionTypeVTT,
+			Source:       models.CaptionSourceFile,
+			Path:         models.NullString(filepath.Join(dir, "video.pt-BR.VTT")),
+		},
+		{
+			LanguageCode: "",
+			CaptionType:  models.CaptionTypeASS,
+			Source:       models.CaptionSourceFile,
+			Path:         models.NullString(filepath.Join(dir, "video.ssa")),
+		},
+	}, got)
+}
+
+func TestGetEmbeddedCaptions(t *testing.T) {
+	videoFile := &ffmpeg.VideoFile{}
+
+	streams := []ffmpeg.FFProbeStream{
+		{Index: 2, CodecName: "subrip"},
+		{Index: 3, CodecName: "ass"},
+		{Index: 4, CodecName: "hdmv_pgs_subtitle"},
+		{Index: 5, CodecName: "mov_text"},
+	}
+	streams[0].Tags.Language = "eng"
+	streams[1].Tags.Language = "und"
+	streams[2].Tags.Language = "eng"
+
+	for i := range streams {
+		videoFile.SubtitleStreams = append(videoFile.SubtitleStreams, &streams[i])
+	}
+
+	got := GetEmbeddedCaptions(videoFile)
+
+	assert.Equal(t, []models.SceneCaption{
+		{
+			LanguageCode: "eng",
+			CaptionType:  models.CaptionTypeSRT,
+			Source:       models.CaptionSourceEmbedded,
+			StreamIndex:  sql.NullInt64{Int64: 2, Valid: true},
+		},
+		{
+			LanguageCode: "",
+			CaptionType:  models.CaptionTypeASS,
+			Source:       models.CaptionSourceEmbedded,
+			StreamIndex:  sql.NullInt64{Int64: 3, Valid: true},
+		},
+		{
+			LanguageCode: "",
+			CaptionType:  "mov_text",
+			Source:       models.CaptionSourceEmbedded,
+			StreamIndex:  sql.NullInt64{Int64: 5, Valid: true},
+		},
+	}, got)
+}
+
+func TestCaptionsChanged(t *testing.T) {
+	srt := models.SceneCaption{
+		LanguageCode: "en",
+		CaptionType:  models.CaptionTypeSRT,
+		Source:       models.CaptionSourceFile,
+		Path:         models.NullString("video.en.srt"),
+	}
+	embedded := models.SceneCaption{
+		CaptionType: models.CaptionTypeSRT,
+		Source:      models.CaptionSourceEmbedded,
+		StreamIndex: sql.NullInt64{Int64: 2, Valid: true},
+	}
+
+	existingSRT := srt
+	existingSRT.ID = 1
+	existingSRT.SceneID = 2
+
+	assert.False(t, captionsChanged(nil, nil))
+	assert.False(t, captionsChanged([]*models.SceneCaption{&existingSRT}, []models.SceneCaption{srt}))
+	assert.True(t, captionsChanged([]*models.SceneCaption{&existingSRT}, []models.SceneCaption{srt, embedded}))
+	assert.True(t, captionsChanged([]*models.SceneCaption{&existingSRT}, []models.SceneCaption{embedded}))
+	assert.True(t, captionsChanged([]*models.SceneCaption{&existingSRT}, nil))
+}
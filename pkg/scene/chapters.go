@@ -0,0 +1,107 @@
+package scene
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// existingMarkerTolerance is the distance in seconds within which a
+// detected chapter is considered to duplicate an existing marker.
+const existingMarkerTolerance = 5
+
+// Chapter is a section of a video between two shot changes.
+type Chapter struct {
+	Start float64
+	End   float64
+}
+
+type sceneCutDetector interface {
+	SceneCuts(probeResult ffmpeg.VideoFile, threshold float64) ([]float64, error)
+}
+
+// DetectChapters returns the chapters of the video, split at the shot changes
+// with the provided threshold. See ChaptersFromCuts.
+func DetectChapters(encoder sceneCutDetector, probeResult ffmpeg.VideoFile, threshold float64, minLength float64) ([]Chapter, error) {
+	cuts, err := encoder.SceneCuts(probeResult, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return ChaptersFromCuts(cuts, probeResult.Duration, minLength), nil
+}
+
+// ChaptersFromCuts clusters the shot changes of a video of the provided
+// duration into chapters. Shot changes closer than minLength seconds to the
+// start of the current chapter or to the end of the video are merged into the
+// current chapter. Returns nil if the video consists of a single chapter.
+func ChaptersFromCuts(cuts []float64, duration float64, minLength float64) []Chapter {
+	var ret []Chapter
+	start := 0.0
+	for _, cut := range cuts {
+		if cut-start < minLength || duration-cut < minLength {
+			continue
+		}
+
+		ret = append(ret, Chapter{Start: start, End: cut})
+		start = cut
+	}
+
+	if len(ret) == 0 {
+		return nil
+	}
+
+	return append(ret, Chapter{Start: start, End: duration})
+}
+
+// ChapterSuggestions returns marker suggestions for the chapters, excluding
+// chapters that start near existing markers or near the start times of
+// rejected suggestions.
+func ChapterSuggestions(chapters []Chapter, existing []*models.SceneMarker, rejected []float64, primaryTagID *int) []models.SceneMarkerSuggestion {
+	var ret []models.SceneMarkerSuggestion
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+
+	for i, c := range chapters {
+		if hasMarkerNear(existing, c.Start) || isNear(rejected, c.Start) {
+			continue
+		}
+
+		s := models.SceneMarkerSuggestion{
+			Title:      fmt.Sprintf("Chapter %d", i+1),
+			Seconds:    c.Start,
+			EndSeconds: c.End,
+			CreatedAt:  now,
+		}
+		if primaryTagID != nil {
+			s.PrimaryTagID.Int64 = int64(*primaryTagID)
+			s.PrimaryTagID.Valid = true
+		}
+
+		ret = append(ret, s)
+	}
+
+	return ret
+}
+
+func hasMarkerNear(markers []*models.SceneMarker, seconds float64) bool {
+	for _, m := range markers {
+		if math.Abs(m.Seconds-seconds) <= existingMarkerTolerance {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNear(times []float64, seconds float64) bool {
+	for _, t := range times {
+		if math.Abs(t-seconds) <= existingMarkerTolerance {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaptersFromCuts(t *testing.T) {
+	tests := []struct {
+		name      string
+		cuts      []float64
+		duration  float64
+		minLength float64
+		want      []Chapter
+	}{
+		{
+			"no cuts",
+			nil,
+			600,
+			60,
+			nil,
+		},
+		{
+			"single chapter",
+			[]float64{10, 590},
+			600,
+			60,
+			nil,
+		},
+		{
+			"chapters",
+			[]float64{200, 400},
+			600,
+			60,
+			[]Chapter{{0, 200}, {200, 400}, {400, 600}},
+		},
+		{
+			"short chapters merged",
+			[]float64{30, 100, 130, 250, 570},
+			600,
+			60,
+			[]Chapter{{0, 100}, {100, 250}, {250, 600}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ChaptersFromCuts(tt.cuts, tt.duration, tt.minLength))
+		})
+	}
+}
+
+func TestChapterSuggestions(t *testing.T) {
+	chapters := []Chapter{{0, 200}, {200, 400}, {400, 600}}
+	existing := []*models.SceneMarker{
+		{Seconds: 203},
+	}
+
+	primaryTagID := 5
+	got := ChapterSuggestions(chapters, existing, nil, &primaryTagID)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "Chapter 1", got[0].Title)
+		assert.Equal(t, 0.0, got[0].Seconds)
+		assert.Equal(t, 200.0, got[0].EndSeconds)
+		assert.Equal(t, int64(primaryTagID), got[0].PrimaryTagID.Int64)
+		assert.True(t, got[0].PrimaryTagID.Valid)
+
+		// chapter 2 is excluded as it starts near an existing marker
+		assert.Equal(t, "Chapter 3", got[1].Title)
+		assert.Equal(t, 400.0, got[1].Seconds)
+	}
+
+	got = ChapterSuggestions(chapters, nil, nil, nil)
+	if assert.Len(t, got, 3) {
+		assert.False(t, got[0].PrimaryTagID.Valid)
+	}
+
+	// rejected suggestions are not suggested again
+	got = ChapterSuggestions(chapters, nil, []float64{1, 398}, nil)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "Chapter 2", got[0].Title)
+	}
+}
@@ -0,0 +1,248 @@
+package scene
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"math"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/logger"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// DefaultCoverCandidates is the default number of cover candidates.
+	DefaultCoverCandidates = 8
+	// MaxCoverCandidates is the maximum number of cover candidates.
+	MaxCoverCandidates = 24
+
+	// coverCandidateWidth is the width of the frames that are scored.
+	coverCandidateWidth = 320
+	coverJPEGQuality    = 90
+
+	// frames darker than this are considered black
+	blackBrightness = 0.08
+	// frames with less contrast than this are considered part of a fade
+	fadeContrast = 0.05
+	// frames with a smaller laplacian variance than this are considered blurry
+	blurrySharpness = 50
+	// laplacian variance at which frames are considered fully sharp
+	sharpSharpness = 500
+)
+
+type frameExtractor interface {
+	SpriteScreenshot(probeResult ffmpeg.VideoFile, options ffmpeg.SpriteScreenshotOptions) (image.Image, error)
+}
+
+// FrameScore is the suitability of a video frame as a cover image.
+type FrameScore struct {
+	// Brightness is the mean luminance, between 0 and 1.
+	Brightness float64
+	// Contrast is the standard deviation of the luminance, between 0 and 1.
+	Contrast float64
+	// Sharpness is the variance of the laplacian of the luminance.
+	Sharpness float64
+	// Score is the overall suitability, between 0 and 1.
+	Score float64
+}
+
+// IsBlack returns true if the frame is black or part of a fade.
+func (s FrameScore) IsBlack() bool {
+	return s.Brightness < blackBrightness || s.Contrast < fadeContrast
+}
+
+// IsBlurry returns true if the frame lacks detail.
+func (s FrameScore) IsBlurry() bool {
+	return s.Sharpness < blurrySharpness
+}
+
+// IsPoor returns true if the frame is black, fading or blurry.
+func (s FrameScore) IsPoor() bool {
+	return s.IsBlack() || s.IsBlurry()
+}
+
+// ScoreFrame returns the suitability of the image as a cover image.
+func ScoreFrame(img image.Image) FrameScore {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return FrameScore{}
+	}
+
+	luma := make([]float64, w*h)
+	var sum float64
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			// rec. 601 luma, scaled to 0-255
+			l := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+			luma[y*w+x] = l
+			sum += l
+		}
+	}
+
+	n := float64(w * h)
+	mean := sum / n
+
+	var variance float64
+	for _, l := range luma {
+		variance += (l - mean) * (l - mean)
+	}
+	variance /= n
+
+	ret := FrameScore{
+		Brightness: mean / 255,
+		Contrast:   math.Sqrt(variance) / 255,
+		Sharpness:  laplacianVariance(luma, w, h),
+	}
+
+	sharpness := math.Min(ret.Sharpness/sharpSharpness, 1)
+	contrast := math.Min(ret.Contrast/0.25, 1)
+	exposure := 1 - math.Min(math.Abs(ret.Brightness-0.5)*2, 1)
+	ret.Score = 0.5*sharpness + 0.3*contrast + 0.2*exposure
+
+	return ret
+}
+
+// laplacianVariance returns the variance of the 4-neighbour laplacian of the
+// luminance values. Blurry images have a low variance.
+func laplacianVariance(luma []float64, w, h int) float64 {
+	if w < 3 || h < 3 {
+		return 0
+	}
+
+	var sum, sumSq float64
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			v := luma[i-w] + luma[i+w] + luma[i-1] + luma[i+1] - 4*luma[i]
+			sum += v
+			sumSq += v * v
+		}
+	}
+
+	n := float64((w - 2) * (h - 2))
+	mean := sum / n
+	return sumSq/n - mean*mean
+}
+
+// CoverCandidate is a video frame that may be used as a cover image.
+type CoverCandidate struct {
+	FrameScore
+	// At is the time of the frame in seconds.
+	At    float64
+	Image image.Image
+}
+
+// JPEG returns the JPEG encoded candidate frame.
+func (c CoverCandidate) JPEG() ([]byte, error) {
+	return encodeJPEG(c.Image)
+}
+
+// CoverCandidateTimes returns count evenly spaced times in a video of the
+// provided duration, excluding the start and end of the video.
+func CoverCandidateTimes(duration float64, count int) []float64 {
+	var ret []float64
+	for i := 0; i < count; i++ {
+		ret = append(ret, duration*float64(i+1)/float64(count+1))
+	}
+
+	return ret
+}
+
+// GetCoverCandidates extracts and scores count evenly spaced frames of the
+// video. Frames that cannot be extracted are skipped.
+func GetCoverCandidates(encoder frameExtractor, probeResult ffmpeg.VideoFile, count int) ([]CoverCandidate, error) {
+	if count <= 0 {
+		count = DefaultCoverCandidates
+	}
+	if count > MaxCoverCandidates {
+		count = MaxCoverCandidates
+	}
+
+	var ret []CoverCandidate
+	for _, at := range CoverCandidateTimes(probeResult.Duration, count) {
+		img, err := encoder.SpriteScreenshot(probeResult, ffmpeg.SpriteScreenshotOptions{
+			Time:  at,
+			Width: coverCandidateWidth,
+		})
+		if err != nil {
+			logger.Warnf("[cover] error extracting frame at %v of %s: %v", at, probeResult.Path, err)
+			continue
+		}
+
+		ret = append(ret, CoverCandidate{
+			FrameScore: ScoreFrame(img),
+			At:         at,
+			Image:      img,
+		})
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.New("no frames could be extracted")
+	}
+
+	return ret, nil
+}
+
+// BestCoverCandidate returns the highest scoring candidate that is not
+// black, fading or blurry. Returns nil if all candidates are poor.
+func BestCoverCandidate(candidates []CoverCandidate) *CoverCandidate {
+	var ret *CoverCandidate
+	for i := range candidates {
+		c := &candidates[i]
+		if c.IsPoor() {
+			continue
+		}
+
+		if ret == nil || c.Score > ret.Score {
+			ret = c
+		}
+	}
+
+	return ret
+}
+
+// GetFrameCover returns the JPEG encoded frame of the video at the provided
+// time, at the full video width.
+func GetFrameCover(encoder frameExtractor, probeResult ffmpeg.VideoFile, at float64) ([]byte, error) {
+	img, err := encoder.SpriteScreenshot(probeResult, ffmpeg.SpriteScreenshotOptions{
+		Time:  at,
+		Width: probeResult.Width,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeJPEG(img)
+}
+
+// ScoreCover returns the suitability of the encoded cover image.
+func ScoreCover(imageData []byte) (*FrameScore, error) {
+	img, _, err := image.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, err
+	}
+
+	// score at the candidate width so that the sharpness is comparable
+	bounds := img.Bounds()
+	if bounds.Dx() > coverCandidateWidth {
+		height := bounds.Dy() * coverCandidateWidth / bounds.Dx()
+		img = imaging.Resize(img, coverCandidateWidth, height, imaging.Box)
+	}
+
+	ret := ScoreFrame(img)
+	return &ret, nil
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: coverJPEGQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
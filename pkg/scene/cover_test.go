@@ -0,0 +1,145 @@
+package scene
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stretchr/testify/assert"
+)
+
+func uniformImage(c color.Color) image.Image {
+	img := image.NewGray(image.Rect(0, 0, 64, 36))
+	for y := 0; y < 36; y++ {
+		for x := 0; x < 64; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// checkerImage returns a checkerboard image with squares of the provided
+// size. Larger squares have fewer edges and are less sharp.
+func checkerImage(size int) image.Image {
+	img := image.NewGray(image.Rect(0, 0, 64, 36))
+	for y := 0; y < 36; y++ {
+		for x := 0; x < 64; x++ {
+			if (x/size+y/size)%2 == 0 {
+				img.SetGray(x, y, color.Gray{Y: 30})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 220})
+			}
+		}
+	}
+	return img
+}
+
+// gradientImage returns a smooth horizontal gradient, which has contrast but
+// no detail.
+func gradientImage() image.Image {
+	img := image.NewGray(image.Rect(0, 0, 64, 36))
+	for y := 0; y < 36; y++ {
+		for x := 0; x < 64; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(x * 4)})
+		}
+	}
+	return img
+}
+
+func TestScoreFrame(t *testing.T) {
+	black := ScoreFrame(uniformImage(color.Black))
+	assert.True(t, black.IsBlack())
+	assert.True(t, black.IsPoor())
+	assert.InDelta(t, 0, black.Brightness, 0.001)
+
+	// a uniform frame is part of a fade, regardless of its brightness
+	grey := ScoreFrame(uniformImage(color.Gray{Y: 128}))
+	assert.True(t, grey.IsBlack())
+	assert.InDelta(t, 128.0/255, grey.Brightness, 0.001)
+	assert.InDelta(t, 0, grey.Contrast, 0.001)
+
+	blurry := ScoreFrame(gradientImage())
+	assert.False(t, blurry.IsBlack())
+	assert.True(t, blurry.IsBlurry())
+
+	sharp := ScoreFrame(checkerImage(2))
+	assert.False(t, sharp.IsPoor())
+
+	coarse := ScoreFrame(checkerImage(8))
+	assert.False(t, coarse.IsBlack())
+	assert.Greater(t, sharp.Sharpness, coarse.Sharpness)
+	assert.Greater(t, sharp.Score, blurry.Score)
+	assert.Greater(t, blurry.Score, black.Score)
+
+	for _, s := range []FrameScore{black, grey, blurry, sharp, coarse} {
+		assert.GreaterOrEqual(t, s.Score, 0.0)
+		assert.LessOrEqual(t, s.Score, 1.0)
+	}
+}
+
+func TestCoverCandidateTimes(t *testing.T) {
+	assert.Equal(t, []float64{25, 50, 75}, CoverCandidateTimes(100, 3))
+	assert.Equal(t, []float64{5}, CoverCandidateTimes(10, 1))
+	assert.Nil(t, CoverCandidateTimes(10, 0))
+}
+
+func TestBestCoverCandidate(t *testing.T) {
+	candidates := []CoverCandidate{
+		{At: 1, FrameScore: FrameScore{Brightness: 0.5, Contrast: 0.2, Sharpness: 100, Score: 0.5}},
+		{At: 2, FrameScore: FrameScore{Brightness: 0.5, Contrast: 0.2, Sharpness: 10, Score: 0.9}},
+		{At: 3, FrameScore: FrameScore{Brightness: 0.5, Contrast: 0.2, Sharpness: 200, Score: 0.7}},
+		{At: 4, FrameScore: FrameScore{Brightness: 0.01, Contrast: 0.2, Sharpness: 200, Score: 0.8}},
+	}
+
+	best := BestCoverCandidate(candidates)
+	if assert.NotNil(t, best) {
+		assert.Equal(t, 3.0, best.At)
+	}
+
+	assert.Nil(t, BestCoverCandidate(candidates[1:2]))
+	assert.Nil(t, BestCoverCandidate(nil))
+}
+
+type mockFrameExtractor struct {
+	frames map[float64]image.Image
+}
+
+func (e *mockFrameExtractor) SpriteScreenshot(probeResult ffmpeg.VideoFile, options ffmpeg.SpriteScreenshotOptions) (image.Image, error) {
+	img, ok := e.frames[options.Time]
+	if !ok {
+		return nil, errors.New("frame not found")
+	}
+	return img, nil
+}
+
+func TestGetCoverCandidates(t *testing.T) {
+	probeResult := ffmpeg.VideoFile{
+		Path:     "scene.mp4",
+		Duration: 40,
+	}
+
+	encoder := &mockFrameExtractor{
+		frames: map[float64]image.Image{
+			10: uniformImage(color.Black),
+			20: checkerImage(2),
+		},
+	}
+
+	candidates, err := GetCoverCandidates(encoder, probeResult, 3)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// the frame at 30 seconds could not be extracted
+	if assert.Len(t, candidates, 2) {
+		assert.Equal(t, 10.0, candidates[0].At)
+		assert.True(t, candidates[0].IsPoor())
+		assert.Equal(t, 20.0, candidates[1].At)
+		assert.False(t, candidates[1].IsPoor())
+	}
+
+	_, err = GetCoverCandidates(&mockFrameExtractor{}, probeResult, 3)
+	assert.Error(t, err)
+}
@@ -132,16 +132,30 @@ func Destroy(scene *models.Scene, repo models.Repository, fileDeleter *FileDelet
 	}
 
 	if deleteFile {
-		if err := fileDeleter.Files([]string{scene.Path}); err != nil {
+		files, err := repo.SceneFile().FindBySceneID(scene.ID)
+		if err != nil {
 			return err
 		}
 
-		funscriptPath := utils.GetFunscriptPath(scene.Path)
-		funscriptExists, _ := utils.FileExists(funscriptPath)
-		if funscriptExists {
-			if err := fileDeleter.Files([]string{funscriptPath}); err != nil {
+		paths := []string{scene.Path}
+		for _, f := range files {
+			if f.Path != scene.Path {
+				paths = append(paths, f.Path)
+			}
+		}
+
+		for _, p := range paths {
+			if err := fileDeleter.Files([]string{p}); err != nil {
 				return err
 			}
+
+			funscriptPath := utils.GetFunscriptPath(p)
+			funscriptExists, _ := utils.FileExists(funscriptPath)
+			if funscriptExists {
+				if err := fileDeleter.Files([]string{funscriptPath}); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -81,51 +81,90 @@ func ToBasicJSON(reader models.SceneReader, scene *models.Scene) (*jsonschema.Sc
 }
 
 func getSceneFileJSON(scene *models.Scene) *jsonschema.SceneFile {
+	return getFileJSON(models.NewSceneFile(scene))
+}
+
+func getFileJSON(f *models.SceneFile) *jsonschema.SceneFile {
 	ret := &jsonschema.SceneFile{}
 
-	if scene.FileModTime.Valid {
-		ret.ModTime = models.JSONTime{Time: scene.FileModTime.Timestamp}
+	if f.FileModTime.Valid {
+		ret.ModTime = models.JSONTime{Time: f.FileModTime.Timestamp}
 	}
 
-	if scene.Size.Valid {
-		ret.Size = scene.Size.String
+	if f.Size.Valid {
+		ret.Size = f.Size.String
 	}
 
-	if scene.Duration.Valid {
-		ret.Duration = getDecimalString(scene.Duration.Float64)
+	if f.Duration.Valid {
+		ret.Duration = getDecimalString(f.Duration.Float64)
 	}
 
-	if scene.VideoCodec.Valid {
-		ret.VideoCodec = scene.VideoCodec.String
+	if f.VideoCodec.Valid {
+		ret.VideoCodec = f.VideoCodec.String
 	}
 
-	if scene.AudioCodec.Valid {
-		ret.AudioCodec = scene.AudioCodec.String
+	if f.AudioCodec.Valid {
+		ret.AudioCodec = f.AudioCodec.String
 	}
 
-	if scene.Format.Valid {
-		ret.Format = scene.Format.String
+	if f.Format.Valid {
+		ret.Format = f.Format.String
 	}
 
-	if scene.Width.Valid {
-		ret.Width = int(scene.Width.Int64)
+	if f.Width.Valid {
+		ret.Width = int(f.Width.Int64)
 	}
 
-	if scene.Height.Valid {
-		ret.Height = int(scene.Height.Int64)
+	if f.Height.Valid {
+		ret.Height = int(f.Height.Int64)
 	}
 
-	if scene.Framerate.Valid {
-		ret.Framerate = getDecimalString(scene.Framerate.Float64)
+	if f.Framerate.Valid {
+		ret.Framerate = getDecimalString(f.Framerate.Float64)
 	}
 
-	if scene.Bitrate.Valid {
-		ret.Bitrate = int(scene.Bitrate.Int64)
+	if f.Bitrate.Valid {
+		ret.Bitrate = int(f.Bitrate.Int64)
 	}
 
 	return ret
 }
 
+// GetSceneFilesJSON returns the additional files of the provided scene. The
+// primary file is not included, since it is exported with the scene.
+func GetSceneFilesJSON(reader models.SceneFileReader, scene *models.Scene) ([]jsonschema.SceneFile, error) {
+	files, err := reader.FindBySceneID(scene.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene files: %v", err)
+	}
+
+	var results []jsonschema.SceneFile
+	for _, f := range files {
+		if f.Primary {
+			continue
+		}
+
+		fileJSON := getFileJSON(f)
+		fileJSON.Path = f.Path
+
+		if f.Checksum.Valid {
+			fileJSON.Checksum = f.Checksum.String
+		}
+
+		if f.OSHash.Valid {
+			fileJSON.OSHash = f.OSHash.String
+		}
+
+		if f.Phash.Valid {
+			fileJSON.Phash = utils.PhashToString(f.Phash.Int64)
+		}
+
+		results = append(results, *fileJSON)
+	}
+
+	return results, nil
+}
+
 // GetStudioName returns the name of the provided scene's studio. It returns an
 // empty string if there is no studio assigned to the scene.
 func GetStudioName(reader models.StudioReader, scene *models.Scene) (string, error) {
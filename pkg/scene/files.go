@@ -0,0 +1,116 @@
+package scene
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// SetPrimaryFile makes the file with the provided id the primary file of the
+// scene, and sets the file fields of the scene to those of the file. The
+// scene is updated in place.
+func SetPrimaryFile(qb models.SceneWriter, fqb models.SceneFileReaderWriter, s *models.Scene, fileID int) error {
+	files, err := fqb.FindBySceneID(s.ID)
+	if err != nil {
+		return err
+	}
+
+	var primary *models.SceneFile
+	for _, f := range files {
+		if f.ID == fileID {
+			primary = f
+		}
+	}
+
+	if primary == nil {
+		return fmt.Errorf("file %d is not a file of scene %d", fileID, s.ID)
+	}
+
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+	for _, f := range files {
+		isPrimary := f == primary
+		if f.Primary == isPrimary {
+			continue
+		}
+
+		f.Primary = isPrimary
+		f.UpdatedAt = now
+		if _, err := fqb.UpdateFull(*f); err != nil {
+			return err
+		}
+	}
+
+	s.SetPrimaryFile(*primary)
+	s.Interactive = getInteractive(primary.Path)
+	s.UpdatedAt = now
+
+	_, err = qb.UpdateFull(*s)
+	return err
+}
+
+// ensurePrimaryFile makes the first file of the scene its primary file if
+// the scene has no primary file. Returns the number of files of the scene.
+func ensurePrimaryFile(qb models.SceneWriter, fqb models.SceneFileReaderWriter, s *models.Scene) (int, error) {
+	files, err := fqb.FindBySceneID(s.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	// files are returned primary file first
+	if len(files) == 0 || files[0].Primary {
+		return len(files), nil
+	}
+
+	return len(files), SetPrimaryFile(qb, fqb, s, files[0].ID)
+}
+
+// AssignFile moves the file to the destination scene as a non-primary file.
+// If the file was the primary file of its original scene, another file of
+// that scene becomes its primary file. Returns the original scene, updated
+// in place, and the number of files it has left. The caller is responsible
+// for destroying the original scene if it has no files left.
+func AssignFile(qb models.SceneReaderWriter, fqb models.SceneFileReaderWriter, f *models.SceneFile, destination *models.Scene) (*models.Scene, int, error) {
+	if f.SceneID == destination.ID {
+		return nil, 0, fmt.Errorf("file %s is already a file of scene %d", f.Path, destination.ID)
+	}
+
+	origin, err := qb.Find(f.SceneID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if origin == nil {
+		return nil, 0, fmt.Errorf("scene %d not found", f.SceneID)
+	}
+
+	f.SceneID = destination.ID
+	f.Primary = false
+	f.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+	if _, err := fqb.UpdateFull(*f); err != nil {
+		return nil, 0, err
+	}
+
+	remaining, err := ensurePrimaryFile(qb, fqb, origin)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return origin, remaining, nil
+}
+
+// RemoveFile removes the file from the scene. If the file was the primary
+// file of the scene, another file becomes the primary file. The scene is
+// updated in place. Returns the number of files the scene has left. The
+// caller is responsible for destroying the scene if it has no files left.
+func RemoveFile(qb models.SceneWriter, fqb models.SceneFileReaderWriter, s *models.Scene, f *models.SceneFile) (int, error) {
+	if f.SceneID != s.ID {
+		return 0, fmt.Errorf("file %s is not a file of scene %d", f.Path, s.ID)
+	}
+
+	if err := fqb.Destroy(f.ID); err != nil {
+		return 0, err
+	}
+
+	return ensurePrimaryFile(qb, fqb, s)
+}
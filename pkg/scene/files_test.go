@@ -0,0 +1,104 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssignFile(t *testing.T) {
+	const (
+		originID      = 1
+		destinationID = 2
+		movedFileID   = 11
+		otherFileID   = 12
+		otherFilePath = "otherFilePath"
+	)
+
+	qb := &mocks.SceneReaderWriter{}
+	fqb := &mocks.SceneFileReaderWriter{}
+
+	origin := &models.Scene{
+		ID:   originID,
+		Path: "movedFilePath",
+	}
+	destination := &models.Scene{
+		ID: destinationID,
+	}
+
+	moved := &models.SceneFile{
+		ID:      movedFileID,
+		SceneID: originID,
+		Path:    origin.Path,
+		Primary: true,
+	}
+	other := &models.SceneFile{
+		ID:      otherFileID,
+		SceneID: originID,
+		Path:    otherFilePath,
+	}
+
+	qb.On("Find", originID).Return(origin, nil).Once()
+
+	// moved file is no longer primary
+	fqb.On("UpdateFull", mock.MatchedBy(func(f models.SceneFile) bool {
+		return f.ID == movedFileID && f.SceneID == destinationID && !f.Primary
+	})).Return(moved, nil).Once()
+
+	// the remaining file becomes the primary file of the origin
+	fqb.On("FindBySceneID", originID).Return([]*models.SceneFile{other}, nil)
+	fqb.On("UpdateFull", mock.MatchedBy(func(f models.SceneFile) bool {
+		return f.ID == otherFileID && f.Primary
+	})).Return(other, nil).Once()
+	qb.On("UpdateFull", mock.MatchedBy(func(s models.Scene) bool {
+		return s.ID == originID && s.Path == otherFilePath
+	})).Return(origin, nil).Once()
+
+	gotOrigin, remaining, err := AssignFile(qb, fqb, moved, destination)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, remaining)
+	assert.Equal(t, otherFilePath, gotOrigin.Path)
+
+	// file cannot be assigned to its own scene
+	_, _, err = AssignFile(qb, fqb, moved, destination)
+	assert.NotNil(t, err)
+
+	qb.AssertExpectations(t)
+	fqb.AssertExpectations(t)
+}
+
+func TestRemoveFile(t *testing.T) {
+	const (
+		sceneID = 1
+		fileID  = 11
+	)
+
+	qb := &mocks.SceneReaderWriter{}
+	fqb := &mocks.SceneFileReaderWriter{}
+
+	s := &models.Scene{
+		ID: sceneID,
+	}
+	f := &models.SceneFile{
+		ID:      fileID,
+		SceneID: sceneID,
+		Primary: true,
+	}
+
+	fqb.On("Destroy", fileID).Return(nil).Once()
+	fqb.On("FindBySceneID", sceneID).Return(nil, nil).Once()
+
+	remaining, err := RemoveFile(qb, fqb, s, f)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, remaining)
+
+	// file of another scene
+	_, err = RemoveFile(qb, fqb, &models.Scene{ID: sceneID + 1}, f)
+	assert.NotNil(t, err)
+
+	qb.AssertExpectations(t)
+	fqb.AssertExpectations(t)
+}
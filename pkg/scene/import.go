@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/pkg/manager/jsonschema"
 	"github.com/stashapp/stash/pkg/models"
@@ -13,6 +14,7 @@ import (
 
 type Importer struct {
 	ReaderWriter        models.SceneReaderWriter
+	FileWriter          models.SceneFileReaderWriter
 	StudioWriter        models.StudioReaderWriter
 	GalleryWriter       models.GalleryReaderWriter
 	PerformerWriter     models.PerformerReaderWriter
@@ -392,9 +394,123 @@ func (i *Importer) PostImport(id int) error {
 		}
 	}
 
+	if err := i.syncFiles(id); err != nil {
+		return fmt.Errorf("error setting scene files: %v", err)
+	}
+
+	return nil
+}
+
+// syncFiles creates or updates the primary file and the additional files of
+// the scene. Existing files of the scene that are not in the input are
+// retained as additional files.
+func (i *Importer) syncFiles(id int) error {
+	existing, err := i.FileWriter.FindBySceneID(id)
+	if err != nil {
+		return err
+	}
+
+	primary := models.NewSceneFile(&i.scene)
+	primary.SceneID = id
+
+	files := []*models.SceneFile{primary}
+	for _, fileJSON := range i.Input.Files {
+		f := sceneFileJSONToSceneFile(fileJSON)
+		f.SceneID = id
+		files = append(files, &f)
+	}
+
+	for _, f := range files {
+		if e := findMatchingFile(existing, f); e != nil {
+			f.ID = e.ID
+			f.CreatedAt = e.CreatedAt
+			if _, err := i.FileWriter.UpdateFull(*f); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := i.FileWriter.Create(*f); err != nil {
+			return err
+		}
+	}
+
+	// ensure that the imported primary file is the only primary file
+	for _, e := range existing {
+		if e.Primary && e.ID != primary.ID {
+			e.Primary = false
+			if _, err := i.FileWriter.UpdateFull(*e); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
+// findMatchingFile returns the file with the same path or fingerprint as f.
+func findMatchingFile(files []*models.SceneFile, f *models.SceneFile) *models.SceneFile {
+	for _, e := range files {
+		if e.Path == f.Path ||
+			(f.Checksum.Valid && e.Checksum == f.Checksum) ||
+			(f.OSHash.Valid && e.OSHash == f.OSHash) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func sceneFileJSONToSceneFile(fileJSON jsonschema.SceneFile) models.SceneFile {
+	ret := models.SceneFile{
+		Path:      fileJSON.Path,
+		Checksum:  sql.NullString{String: fileJSON.Checksum, Valid: fileJSON.Checksum != ""},
+		OSHash:    sql.NullString{String: fileJSON.OSHash, Valid: fileJSON.OSHash != ""},
+		CreatedAt: models.SQLiteTimestamp{Timestamp: time.Now()},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: time.Now()},
+	}
+
+	if fileJSON.Phash != "" {
+		hash, err := strconv.ParseUint(fileJSON.Phash, 16, 64)
+		ret.Phash = sql.NullInt64{Int64: int64(hash), Valid: err == nil}
+	}
+
+	if !fileJSON.ModTime.GetTime().IsZero() {
+		ret.FileModTime = models.NullSQLiteTimestamp{Timestamp: fileJSON.ModTime.GetTime(), Valid: true}
+	}
+	if fileJSON.Size != "" {
+		ret.Size = sql.NullString{String: fileJSON.Size, Valid: true}
+	}
+	if fileJSON.Duration != "" {
+		duration, _ := strconv.ParseFloat(fileJSON.Duration, 64)
+		ret.Duration = sql.NullFloat64{Float64: duration, Valid: true}
+	}
+	if fileJSON.VideoCodec != "" {
+		ret.VideoCodec = sql.NullString{String: fileJSON.VideoCodec, Valid: true}
+	}
+	if fileJSON.AudioCodec != "" {
+		ret.AudioCodec = sql.NullString{String: fileJSON.AudioCodec, Valid: true}
+	}
+	if fileJSON.Format != "" {
+		ret.Format = sql.NullString{String: fileJSON.Format, Valid: true}
+	}
+	if fileJSON.Width != 0 {
+		ret.Width = sql.NullInt64{Int64: int64(fileJSON.Width), Valid: true}
+	}
+	if fileJSON.Height != 0 {
+		ret.Height = sql.NullInt64{Int64: int64(fileJSON.Height), Valid: true}
+	}
+	if fileJSON.Framerate != "" {
+		framerate, _ := strconv.ParseFloat(fileJSON.Framerate, 64)
+		ret.Framerate = sql.NullFloat64{Float64: framerate, Valid: true}
+	}
+	if fileJSON.Bitrate != 0 {
+		ret.Bitrate = sql.NullInt64{Int64: int64(fileJSON.Bitrate), Valid: true}
+	}
+
+	return ret
+}
+
 func (i *Importer) Name() string {
 	return i.Path
 }
@@ -492,11 +492,22 @@ func TestImporterPreImportWithMissingTagCreateErr(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+// newImportFileReaderWriter returns a scene file mock that accepts the
+// creation of any scene file.
+func newImportFileReaderWriter() *mocks.SceneFileReaderWriter {
+	fileReaderWriter := &mocks.SceneFileReaderWriter{}
+	fileReaderWriter.On("FindBySceneID", mock.AnythingOfType("int")).Return(nil, nil)
+	fileReaderWriter.On("Create", mock.AnythingOfType("models.SceneFile")).Return(&models.SceneFile{}, nil)
+	return fileReaderWriter
+}
+
 func TestImporterPostImport(t *testing.T) {
 	readerWriter := &mocks.SceneReaderWriter{}
+	fileReaderWriter := newImportFileReaderWriter()
 
 	i := Importer{
 		ReaderWriter:   readerWriter,
+		FileWriter:     fileReaderWriter,
 		coverImageData: imageBytes,
 	}
 
@@ -516,9 +527,11 @@ func TestImporterPostImport(t *testing.T) {
 
 func TestImporterPostImportUpdateGalleries(t *testing.T) {
 	sceneReaderWriter := &mocks.SceneReaderWriter{}
+	fileReaderWriter := newImportFileReaderWriter()
 
 	i := Importer{
 		ReaderWriter: sceneReaderWriter,
+		FileWriter:   fileReaderWriter,
 		galleries: []*models.Gallery{
 			{
 				ID: existingGalleryID,
@@ -542,9 +555,11 @@ func TestImporterPostImportUpdateGalleries(t *testing.T) {
 
 func TestImporterPostImportUpdatePerformers(t *testing.T) {
 	sceneReaderWriter := &mocks.SceneReaderWriter{}
+	fileReaderWriter := newImportFileReaderWriter()
 
 	i := Importer{
 		ReaderWriter: sceneReaderWriter,
+		FileWriter:   fileReaderWriter,
 		performers: []*models.Performer{
 			{
 				ID: existingPerformerID,
@@ -568,9 +583,11 @@ func TestImporterPostImportUpdatePerformers(t *testing.T) {
 
 func TestImporterPostImportUpdateMovies(t *testing.T) {
 	sceneReaderWriter := &mocks.SceneReaderWriter{}
+	fileReaderWriter := newImportFileReaderWriter()
 
 	i := Importer{
 		ReaderWriter: sceneReaderWriter,
+		FileWriter:   fileReaderWriter,
 		movies: []models.MoviesScenes{
 			{
 				MovieID: existingMovieID,
@@ -599,9 +616,11 @@ func TestImporterPostImportUpdateMovies(t *testing.T) {
 
 func TestImporterPostImportUpdateTags(t *testing.T) {
 	sceneReaderWriter := &mocks.SceneReaderWriter{}
+	fileReaderWriter := newImportFileReaderWriter()
 
 	i := Importer{
 		ReaderWriter: sceneReaderWriter,
+		FileWriter:   fileReaderWriter,
 		tags: []*models.Tag{
 			{
 				ID: existingTagID,
@@ -623,6 +642,72 @@ func TestImporterPostImportUpdateTags(t *testing.T) {
 	sceneReaderWriter.AssertExpectations(t)
 }
 
+func TestImporterPostImportFiles(t *testing.T) {
+	fileReaderWriter := &mocks.SceneFileReaderWriter{}
+
+	const (
+		additionalPath = "additionalPath"
+		existingFileID = 201
+		oldPrimaryID   = 202
+	)
+
+	i := Importer{
+		ReaderWriter: &mocks.SceneReaderWriter{},
+		FileWriter:   fileReaderWriter,
+		Path:         path,
+		Input: jsonschema.Scene{
+			Files: []jsonschema.SceneFile{
+				{
+					Path:   additionalPath,
+					OSHash: missingOSHash,
+					Width:  width,
+				},
+			},
+		},
+		scene: models.Scene{
+			Path:     path,
+			Checksum: models.NullString(checksum),
+		},
+	}
+
+	existingPrimary := &models.SceneFile{
+		ID:       existingFileID,
+		SceneID:  sceneID,
+		Path:     "oldPath",
+		Checksum: models.NullString(checksum),
+		Primary:  false,
+	}
+	oldPrimary := &models.SceneFile{
+		ID:      oldPrimaryID,
+		SceneID: sceneID,
+		Path:    "otherPath",
+		Primary: true,
+	}
+
+	fileReaderWriter.On("FindBySceneID", sceneID).Return([]*models.SceneFile{existingPrimary, oldPrimary}, nil).Once()
+
+	// primary file matched by checksum
+	fileReaderWriter.On("UpdateFull", mock.MatchedBy(func(f models.SceneFile) bool {
+		return f.ID == existingFileID && f.Path == path && f.Primary
+	})).Return(existingPrimary, nil).Once()
+
+	// additional file is created
+	fileReaderWriter.On("Create", mock.MatchedBy(func(f models.SceneFile) bool {
+		return f.Path == additionalPath && f.SceneID == sceneID && !f.Primary &&
+			f.OSHash.String == missingOSHash && f.Width.Int64 == int64(width)
+	})).Return(&models.SceneFile{}, nil).Once()
+
+	// previous primary file is demoted
+	fileReaderWriter.On("UpdateFull", mock.MatchedBy(func(f models.SceneFile) bool {
+		return f.ID == oldPrimaryID && !f.Primary
+	})).Return(oldPrimary, nil).Once()
+
+	err := i.PostImport(sceneID)
+	assert.Nil(t, err)
+
+	fileReaderWriter.AssertExpectations(t)
+}
+
 func TestImporterFindExistingID(t *testing.T) {
 	readerWriter := &mocks.SceneReaderWriter{}
 
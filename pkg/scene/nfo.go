@@ -0,0 +1,203 @@
+package scene
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/manager/jsonschema"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// ApplyNFO sets the title, details, date, rating, studio, performers and tags
+// of the scene from the NFO file. Studios, performers and tags that do not
+// exist are created.
+func ApplyNFO(r models.Repository, s *models.Scene, n *nfo.Movie) (*models.Scene, error) {
+	i := Importer{
+		StudioWriter:    r.Studio(),
+		PerformerWriter: r.Performer(),
+		TagWriter:       r.Tag(),
+		Input: jsonschema.Scene{
+			Performers: n.ActorNames(),
+			Tags:       n.TagNames(),
+		},
+		MissingRefBehaviour: models.ImportMissingRefEnumCreate,
+	}
+
+	if len(n.Studios) > 0 {
+		i.Input.Studio = strings.TrimSpace(n.Studios[0])
+	}
+
+	if err := i.populateStudio(); err != nil {
+		return nil, err
+	}
+
+	if err := i.populatePerformers(); err != nil {
+		return nil, err
+	}
+
+	if err := i.populateTags(); err != nil {
+		return nil, err
+	}
+
+	partial := models.ScenePartial{
+		ID:        s.ID,
+		UpdatedAt: &models.SQLiteTimestamp{Timestamp: time.Now()},
+	}
+
+	if title := strings.TrimSpace(n.Title); title != "" {
+		partial.Title = &sql.NullString{String: title, Valid: true}
+	}
+
+	if plot := strings.TrimSpace(n.Plot); plot != "" {
+		partial.Details = &sql.NullString{String: plot, Valid: true}
+	}
+
+	if date := n.Date(); date != "" {
+		if _, err := time.Parse("2006-01-02", date); err == nil {
+			partial.Date = &models.SQLiteDate{String: date, Valid: true}
+		}
+	}
+
+	if rating := n.Rating(); rating > 0 {
+		partial.Rating = &sql.NullInt64{Int64: int64(rating), Valid: true}
+	}
+
+	if i.scene.StudioID.Valid {
+		partial.StudioID = &i.scene.StudioID
+	}
+
+	qb := r.Scene()
+	updated, err := qb.Update(partial)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(i.performers) > 0 {
+		performerIDs, err := qb.GetPerformerIDs(s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range i.performers {
+			performerIDs = utils.IntAppendUnique(performerIDs, p.ID)
+		}
+
+		if err := qb.UpdatePerformers(s.ID, performerIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(i.tags) > 0 {
+		tagIDs, err := qb.GetTagIDs(s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range i.tags {
+			tagIDs = utils.IntAppendUnique(tagIDs, t.ID)
+		}
+
+		if err := qb.UpdateTags(s.ID, tagIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+// ToNFO returns the NFO of the scene. If existing is not nil, then the
+// existing NFO is updated with the scene metadata, retaining the elements
+// that are not managed by stash.
+func ToNFO(r models.ReaderRepository, s *models.Scene, existing *nfo.Movie) (*nfo.Movie, error) {
+	ret := existing
+	if ret == nil {
+		ret = &nfo.Movie{}
+	}
+
+	ret.Title = s.Title.String
+	if ret.Title == "" {
+		ret.Title = strings.TrimSuffix(filepath.Base(s.Path), filepath.Ext(s.Path))
+	}
+
+	ret.Plot = s.Details.String
+	ret.Premiered = ""
+	ret.Year = ""
+	if s.Date.Valid {
+		ret.Premiered = s.Date.String
+		if len(s.Date.String) >= 4 {
+			ret.Year = s.Date.String[0:4]
+		}
+	}
+
+	ret.Runtime = 0
+	if s.Duration.Valid {
+		ret.Runtime = int(math.Round(s.Duration.Float64 / 60))
+	}
+
+	ret.SetRating(int(s.Rating.Int64))
+	ret.SetUniqueID(nfo.UniqueIDTypeStash, strconv.Itoa(s.ID))
+
+	ret.Studios = nil
+	if s.StudioID.Valid {
+		studio, err := r.Studio().Find(int(s.StudioID.Int64))
+		if err != nil {
+			return nil, fmt.Errorf("error getting scene studio: %w", err)
+		}
+
+		if studio != nil {
+			ret.Studios = []string{studio.Name.String}
+		}
+	}
+
+	performers, err := r.Performer().FindBySceneID(s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene performers: %w", err)
+	}
+
+	ret.Actors = nil
+	for i, p := range performers {
+		ret.Actors = append(ret.Actors, nfo.Actor{
+			Name:  p.Name.String,
+			Order: i,
+		})
+	}
+
+	tags, err := r.Tag().FindBySceneID(s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene tags: %w", err)
+	}
+
+	ret.Tags = nil
+	for _, t := range tags {
+		ret.Tags = append(ret.Tags, t.Name)
+	}
+
+	movies, err := r.Scene().GetMovies(s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene movies: %w", err)
+	}
+
+	ret.Set = nil
+	if len(movies) > 0 {
+		movie, err := r.Movie().Find(movies[0].MovieID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting scene movie: %w", err)
+		}
+
+		if movie != nil {
+			ret.Set = &nfo.Set{
+				Name:     movie.Name.String,
+				Overview: movie.Synopsis.String,
+			}
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,158 @@
+package scene
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApplyNFO(t *testing.T) {
+	const (
+		sceneID           = 1
+		studioID          = 2
+		existingPerformer = 3
+		createdPerformer  = 4
+		existingTag       = 5
+		otherTag          = 6
+	)
+
+	n := &nfo.Movie{
+		Title:      "Title",
+		Plot:       "Plot",
+		Premiered:  "2021-01-02",
+		UserRating: 8,
+		Studios:    []string{"Studio"},
+		Actors: []nfo.Actor{
+			{Name: "Existing"},
+			{Name: "New"},
+		},
+		Tags:   []string{"Tag"},
+		Genres: []string{"tag"},
+	}
+
+	mockTxn := mocks.NewTransactionManager()
+	mockTxn.StudioMock().On("FindByName", "Studio", false).Return(&models.Studio{
+		ID: studioID,
+	}, nil).Once()
+	mockTxn.PerformerMock().On("FindByNames", []string{"Existing", "New"}, false).Return([]*models.Performer{
+		{ID: existingPerformer, Name: models.NullString("Existing")},
+	}, nil).Once()
+	mockTxn.PerformerMock().On("Create", mock.MatchedBy(func(p models.Performer) bool {
+		return p.Name.String == "New"
+	})).Return(&models.Performer{ID: createdPerformer, Name: models.NullString("New")}, nil).Once()
+	mockTxn.TagMock().On("FindByNames", []string{"Tag"}, false).Return([]*models.Tag{
+		{ID: existingTag, Name: "Tag"},
+	}, nil).Once()
+
+	mockTxn.SceneMock().On("Update", mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.ID == sceneID &&
+			p.Title.String == "Title" &&
+			p.Details.String == "Plot" &&
+			p.Date.String == "2021-01-02" &&
+			p.Rating.Int64 == 4 &&
+			p.StudioID.Int64 == studioID
+	})).Return(&models.Scene{ID: sceneID}, nil).Once()
+	mockTxn.SceneMock().On("GetPerformerIDs", sceneID).Return([]int{existingPerformer}, nil).Once()
+	mockTxn.SceneMock().On("UpdatePerformers", sceneID, []int{existingPerformer, createdPerformer}).Return(nil).Once()
+	mockTxn.SceneMock().On("GetTagIDs", sceneID).Return([]int{otherTag}, nil).Once()
+	mockTxn.SceneMock().On("UpdateTags", sceneID, []int{otherTag, existingTag}).Return(nil).Once()
+
+	err := mockTxn.WithTxn(context.TODO(), func(r models.Repository) error {
+		_, err := ApplyNFO(r, &models.Scene{ID: sceneID}, n)
+		return err
+	})
+	assert.Nil(t, err)
+
+	mockTxn.StudioMock().AssertExpectations(t)
+	mockTxn.PerformerMock().AssertExpectations(t)
+	mockTxn.TagMock().AssertExpectations(t)
+	mockTxn.SceneMock().AssertExpectations(t)
+}
+
+func TestToNFO(t *testing.T) {
+	const (
+		sceneID  = 1
+		studioID = 2
+		movieID  = 3
+	)
+
+	s := &models.Scene{
+		ID:       sceneID,
+		Path:     "file.mp4",
+		Details:  models.NullString("Details"),
+		Date:     models.SQLiteDate{String: "2021-01-02", Valid: true},
+		Rating:   sql.NullInt64{Int64: 3, Valid: true},
+		StudioID: sql.NullInt64{Int64: studioID, Valid: true},
+		Duration: sql.NullFloat64{Float64: 1810, Valid: true},
+	}
+
+	mockTxn := mocks.NewTransactionManager()
+	mockTxn.StudioMock().On("Find", studioID).Return(&models.Studio{
+		ID:   studioID,
+		Name: models.NullString("Studio"),
+	}, nil)
+	mockTxn.PerformerMock().On("FindBySceneID", sceneID).Return([]*models.Performer{
+		{Name: models.NullString("A")},
+		{Name: models.NullString("B")},
+	}, nil)
+	mockTxn.TagMock().On("FindBySceneID", sceneID).Return([]*models.Tag{
+		{Name: "Tag"},
+	}, nil)
+	mockTxn.SceneMock().On("GetMovies", sceneID).Return([]models.MoviesScenes{
+		{MovieID: movieID, SceneID: sceneID},
+	}, nil)
+	mockTxn.MovieMock().On("Find", movieID).Return(&models.Movie{
+		ID:       movieID,
+		Name:     models.NullString("Movie"),
+		Synopsis: models.NullString("Synopsis"),
+	}, nil)
+
+	existing := &nfo.Movie{
+		Title:  "Old title",
+		Tags:   []string{"Old tag"},
+		Genres: []string{"Genre"},
+		UniqueIDs: []nfo.UniqueID{
+			{Type: "imdb", Default: true, Value: "tt0000001"},
+		},
+	}
+
+	var got *nfo.Movie
+	err := mockTxn.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		got, err = ToNFO(r, s, existing)
+		return err
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, &nfo.Movie{
+		Title:      "file",
+		Plot:       "Details",
+		Premiered:  "2021-01-02",
+		Year:       "2021",
+		Runtime:    30,
+		UserRating: 6,
+		Studios:    []string{"Studio"},
+		Actors: []nfo.Actor{
+			{Name: "A", Order: 0},
+			{Name: "B", Order: 1},
+		},
+		Tags:   []string{"Tag"},
+		Genres: []string{"Genre"},
+		UniqueIDs: []nfo.UniqueID{
+			{Type: "imdb", Default: true, Value: "tt0000001"},
+			{Type: nfo.UniqueIDTypeStash, Value: "1"},
+		},
+		Set: &nfo.Set{
+			Name:     "Movie",
+			Overview: "Synopsis",
+		},
+	}, got)
+}
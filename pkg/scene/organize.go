@@ -0,0 +1,350 @@
+package scene
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// organize template fields
+const (
+	organizeFieldID           = "id"
+	organizeFieldTitle        = "title"
+	organizeFieldDate         = "date"
+	organizeFieldYear         = "year"
+	organizeFieldStudio       = "studio"
+	organizeFieldParentStudio = "studio.parent"
+	organizeFieldPerformers   = "performers"
+	organizeFieldResolution   = "resolution"
+	organizeFieldExt          = "ext"
+	organizeFieldOSHash       = "oshash"
+	organizeFieldChecksum     = "checksum"
+)
+
+var organizeFields = []string{
+	organizeFieldID,
+	organizeFieldTitle,
+	organizeFieldDate,
+	organizeFieldYear,
+	organizeFieldStudio,
+	organizeFieldParentStudio,
+	organizeFieldPerformers,
+	organizeFieldResolution,
+	organizeFieldExt,
+	organizeFieldOSHash,
+	organizeFieldChecksum,
+}
+
+var (
+	organizeFieldRE = regexp.MustCompile(`\{([^{}]*)\}`)
+
+	// characters that are not valid in file names on common filesystems
+	invalidPathCharsRE = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]`)
+
+	// brackets left empty by missing values
+	emptyBracketsRE = regexp.MustCompile(`\[\s*\]|\(\s*\)`)
+
+	multipleSpacesRE = regexp.MustCompile(`\s{2,}`)
+)
+
+var resolutionLabels = map[models.ResolutionEnum]string{
+	models.ResolutionEnumFourK:  "4K",
+	models.ResolutionEnumFiveK:  "5K",
+	models.ResolutionEnumSixK:   "6K",
+	models.ResolutionEnumEightK: "8K",
+}
+
+// ValidateOrganizeTemplate returns an error if the organize template is
+// invalid.
+func ValidateOrganizeTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return nil
+	}
+
+	for _, m := range organizeFieldRE.FindAllStringSubmatch(template, -1) {
+		if !isOrganizeField(m[1]) {
+			return fmt.Errorf("unknown organize template field {%s}; valid fields are %s", m[1], strings.Join(organizeFields, ", "))
+		}
+	}
+
+	if filepath.IsAbs(template) {
+		return fmt.Errorf("organize template must be relative to the library path")
+	}
+
+	for _, segment := range strings.Split(filepath.ToSlash(template), "/") {
+		if segment == ".." {
+			return fmt.Errorf("organize template must not refer to parent directories")
+		}
+	}
+
+	return nil
+}
+
+func isOrganizeField(field string) bool {
+	for _, f := range organizeFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrganizePath returns the path of the scene generated from the organize
+// template, relative to the library path. Fields without a value are
+// replaced with an empty string, and directories left empty are omitted. The
+// file extension of the scene is always retained.
+func OrganizePath(template string, s *models.Scene, studioReader models.StudioReader, performerReader models.PerformerReader) (string, error) {
+	if err := ValidateOrganizeTemplate(template); err != nil {
+		return "", err
+	}
+
+	values, err := getOrganizeValues(template, s, studioReader, performerReader)
+	if err != nil {
+		return "", err
+	}
+
+	templateSegments := strings.Split(filepath.ToSlash(template), "/")
+	var segments []string
+	for i, segment := range templateSegments {
+		isFilename := i == len(templateSegments)-1
+		if isFilename {
+			// the extension is always retained
+			segment = strings.TrimSuffix(segment, "{"+organizeFieldExt+"}")
+		}
+
+		rendered := organizeFieldRE.ReplaceAllStringFunc(segment, func(m string) string {
+			return sanitizePathSegment(values[m[1:len(m)-1]])
+		})
+
+		rendered = cleanPathSegment(rendered)
+		if isFilename && rendered != "" {
+			rendered += values[organizeFieldExt]
+		}
+
+		if rendered != "" {
+			segments = append(segments, rendered)
+		}
+	}
+
+	if len(segments) == 0 {
+		return "", fmt.Errorf("organize template produced an empty path for scene %s", s.Path)
+	}
+
+	return filepath.Join(segments...), nil
+}
+
+func getOrganizeValues(template string, s *models.Scene, studioReader models.StudioReader, performerReader models.PerformerReader) (map[string]string, error) {
+	ext := filepath.Ext(s.Path)
+
+	title := s.Title.String
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(s.Path), ext)
+	}
+
+	ret := map[string]string{
+		organizeFieldID:         strconv.Itoa(s.ID),
+		organizeFieldTitle:      title,
+		organizeFieldExt:        ext,
+		organizeFieldOSHash:     s.OSHash.String,
+		organizeFieldChecksum:   s.Checksum.String,
+		organizeFieldResolution: getResolutionLabel(int(s.Width.Int64), int(s.Height.Int64)),
+	}
+
+	if s.Date.Valid {
+		ret[organizeFieldDate] = s.Date.String
+		if len(s.Date.String) >= 4 {
+			ret[organizeFieldYear] = s.Date.String[0:4]
+		}
+	}
+
+	if s.StudioID.Valid && strings.Contains(template, "{"+organizeFieldStudio) {
+		studio, err := studioReader.Find(int(s.StudioID.Int64))
+		if err != nil {
+			return nil, fmt.Errorf("error getting studio: %w", err)
+		}
+
+		if studio != nil {
+			ret[organizeFieldStudio] = studio.Name.String
+
+			if studio.ParentID.Valid {
+				parent, err := studioReader.Find(int(studio.ParentID.Int64))
+				if err != nil {
+					return nil, fmt.Errorf("error getting parent studio: %w", err)
+				}
+
+				if parent != nil {
+					ret[organizeFieldParentStudio] = parent.Name.String
+				}
+			}
+		}
+	}
+
+	if strings.Contains(template, "{"+organizeFieldPerformers+"}") {
+		performers, err := performerReader.FindBySceneID(s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting performers: %w", err)
+		}
+
+		var names []string
+		for _, p := range performers {
+			names = append(names, p.Name.String)
+		}
+
+		ret[organizeFieldPerformers] = strings.Join(names, ", ")
+	}
+
+	return ret, nil
+}
+
+// getResolutionLabel returns a label such as 1080p or 4K for the provided
+// dimensions. Returns an empty string if the dimensions are unknown.
+func getResolutionLabel(width int, height int) string {
+	size := height
+	if width < height {
+		size = width
+	}
+
+	if size <= 0 {
+		return ""
+	}
+
+	for _, r := range models.AllResolutionEnum {
+		if size < r.GetMinResolution() || size > r.GetMaxResolution() {
+			continue
+		}
+
+		if label, found := resolutionLabels[r]; found {
+			return label
+		}
+
+		return strconv.Itoa(r.GetMinResolution()) + "p"
+	}
+
+	return strconv.Itoa(size) + "p"
+}
+
+func sanitizePathSegment(v string) string {
+	return strings.TrimSpace(invalidPathCharsRE.ReplaceAllString(v, ""))
+}
+
+// cleanPathSegment removes the brackets and separators left over by fields
+// without a value.
+func cleanPathSegment(segment string) string {
+	segment = emptyBracketsRE.ReplaceAllString(segment, "")
+	segment = multipleSpacesRE.ReplaceAllString(segment, " ")
+	return strings.Trim(segment, " -_.")
+}
+
+// MovePrimaryFile moves the primary file of the scene to the provided path,
+// along with its funscript, NFO, poster and caption files, and updates the
+// paths of the scene, its file and its captions. The file is moved using the provided mover, which should be rolled
+// back if the transaction fails.
+func MovePrimaryFile(mover *file.Mover, qb models.SceneReaderWriter, fqb models.SceneFileReaderWriter, s *models.Scene, to string) error {
+	f, err := fqb.FindByPath(s.Path)
+	if err != nil {
+		return fmt.Errorf("error getting file for scene %s: %w", s.Path, err)
+	}
+
+	if f == nil || f.SceneID != s.ID {
+		return fmt.Errorf("primary file of scene %s not found", s.Path)
+	}
+
+	from := s.Path
+	if err := mover.Move(from, to); err != nil {
+		return err
+	}
+
+	if err := moveSidecarFiles(mover, from, to); err != nil {
+		return err
+	}
+
+	if err := moveCaptionFiles(mover, qb, s.ID, from, to); err != nil {
+		return err
+	}
+
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+	f.Path = to
+	f.UpdatedAt = now
+	if _, err := fqb.UpdateFull(*f); err != nil {
+		return err
+	}
+
+	updated, err := qb.Update(models.ScenePartial{
+		ID:        s.ID,
+		Path:      &to,
+		UpdatedAt: &now,
+	})
+	if err != nil {
+		return err
+	}
+
+	*s = *updated
+	return nil
+}
+
+// moveSidecarFiles moves the existing funscript, NFO and poster files of the
+// video file to match the new path of the video file.
+func moveSidecarFiles(mover *file.Mover, from string, to string) error {
+	fromPaths := append([]string{utils.GetFunscriptPath(from), nfo.Path(from)}, nfo.PosterPaths(from)...)
+	toPaths := append([]string{utils.GetFunscriptPath(to), nfo.Path(to)}, nfo.PosterPaths(to)...)
+
+	for i, p := range fromPaths {
+		if exists, _ := utils.FileExists(p); !exists {
+			continue
+		}
+
+		if err := mover.Move(p, toPaths[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moveCaptionFiles moves the caption files of the scene to match the new path
+// of the video file, and updates the paths of the captions.
+func moveCaptionFiles(mover *file.Mover, qb models.SceneReaderWriter, sceneID int, from string, to string) error {
+	existing, err := qb.GetCaptions(sceneID)
+	if err != nil {
+		return fmt.Errorf("error getting captions: %w", err)
+	}
+
+	fromBase := strings.TrimSuffix(filepath.Base(from), filepath.Ext(from))
+
+	changed := false
+	captions := make([]models.SceneCaption, len(existing))
+	for i, c := range existing {
+		captions[i] = *c
+
+		if c.Source != models.CaptionSourceFile || !c.Path.Valid || filepath.Dir(c.Path.String) != filepath.Dir(from) {
+			continue
+		}
+
+		if !strings.HasPrefix(filepath.Base(c.Path.String), fromBase) {
+			continue
+		}
+
+		newPath := CaptionPath(c.Path.String, from, to)
+		if err := mover.Move(c.Path.String, newPath); err != nil {
+			return err
+		}
+
+		captions[i].Path = models.NullString(newPath)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return qb.UpdateCaptions(sceneID, captions)
+}
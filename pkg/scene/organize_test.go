@@ -0,0 +1,237 @@
+package scene
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidateOrganizeTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  bool
+	}{
+		{"", false},
+		{"{studio.parent}/{studio}/{date} - {title} [{resolution}]{ext}", false},
+		{"{performers}/{year}/{title}", false},
+		{"{unknown}/{title}", true},
+		{"../{title}", true},
+		{"/{title}", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateOrganizeTemplate(tt.template)
+		assert.Equal(t, tt.wantErr, err != nil, "ValidateOrganizeTemplate(%q) error = %v", tt.template, err)
+	}
+}
+
+func TestOrganizePath(t *testing.T) {
+	const (
+		sceneID         = 1
+		studioID        = 2
+		parentStudioID  = 3
+		noParentID      = 4
+		studioName      = "Studio"
+		parentName      = "Parent"
+		noParentName    = "No: Parent"
+		title           = "Title"
+		date            = "2021-01-02"
+		defaultTemplate = "{studio.parent}/{studio}/{date} - {title} [{resolution}]{ext}"
+	)
+
+	sqb := &mocks.StudioReaderWriter{}
+	pqb := &mocks.PerformerReaderWriter{}
+
+	sqb.On("Find", studioID).Return(&models.Studio{
+		ID:       studioID,
+		Name:     models.NullString(studioName),
+		ParentID: sql.NullInt64{Int64: parentStudioID, Valid: true},
+	}, nil)
+	sqb.On("Find", parentStudioID).Return(&models.Studio{
+		ID:   parentStudioID,
+		Name: models.NullString(parentName),
+	}, nil)
+	sqb.On("Find", noParentID).Return(&models.Studio{
+		ID:   noParentID,
+		Name: models.NullString(noParentName),
+	}, nil)
+	pqb.On("FindBySceneID", sceneID).Return([]*models.Performer{
+		{Name: models.NullString("A")},
+		{Name: models.NullString("B")},
+	}, nil)
+
+	fullScene := &models.Scene{
+		ID:       sceneID,
+		Path:     filepath.Join("library", "file.MP4"),
+		Title:    models.NullString(title),
+		Date:     models.SQLiteDate{String: date, Valid: true},
+		StudioID: sql.NullInt64{Int64: studioID, Valid: true},
+		Width:    sql.NullInt64{Int64: 1920, Valid: true},
+		Height:   sql.NullInt64{Int64: 1080, Valid: true},
+	}
+
+	noParentScene := &models.Scene{
+		ID:       sceneID,
+		Path:     "file.mp4",
+		Title:    models.NullString(title),
+		StudioID: sql.NullInt64{Int64: noParentID, Valid: true},
+		Width:    sql.NullInt64{Int64: 3840, Valid: true},
+		Height:   sql.NullInt64{Int64: 2160, Valid: true},
+	}
+
+	emptyScene := &models.Scene{
+		ID:   sceneID,
+		Path: filepath.Join("library", "file.mp4"),
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		scene    *models.Scene
+		want     string
+	}{
+		{
+			"full",
+			defaultTemplate,
+			fullScene,
+			filepath.Join(parentName, studioName, "2021-01-02 - Title [1080p].MP4"),
+		},
+		{
+			"missing values",
+			defaultTemplate,
+			noParentScene,
+			filepath.Join("No Parent", "Title [4K].mp4"),
+		},
+		{
+			"empty scene",
+			defaultTemplate,
+			emptyScene,
+			"file.mp4",
+		},
+		{
+			"extension retained",
+			"{year}/{performers} - {title}",
+			fullScene,
+			filepath.Join("2021", "A, B - Title.MP4"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OrganizePath(tt.template, tt.scene, sqb, pqb)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := OrganizePath("{unknown}", fullScene, sqb, pqb)
+	assert.NotNil(t, err)
+}
+
+func TestMovePrimaryFile(t *testing.T) {
+	const (
+		sceneID = 1
+		fileID  = 2
+	)
+
+	dir := t.TempDir()
+	from := filepath.Join(dir, "file.mp4")
+	to := filepath.Join(dir, "studio", "title.mp4")
+
+	fromPoster := filepath.Join(dir, "file-poster.jpg")
+	toPoster := filepath.Join(dir, "studio", "title-poster.jpg")
+	fromCaption := filepath.Join(dir, "file.en.srt")
+	toCaption := filepath.Join(dir, "studio", "title.en.srt")
+
+	for _, p := range []string{from, utils.GetFunscriptPath(from), nfo.Path(from), fromPoster, fromCaption} {
+		if err := os.WriteFile(p, []byte{}, 0644); err != nil {
+			t.Fatalf("error creating file: %v", err)
+		}
+	}
+
+	qb := &mocks.SceneReaderWriter{}
+	fqb := &mocks.SceneFileReaderWriter{}
+
+	s := &models.Scene{
+		ID:   sceneID,
+		Path: from,
+	}
+
+	fqb.On("FindByPath", from).Return(&models.SceneFile{
+		ID:      fileID,
+		SceneID: sceneID,
+		Path:    from,
+		Primary: true,
+	}, nil).Once()
+	fqb.On("UpdateFull", mock.MatchedBy(func(f models.SceneFile) bool {
+		return f.ID == fileID && f.Path == to
+	})).Return(nil, nil).Once()
+	qb.On("GetCaptions", sceneID).Return([]*models.SceneCaption{
+		{
+			SceneID:      sceneID,
+			LanguageCode: "en",
+			CaptionType:  models.CaptionTypeSRT,
+			Source:       models.CaptionSourceFile,
+			Path:         models.NullString(fromCaption),
+		},
+		{
+			SceneID:     sceneID,
+			CaptionType: models.CaptionTypeSRT,
+			Source:      models.CaptionSourceEmbedded,
+			StreamIndex: sql.NullInt64{Int64: 2, Valid: true},
+		},
+	}, nil).Once()
+	qb.On("UpdateCaptions", sceneID, []models.SceneCaption{
+		{
+			SceneID:      sceneID,
+			LanguageCode: "en",
+			CaptionType:  models.CaptionTypeSRT,
+			Source:       models.CaptionSourceFile,
+			Path:         models.NullString(toCaption),
+		},
+		{
+			SceneID:     sceneID,
+			CaptionType: models.CaptionTypeSRT,
+			Source:      models.CaptionSourceEmbedded,
+			StreamIndex: sql.NullInt64{Int64: 2, Valid: true},
+		},
+	}).Return(nil).Once()
+	qb.On("Update", mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.ID == sceneID && *p.Path == to
+	})).Return(&models.Scene{ID: sceneID, Path: to}, nil).Once()
+
+	mover := file.NewMover()
+	err := MovePrimaryFile(mover, qb, fqb, s, to)
+	assert.Nil(t, err)
+	assert.Equal(t, to, s.Path)
+
+	assert.FileExists(t, to)
+	assert.FileExists(t, utils.GetFunscriptPath(to))
+	assert.FileExists(t, nfo.Path(to))
+	assert.FileExists(t, toPoster)
+	assert.FileExists(t, toCaption)
+	assert.NoFileExists(t, from)
+	assert.NoFileExists(t, fromPoster)
+
+	// rolling back restores the files and removes the created directory
+	mover.Rollback()
+
+	assert.FileExists(t, from)
+	assert.FileExists(t, utils.GetFunscriptPath(from))
+	assert.FileExists(t, nfo.Path(from))
+	assert.FileExists(t, fromPoster)
+	assert.FileExists(t, fromCaption)
+	assert.NoDirExists(t, filepath.Dir(to))
+
+	qb.AssertExpectations(t)
+	fqb.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/stashapp/stash/pkg/job"
@@ -123,3 +124,22 @@ func FilterFromPaths(paths []string) *models.SceneFilterType {
 
 	return ret
 }
+
+// CountByFolderID returns the number of scenes in the folder. Scenes in
+// sub-folders up to the provided depth are included.
+func CountByFolderID(r Queryer, id int, depth *int) (int, error) {
+	filter := &models.SceneFilterType{
+		ParentFolder: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{strconv.Itoa(id)},
+			Modifier: models.CriterionModifierIncludes,
+			Depth:    depth,
+		},
+	}
+
+	result, err := r.Query(QueryOptions(filter, nil, true))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, nil
+}
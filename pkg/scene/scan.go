@@ -15,6 +15,7 @@ import (
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/manager/paths"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -30,6 +31,7 @@ type Scanner struct {
 
 	StripFileExtension  bool
 	UseFileMetadata     bool
+	UseNfoMetadata      bool
 	FileNamingAlgorithm models.HashAlgorithm
 
 	Ctx              context.Context
@@ -50,13 +52,28 @@ func FileScanner(hasher file.Hasher, fileNamingAlgorithm models.HashAlgorithm, c
 	}
 }
 
+// ScanExisting rescans an existing scene file. If the file is the primary
+// file of its scene, the file fields of the scene are updated as well.
 func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFile) (err error) {
 	scanned, err := scanner.Scanner.ScanExisting(existing, file)
 	if err != nil {
 		return err
 	}
 
-	s := existing.(*models.Scene)
+	f := existing.(*models.SceneFile)
+
+	var s *models.Scene
+	if err := scanner.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		s, err = r.Scene().Find(f.SceneID)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if s == nil {
+		return fmt.Errorf("scene %d of file %s not found", f.SceneID, f.Path)
+	}
 
 	path := scanned.New.Path
 	interactive := getInteractive(path)
@@ -70,25 +87,40 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 	if scanned.ContentsChanged() {
 		logger.Infof("%s has been updated: rescanning", path)
 
-		s.SetFile(*scanned.New)
+		f.SetFile(*scanned.New)
 
 		videoFile, err = scanner.VideoFileCreator.NewVideoFile(path, scanner.StripFileExtension)
 		if err != nil {
 			return err
 		}
 
-		videoFileToScene(s, videoFile)
+		videoFileToSceneFile(f, videoFile)
 		changed = true
-	} else if scanned.FileUpdated() || s.Interactive != interactive {
+	} else if scanned.FileUpdated() || (f.Primary && s.Interactive != interactive) {
 		logger.Infof("Updated scene file %s", path)
 
 		// update fields as needed
-		s.SetFile(*scanned.New)
+		f.SetFile(*scanned.New)
 		changed = true
 	}
 
+	// probe the primary file if its audio streams were not recorded yet
+	if videoFile == nil && f.Primary {
+		missing, err := scanner.audioStreamsMissing(s)
+		if err != nil {
+			return fmt.Errorf("error getting audio streams: %w", err)
+		}
+
+		if missing {
+			videoFile, err = scanner.VideoFileCreator.NewVideoFile(path, scanner.StripFileExtension)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	// check for container
-	if !s.Format.Valid {
+	if !f.Format.Valid {
 		if videoFile == nil {
 			videoFile, err = scanner.VideoFileCreator.NewVideoFile(path, scanner.StripFileExtension)
 			if err != nil {
@@ -97,7 +129,7 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 		}
 		container := ffmpeg.MatchContainer(videoFile.Container, path)
 		logger.Infof("Adding container %s to file %s", container, path)
-		s.Format = models.NullString(string(container))
+		f.Format = models.NullString(string(container))
 		changed = true
 	}
 
@@ -113,27 +145,38 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 
 		if err := scanner.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
 			defer close(done)
-			qb := r.Scene()
+			fqb := r.SceneFile()
 
 			// ensure no clashes of hashes
 			if scanned.New.Checksum != "" && scanned.Old.Checksum != scanned.New.Checksum {
-				dupe, _ := qb.FindByChecksum(s.Checksum.String)
-				if dupe != nil {
+				dupe, _ := fqb.FindByChecksum(scanned.New.Checksum)
+				if dupe != nil && dupe.ID != f.ID {
 					return fmt.Errorf("MD5 for file %s is the same as that of %s", path, dupe.Path)
 				}
 			}
 
 			if scanned.New.OSHash != "" && scanned.Old.OSHash != scanned.New.OSHash {
-				dupe, _ := qb.FindByOSHash(scanned.New.OSHash)
-				if dupe != nil {
+				dupe, _ := fqb.FindByOSHash(scanned.New.OSHash)
+				if dupe != nil && dupe.ID != f.ID {
 					return fmt.Errorf("OSHash for file %s is the same as that of %s", path, dupe.Path)
 				}
 			}
 
+			now := models.SQLiteTimestamp{Timestamp: time.Now()}
+			f.UpdatedAt = now
+			if _, err := fqb.UpdateFull(*f); err != nil {
+				return err
+			}
+
+			if !f.Primary {
+				return nil
+			}
+
+			s.SetPrimaryFile(*f)
 			s.Interactive = interactive
-			s.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+			s.UpdatedAt = now
 
-			_, err := qb.UpdateFull(*s)
+			_, err := r.Scene().UpdateFull(*s)
 			return err
 		}); err != nil {
 			return err
@@ -149,8 +192,21 @@ func (scanner *Scanner) ScanExisting(existing file.FileBased, file file.SourceFi
 	}
 
 	// We already have this item in the database
-	// check for thumbnails, screenshots
-	scanner.makeScreenshots(path, videoFile, s.GetHash(scanner.FileNamingAlgorithm))
+	// check for thumbnails, screenshots, audio streams and captions of the
+	// primary file
+	if f.Primary {
+		scanner.makeScreenshots(path, videoFile, s.GetHash(scanner.FileNamingAlgorithm))
+
+		if videoFile != nil {
+			if err := scanner.updateAudioStreams(s.ID, videoFile); err != nil {
+				return fmt.Errorf("error updating audio streams: %w", err)
+			}
+		}
+
+		if err := scanner.updateCaptions(s.ID, path, videoFile); err != nil {
+			return fmt.Errorf("error updating captions: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -176,17 +232,17 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retScene *models.Scene, e
 
 	defer close(done)
 
-	// check for scene by checksum and oshash - MD5 should be
+	// check for scene file by checksum and oshash - MD5 should be
 	// redundant, but check both
-	var s *models.Scene
+	var f *models.SceneFile
 	if err := scanner.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
-		qb := r.Scene()
+		fqb := r.SceneFile()
 		if checksum != "" {
-			s, _ = qb.FindByChecksum(checksum)
+			f, _ = fqb.FindByChecksum(checksum)
 		}
 
-		if s == nil {
-			s, _ = qb.FindByOSHash(oshash)
+		if f == nil {
+			f, _ = fqb.FindByOSHash(oshash)
 		}
 
 		return nil
@@ -202,34 +258,52 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retScene *models.Scene, e
 
 	interactive := getInteractive(file.Path())
 
-	if s != nil {
-		exists, _ := utils.FileExists(s.Path)
+	if f != nil {
+		exists, _ := utils.FileExists(f.Path)
 		if !scanner.CaseSensitiveFs {
 			// #1426 - if file exists but is a case-insensitive match for the
 			// original filename, then treat it as a move
-			if exists && strings.EqualFold(path, s.Path) {
+			if exists && strings.EqualFold(path, f.Path) {
 				exists = false
 			}
 		}
 
 		if exists {
-			logger.Infof("%s already exists. Duplicate of %s", path, s.Path)
+			logger.Infof("%s already exists. Duplicate of %s", path, f.Path)
 		} else {
-			logger.Infof("%s already exists. Updating path...", path)
-			scenePartial := models.ScenePartial{
-				ID:          s.ID,
-				Path:        &path,
-				Interactive: &interactive,
-			}
+			oldPath := f.Path
+			logger.Infof("%s moved from %s. Updating path...", path, oldPath)
 			if err := scanner.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+				f.Path = path
+				f.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+				if _, err := r.SceneFile().UpdateFull(*f); err != nil {
+					return err
+				}
+
+				if !f.Primary {
+					return nil
+				}
+
+				scenePartial := models.ScenePartial{
+					ID:          f.SceneID,
+					Path:        &path,
+					Interactive: &interactive,
+				}
 				_, err := r.Scene().Update(scenePartial)
 				return err
 			}); err != nil {
 				return nil, err
 			}
 
-			scanner.makeScreenshots(path, nil, sceneHash)
-			scanner.PluginCache.ExecutePostHooks(scanner.Ctx, s.ID, plugin.SceneUpdatePost, nil, nil)
+			if f.Primary {
+				scanner.makeScreenshots(path, nil, sceneHash)
+
+				if err := scanner.updateCaptions(f.SceneID, path, nil); err != nil {
+					return nil, fmt.Errorf("error updating captions: %w", err)
+				}
+			}
+			scanner.PluginCache.ExecutePostHooks(scanner.Ctx, f.SceneID, plugin.SceneUpdatePost, nil, nil)
+			scanner.PluginCache.ExecuteMovePostHooks(scanner.Ctx, f.SceneID, plugin.SceneMovePost, oldPath, path)
 		}
 	} else {
 		logger.Infof("%s doesn't exist. Creating new item...", path)
@@ -266,10 +340,48 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retScene *models.Scene, e
 			_ = newScene.Date.Scan(videoFile.CreationTime)
 		}
 
+		audioStreams := GetAudioStreams(videoFile)
+		captions := append(GetCaptionFiles(path), GetEmbeddedCaptions(videoFile)...)
+
+		var sceneNFO *nfo.Movie
+		if scanner.UseNfoMetadata && nfo.Exists(path) {
+			sceneNFO, err = nfo.Read(nfo.Path(path))
+			if err != nil {
+				logger.Warnf("Error reading NFO file for %s: %v", path, err)
+			}
+		}
+
 		if err := scanner.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
 			var err error
 			retScene, err = r.Scene().Create(newScene)
-			return err
+			if err != nil {
+				return err
+			}
+
+			if _, err := r.SceneFile().Create(*models.NewSceneFile(retScene)); err != nil {
+				return err
+			}
+
+			if len(audioStreams) > 0 {
+				if err := r.Scene().UpdateAudioStreams(retScene.ID, audioStreams); err != nil {
+					return err
+				}
+			}
+
+			if len(captions) > 0 {
+				if err := r.Scene().UpdateCaptions(retScene.ID, captions); err != nil {
+					return err
+				}
+			}
+
+			if sceneNFO != nil {
+				retScene, err = ApplyNFO(r, retScene, sceneNFO)
+				if err != nil {
+					return fmt.Errorf("error applying NFO metadata: %w", err)
+				}
+			}
+
+			return nil
 		}); err != nil {
 			return nil, err
 		}
@@ -282,17 +394,32 @@ func (scanner *Scanner) ScanNew(file file.SourceFile) (retScene *models.Scene, e
 }
 
 func videoFileToScene(s *models.Scene, videoFile *ffmpeg.VideoFile) {
-	container := ffmpeg.MatchContainer(videoFile.Container, s.Path)
-
-	s.Duration = sql.NullFloat64{Float64: videoFile.Duration, Valid: true}
-	s.VideoCodec = sql.NullString{String: videoFile.VideoCodec, Valid: true}
-	s.AudioCodec = sql.NullString{String: videoFile.AudioCodec, Valid: true}
-	s.Format = sql.NullString{String: string(container), Valid: true}
-	s.Width = sql.NullInt64{Int64: int64(videoFile.Width), Valid: true}
-	s.Height = sql.NullInt64{Int64: int64(videoFile.Height), Valid: true}
-	s.Framerate = sql.NullFloat64{Float64: videoFile.FrameRate, Valid: true}
-	s.Bitrate = sql.NullInt64{Int64: videoFile.Bitrate, Valid: true}
-	s.Size = sql.NullString{String: strconv.FormatInt(videoFile.Size, 10), Valid: true}
+	f := models.SceneFile{Path: s.Path}
+	videoFileToSceneFile(&f, videoFile)
+
+	s.Duration = f.Duration
+	s.VideoCodec = f.VideoCodec
+	s.AudioCodec = f.AudioCodec
+	s.Format = f.Format
+	s.Width = f.Width
+	s.Height = f.Height
+	s.Framerate = f.Framerate
+	s.Bitrate = f.Bitrate
+	s.Size = f.Size
+}
+
+func videoFileToSceneFile(f *models.SceneFile, videoFile *ffmpeg.VideoFile) {
+	container := ffmpeg.MatchContainer(videoFile.Container, f.Path)
+
+	f.Duration = sql.NullFloat64{Float64: videoFile.Duration, Valid: true}
+	f.VideoCodec = sql.NullString{String: videoFile.VideoCodec, Valid: true}
+	f.AudioCodec = sql.NullString{String: videoFile.AudioCodec, Valid: true}
+	f.Format = sql.NullString{String: string(container), Valid: true}
+	f.Width = sql.NullInt64{Int64: int64(videoFile.Width), Valid: true}
+	f.Height = sql.NullInt64{Int64: int64(videoFile.Height), Valid: true}
+	f.Framerate = sql.NullFloat64{Float64: videoFile.FrameRate, Valid: true}
+	f.Bitrate = sql.NullInt64{Int64: videoFile.Bitrate, Valid: true}
+	f.Size = sql.NullString{String: strconv.FormatInt(videoFile.Size, 10), Valid: true}
 }
 
 func (scanner *Scanner) makeScreenshots(path string, probeResult *ffmpeg.VideoFile, checksum string) {
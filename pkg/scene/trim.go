@@ -0,0 +1,465 @@
+package scene
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// keyframeTolerance is the distance in seconds within which a cut is
+// considered to be on a keyframe.
+const keyframeTolerance = 0.05
+
+var (
+	// captionTimeRE matches the cue times of SRT, WebVTT and ASS captions,
+	// such as 00:01:02,500, 01:02.500 and 0:01:02.50.
+	captionTimeRE = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})[,.](\d{2,3})$`)
+
+	// cueTimingRE matches the timing line of SRT and WebVTT cues, including
+	// any cue settings.
+	cueTimingRE = regexp.MustCompile(`^\s*(\S+)\s+-->\s+(\S+)(.*)$`)
+)
+
+// assDefaultFieldCount is the number of fields of ASS events when the events
+// section has no format line.
+const assDefaultFieldCount = 10
+
+// KeptRanges returns the sections of a video of the provided duration that
+// remain after removing the provided ranges. Removed ranges are clamped to
+// the video and may overlap.
+func KeptRanges(removed []ffmpeg.TrimRange, duration float64) ([]ffmpeg.TrimRange, error) {
+	if len(removed) == 0 {
+		return nil, errors.New("no ranges to remove")
+	}
+
+	sorted := make([]ffmpeg.TrimRange, 0, len(removed))
+	for _, r := range removed {
+		if r.End <= r.Start {
+			return nil, fmt.Errorf("invalid range %v-%v: end must be after start", r.Start, r.End)
+		}
+
+		r.Start = math.Max(r.Start, 0)
+		r.End = math.Min(r.End, duration)
+		if r.End <= r.Start {
+			return nil, fmt.Errorf("range %v-%v is outside of the video", r.Start, r.End)
+		}
+
+		sorted = append(sorted, r)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var ret []ffmpeg.TrimRange
+	start := 0.0
+	for _, r := range sorted {
+		if r.Start > start {
+			ret = append(ret, ffmpeg.TrimRange{Start: start, End: r.Start})
+		}
+		start = math.Max(start, r.End)
+	}
+
+	if start < duration {
+		ret = append(ret, ffmpeg.TrimRange{Start: start, End: duration})
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.New("ranges remove the entire video")
+	}
+
+	return ret, nil
+}
+
+// TrimmedTime returns the time in the trimmed video of the provided time in
+// the original video. Returns false if the time is in a removed section.
+func TrimmedTime(kept []ffmpeg.TrimRange, t float64) (float64, bool) {
+	offset := 0.0
+	for _, r := range kept {
+		if t < r.Start {
+			return 0, false
+		}
+
+		if t <= r.End {
+			return offset + t - r.Start, true
+		}
+
+		offset += r.Duration()
+	}
+
+	return 0, false
+}
+
+// TrimmedDuration returns the duration of the trimmed video.
+func TrimmedDuration(kept []ffmpeg.TrimRange) float64 {
+	ret := 0.0
+	for _, r := range kept {
+		ret += r.Duration()
+	}
+
+	return ret
+}
+
+// CanStreamCopy returns true if every kept section after the start of the
+// video starts on one of the keyframes, so that the video can be trimmed
+// without re-encoding. Keyframes must be sorted.
+func CanStreamCopy(kept []ffmpeg.TrimRange, keyframes []float64) bool {
+	for _, r := range kept {
+		if r.Start == 0 {
+			continue
+		}
+
+		i := sort.SearchFloat64s(keyframes, r.Start-keyframeTolerance)
+		if i == len(keyframes) || keyframes[i] > r.Start+keyframeTolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TrimFunscript returns the funscript with the actions in removed sections
+// dropped and the remaining actions shifted to match the trimmed video.
+// Other fields of the funscript are retained.
+func TrimFunscript(data []byte, kept []ffmpeg.TrimRange) ([]byte, error) {
+	var script map[string]json.RawMessage
+	if err := json.Unmarshal(data, &script); err != nil {
+		return nil, err
+	}
+
+	var actions []map[string]json.RawMessage
+	if err := json.Unmarshal(script["actions"], &actions); err != nil {
+		return nil, fmt.Errorf("invalid actions: %w", err)
+	}
+
+	trimmed := make([]map[string]json.RawMessage, 0, len(actions))
+	for _, a := range actions {
+		var at float64
+		if err := json.Unmarshal(a["at"], &at); err != nil {
+			return nil, fmt.Errorf("invalid action time: %w", err)
+		}
+
+		t, ok := TrimmedTime(kept, at/1000)
+		if !ok {
+			continue
+		}
+
+		a["at"] = json.RawMessage(fmt.Sprint(int64(math.Round(t * 1000))))
+		trimmed = append(trimmed, a)
+	}
+
+	encoded, err := json.Marshal(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	script["actions"] = encoded
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(script); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// TrimCaption returns the caption file with the cues in removed sections
+// dropped and the remaining cues shifted to match the trimmed video. Cues
+// that are partially removed are shortened. Other content of the caption
+// file is retained.
+func TrimCaption(data []byte, captionType string, kept []ffmpeg.TrimRange) ([]byte, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	switch captionType {
+	case models.CaptionTypeSRT, models.CaptionTypeVTT:
+		return trimCues(text, captionType, kept)
+	case models.CaptionTypeASS:
+		return trimASSEvents(text, kept)
+	default:
+		return nil, fmt.Errorf("unsupported caption type %q", captionType)
+	}
+}
+
+// trimCues trims the cues of SRT and WebVTT captions. Blocks without a
+// timing line, such as the WebVTT header and notes, are retained. SRT cues
+// are renumbered.
+func trimCues(text string, captionType string, kept []ffmpeg.TrimRange) ([]byte, error) {
+	var blocks []string
+	number := 0
+	for _, block := range strings.Split(text, "\n\n") {
+		block = strings.Trim(block, "\n")
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+
+		lines := strings.Split(block, "\n")
+		timing := -1
+		for i, l := range lines {
+			if cueTimingRE.MatchString(l) {
+				timing = i
+				break
+			}
+		}
+
+		if timing == -1 {
+			blocks = append(blocks, block)
+			continue
+		}
+
+		m := cueTimingRE.FindStringSubmatch(lines[timing])
+		start, end, ok, err := trimCaptionTimes(m[1], m[2], kept)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		lines[timing] = formatCaptionTime(start, captionType) + " --> " + formatCaptionTime(end, captionType) + m[3]
+		if captionType == models.CaptionTypeSRT && timing > 0 {
+			number++
+			lines[timing-1] = strconv.Itoa(number)
+		}
+
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	return []byte(strings.Join(blocks, "\n\n") + "\n"), nil
+}
+
+// trimASSEvents trims the dialogue events of ASS captions. The positions of
+// the start and end fields are read from the format line of the events
+// section.
+func trimASSEvents(text string, kept []ffmpeg.TrimRange) ([]byte, error) {
+	startField, endField, fieldCount := 1, 2, assDefaultFieldCount
+
+	lines := strings.Split(text, "\n")
+	ret := make([]string, 0, len(lines))
+	for _, l := range lines {
+		i := strings.Index(l, ":")
+		if i == -1 {
+			ret = append(ret, l)
+			continue
+		}
+
+		switch strings.TrimSpace(l[:i]) {
+		case "Format":
+			fields := strings.Split(l[i+1:], ",")
+			for j, f := range fields {
+				switch strings.TrimSpace(f) {
+				case "Start":
+					startField = j
+					fieldCount = len(fields)
+				case "End":
+					endField = j
+				}
+			}
+		case "Dialogue":
+			// the text field is last and may contain commas
+			fields := strings.SplitN(l[i+1:], ",", fieldCount)
+			if len(fields) <= startField || len(fields) <= endField {
+				return nil, fmt.Errorf("invalid dialogue %q", l)
+			}
+
+			start, end, ok, err := trimCaptionTimes(fields[startField], fields[endField], kept)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			fields[startField] = formatCaptionTime(start, models.CaptionTypeASS)
+			fields[endField] = formatCaptionTime(end, models.CaptionTypeASS)
+			l = l[:i+1] + strings.Join(fields, ",")
+		}
+
+		ret = append(ret, l)
+	}
+
+	return []byte(strings.Join(ret, "\n")), nil
+}
+
+// trimCaptionTimes returns the start and end times of the cue in the
+// trimmed video. Returns false if the cue is entirely in removed sections.
+// Cues that overlap a removed section are cut at the section.
+func trimCaptionTimes(startStr string, endStr string, kept []ffmpeg.TrimRange) (float64, float64, bool, error) {
+	start, err := parseCaptionTime(startStr)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	end, err := parseCaptionTime(endStr)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	offset := 0.0
+	for _, r := range kept {
+		if r.Start < end && r.End > start {
+			return offset + math.Max(start, r.Start) - r.Start, offset + math.Min(end, r.End) - r.Start, true, nil
+		}
+
+		offset += r.Duration()
+	}
+
+	return 0, 0, false, nil
+}
+
+func parseCaptionTime(v string) (float64, error) {
+	m := captionTimeRE.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil {
+		return 0, fmt.Errorf("invalid caption time %q", v)
+	}
+
+	var hours int
+	if m[1] != "" {
+		hours, _ = strconv.Atoi(m[1])
+	}
+	minutes, _ := strconv.Atoi(m[2])
+	seconds, _ := strconv.Atoi(m[3])
+	fraction, _ := strconv.ParseFloat("0."+m[4], 64)
+
+	return float64(hours*3600+minutes*60+seconds) + fraction, nil
+}
+
+// formatCaptionTime formats the time in seconds as a cue time of the
+// caption type.
+func formatCaptionTime(t float64, captionType string) string {
+	if captionType == models.CaptionTypeASS {
+		cs := int64(math.Round(t * 100))
+		return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+	}
+
+	sep := "."
+	if captionType == models.CaptionTypeSRT {
+		sep = ","
+	}
+
+	ms := int64(math.Round(t * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// ApplyTrim updates the scene after its primary file has been trimmed to
+// the kept sections. The file is updated with the metadata of the trimmed
+// video. If the file has no ID it is created and made the primary file of the
+// scene, otherwise the primary file is updated with it.
+// Markers are shifted to match the trimmed video, and markers in removed
+// sections are destroyed. Generated files of the untrimmed video are marked
+// for deletion. Pending marker suggestions are removed, since they no longer
+// match the video, and the file status is cleared, since the trimmed file has
+// not been verified. The captions of the scene are replaced with the provided
+// captions of the trimmed video. The scene is updated in place.
+func ApplyTrim(repo models.Repository, s *models.Scene, f *models.SceneFile, videoFile *ffmpeg.VideoFile, kept []ffmpeg.TrimRange, captions []models.SceneCaption, fileDeleter *FileDeleter) error {
+	qb := repo.Scene()
+	fqb := repo.SceneFile()
+	mqb := repo.SceneMarker()
+
+	videoFileToSceneFile(f, videoFile)
+	// the phash of the untrimmed video no longer applies
+	f.Phash = sql.NullInt64{}
+
+	// ensure no clashes of hashes
+	if f.Checksum.Valid {
+		dupe, err := fqb.FindByChecksum(f.Checksum.String)
+		if err != nil {
+			return err
+		}
+		if dupe != nil && dupe.ID != f.ID {
+			return fmt.Errorf("MD5 of trimmed file %s is the same as that of %s", f.Path, dupe.Path)
+		}
+	}
+
+	if f.OSHash.Valid {
+		dupe, err := fqb.FindByOSHash(f.OSHash.String)
+		if err != nil {
+			return err
+		}
+		if dupe != nil && dupe.ID != f.ID {
+			return fmt.Errorf("OSHash of trimmed file %s is the same as that of %s", f.Path, dupe.Path)
+		}
+	}
+
+	markers, err := mqb.FindBySceneID(s.ID)
+	if err != nil {
+		return err
+	}
+
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+	for _, m := range markers {
+		t, ok := TrimmedTime(kept, m.Seconds)
+		if !ok {
+			// the generated files of the marker are deleted with the
+			// generated files of the scene
+			if err := mqb.Destroy(m.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if t == m.Seconds {
+			continue
+		}
+
+		m.Seconds = t
+		m.UpdatedAt = now
+		if _, err := mqb.Update(*m); err != nil {
+			return err
+		}
+	}
+
+	if err := mqb.UpdateSuggestions(s.ID, nil); err != nil {
+		return err
+	}
+
+	// detect the chapters of the trimmed video again
+	if err := mqb.ResetChapterDetection(s.ID); err != nil {
+		return err
+	}
+
+	if err := qb.UpdateCaptions(s.ID, captions); err != nil {
+		return err
+	}
+
+	if err := fileDeleter.MarkGeneratedFiles(s); err != nil {
+		return err
+	}
+
+	// the trimmed file has not been verified
+	s.FileStatus = sql.NullString{}
+	s.FileStatusError = sql.NullString{}
+	s.FileCheckedAt = models.NullSQLiteTimestamp{}
+
+	f.SceneID = s.ID
+	f.UpdatedAt = now
+	if f.ID == 0 {
+		f.CreatedAt = now
+		created, err := fqb.Create(*f)
+		if err != nil {
+			return err
+		}
+
+		return SetPrimaryFile(qb, fqb, s, created.ID)
+	}
+
+	if _, err := fqb.UpdateFull(*f); err != nil {
+		return err
+	}
+
+	s.SetPrimaryFile(*f)
+	s.UpdatedAt = now
+	_, err = qb.UpdateFull(*s)
+	return err
+}
@@ -0,0 +1,284 @@
+package scene
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestKeptRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		removed  []ffmpeg.TrimRange
+		duration float64
+		want     []ffmpeg.TrimRange
+		wantErr  bool
+	}{
+		{
+			"intro and outro",
+			[]ffmpeg.TrimRange{{Start: 0, End: 10}, {Start: 90, End: 100}},
+			100,
+			[]ffmpeg.TrimRange{{Start: 10, End: 90}},
+			false,
+		},
+		{
+			"unsorted and overlapping",
+			[]ffmpeg.TrimRange{{Start: 50, End: 60}, {Start: 20, End: 30}, {Start: 25, End: 40}},
+			100,
+			[]ffmpeg.TrimRange{{Start: 0, End: 20}, {Start: 40, End: 50}, {Start: 60, End: 100}},
+			false,
+		},
+		{
+			"clamped to video",
+			[]ffmpeg.TrimRange{{Start: -5, End: 10}, {Start: 90, End: 200}},
+			100,
+			[]ffmpeg.TrimRange{{Start: 10, End: 90}},
+			false,
+		},
+		{
+			"no ranges",
+			nil,
+			100,
+			nil,
+			true,
+		},
+		{
+			"invalid range",
+			[]ffmpeg.TrimRange{{Start: 20, End: 10}},
+			100,
+			nil,
+			true,
+		},
+		{
+			"outside of video",
+			[]ffmpeg.TrimRange{{Start: 150, End: 200}},
+			100,
+			nil,
+			true,
+		},
+		{
+			"entire video",
+			[]ffmpeg.TrimRange{{Start: 0, End: 60}, {Start: 50, End: 100}},
+			100,
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KeptRanges(tt.removed, tt.duration)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTrimmedTime(t *testing.T) {
+	kept := []ffmpeg.TrimRange{{Start: 10, End: 40}, {Start: 60, End: 100}}
+
+	tests := []struct {
+		at     float64
+		want   float64
+		wantOk bool
+	}{
+		{5, 0, false},
+		{10, 0, true},
+		{25, 15, true},
+		{50, 0, false},
+		{60, 30, true},
+		{100, 70, true},
+		{110, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := TrimmedTime(kept, tt.at)
+		assert.Equal(t, tt.wantOk, ok, "at %v", tt.at)
+		assert.Equal(t, tt.want, got, "at %v", tt.at)
+	}
+
+	assert.Equal(t, 70.0, TrimmedDuration(kept))
+}
+
+func TestCanStreamCopy(t *testing.T) {
+	keyframes := []float64{0, 2, 4.004, 6}
+
+	assert.True(t, CanStreamCopy([]ffmpeg.TrimRange{{Start: 0, End: 3}, {Start: 4, End: 8}}, keyframes))
+	assert.False(t, CanStreamCopy([]ffmpeg.TrimRange{{Start: 3, End: 8}}, keyframes))
+	assert.False(t, CanStreamCopy([]ffmpeg.TrimRange{{Start: 7, End: 8}}, keyframes))
+	assert.False(t, CanStreamCopy([]ffmpeg.TrimRange{{Start: 2, End: 8}}, nil))
+}
+
+func TestTrimFunscript(t *testing.T) {
+	kept := []ffmpeg.TrimRange{{Start: 0, End: 1}, {Start: 2, End: 3}}
+	data := []byte(`{"version":"1.0","inverted":false,"metadata":{"title":"<scene>"},"actions":[{"at":500,"pos":10},{"at":1500,"pos":20},{"at":2500,"pos":30,"extra":true}]}`)
+
+	got, err := TrimFunscript(data, kept)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var script struct {
+		Version  string
+		Metadata map[string]string
+		Actions  []map[string]interface{}
+	}
+	if !assert.NoError(t, json.Unmarshal(got, &script)) {
+		return
+	}
+
+	assert.Equal(t, "1.0", script.Version)
+	assert.Equal(t, "<scene>", script.Metadata["title"])
+	if assert.Len(t, script.Actions, 2) {
+		assert.Equal(t, 500.0, script.Actions[0]["at"])
+		assert.Equal(t, 1500.0, script.Actions[1]["at"])
+		assert.Equal(t, 30.0, script.Actions[1]["pos"])
+		assert.Equal(t, true, script.Actions[1]["extra"])
+	}
+
+	_, err = TrimFunscript([]byte(`{"version":"1.0"}`), kept)
+	assert.Error(t, err)
+}
+
+func TestTrimCaption(t *testing.T) {
+	kept := []ffmpeg.TrimRange{{Start: 0, End: 10}, {Start: 20, End: 30}}
+
+	tests := []struct {
+		name        string
+		captionType string
+		data        string
+		want        string
+	}{
+		{
+			"srt",
+			models.CaptionTypeSRT,
+			"1\r\n00:00:01,000 --> 00:00:02,500\r\nfirst\r\n\r\n2\r\n00:00:12,000 --> 00:00:13,000\r\nremoved\r\n\r\n3\r\n00:00:19,000 --> 00:00:21,000\r\nshortened\r\nsecond line\r\n",
+			"1\n00:00:01,000 --> 00:00:02,500\nfirst\n\n2\n00:00:10,000 --> 00:00:11,000\nshortened\nsecond line\n",
+		},
+		{
+			"vtt",
+			models.CaptionTypeVTT,
+			"WEBVTT\n\nNOTE a note\n\ncue\n00:25.000 --> 00:26.000 align:start\nshifted\n",
+			"WEBVTT\n\nNOTE a note\n\ncue\n00:00:15.000 --> 00:00:16.000 align:start\nshifted\n",
+		},
+		{
+			"ass",
+			models.CaptionTypeASS,
+			"[Events]\nFormat: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\nDialogue: 0,0:00:05.00,0:00:06.00,Default,,0,0,0,,first, with comma\nDialogue: 0,0:00:15.00,0:00:16.00,Default,,0,0,0,,removed\nDialogue: 0,0:00:25.50,0:00:26.00,Default,,0,0,0,,shifted\n",
+			"[Events]\nFormat: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\nDialogue: 0,0:00:05.00,0:00:06.00,Default,,0,0,0,,first, with comma\nDialogue: 0,0:00:15.50,0:00:16.00,Default,,0,0,0,,shifted\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TrimCaption([]byte(tt.data), tt.captionType, kept)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := TrimCaption([]byte("1\n00:00:01 --> 00:00:02,000\ntext\n"), models.CaptionTypeSRT, kept)
+	assert.Error(t, err)
+
+	_, err = TrimCaption([]byte{}, "mov_text", kept)
+	assert.Error(t, err)
+}
+
+func TestApplyTrim(t *testing.T) {
+	const (
+		sceneID  = 1
+		fileID   = 11
+		keptID   = 21
+		shiftID  = 22
+		removeID = 23
+		path     = "path.mp4"
+		oshash   = "oshash"
+	)
+
+	mockTxn := mocks.NewTransactionManager()
+	qb := mockTxn.SceneMock()
+	fqb := mockTxn.SceneFileMock()
+	mqb := mockTxn.SceneMarkerMock()
+
+	s := &models.Scene{
+		ID:              sceneID,
+		Path:            path,
+		FileStatus:      models.NullString(models.FileStatusEnumCorrupt.String()),
+		FileStatusError: models.NullString("error"),
+		FileCheckedAt:   models.NullSQLiteTimestamp{Timestamp: time.Now(), Valid: true},
+	}
+
+	f := &models.SceneFile{
+		ID:      fileID,
+		Path:    path,
+		OSHash:  models.NullString(oshash),
+		Phash:   sql.NullInt64{Int64: 1, Valid: true},
+		Primary: true,
+	}
+
+	videoFile := &ffmpeg.VideoFile{
+		Path:     path,
+		Duration: 70,
+		Size:     1000,
+	}
+
+	kept := []ffmpeg.TrimRange{{Start: 0, End: 40}, {Start: 60, End: 90}}
+
+	captions := []models.SceneCaption{
+		{
+			SceneID:     sceneID,
+			CaptionType: models.CaptionTypeSRT,
+			Source:      models.CaptionSourceFile,
+			Path:        models.NullString("path.srt"),
+		},
+	}
+
+	fqb.On("FindByOSHash", oshash).Return(nil, nil).Once()
+	mqb.On("FindBySceneID", sceneID).Return([]*models.SceneMarker{
+		{ID: keptID, Seconds: 10},
+		{ID: shiftID, Seconds: 70},
+		{ID: removeID, Seconds: 50},
+	}, nil).Once()
+
+	mqb.On("Update", mock.MatchedBy(func(m models.SceneMarker) bool {
+		return m.ID == shiftID && m.Seconds == 50
+	})).Return(nil, nil).Once()
+	mqb.On("Destroy", removeID).Return(nil).Once()
+	mqb.On("UpdateSuggestions", sceneID, []models.SceneMarkerSuggestion(nil)).Return(nil).Once()
+	mqb.On("ResetChapterDetection", sceneID).Return(nil).Once()
+	qb.On("UpdateCaptions", sceneID, captions).Return(nil).Once()
+
+	fqb.On("UpdateFull", mock.MatchedBy(func(f models.SceneFile) bool {
+		return f.ID == fileID && f.Duration.Float64 == 70 && !f.Phash.Valid
+	})).Return(f, nil).Once()
+	qb.On("UpdateFull", mock.MatchedBy(func(s models.Scene) bool {
+		return s.ID == sceneID && s.Duration.Float64 == 70 && s.OSHash.String == oshash &&
+			!s.FileStatus.Valid && !s.FileStatusError.Valid && !s.FileCheckedAt.Valid
+	})).Return(s, nil).Once()
+
+	// the untrimmed scene has no hash, so there are no generated files
+	fileDeleter := &FileDeleter{
+		Deleter:        *file.NewDeleter(),
+		FileNamingAlgo: models.HashAlgorithmOshash,
+	}
+
+	err := ApplyTrim(mockTxn, s, f, videoFile, kept, captions, fileDeleter)
+	assert.NoError(t, err)
+	assert.Equal(t, 70.0, s.Duration.Float64)
+
+	qb.AssertExpectations(t)
+	fqb.AssertExpectations(t)
+	mqb.AssertExpectations(t)
+}
@@ -0,0 +1,85 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldSourceReaderWriter interface {
+	GetFieldSources(id int) ([]*models.FieldSource, error)
+	UpdateFieldSources(id int, sources []models.FieldSource) error
+}
+
+func testFieldSourceReaderWriter(t *testing.T, r fieldSourceReaderWriter, id int) {
+	// ensure no field sources to begin with
+	testFieldSources(t, r, id, nil)
+
+	const (
+		source = "endpoint"
+		title  = "title"
+		date   = "date"
+	)
+
+	// truncate to second to match stored precision
+	now := models.SQLiteTimestamp{Timestamp: time.Now().Truncate(time.Second)}
+	titleSource := models.FieldSource{
+		Field:     title,
+		Source:    source,
+		UpdatedAt: now,
+	}
+	dateSource := models.FieldSource{
+		Field:     date,
+		Source:    source,
+		UpdatedAt: now,
+	}
+
+	if err := r.UpdateFieldSources(id, []models.FieldSource{titleSource, dateSource}); err != nil {
+		t.Error(err.Error())
+	}
+
+	testFieldSources(t, r, id, []*models.FieldSource{&dateSource, &titleSource})
+
+	// updating a single field should leave the others unchanged
+	userTitleSource := titleSource
+	userTitleSource.Source = models.FieldSourceUser
+	if err := r.UpdateFieldSources(id, []models.FieldSource{userTitleSource}); err != nil {
+		t.Error(err.Error())
+	}
+
+	testFieldSources(t, r, id, []*models.FieldSource{&dateSource, &userTitleSource})
+
+	// update non-existing id - should return error
+	if err := r.UpdateFieldSources(-1, []models.FieldSource{titleSource}); err == nil {
+		t.Error("expected error when updating non-existing id")
+	}
+}
+
+func testFieldSources(t *testing.T, r fieldSourceReaderWriter, id int, expected []*models.FieldSource) {
+	t.Helper()
+	sources, err := r.GetFieldSources(id)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(expected) == 0 {
+		assert.Len(t, sources, 0)
+		return
+	}
+
+	// compare timestamps by value
+	for _, s := range sources {
+		s.UpdatedAt.Timestamp = s.UpdatedAt.Timestamp.Local()
+	}
+	for _, s := range expected {
+		s.UpdatedAt.Timestamp = s.UpdatedAt.Timestamp.Local()
+	}
+
+	assert.Equal(t, expected, sources)
+}
@@ -40,6 +40,8 @@ type join struct {
 	as       string
 	onClause string
 	joinType string
+	// arguments of placeholders in the table or on clause
+	args []interface{}
 }
 
 // equals returns true if the other join alias/table is equal to this one
@@ -101,6 +103,17 @@ func (j *joins) toSQL() string {
 	return " " + strings.Join(ret, " ")
 }
 
+// args returns the arguments of the joins, in the order that the joins are
+// added to the query.
+func (j *joins) args() []interface{} {
+	var ret []interface{}
+	for _, jj := range *j {
+		ret = append(ret, jj.args...)
+	}
+
+	return ret
+}
+
 type filterBuilder struct {
 	subFilter   *filterBuilder
 	subFilterOp string
@@ -407,6 +420,83 @@ func intCriterionHandler(c *models.IntCriterionInput, column string) criterionHa
 	}
 }
 
+func floatCriterionHandler(c *models.FloatCriterionInput, column string) criterionHandlerFunc {
+	return func(f *filterBuilder) {
+		if c != nil {
+			clause, args, err := getFloatWhereClause(column, c.Modifier, c.Value, c.Value2)
+			if err != nil {
+				f.setError(err)
+				return
+			}
+			f.addWhere(clause, args...)
+		}
+	}
+}
+
+// sizeCriterionHandler filters on a file size column, which may be stored
+// as a string.
+func sizeCriterionHandler(c *models.FloatCriterionInput, column string) criterionHandlerFunc {
+	return floatCriterionHandler(c, "cast("+column+" as integer)")
+}
+
+// timestampCriterionHandler filters on a date or timestamp column. The
+// criterion values are parsed as timestamps, and compared with the
+// normalised column value.
+func timestampCriterionHandler(c *models.TimestampCriterionInput, column string) criterionHandlerFunc {
+	return func(f *filterBuilder) {
+		if c == nil {
+			return
+		}
+
+		column := "datetime(" + column + ")"
+
+		switch c.Modifier {
+		case models.CriterionModifierIsNull, models.CriterionModifierNotNull:
+			clause, _, _ := getComparisonWhereClause(column, c.Modifier, nil, nil)
+			f.addWhere(clause)
+			return
+		}
+
+		value, err := parseTimestampCriterionValue(c.Value)
+		if err != nil {
+			f.setError(err)
+			return
+		}
+
+		var upper string
+		if c.Modifier == models.CriterionModifierBetween || c.Modifier == models.CriterionModifierNotBetween {
+			if c.Value2 == nil {
+				f.setError(fmt.Errorf("upper value required for %s timestamp criterion", c.Modifier))
+				return
+			}
+
+			upper, err = parseTimestampCriterionValue(*c.Value2)
+			if err != nil {
+				f.setError(err)
+				return
+			}
+		}
+
+		clause, args, err := getComparisonWhereClause(column, c.Modifier, value, upper)
+		if err != nil {
+			f.setError(err)
+			return
+		}
+		f.addWhere(clause, args...)
+	}
+}
+
+// parseTimestampCriterionValue returns the criterion value in the form of
+// normalised timestamp column values.
+func parseTimestampCriterionValue(v string) (string, error) {
+	t, err := utils.ParseDateStringAsTime(v)
+	if err != nil {
+		return "", fmt.Errorf("invalid timestamp criterion value %q", v)
+	}
+
+	return t.UTC().Format("2006-01-02 15:04:05"), nil
+}
+
 func boolCriterionHandler(c *bool, column string) criterionHandlerFunc {
 	return func(f *filterBuilder) {
 		if c != nil {
@@ -779,3 +869,43 @@ func (m *joinedHierarchicalMultiCriterionHandlerBuilder) handler(criterion *mode
 		}
 	}
 }
+
+// relatedFilterCriterionHandlerBuilder restricts the results to objects that
+// are related to at least one object matching a filter of the related type.
+type relatedFilterCriterionHandlerBuilder struct {
+	primaryTable string
+
+	// table relating the primary objects to the related objects. If empty,
+	// foreignFK is a column of the primary table.
+	joinTable string
+	primaryFK string
+	foreignFK string
+}
+
+// handler returns a handler that adds a correlated subquery matching the
+// related objects selected by subQuery.
+func (m *relatedFilterCriterionHandlerBuilder) handler(subQuery queryBuilder) criterionHandlerFunc {
+	return func(f *filterBuilder) {
+		if subQuery.err != nil {
+			f.setError(subQuery.err)
+			return
+		}
+
+		const includeSortPagination = false
+		subQuerySQL := subQuery.toSQL(includeSortPagination)
+
+		if m.joinTable == "" {
+			f.addWhere(fmt.Sprintf("%s IN (%s)", getColumn(m.primaryTable, m.foreignFK), subQuerySQL), subQuery.allArgs()...)
+			return
+		}
+
+		f.addWhere(utils.StrFormat("EXISTS (SELECT 1 FROM {joinTable} AS {as} WHERE {as}.{primaryFK} = {primaryTable}.id AND {as}.{foreignFK} IN ({subQuery}))", utils.StrFormatMap{
+			"joinTable":    m.joinTable,
+			"as":           m.joinTable + "_filter",
+			"primaryFK":    m.primaryFK,
+			"primaryTable": m.primaryTable,
+			"foreignFK":    m.foreignFK,
+			"subQuery":     subQuerySQL,
+		}), subQuery.allArgs()...)
+	}
+}
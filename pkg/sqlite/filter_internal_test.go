@@ -429,6 +429,27 @@ func TestGetAllJoins(t *testing.T) {
 	assert.Len(joins, 2)
 }
 
+func TestQueryBuilderAllArgs(t *testing.T) {
+	qb := queryBuilder{}
+
+	qb.addWhere("where = ?")
+	qb.addArg("where")
+
+	f := &filterBuilder{}
+	f.addWith("with AS (SELECT ?)", "with")
+	f.addWhere("filter = ?", "filter")
+	qb.addFilter(f)
+
+	// joins added after where clauses precede them in the query
+	qb.addJoins(join{
+		table: "(SELECT ?)",
+		as:    "joined",
+		args:  []interface{}{"join"},
+	})
+
+	assert.Equal(t, []interface{}{"with", "join", "where", "filter"}, qb.allArgs())
+}
+
 func TestGetError(t *testing.T) {
 	assert := assert.New(t)
 	f := &filterBuilder{}
@@ -637,3 +658,30 @@ func TestStringCriterionHandlerNotNull(t *testing.T) {
 	assert.Equal(fmt.Sprintf("(%[1]s IS NOT NULL AND TRIM(%[1]s) != '')", column), f.whereClauses[0].sql)
 	assert.Len(f.whereClauses[0].args, 0)
 }
+
+func TestComparisonCriterionHandlerUnsupportedModifier(t *testing.T) {
+	assert := assert.New(t)
+
+	handlers := []criterionHandlerFunc{
+		floatCriterionHandler(&models.FloatCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    1,
+		}, "column"),
+		sizeCriterionHandler(&models.FloatCriterionInput{
+			Modifier: models.CriterionModifierExcludes,
+			Value:    1,
+		}, "column"),
+		timestampCriterionHandler(&models.TimestampCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    "2021-01-01",
+		}, "column"),
+	}
+
+	for _, h := range handlers {
+		f := &filterBuilder{}
+		h(f)
+
+		assert.NotNil(f.getError())
+		assert.Len(f.whereClauses, 0)
+	}
+}
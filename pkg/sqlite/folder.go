@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const folderTable = "folders"
+const folderIDColumn = "folder_id"
+
+type folderQueryBuilder struct {
+	repository
+}
+
+func NewFolderReaderWriter(tx dbi) *folderQueryBuilder {
+	return &folderQueryBuilder{
+		repository{
+			tx:        tx,
+			tableName: folderTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+func (qb *folderQueryBuilder) Create(newObject models.Folder) (*models.Folder, error) {
+	var ret models.Folder
+	if err := qb.insertObject(newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *folderQueryBuilder) UpdateFull(updatedObject models.Folder) (*models.Folder, error) {
+	const partial = false
+	if err := qb.update(updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	return qb.Find(updatedObject.ID)
+}
+
+func (qb *folderQueryBuilder) Destroy(id int) error {
+	return qb.destroyExisting([]int{id})
+}
+
+func (qb *folderQueryBuilder) Find(id int) (*models.Folder, error) {
+	var ret models.Folder
+	if err := qb.get(id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *folderQueryBuilder) FindByPath(path string) (*models.Folder, error) {
+	query := selectAll(folderTable) + "WHERE path = ? LIMIT 1"
+	args := []interface{}{path}
+	return qb.queryFolder(query, args)
+}
+
+func (qb *folderQueryBuilder) FindByParentFolderID(parentFolderID int) ([]*models.Folder, error) {
+	query := selectAll(folderTable) + "WHERE parent_folder_id = ? ORDER BY path ASC"
+	args := []interface{}{parentFolderID}
+	return qb.queryFolders(query, args)
+}
+
+func (qb *folderQueryBuilder) All() ([]*models.Folder, error) {
+	return qb.queryFolders(selectAll(folderTable)+qb.getFolderSort(nil), nil)
+}
+
+func (qb *folderQueryBuilder) Query(parentFolderID *int, findFilter *models.FindFilterType) ([]*models.Folder, int, error) {
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	query := qb.newQuery()
+	distinctIDs(&query, folderTable)
+
+	if parentFolderID != nil {
+		query.addWhere("folders.parent_folder_id = ?")
+		query.addArg(*parentFolderID)
+	} else {
+		query.addWhere("folders.parent_folder_id IS NULL")
+	}
+
+	if q := findFilter.Q; q != nil && *q != "" {
+		query.parseQueryString([]string{"folders.path"}, *q)
+	}
+
+	query.sortAndPagination = qb.getFolderSort(findFilter) + getPagination(findFilter)
+	idsResult, countResult, err := query.executeFind()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var folders []*models.Folder
+	for _, id := range idsResult {
+		folder, err := qb.Find(id)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		folders = append(folders, folder)
+	}
+
+	return folders, countResult, nil
+}
+
+func (qb *folderQueryBuilder) getFolderSort(findFilter *models.FindFilterType) string {
+	var sort string
+	var direction string
+	if findFilter == nil {
+		sort = "path"
+		direction = "ASC"
+	} else {
+		sort = findFilter.GetSort("path")
+		direction = findFilter.GetDirection()
+	}
+
+	switch sort {
+	case "scenes_count":
+		return getCountSort(folderTable, sceneTable, folderIDColumn, direction)
+	case "images_count":
+		return getCountSort(folderTable, imageTable, folderIDColumn, direction)
+	case "galleries_count":
+		return getCountSort(folderTable, galleryTable, folderIDColumn, direction)
+	default:
+		return getSort(sort, direction, folderTable)
+	}
+}
+
+func (qb *folderQueryBuilder) queryFolder(query string, args []interface{}) (*models.Folder, error) {
+	results, err := qb.queryFolders(query, args)
+	if err != nil || len(results) < 1 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (qb *folderQueryBuilder) queryFolders(query string, args []interface{}) ([]*models.Folder, error) {
+	var ret models.Folders
+	if err := qb.query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.Folder(ret), nil
+}
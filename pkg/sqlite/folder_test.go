@@ -0,0 +1,121 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFolders(t *testing.T, qb models.FolderReaderWriter) (root *models.Folder, child *models.Folder) {
+	rootPath := filepath.Join("folder_test", "root")
+	root, err := qb.Create(*models.NewFolder(rootPath))
+	if err != nil {
+		t.Fatalf("Error creating root folder: %s", err.Error())
+	}
+
+	newFolder := models.NewFolder(filepath.Join(rootPath, "child"))
+	newFolder.ParentFolderID = sql.NullInt64{Int64: int64(root.ID), Valid: true}
+	child, err = qb.Create(*newFolder)
+	if err != nil {
+		t.Fatalf("Error creating child folder: %s", err.Error())
+	}
+
+	return root, child
+}
+
+func TestFolderQuery(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		qb := r.Folder()
+		root, child := createFolders(t, qb)
+
+		found, err := qb.FindByPath(child.Path)
+		if err != nil {
+			t.Errorf("Error finding folder by path: %s", err.Error())
+		}
+		assert.Equal(t, child.ID, found.ID)
+		assert.Equal(t, "child", found.Basename())
+
+		// root folders
+		folders, _, err := qb.Query(nil, nil)
+		if err != nil {
+			t.Errorf("Error querying folders: %s", err.Error())
+		}
+		ids := make([]int, len(folders))
+		for i, f := range folders {
+			ids[i] = f.ID
+		}
+		assert.Contains(t, ids, root.ID)
+		assert.NotContains(t, ids, child.ID)
+
+		// sub-folders
+		folders, count, err := qb.Query(&root.ID, nil)
+		if err != nil {
+			t.Errorf("Error querying folders: %s", err.Error())
+		}
+		assert.Equal(t, 1, count)
+		if assert.Len(t, folders, 1) {
+			assert.Equal(t, child.ID, folders[0].ID)
+		}
+
+		return nil
+	})
+}
+
+func TestSceneQueryParentFolder(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		root, child := createFolders(t, r.Folder())
+		sqb := r.Scene()
+
+		sceneID := sceneIDs[sceneIdxWithGallery]
+		folderID := sql.NullInt64{Int64: int64(child.ID), Valid: true}
+		if _, err := sqb.Update(models.ScenePartial{
+			ID:       sceneID,
+			FolderID: &folderID,
+		}); err != nil {
+			t.Errorf("Error updating scene: %s", err.Error())
+			return nil
+		}
+
+		count, err := scene.CountByFolderID(sqb, child.ID, nil)
+		if err != nil {
+			t.Errorf("Error counting scenes: %s", err.Error())
+		}
+		assert.Equal(t, 1, count)
+
+		// scene is not directly within the root folder
+		count, err = scene.CountByFolderID(sqb, root.ID, nil)
+		if err != nil {
+			t.Errorf("Error counting scenes: %s", err.Error())
+		}
+		assert.Equal(t, 0, count)
+
+		// scene is included with sub-folders
+		depth := -1
+		count, err = scene.CountByFolderID(sqb, root.ID, &depth)
+		if err != nil {
+			t.Errorf("Error counting scenes: %s", err.Error())
+		}
+		assert.Equal(t, 1, count)
+
+		// scene folder is unset when the folder is removed
+		if err := r.Folder().Destroy(root.ID); err != nil {
+			t.Errorf("Error destroying folder: %s", err.Error())
+			return nil
+		}
+
+		s, err := sqb.Find(sceneID)
+		if err != nil {
+			t.Errorf("Error finding scene: %s", err.Error())
+		}
+		assert.False(t, s.FolderID.Valid)
+
+		return nil
+	})
+}
@@ -216,6 +216,7 @@ func (qb *galleryQueryBuilder) makeFilter(galleryFilter *models.GalleryFilterTyp
 	query.handleCriterion(galleryTagCountCriterionHandler(qb, galleryFilter.TagCount))
 	query.handleCriterion(galleryPerformersCriterionHandler(qb, galleryFilter.Performers))
 	query.handleCriterion(galleryPerformerCountCriterionHandler(qb, galleryFilter.PerformerCount))
+	query.handleCriterion(galleryParentFolderCriterionHandler(qb, galleryFilter.ParentFolder))
 	query.handleCriterion(galleryStudioCriterionHandler(qb, galleryFilter.Studios))
 	query.handleCriterion(galleryPerformerTagsCriterionHandler(qb, galleryFilter.PerformerTags))
 	query.handleCriterion(galleryAverageResolutionCriterionHandler(qb, galleryFilter.AverageResolution))
@@ -223,6 +224,26 @@ func (qb *galleryQueryBuilder) makeFilter(galleryFilter *models.GalleryFilterTyp
 	query.handleCriterion(galleryPerformerFavoriteCriterionHandler(galleryFilter.PerformerFavorite))
 	query.handleCriterion(galleryPerformerAgeCriterionHandler(galleryFilter.PerformerAge))
 
+	query.handleCriterion(galleryPerformersFilterCriterionHandler(qb, galleryFilter.PerformersFilter))
+	query.handleCriterion(galleryStudiosFilterCriterionHandler(qb, galleryFilter.StudiosFilter))
+	query.handleCriterion(galleryTagsFilterCriterionHandler(qb, galleryFilter.TagsFilter))
+
+	return query
+}
+
+// filterSubQuery returns a query selecting the ids of the galleries matching
+// the provided filter.
+func (qb *galleryQueryBuilder) filterSubQuery(filter *models.GalleryFilterType) queryBuilder {
+	query := qb.newQuery()
+	distinctIDs(&query, galleryTable)
+
+	if err := qb.validateFilter(filter); err != nil {
+		query.err = err
+		return query
+	}
+
+	query.addFilter(qb.makeFilter(filter))
+
 	return query
 }
 
@@ -374,6 +395,20 @@ func galleryImageCountCriterionHandler(qb *galleryQueryBuilder, imageCount *mode
 	return h.handler(imageCount)
 }
 
+func galleryParentFolderCriterionHandler(qb *galleryQueryBuilder, folders *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
+	h := hierarchicalMultiCriterionHandlerBuilder{
+		tx: qb.tx,
+
+		primaryTable: galleryTable,
+		foreignTable: folderTable,
+		foreignFK:    folderIDColumn,
+		derivedTable: "parent_folder",
+		parentFK:     "parent_folder_id",
+	}
+
+	return h.handler(folders)
+}
+
 func galleryStudioCriterionHandler(qb *galleryQueryBuilder, studios *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
 	h := hierarchicalMultiCriterionHandlerBuilder{
 		tx: qb.tx,
@@ -388,6 +423,49 @@ func galleryStudioCriterionHandler(qb *galleryQueryBuilder, studios *models.Hier
 	return h.handler(studios)
 }
 
+func galleryPerformersFilterCriterionHandler(qb *galleryQueryBuilder, filter *models.PerformerFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: galleryTable,
+		joinTable:    performersGalleriesTable,
+		primaryFK:    galleryIDColumn,
+		foreignFK:    performerIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewPerformerReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func galleryStudiosFilterCriterionHandler(qb *galleryQueryBuilder, filter *models.StudioFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: galleryTable,
+		foreignFK:    studioIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewStudioReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func galleryTagsFilterCriterionHandler(qb *galleryQueryBuilder, filter *models.TagFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: galleryTable,
+		joinTable:    galleriesTagsTable,
+		primaryFK:    galleryIDColumn,
+		foreignFK:    tagIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewTagReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
 func galleryPerformerTagsCriterionHandler(qb *galleryQueryBuilder, tags *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
 	return func(f *filterBuilder) {
 		if tags != nil {
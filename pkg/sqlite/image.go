@@ -9,10 +9,17 @@ import (
 )
 
 const imageTable = "images"
+const imagesFTSTable = "images_fts"
 const imageIDColumn = "image_id"
 const performersImagesTable = "performers_images"
 const imagesTagsTable = "images_tags"
 
+var imageFullTextSearch = fullTextSearch{
+	ftsTable: imagesFTSTable,
+	idColumn: "images.id",
+	columns:  []string{"images.title", "images.path", "images.checksum"},
+}
+
 var imagesForGalleryQuery = selectAll(imageTable) + `
 INNER JOIN galleries_images as galleries_join on galleries_join.image_id = images.id
 WHERE galleries_join.gallery_id = ?
@@ -168,7 +175,7 @@ func (qb *imageQueryBuilder) FindByPath(path string) (*models.Image, error) {
 
 func (qb *imageQueryBuilder) FindByGalleryID(galleryID int) ([]*models.Image, error) {
 	args := []interface{}{galleryID}
-	return qb.queryImages(imagesForGalleryQuery+qb.getImageSort(nil), args)
+	return qb.queryImages(imagesForGalleryQuery+qb.getImageSort(nil, nil), args)
 }
 
 func (qb *imageQueryBuilder) CountByGalleryID(galleryID int) (int, error) {
@@ -185,7 +192,7 @@ func (qb *imageQueryBuilder) Size() (float64, error) {
 }
 
 func (qb *imageQueryBuilder) All() ([]*models.Image, error) {
-	return qb.queryImages(selectAll(imageTable)+qb.getImageSort(nil), nil)
+	return qb.queryImages(selectAll(imageTable)+qb.getImageSort(nil, nil), nil)
 }
 
 func (qb *imageQueryBuilder) validateFilter(imageFilter *models.ImageFilterType) error {
@@ -238,7 +245,19 @@ func (qb *imageQueryBuilder) makeFilter(imageFilter *models.ImageFilterType) *fi
 	query.handleCriterion(intCriterionHandler(imageFilter.Rating, "images.rating"))
 	query.handleCriterion(intCriterionHandler(imageFilter.OCounter, "images.o_counter"))
 	query.handleCriterion(boolCriterionHandler(imageFilter.Organized, "images.organized"))
+	query.handleCriterion(boolCriterionHandler(imageFilter.IsAnimated, "images.is_animated"))
+	query.handleCriterion(timestampCriterionHandler(imageFilter.Date, "images.date"))
+	query.handleCriterion(stringCriterionHandler(imageFilter.CameraMake, "images.camera_make"))
+	query.handleCriterion(stringCriterionHandler(imageFilter.CameraModel, "images.camera_model"))
+	query.handleCriterion(stringCriterionHandler(imageFilter.Lens, "images.lens"))
+	query.handleCriterion(fileStatusCriterionHandler(imageFilter.FileStatus, "images.file_status"))
 	query.handleCriterion(resolutionCriterionHandler(imageFilter.Resolution, "images.height", "images.width"))
+	query.handleCriterion(intCriterionHandler(imageFilter.Width, "images.width"))
+	query.handleCriterion(intCriterionHandler(imageFilter.Height, "images.height"))
+	query.handleCriterion(sizeCriterionHandler(imageFilter.Size, "images.size"))
+	query.handleCriterion(timestampCriterionHandler(imageFilter.CreatedAt, "images.created_at"))
+	query.handleCriterion(timestampCriterionHandler(imageFilter.UpdatedAt, "images.updated_at"))
+	query.handleCriterion(timestampCriterionHandler(imageFilter.FileModTime, "images.file_mod_time"))
 	query.handleCriterion(imageIsMissingCriterionHandler(qb, imageFilter.IsMissing))
 
 	query.handleCriterion(imageTagsCriterionHandler(qb, imageFilter.Tags))
@@ -246,10 +265,16 @@ func (qb *imageQueryBuilder) makeFilter(imageFilter *models.ImageFilterType) *fi
 	query.handleCriterion(imageGalleriesCriterionHandler(qb, imageFilter.Galleries))
 	query.handleCriterion(imagePerformersCriterionHandler(qb, imageFilter.Performers))
 	query.handleCriterion(imagePerformerCountCriterionHandler(qb, imageFilter.PerformerCount))
+	query.handleCriterion(imageParentFolderCriterionHandler(qb, imageFilter.ParentFolder))
 	query.handleCriterion(imageStudioCriterionHandler(qb, imageFilter.Studios))
 	query.handleCriterion(imagePerformerTagsCriterionHandler(qb, imageFilter.PerformerTags))
 	query.handleCriterion(imagePerformerFavoriteCriterionHandler(imageFilter.PerformerFavorite))
 
+	query.handleCriterion(imagePerformersFilterCriterionHandler(qb, imageFilter.PerformersFilter))
+	query.handleCriterion(imageStudiosFilterCriterionHandler(qb, imageFilter.StudiosFilter))
+	query.handleCriterion(imageTagsFilterCriterionHandler(qb, imageFilter.TagsFilter))
+	query.handleCriterion(imageGalleriesFilterCriterionHandler(qb, imageFilter.GalleriesFilter))
+
 	return query
 }
 
@@ -265,8 +290,7 @@ func (qb *imageQueryBuilder) makeQuery(imageFilter *models.ImageFilterType, find
 	distinctIDs(&query, imageTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		searchColumns := []string{"images.title", "images.path", "images.checksum"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(imageFullTextSearch, *q)
 	}
 
 	if err := qb.validateFilter(imageFilter); err != nil {
@@ -276,7 +300,7 @@ func (qb *imageQueryBuilder) makeQuery(imageFilter *models.ImageFilterType, find
 
 	query.addFilter(filter)
 
-	query.sortAndPagination = qb.getImageSort(findFilter) + getPagination(findFilter)
+	query.sortAndPagination = qb.getImageSort(&query, findFilter) + getPagination(findFilter)
 
 	return &query, nil
 }
@@ -331,7 +355,7 @@ func (qb *imageQueryBuilder) queryGroupedFields(options models.ImageQueryOptions
 		Megapixels float64
 		Size       float64
 	}{}
-	if err := qb.repository.queryStruct(aggregateQuery.toSQL(includeSortPagination), query.args, &out); err != nil {
+	if err := qb.repository.queryStruct(aggregateQuery.toSQL(includeSortPagination), query.allArgs(), &out); err != nil {
 		return nil, err
 	}
 
@@ -467,6 +491,20 @@ GROUP BY performers_images.image_id HAVING SUM(performers.favorite) = 0)`, "nofa
 	}
 }
 
+func imageParentFolderCriterionHandler(qb *imageQueryBuilder, folders *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
+	h := hierarchicalMultiCriterionHandlerBuilder{
+		tx: qb.tx,
+
+		primaryTable: imageTable,
+		foreignTable: folderTable,
+		foreignFK:    folderIDColumn,
+		derivedTable: "parent_folder",
+		parentFK:     "parent_folder_id",
+	}
+
+	return h.handler(folders)
+}
+
 func imageStudioCriterionHandler(qb *imageQueryBuilder, studios *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
 	h := hierarchicalMultiCriterionHandlerBuilder{
 		tx: qb.tx,
@@ -481,6 +519,64 @@ func imageStudioCriterionHandler(qb *imageQueryBuilder, studios *models.Hierarch
 	return h.handler(studios)
 }
 
+func imagePerformersFilterCriterionHandler(qb *imageQueryBuilder, filter *models.PerformerFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: imageTable,
+		joinTable:    performersImagesTable,
+		primaryFK:    imageIDColumn,
+		foreignFK:    performerIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewPerformerReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func imageStudiosFilterCriterionHandler(qb *imageQueryBuilder, filter *models.StudioFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: imageTable,
+		foreignFK:    studioIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewStudioReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func imageTagsFilterCriterionHandler(qb *imageQueryBuilder, filter *models.TagFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: imageTable,
+		joinTable:    imagesTagsTable,
+		primaryFK:    imageIDColumn,
+		foreignFK:    tagIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewTagReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func imageGalleriesFilterCriterionHandler(qb *imageQueryBuilder, filter *models.GalleryFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: imageTable,
+		joinTable:    galleriesImagesTable,
+		primaryFK:    imageIDColumn,
+		foreignFK:    galleryIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewGalleryReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
 func imagePerformerTagsCriterionHandler(qb *imageQueryBuilder, tags *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
 	return func(f *filterBuilder) {
 		if tags != nil {
@@ -516,13 +612,20 @@ INNER JOIN (` + valuesClause + `) t ON t.column2 = pt.tag_id
 	}
 }
 
-func (qb *imageQueryBuilder) getImageSort(findFilter *models.FindFilterType) string {
+func (qb *imageQueryBuilder) getImageSort(query *queryBuilder, findFilter *models.FindFilterType) string {
 	if findFilter == nil {
 		return " ORDER BY images.path ASC "
 	}
 	sort := findFilter.GetSort("title")
 	direction := findFilter.GetDirection()
 
+	if isRelevanceSort(sort) {
+		if relevanceSort := query.getRelevanceSort(direction, imageTable); relevanceSort != "" {
+			return relevanceSort
+		}
+		sort = "title"
+	}
+
 	switch sort {
 	case "tag_count":
 		return getCountSort(imageTable, imagesTagsTable, imageIDColumn, direction)
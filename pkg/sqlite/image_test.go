@@ -5,12 +5,15 @@ package sqlite_test
 
 import (
 	"database/sql"
+	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func TestImageFind(t *testing.T) {
@@ -414,6 +417,185 @@ func verifyImagesOCounter(t *testing.T, oCounterCriterion models.IntCriterionInp
 	})
 }
 
+func TestImageQueryWidth(t *testing.T) {
+	widthCriterion := models.IntCriterionInput{
+		Value:    1000,
+		Modifier: models.CriterionModifierGreaterThan,
+	}
+	verifyImagesWidth(t, widthCriterion)
+
+	widthCriterion.Modifier = models.CriterionModifierLessThan
+	verifyImagesWidth(t, widthCriterion)
+
+	widthCriterion.Modifier = models.CriterionModifierIsNull
+	verifyImagesWidth(t, widthCriterion)
+}
+
+func verifyImagesWidth(t *testing.T, widthCriterion models.IntCriterionInput) {
+	withTxn(func(r models.Repository) error {
+		sqb := r.Image()
+		imageFilter := models.ImageFilterType{
+			Width: &widthCriterion,
+		}
+
+		images, _, err := queryImagesWithCount(sqb, &imageFilter, nil)
+		if err != nil {
+			t.Errorf("Error querying image: %s", err.Error())
+		}
+
+		assert.Greater(t, len(images), 0)
+		for _, image := range images {
+			verifyInt64(t, image.Width, widthCriterion)
+		}
+
+		return nil
+	})
+}
+
+func TestImageQueryIsAnimated(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		sqb := r.Image()
+
+		const name = "TestImageQueryIsAnimated.gif"
+		created, err := sqb.Create(models.Image{
+			Path:       name,
+			Checksum:   utils.MD5FromString(name),
+			IsAnimated: true,
+			Duration:   sql.NullFloat64{Float64: 1.5, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating image: %s", err.Error())
+		}
+
+		isAnimated := true
+		imageFilter := models.ImageFilterType{
+			IsAnimated: &isAnimated,
+		}
+
+		images := queryImages(t, sqb, &imageFilter, nil)
+		assert.Len(t, images, 1)
+		if len(images) == 1 {
+			assert.Equal(t, created.ID, images[0].ID)
+			assert.Equal(t, 1.5, images[0].Duration.Float64)
+		}
+
+		isAnimated = false
+		images = queryImages(t, sqb, &imageFilter, nil)
+		assert.Greater(t, len(images), 0)
+		for _, image := range images {
+			assert.False(t, image.IsAnimated)
+		}
+
+		return nil
+	})
+}
+
+func TestImageQueryMetadata(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		sqb := r.Image()
+
+		const name = "TestImageQueryMetadata.jpg"
+		created, err := sqb.Create(models.Image{
+			Path:        name,
+			Checksum:    utils.MD5FromString(name),
+			Date:        models.SQLiteDate{String: "1999-12-31", Valid: true},
+			Orientation: sql.NullInt64{Int64: 1, Valid: true},
+			CameraMake:  sql.NullString{String: "TestImageQueryMetadata Make", Valid: true},
+			CameraModel: sql.NullString{String: "TestImageQueryMetadata Model", Valid: true},
+			Lens:        sql.NullString{String: "TestImageQueryMetadata Lens", Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating image: %s", err.Error())
+		}
+
+		filters := []models.ImageFilterType{
+			{
+				CameraMake: &models.StringCriterionInput{
+					Value:    "TestImageQueryMetadata Make",
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			{
+				CameraModel: &models.StringCriterionInput{
+					Value:    "TestImageQueryMetadata",
+					Modifier: models.CriterionModifierIncludes,
+				},
+			},
+			{
+				Lens: &models.StringCriterionInput{
+					Value:    "TestImageQueryMetadata Lens",
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			{
+				Date: &models.TimestampCriterionInput{
+					Value:    "2000-01-01",
+					Modifier: models.CriterionModifierLessThan,
+				},
+			},
+		}
+
+		for _, imageFilter := range filters {
+			imageFilter := imageFilter
+			images := queryImages(t, sqb, &imageFilter, nil)
+			assert.Len(t, images, 1)
+			if len(images) == 1 {
+				assert.Equal(t, created.ID, images[0].ID)
+				assert.Equal(t, "1999-12-31", images[0].Date.String)
+			}
+		}
+
+		return nil
+	})
+}
+
+func TestImageQueryFileStatus(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		sqb := r.Image()
+
+		const name = "TestImageQueryFileStatus.jpg"
+		created, err := sqb.Create(models.Image{
+			Path:            name,
+			Checksum:        utils.MD5FromString(name),
+			FileStatus:      sql.NullString{String: models.FileStatusEnumCorrupt.String(), Valid: true},
+			FileStatusError: sql.NullString{String: "unexpected EOF", Valid: true},
+			FileCheckedAt:   models.NullSQLiteTimestamp{Timestamp: time.Now(), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating image: %s", err.Error())
+		}
+
+		corrupt := models.FileStatusEnumCorrupt
+		imageFilter := models.ImageFilterType{
+			FileStatus: &models.FileStatusCriterionInput{
+				Value:    &corrupt,
+				Modifier: models.CriterionModifierEquals,
+			},
+		}
+
+		images := queryImages(t, sqb, &imageFilter, nil)
+		assert.Len(t, images, 1)
+		if len(images) == 1 {
+			assert.Equal(t, created.ID, images[0].ID)
+			assert.Equal(t, "unexpected EOF", images[0].FileStatusError.String)
+		}
+
+		// unverified images are included
+		imageFilter.FileStatus.Modifier = models.CriterionModifierNotEquals
+		images = queryImages(t, sqb, &imageFilter, nil)
+		assert.Greater(t, len(images), 0)
+		for _, image := range images {
+			assert.NotEqual(t, created.ID, image.ID)
+		}
+
+		imageFilter.FileStatus.Modifier = models.CriterionModifierNotNull
+		images = queryImages(t, sqb, &imageFilter, nil)
+		assert.Len(t, images, 1)
+
+		return nil
+	})
+}
+
 func TestImageQueryResolution(t *testing.T) {
 	verifyImagesResolution(t, models.ResolutionEnumLow)
 	verifyImagesResolution(t, models.ResolutionEnumStandard)
@@ -629,6 +811,27 @@ func TestImageQueryIsMissingRating(t *testing.T) {
 	})
 }
 
+func TestImageQueryGalleriesFilter(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		sqb := r.Image()
+		title := getGalleryStringValue(galleryIdxWithImage, titleField)
+		imageFilter := models.ImageFilterType{
+			GalleriesFilter: &models.GalleryFilterType{
+				Title: &models.StringCriterionInput{
+					Value:    title,
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+		}
+
+		images := queryImages(t, sqb, &imageFilter, nil)
+		assert.Len(t, images, 1)
+		assert.Equal(t, imageIDs[imageIdxWithGallery], images[0].ID)
+
+		return nil
+	})
+}
+
 func TestImageQueryGallery(t *testing.T) {
 	withTxn(func(r models.Repository) error {
 		sqb := r.Image()
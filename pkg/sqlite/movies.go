@@ -132,6 +132,17 @@ func (qb *movieQueryBuilder) makeFilter(movieFilter *models.MovieFilterType) *fi
 	return query
 }
 
+// filterSubQuery returns a query selecting the ids of the movies matching
+// the provided filter.
+func (qb *movieQueryBuilder) filterSubQuery(filter *models.MovieFilterType) queryBuilder {
+	query := qb.newQuery()
+	distinctIDs(&query, movieTable)
+
+	query.addFilter(qb.makeFilter(filter))
+
+	return query
+}
+
 func (qb *movieQueryBuilder) Query(movieFilter *models.MovieFilterType, findFilter *models.FindFilterType) ([]*models.Movie, int, error) {
 	if findFilter == nil {
 		findFilter = &models.FindFilterType{}
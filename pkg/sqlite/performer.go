@@ -11,10 +11,17 @@ import (
 )
 
 const performerTable = "performers"
+const performersFTSTable = "performers_fts"
 const performerIDColumn = "performer_id"
 const performersTagsTable = "performers_tags"
 const performersImageTable = "performers_image" // performer cover image
 
+var performerFullTextSearch = fullTextSearch{
+	ftsTable: performersFTSTable,
+	idColumn: "performers.id",
+	columns:  []string{"performers.name", "performers.aliases"},
+}
+
 var countPerformersForTagQuery = `
 SELECT tag_id AS id FROM performers_tags
 WHERE performers_tags.tag_id = ?
@@ -178,7 +185,7 @@ func (qb *performerQueryBuilder) Count() (int, error) {
 }
 
 func (qb *performerQueryBuilder) All() ([]*models.Performer, error) {
-	return qb.queryPerformers(selectAll("performers")+qb.getPerformerSort(nil), nil)
+	return qb.queryPerformers(selectAll("performers")+qb.getPerformerSort(nil, nil), nil)
 }
 
 func (qb *performerQueryBuilder) QueryForAutoTag(words []string) ([]*models.Performer, error) {
@@ -301,6 +308,22 @@ func (qb *performerQueryBuilder) makeFilter(filter *models.PerformerFilterType)
 	return query
 }
 
+// filterSubQuery returns a query selecting the ids of the performers matching
+// the provided filter.
+func (qb *performerQueryBuilder) filterSubQuery(filter *models.PerformerFilterType) queryBuilder {
+	query := qb.newQuery()
+	distinctIDs(&query, performerTable)
+
+	if err := qb.validateFilter(filter); err != nil {
+		query.err = err
+		return query
+	}
+
+	query.addFilter(qb.makeFilter(filter))
+
+	return query
+}
+
 func (qb *performerQueryBuilder) Query(performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) ([]*models.Performer, int, error) {
 	if performerFilter == nil {
 		performerFilter = &models.PerformerFilterType{}
@@ -313,8 +336,7 @@ func (qb *performerQueryBuilder) Query(performerFilter *models.PerformerFilterTy
 	distinctIDs(&query, performerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		searchColumns := []string{"performers.name", "performers.aliases"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(performerFullTextSearch, *q)
 	}
 
 	if err := qb.validateFilter(performerFilter); err != nil {
@@ -324,7 +346,7 @@ func (qb *performerQueryBuilder) Query(performerFilter *models.PerformerFilterTy
 
 	query.addFilter(filter)
 
-	query.sortAndPagination = qb.getPerformerSort(findFilter) + getPagination(findFilter)
+	query.sortAndPagination = qb.getPerformerSort(&query, findFilter) + getPagination(findFilter)
 	idsResult, countResult, err := query.executeFind()
 	if err != nil {
 		return nil, 0, err
@@ -517,7 +539,7 @@ func performerStudiosCriterionHandler(qb *performerQueryBuilder, studios *models
 	}
 }
 
-func (qb *performerQueryBuilder) getPerformerSort(findFilter *models.FindFilterType) string {
+func (qb *performerQueryBuilder) getPerformerSort(query *queryBuilder, findFilter *models.FindFilterType) string {
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -528,6 +550,13 @@ func (qb *performerQueryBuilder) getPerformerSort(findFilter *models.FindFilterT
 		direction = findFilter.GetDirection()
 	}
 
+	if isRelevanceSort(sort) {
+		if relevanceSort := query.getRelevanceSort(direction, performerTable); relevanceSort != "" {
+			return relevanceSort
+		}
+		sort = "name"
+	}
+
 	if sort == "tag_count" {
 		return getCountSort(performerTable, performersTagsTable, performerIDColumn, direction)
 	}
@@ -614,6 +643,24 @@ func (qb *performerQueryBuilder) UpdateStashIDs(performerID int, stashIDs []mode
 	return qb.stashIDRepository().replace(performerID, stashIDs)
 }
 
+func (qb *performerQueryBuilder) fieldSourceRepository() *fieldSourceRepository {
+	return &fieldSourceRepository{
+		repository{
+			tx:        qb.tx,
+			tableName: "performer_field_sources",
+			idColumn:  performerIDColumn,
+		},
+	}
+}
+
+func (qb *performerQueryBuilder) GetFieldSources(performerID int) ([]*models.FieldSource, error) {
+	return qb.fieldSourceRepository().get(performerID)
+}
+
+func (qb *performerQueryBuilder) UpdateFieldSources(performerID int, sources []models.FieldSource) error {
+	return qb.fieldSourceRepository().update(performerID, sources)
+}
+
 func (qb *performerQueryBuilder) FindByStashID(stashID models.StashID) ([]*models.Performer, error) {
 	query := selectAll("performers") + `
 		LEFT JOIN performer_stash_ids on performer_stash_ids.performer_id = performers.id
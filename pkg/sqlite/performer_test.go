@@ -841,6 +841,29 @@ func TestPerformerStashIDs(t *testing.T) {
 		t.Error(err.Error())
 	}
 }
+
+func TestPerformerFieldSources(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Performer()
+
+		// create performer to test against
+		const name = "TestPerformerFieldSources"
+		performer := models.Performer{
+			Name:     sql.NullString{String: name, Valid: true},
+			Checksum: utils.MD5FromString(name),
+			Favorite: sql.NullBool{Bool: false, Valid: true},
+		}
+		created, err := qb.Create(performer)
+		if err != nil {
+			return fmt.Errorf("Error creating performer: %s", err.Error())
+		}
+
+		testFieldSourceReaderWriter(t, qb, created.ID)
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
 func TestPerformerQueryRating(t *testing.T) {
 	const rating = 3
 	ratingCriterion := models.IntCriterionInput{
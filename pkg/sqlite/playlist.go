@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const playlistTable = "playlists"
+const playlistIDColumn = "playlist_id"
+const playlistsScenesTable = "playlists_scenes"
+
+type playlistQueryBuilder struct {
+	repository
+}
+
+func NewPlaylistReaderWriter(tx dbi) *playlistQueryBuilder {
+	return &playlistQueryBuilder{
+		repository{
+			tx:        tx,
+			tableName: playlistTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+func (qb *playlistQueryBuilder) Create(newObject models.Playlist) (*models.Playlist, error) {
+	var ret models.Playlist
+	if err := qb.insertObject(newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *playlistQueryBuilder) Update(updatedObject models.PlaylistPartial) (*models.Playlist, error) {
+	const partial = true
+	if err := qb.update(updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	return qb.Find(updatedObject.ID)
+}
+
+func (qb *playlistQueryBuilder) Destroy(id int) error {
+	return qb.destroyExisting([]int{id})
+}
+
+func (qb *playlistQueryBuilder) Find(id int) (*models.Playlist, error) {
+	var ret models.Playlist
+	if err := qb.get(id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *playlistQueryBuilder) FindMany(ids []int) ([]*models.Playlist, error) {
+	var playlists []*models.Playlist
+	for _, id := range ids {
+		playlist, err := qb.Find(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if playlist == nil {
+			return nil, fmt.Errorf("playlist with id %d not found", id)
+		}
+
+		playlists = append(playlists, playlist)
+	}
+
+	return playlists, nil
+}
+
+func (qb *playlistQueryBuilder) FindByName(name string) (*models.Playlist, error) {
+	query := "SELECT * FROM playlists WHERE name = ? LIMIT 1"
+	args := []interface{}{name}
+
+	var ret models.Playlists
+	if err := qb.query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) > 0 {
+		return ret[0], nil
+	}
+
+	return nil, nil
+}
+
+func (qb *playlistQueryBuilder) All() ([]*models.Playlist, error) {
+	var ret models.Playlists
+	if err := qb.query(selectAll(playlistTable)+"ORDER BY name COLLATE NOCASE ASC, id ASC", nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.Playlist(ret), nil
+}
+
+func (qb *playlistQueryBuilder) Count() (int, error) {
+	return qb.runCountQuery(qb.buildCountQuery("SELECT playlists.id FROM playlists"), nil)
+}
+
+func (qb *playlistQueryBuilder) scenesRepository() *repository {
+	return &repository{
+		tx:        qb.tx,
+		tableName: playlistsScenesTable,
+		idColumn:  playlistIDColumn,
+	}
+}
+
+func (qb *playlistQueryBuilder) GetSceneIDs(playlistID int) ([]int, error) {
+	query := fmt.Sprintf("SELECT %s as id FROM %s WHERE %s = ? ORDER BY scene_index ASC", sceneIDColumn, playlistsScenesTable, playlistIDColumn)
+	return qb.runIdsQuery(query, []interface{}{playlistID})
+}
+
+func (qb *playlistQueryBuilder) UpdateScenes(playlistID int, sceneIDs []int) error {
+	r := qb.scenesRepository()
+	if err := r.destroy([]int{playlistID}); err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf("INSERT INTO %s (%s, %s, scene_index) VALUES (?, ?, ?)", playlistsScenesTable, playlistIDColumn, sceneIDColumn)
+	for i, sceneID := range sceneIDs {
+		if _, err := qb.tx.Exec(stmt, playlistID, sceneID, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaylistCreate(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		qb := r.Playlist()
+
+		const name = "TestPlaylistCreate"
+		created, err := qb.Create(*models.NewPlaylist(name))
+		if err != nil {
+			t.Errorf("Error creating playlist: %s", err.Error())
+			return nil
+		}
+
+		found, err := qb.Find(created.ID)
+		if err != nil {
+			t.Errorf("Error finding playlist: %s", err.Error())
+		}
+
+		assert.Equal(t, name, found.Name)
+		assert.False(t, found.IsSmart())
+
+		found, err = qb.FindByName(name)
+		if err != nil {
+			t.Errorf("Error finding playlist by name: %s", err.Error())
+		}
+
+		assert.Equal(t, created.ID, found.ID)
+
+		return nil
+	})
+}
+
+func TestPlaylistUpdateScenes(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		qb := r.Playlist()
+
+		created, err := qb.Create(*models.NewPlaylist("TestPlaylistUpdateScenes"))
+		if err != nil {
+			t.Errorf("Error creating playlist: %s", err.Error())
+			return nil
+		}
+
+		// scenes are returned in playlist order
+		sceneIDs := []int{sceneIDs[sceneIdxWithTag], sceneIDs[sceneIdxWithGallery], sceneIDs[sceneIdxWithPerformer]}
+		if err := qb.UpdateScenes(created.ID, sceneIDs); err != nil {
+			t.Errorf("Error updating playlist scenes: %s", err.Error())
+		}
+
+		ids, err := qb.GetSceneIDs(created.ID)
+		if err != nil {
+			t.Errorf("Error getting playlist scenes: %s", err.Error())
+		}
+
+		assert.Equal(t, sceneIDs, ids)
+
+		// replaces the existing scenes
+		sceneIDs = sceneIDs[1:2]
+		if err := qb.UpdateScenes(created.ID, sceneIDs); err != nil {
+			t.Errorf("Error updating playlist scenes: %s", err.Error())
+		}
+
+		ids, err = qb.GetSceneIDs(created.ID)
+		if err != nil {
+			t.Errorf("Error getting playlist scenes: %s", err.Error())
+		}
+
+		assert.Equal(t, sceneIDs, ids)
+
+		return nil
+	})
+}
+
+func TestPlaylistUpdate(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		qb := r.Playlist()
+
+		created, err := qb.Create(*models.NewPlaylist("TestPlaylistUpdate"))
+		if err != nil {
+			t.Errorf("Error creating playlist: %s", err.Error())
+			return nil
+		}
+
+		name := "TestPlaylistUpdated"
+		updated, err := qb.Update(models.PlaylistPartial{
+			ID:            created.ID,
+			Name:          &name,
+			SavedFilterID: &sql.NullInt64{Int64: int64(savedFilterIDs[savedFilterIdxScene]), Valid: true},
+			SceneLimit:    &sql.NullInt64{Int64: 10, Valid: true},
+		})
+		if err != nil {
+			t.Errorf("Error updating playlist: %s", err.Error())
+			return nil
+		}
+
+		assert.Equal(t, name, updated.Name)
+		assert.True(t, updated.IsSmart())
+		assert.Equal(t, int64(10), updated.SceneLimit.Int64)
+
+		return nil
+	})
+}
+
+func TestPlaylistDestroySavedFilter(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		savedFilter, err := r.SavedFilter().Create(models.SavedFilter{
+			Mode:   models.FilterModeScenes,
+			Name:   "TestPlaylistDestroySavedFilter",
+			Filter: "{}",
+		})
+		if err != nil {
+			t.Errorf("Error creating saved filter: %s", err.Error())
+			return nil
+		}
+
+		qb := r.Playlist()
+		newPlaylist := models.NewPlaylist("TestPlaylistDestroySavedFilter")
+		newPlaylist.SavedFilterID = sql.NullInt64{Int64: int64(savedFilter.ID), Valid: true}
+		created, err := qb.Create(*newPlaylist)
+		if err != nil {
+			t.Errorf("Error creating playlist: %s", err.Error())
+			return nil
+		}
+
+		// smart playlists are destroyed with their saved filter
+		if err := r.SavedFilter().Destroy(savedFilter.ID); err != nil {
+			t.Errorf("Error destroying saved filter: %s", err.Error())
+		}
+
+		found, err := qb.Find(created.ID)
+		if err != nil {
+			t.Errorf("Error finding playlist: %s", err.Error())
+		}
+
+		assert.Nil(t, found)
+
+		return nil
+	})
+}
+
+func TestPlaylistDestroy(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		qb := r.Playlist()
+
+		created, err := qb.Create(*models.NewPlaylist("TestPlaylistDestroy"))
+		if err != nil {
+			t.Errorf("Error creating playlist: %s", err.Error())
+			return nil
+		}
+
+		if err := qb.UpdateScenes(created.ID, []int{sceneIDs[sceneIdxWithTag]}); err != nil {
+			t.Errorf("Error updating playlist scenes: %s", err.Error())
+		}
+
+		if err := qb.Destroy(created.ID); err != nil {
+			t.Errorf("Error destroying playlist: %s", err.Error())
+		}
+
+		found, err := qb.Find(created.ID)
+		if err != nil {
+			t.Errorf("Error finding playlist: %s", err.Error())
+		}
+
+		assert.Nil(t, found)
+
+		ids, err := qb.GetSceneIDs(created.ID)
+		if err != nil {
+			t.Errorf("Error getting playlist scenes: %s", err.Error())
+		}
+
+		assert.Len(t, ids, 0)
+
+		// destroying a missing playlist is an error
+		assert.NotNil(t, qb.Destroy(created.ID))
+
+		return nil
+	})
+}
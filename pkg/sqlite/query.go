@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/stashapp/stash/pkg/database"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -17,12 +18,20 @@ type queryBuilder struct {
 	joins         joins
 	whereClauses  []string
 	havingClauses []string
-	args          []interface{}
 	withClauses   []string
 	recursiveWith bool
 
+	// arguments of the with clauses, and of the where and having clauses.
+	// Join arguments are held by the joins.
+	withArgs []interface{}
+	args     []interface{}
+
 	sortAndPagination string
 
+	// rank column of the full-text search results, if the query is
+	// restricted by a full-text search
+	searchRank string
+
 	err error
 }
 
@@ -54,11 +63,26 @@ func (qb queryBuilder) toSQL(includeSortPagination bool) string {
 	return body
 }
 
+// allArgs returns the arguments of the query in the order that their
+// clauses appear in the SQL: with clauses, joins, then the where and having
+// clauses.
+func (qb queryBuilder) allArgs() []interface{} {
+	var ret []interface{}
+	ret = append(ret, qb.withArgs...)
+	ret = append(ret, qb.joins.args()...)
+	return append(ret, qb.args...)
+}
+
 func (qb queryBuilder) findIDs() ([]int, error) {
+	if qb.err != nil {
+		return nil, qb.err
+	}
+
 	const includeSortPagination = true
 	sql := qb.toSQL(includeSortPagination)
-	logger.Tracef("SQL: %s, args: %v", sql, qb.args)
-	return qb.repository.runIdsQuery(sql, qb.args)
+	args := qb.allArgs()
+	logger.Tracef("SQL: %s, args: %v", sql, args)
+	return qb.repository.runIdsQuery(sql, args)
 }
 
 func (qb queryBuilder) executeFind() ([]int, int, error) {
@@ -68,7 +92,7 @@ func (qb queryBuilder) executeFind() ([]int, int, error) {
 
 	body := qb.body()
 
-	return qb.repository.executeFindQuery(body, qb.args, qb.sortAndPagination, qb.whereClauses, qb.havingClauses, qb.withClauses, qb.recursiveWith)
+	return qb.repository.executeFindQuery(body, qb.allArgs(), qb.sortAndPagination, qb.whereClauses, qb.havingClauses, qb.withClauses, qb.recursiveWith)
 }
 
 func (qb queryBuilder) executeCount() (int, error) {
@@ -89,7 +113,7 @@ func (qb queryBuilder) executeCount() (int, error) {
 
 	body = qb.repository.buildQueryBody(body, qb.whereClauses, qb.havingClauses)
 	countQuery := withClause + qb.repository.buildCountQuery(body)
-	return qb.repository.runCountQuery(countQuery, qb.args)
+	return qb.repository.runCountQuery(countQuery, qb.allArgs())
 }
 
 func (qb *queryBuilder) addWhere(clauses ...string) {
@@ -149,10 +173,7 @@ func (qb *queryBuilder) addFilter(f *filterBuilder) {
 		qb.addWith(f.recursiveWith, clause)
 	}
 
-	if len(args) > 0 {
-		// WITH clause always comes first and thus precedes alk args
-		qb.args = append(args, qb.args...)
-	}
+	qb.withArgs = append(qb.withArgs, args...)
 
 	clause, args = f.generateWhereClauses()
 	if len(clause) > 0 {
@@ -176,8 +197,12 @@ func (qb *queryBuilder) addFilter(f *filterBuilder) {
 }
 
 func (qb *queryBuilder) parseQueryString(columns []string, q string) {
-	specs := models.ParseSearchString(q)
+	qb.addSearchSpecs(columns, models.ParseSearchString(q))
+}
 
+// addSearchSpecs restricts the query to the objects with columns matching
+// specs using LIKE.
+func (qb *queryBuilder) addSearchSpecs(columns []string, specs models.SearchSpecs) {
 	for _, t := range specs.MustHave {
 		var clauses []string
 
@@ -209,3 +234,78 @@ func (qb *queryBuilder) parseQueryString(columns []string, q string) {
 		qb.addWhere("(" + strings.Join(clauses, " OR ") + ")")
 	}
 }
+
+// fullTextSearch describes how the objects of a table are searched.
+type fullTextSearch struct {
+	// full-text index table, keyed by idColumn
+	ftsTable string
+	idColumn string
+	// columns matched using LIKE when a term cannot be matched using the
+	// index, and the joins they require
+	columns []string
+	joins   []join
+}
+
+// parseFullTextQuery restricts the query to the objects matching q. Terms
+// are matched against any part of the indexed fields. Terms are matched
+// using the full-text index where possible, which sets searchRank.
+// Otherwise, or if full-text search is not available, terms are matched
+// against the search columns using LIKE.
+func (qb *queryBuilder) parseFullTextQuery(s fullTextSearch, q string) {
+	specs := models.ParseSearchString(q)
+
+	if !database.FullTextSearchAvailable() {
+		qb.addJoins(s.joins...)
+		qb.addSearchSpecs(s.columns, specs)
+		return
+	}
+
+	ftq := getFullTextQuery(specs)
+
+	if ftq.match != "" {
+		as := s.ftsTable + "_search"
+		qb.addJoins(join{
+			table:    "(" + fullTextSearchQuery(s.ftsTable) + ")",
+			as:       as,
+			onClause: as + ".id = " + s.idColumn,
+			joinType: "INNER",
+			args:     []interface{}{ftq.match},
+		})
+		qb.searchRank = as + ".rank"
+	}
+
+	if ftq.mustNot != "" {
+		qb.addWhere(s.idColumn + " NOT IN (SELECT id FROM (" + fullTextSearchQuery(s.ftsTable) + "))")
+		qb.addArg(ftq.mustNot)
+	}
+
+	if len(ftq.like.MustHave) > 0 || len(ftq.like.AnySets) > 0 || len(ftq.like.MustNot) > 0 {
+		qb.addJoins(s.joins...)
+		qb.addSearchSpecs(s.columns, ftq.like)
+	}
+}
+
+// fullTextSearchQuery returns a query selecting the id and rank of the rows
+// of the fts5 table that match a search query placeholder. Rows with a lower
+// rank are more relevant.
+func fullTextSearchQuery(ftsTable string) string {
+	return fmt.Sprintf("SELECT rowid AS id, rank FROM %s WHERE %[1]s MATCH ?", ftsTable)
+}
+
+// getRelevanceSort returns the sort clause ordering by the relevance of the
+// full-text search results. Descending order returns the most relevant
+// results first. Returns an empty string if the query is not restricted by
+// a full-text search.
+func (qb queryBuilder) getRelevanceSort(direction string, tableName string) string {
+	if qb.searchRank == "" {
+		return ""
+	}
+
+	// lower ranks are more relevant
+	rankDirection := "ASC"
+	if getSortDirection(direction) == "ASC" {
+		rankDirection = "DESC"
+	}
+
+	return " ORDER BY " + qb.searchRank + " " + rankDirection + ", " + getColumn(tableName, "id") + " ASC"
+}
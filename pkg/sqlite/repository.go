@@ -440,6 +440,112 @@ func (r *stashIDRepository) replace(id int, newIDs []models.StashID) error {
 	return nil
 }
 
+type captionRepository struct {
+	repository
+}
+
+type sceneCaptions []*models.SceneCaption
+
+func (s *sceneCaptions) Append(o interface{}) {
+	*s = append(*s, o.(*models.SceneCaption))
+}
+
+func (s *sceneCaptions) New() interface{} {
+	return &models.SceneCaption{}
+}
+
+func (r *captionRepository) get(id int) ([]*models.SceneCaption, error) {
+	query := fmt.Sprintf("SELECT * from %s WHERE %s = ? ORDER BY source, language_code, path, stream_index", r.tableName, r.idColumn)
+	var ret sceneCaptions
+	err := r.query(query, []interface{}{id}, &ret)
+	return []*models.SceneCaption(ret), err
+}
+
+func (r *captionRepository) replace(id int, captions []models.SceneCaption) error {
+	if err := r.destroy([]int{id}); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s, language_code, caption_type, source, path, stream_index) VALUES (?, ?, ?, ?, ?, ?)", r.tableName, r.idColumn)
+	for _, c := range captions {
+		_, err := r.tx.Exec(query, id, c.LanguageCode, c.CaptionType, c.Source, c.Path, c.StreamIndex)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type audioStreamRepository struct {
+	repository
+}
+
+type audioStreams []*models.SceneAudioStream
+
+func (s *audioStreams) Append(o interface{}) {
+	*s = append(*s, o.(*models.SceneAudioStream))
+}
+
+func (s *audioStreams) New() interface{} {
+	return &models.SceneAudioStream{}
+}
+
+func (r *audioStreamRepository) get(id int) ([]*models.SceneAudioStream, error) {
+	query := fmt.Sprintf("SELECT * from %s WHERE %s = ? ORDER BY stream_index", r.tableName, r.idColumn)
+	var ret audioStreams
+	err := r.query(query, []interface{}{id}, &ret)
+	return []*models.SceneAudioStream(ret), err
+}
+
+func (r *audioStreamRepository) replace(id int, streams []models.SceneAudioStream) error {
+	if err := r.destroy([]int{id}); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s, stream_index, codec, language_code, channels, title, is_default) VALUES (?, ?, ?, ?, ?, ?, ?)", r.tableName, r.idColumn)
+	for _, s := range streams {
+		_, err := r.tx.Exec(query, id, s.StreamIndex, s.Codec, s.LanguageCode, s.Channels, s.Title, s.Default)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fieldSourceRepository struct {
+	repository
+}
+
+type fieldSources []*models.FieldSource
+
+func (s *fieldSources) Append(o interface{}) {
+	*s = append(*s, o.(*models.FieldSource))
+}
+
+func (s *fieldSources) New() interface{} {
+	return &models.FieldSource{}
+}
+
+func (r *fieldSourceRepository) get(id int) ([]*models.FieldSource, error) {
+	query := fmt.Sprintf("SELECT field, source, updated_at from %s WHERE %s = ? ORDER BY field", r.tableName, r.idColumn)
+	var ret fieldSources
+	err := r.query(query, []interface{}{id}, &ret)
+	return []*models.FieldSource(ret), err
+}
+
+// update sets the source of each of the provided fields, leaving the
+// sources of other fields unchanged.
+func (r *fieldSourceRepository) update(id int, sources []models.FieldSource) error {
+	query := fmt.Sprintf("INSERT OR REPLACE INTO %s (%s, field, source, updated_at) VALUES (?, ?, ?, ?)", r.tableName, r.idColumn)
+	for _, s := range sources {
+		_, err := r.tx.Exec(query, id, s.Field, s.Source, s.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func listKeys(i interface{}, addPrefix bool) string {
 	var query []string
 	v := reflect.ValueOf(i)
@@ -108,3 +108,27 @@ func (qb *savedFilterQueryBuilder) FindDefault(mode models.FilterMode) (*models.
 
 	return nil, nil
 }
+
+func (qb *savedFilterQueryBuilder) FindByName(mode models.FilterMode, name string) (*models.SavedFilter, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE mode = ? AND name = ?`, savedFilterTable)
+
+	var ret models.SavedFilters
+	if err := qb.query(query, []interface{}{mode, name}, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) > 0 {
+		return ret[0], nil
+	}
+
+	return nil, nil
+}
+
+func (qb *savedFilterQueryBuilder) All() ([]*models.SavedFilter, error) {
+	var ret models.SavedFilters
+	if err := qb.query(selectAll(savedFilterTable)+"ORDER BY mode, name", nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.SavedFilter(ret), nil
+}
@@ -39,6 +39,52 @@ func TestSavedFilterFindByMode(t *testing.T) {
 	})
 }
 
+func TestSavedFilterFindByName(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		name := getSavedFilterName(savedFilterIdxScene)
+		savedFilter, err := r.SavedFilter().FindByName(models.FilterModeScenes, name)
+
+		if err != nil {
+			t.Errorf("Error finding saved filter: %s", err.Error())
+		}
+
+		assert.Equal(t, savedFilterIDs[savedFilterIdxScene], savedFilter.ID)
+
+		// name must match the mode
+		savedFilter, err = r.SavedFilter().FindByName(models.FilterModePerformers, name)
+
+		if err != nil {
+			t.Errorf("Error finding saved filter: %s", err.Error())
+		}
+
+		assert.Nil(t, savedFilter)
+
+		return nil
+	})
+}
+
+func TestSavedFilterAll(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		savedFilters, err := r.SavedFilter().All()
+
+		if err != nil {
+			t.Errorf("Error finding saved filters: %s", err.Error())
+		}
+
+		// includes default filters
+		var ids []int
+		for _, f := range savedFilters {
+			ids = append(ids, f.ID)
+		}
+
+		for _, id := range savedFilterIDs {
+			assert.Contains(t, ids, id)
+		}
+
+		return nil
+	})
+}
+
 func TestSavedFilterDestroy(t *testing.T) {
 	const filterName = "filterToDestroy"
 	const testFilter = "{}"
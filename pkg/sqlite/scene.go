@@ -13,11 +13,21 @@ import (
 )
 
 const sceneTable = "scenes"
+const scenesFTSTable = "scenes_fts"
 const sceneIDColumn = "scene_id"
 const performersScenesTable = "performers_scenes"
 const scenesTagsTable = "scenes_tags"
 const scenesGalleriesTable = "scenes_galleries"
 const moviesScenesTable = "movies_scenes"
+const sceneCaptionsTable = "scene_captions"
+const sceneAudioStreamsTable = "scene_audio_streams"
+
+var sceneFullTextSearch = fullTextSearch{
+	ftsTable: scenesFTSTable,
+	idColumn: "scenes.id",
+	columns:  []string{"scenes.title", "scenes.details", "scenes.path", "scenes.oshash", "scenes.checksum", "scene_markers.title"},
+	joins:    []join{{table: "scene_markers", onClause: "scene_markers.scene_id = scenes.id", joinType: "LEFT"}},
+}
 
 var scenesForPerformerQuery = selectAll(sceneTable) + `
 LEFT JOIN performers_scenes as performers_join on performers_join.scene_id = scenes.id
@@ -374,6 +384,17 @@ func (qb *sceneQueryBuilder) makeFilter(sceneFilter *models.SceneFilterType) *fi
 	query.handleCriterion(hasMarkersCriterionHandler(sceneFilter.HasMarkers))
 	query.handleCriterion(sceneIsMissingCriterionHandler(qb, sceneFilter.IsMissing))
 	query.handleCriterion(stringCriterionHandler(sceneFilter.URL, "scenes.url"))
+	query.handleCriterion(timestampCriterionHandler(sceneFilter.Date, "scenes.date"))
+	query.handleCriterion(timestampCriterionHandler(sceneFilter.CreatedAt, "scenes.created_at"))
+	query.handleCriterion(timestampCriterionHandler(sceneFilter.UpdatedAt, "scenes.updated_at"))
+	query.handleCriterion(timestampCriterionHandler(sceneFilter.FileModTime, "scenes.file_mod_time"))
+	query.handleCriterion(floatCriterionHandler(sceneFilter.Framerate, "scenes.framerate"))
+	query.handleCriterion(intCriterionHandler(sceneFilter.Bitrate, "scenes.bitrate"))
+	query.handleCriterion(stringCriterionHandler(sceneFilter.VideoCodec, "scenes.video_codec"))
+	query.handleCriterion(stringCriterionHandler(sceneFilter.AudioCodec, "scenes.audio_codec"))
+	query.handleCriterion(stringCriterionHandler(sceneFilter.Format, "scenes.format"))
+	query.handleCriterion(sizeCriterionHandler(sceneFilter.Size, "scenes.size"))
+	query.handleCriterion(fileStatusCriterionHandler(sceneFilter.FileStatus, "scenes.file_status"))
 
 	query.handleCriterion(criterionHandlerFunc(func(f *filterBuilder) {
 		if sceneFilter.StashID != nil {
@@ -382,6 +403,13 @@ func (qb *sceneQueryBuilder) makeFilter(sceneFilter *models.SceneFilterType) *fi
 		}
 	}))
 
+	query.handleCriterion(criterionHandlerFunc(func(f *filterBuilder) {
+		if sceneFilter.Captions != nil {
+			qb.captionRepository().join(f, "", "scenes.id")
+			stringCriterionHandler(sceneFilter.Captions, sceneCaptionsTable+".language_code")(f)
+		}
+	}))
+
 	query.handleCriterion(boolCriterionHandler(sceneFilter.Interactive, "scenes.interactive"))
 	query.handleCriterion(intCriterionHandler(sceneFilter.InteractiveSpeed, "scenes.interactive_speed"))
 
@@ -389,6 +417,7 @@ func (qb *sceneQueryBuilder) makeFilter(sceneFilter *models.SceneFilterType) *fi
 	query.handleCriterion(sceneTagCountCriterionHandler(qb, sceneFilter.TagCount))
 	query.handleCriterion(scenePerformersCriterionHandler(qb, sceneFilter.Performers))
 	query.handleCriterion(scenePerformerCountCriterionHandler(qb, sceneFilter.PerformerCount))
+	query.handleCriterion(sceneParentFolderCriterionHandler(qb, sceneFilter.ParentFolder))
 	query.handleCriterion(sceneStudioCriterionHandler(qb, sceneFilter.Studios))
 	query.handleCriterion(sceneMoviesCriterionHandler(qb, sceneFilter.Movies))
 	query.handleCriterion(scenePerformerTagsCriterionHandler(qb, sceneFilter.PerformerTags))
@@ -396,6 +425,12 @@ func (qb *sceneQueryBuilder) makeFilter(sceneFilter *models.SceneFilterType) *fi
 	query.handleCriterion(scenePerformerAgeCriterionHandler(sceneFilter.PerformerAge))
 	query.handleCriterion(scenePhashDuplicatedCriterionHandler(sceneFilter.Duplicated))
 
+	query.handleCriterion(scenePerformersFilterCriterionHandler(qb, sceneFilter.PerformersFilter))
+	query.handleCriterion(sceneStudiosFilterCriterionHandler(qb, sceneFilter.StudiosFilter))
+	query.handleCriterion(sceneTagsFilterCriterionHandler(qb, sceneFilter.TagsFilter))
+	query.handleCriterion(sceneGalleriesFilterCriterionHandler(qb, sceneFilter.GalleriesFilter))
+	query.handleCriterion(sceneMoviesFilterCriterionHandler(qb, sceneFilter.MoviesFilter))
+
 	return query
 }
 
@@ -414,9 +449,7 @@ func (qb *sceneQueryBuilder) Query(options models.SceneQueryOptions) (*models.Sc
 	distinctIDs(&query, sceneTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join("scene_markers", "", "scene_markers.scene_id = scenes.id")
-		searchColumns := []string{"scenes.title", "scenes.details", "scenes.path", "scenes.oshash", "scenes.checksum", "scene_markers.title"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(sceneFullTextSearch, *q)
 	}
 
 	if err := qb.validateFilter(sceneFilter); err != nil {
@@ -473,7 +506,7 @@ func (qb *sceneQueryBuilder) queryGroupedFields(options models.SceneQueryOptions
 		Duration float64
 		Size     float64
 	}{}
-	if err := qb.repository.queryStruct(aggregateQuery.toSQL(includeSortPagination), query.args, &out); err != nil {
+	if err := qb.repository.queryStruct(aggregateQuery.toSQL(includeSortPagination), query.allArgs(), &out); err != nil {
 		return nil, err
 	}
 
@@ -553,6 +586,30 @@ func resolutionCriterionHandler(resolution *models.ResolutionCriterionInput, hei
 	}
 }
 
+func fileStatusCriterionHandler(status *models.FileStatusCriterionInput, column string) criterionHandlerFunc {
+	return func(f *filterBuilder) {
+		if status == nil {
+			return
+		}
+
+		switch status.Modifier {
+		case models.CriterionModifierIsNull:
+			f.addWhere(column + " IS NULL")
+		case models.CriterionModifierNotNull:
+			f.addWhere(column + " IS NOT NULL")
+		case models.CriterionModifierEquals:
+			if status.Value != nil {
+				f.addWhere(column+" = ?", status.Value.String())
+			}
+		case models.CriterionModifierNotEquals:
+			if status.Value != nil {
+				// unverified files do not have the status
+				f.addWhere(fmt.Sprintf("(%s IS NULL OR %s != ?)", column, column), status.Value.String())
+			}
+		}
+	}
+}
+
 func hasMarkersCriterionHandler(hasMarkers *string) criterionHandlerFunc {
 	return func(f *filterBuilder) {
 		if hasMarkers != nil {
@@ -697,6 +754,20 @@ func scenePerformerAgeCriterionHandler(performerAge *models.IntCriterionInput) c
 	}
 }
 
+func sceneParentFolderCriterionHandler(qb *sceneQueryBuilder, folders *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
+	h := hierarchicalMultiCriterionHandlerBuilder{
+		tx: qb.tx,
+
+		primaryTable: sceneTable,
+		foreignTable: folderTable,
+		foreignFK:    folderIDColumn,
+		derivedTable: "parent_folder",
+		parentFK:     "parent_folder_id",
+	}
+
+	return h.handler(folders)
+}
+
 func sceneStudioCriterionHandler(qb *sceneQueryBuilder, studios *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
 	h := hierarchicalMultiCriterionHandlerBuilder{
 		tx: qb.tx,
@@ -720,6 +791,79 @@ func sceneMoviesCriterionHandler(qb *sceneQueryBuilder, movies *models.MultiCrit
 	return h.handler(movies)
 }
 
+func scenePerformersFilterCriterionHandler(qb *sceneQueryBuilder, filter *models.PerformerFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		joinTable:    performersScenesTable,
+		primaryFK:    sceneIDColumn,
+		foreignFK:    performerIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewPerformerReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func sceneStudiosFilterCriterionHandler(qb *sceneQueryBuilder, filter *models.StudioFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		foreignFK:    studioIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewStudioReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func sceneTagsFilterCriterionHandler(qb *sceneQueryBuilder, filter *models.TagFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		joinTable:    scenesTagsTable,
+		primaryFK:    sceneIDColumn,
+		foreignFK:    tagIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewTagReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func sceneGalleriesFilterCriterionHandler(qb *sceneQueryBuilder, filter *models.GalleryFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		joinTable:    scenesGalleriesTable,
+		primaryFK:    sceneIDColumn,
+		foreignFK:    galleryIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewGalleryReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
+func sceneMoviesFilterCriterionHandler(qb *sceneQueryBuilder, filter *models.MovieFilterType) criterionHandlerFunc {
+	h := relatedFilterCriterionHandlerBuilder{
+		primaryTable: sceneTable,
+		joinTable:    moviesScenesTable,
+		primaryFK:    sceneIDColumn,
+		foreignFK:    movieIDColumn,
+	}
+
+	return func(f *filterBuilder) {
+		if filter != nil {
+			h.handler(NewMovieReaderWriter(qb.tx).filterSubQuery(filter))(f)
+		}
+	}
+}
+
 func scenePerformerTagsCriterionHandler(qb *sceneQueryBuilder, tags *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
 	return func(f *filterBuilder) {
 		if tags != nil {
@@ -766,6 +910,15 @@ func (qb *sceneQueryBuilder) setSceneSort(query *queryBuilder, findFilter *model
 	}
 	sort := findFilter.GetSort("title")
 	direction := findFilter.GetDirection()
+
+	if isRelevanceSort(sort) {
+		if relevanceSort := query.getRelevanceSort(direction, sceneTable); relevanceSort != "" {
+			query.sortAndPagination += relevanceSort
+			return
+		}
+		sort = "title"
+	}
+
 	switch sort {
 	case "movie_scene_number":
 		query.join(moviesScenesTable, "movies_join", "scenes.id = movies_join.scene_id")
@@ -938,6 +1091,60 @@ func (qb *sceneQueryBuilder) UpdateStashIDs(sceneID int, stashIDs []models.Stash
 	return qb.stashIDRepository().replace(sceneID, stashIDs)
 }
 
+func (qb *sceneQueryBuilder) fieldSourceRepository() *fieldSourceRepository {
+	return &fieldSourceRepository{
+		repository{
+			tx:        qb.tx,
+			tableName: "scene_field_sources",
+			idColumn:  sceneIDColumn,
+		},
+	}
+}
+
+func (qb *sceneQueryBuilder) GetFieldSources(sceneID int) ([]*models.FieldSource, error) {
+	return qb.fieldSourceRepository().get(sceneID)
+}
+
+func (qb *sceneQueryBuilder) UpdateFieldSources(sceneID int, sources []models.FieldSource) error {
+	return qb.fieldSourceRepository().update(sceneID, sources)
+}
+
+func (qb *sceneQueryBuilder) captionRepository() *captionRepository {
+	return &captionRepository{
+		repository{
+			tx:        qb.tx,
+			tableName: sceneCaptionsTable,
+			idColumn:  sceneIDColumn,
+		},
+	}
+}
+
+func (qb *sceneQueryBuilder) GetCaptions(sceneID int) ([]*models.SceneCaption, error) {
+	return qb.captionRepository().get(sceneID)
+}
+
+func (qb *sceneQueryBuilder) UpdateCaptions(sceneID int, captions []models.SceneCaption) error {
+	return qb.captionRepository().replace(sceneID, captions)
+}
+
+func (qb *sceneQueryBuilder) audioStreamRepository() *audioStreamRepository {
+	return &audioStreamRepository{
+		repository{
+			tx:        qb.tx,
+			tableName: sceneAudioStreamsTable,
+			idColumn:  sceneIDColumn,
+		},
+	}
+}
+
+func (qb *sceneQueryBuilder) GetAudioStreams(sceneID int) ([]*models.SceneAudioStream, error) {
+	return qb.audioStreamRepository().get(sceneID)
+}
+
+func (qb *sceneQueryBuilder) UpdateAudioStreams(sceneID int, streams []models.SceneAudioStream) error {
+	return qb.audioStreamRepository().replace(sceneID, streams)
+}
+
 func (qb *sceneQueryBuilder) FindDuplicates(distance int) ([][]*models.Scene, error) {
 	var dupeIds [][]int
 	if distance == 0 {
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const sceneFileTable = "scene_files"
+
+type sceneFileQueryBuilder struct {
+	repository
+}
+
+func NewSceneFileReaderWriter(tx dbi) *sceneFileQueryBuilder {
+	return &sceneFileQueryBuilder{
+		repository{
+			tx:        tx,
+			tableName: sceneFileTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+func (qb *sceneFileQueryBuilder) Create(newObject models.SceneFile) (*models.SceneFile, error) {
+	var ret models.SceneFile
+	if err := qb.insertObject(newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *sceneFileQueryBuilder) UpdateFull(updatedObject models.SceneFile) (*models.SceneFile, error) {
+	const partial = false
+	if err := qb.update(updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	return qb.Find(updatedObject.ID)
+}
+
+func (qb *sceneFileQueryBuilder) Destroy(id int) error {
+	return qb.destroyExisting([]int{id})
+}
+
+func (qb *sceneFileQueryBuilder) Find(id int) (*models.SceneFile, error) {
+	var ret models.SceneFile
+	if err := qb.get(id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *sceneFileQueryBuilder) FindByPath(path string) (*models.SceneFile, error) {
+	query := selectAll(sceneFileTable) + "WHERE path = ? LIMIT 1"
+	args := []interface{}{path}
+	return qb.querySceneFile(query, args)
+}
+
+func (qb *sceneFileQueryBuilder) FindByChecksum(checksum string) (*models.SceneFile, error) {
+	query := selectAll(sceneFileTable) + "WHERE checksum = ? LIMIT 1"
+	args := []interface{}{checksum}
+	return qb.querySceneFile(query, args)
+}
+
+func (qb *sceneFileQueryBuilder) FindByOSHash(oshash string) (*models.SceneFile, error) {
+	query := selectAll(sceneFileTable) + "WHERE oshash = ? LIMIT 1"
+	args := []interface{}{oshash}
+	return qb.querySceneFile(query, args)
+}
+
+func (qb *sceneFileQueryBuilder) FindBySceneID(sceneID int) ([]*models.SceneFile, error) {
+	query := selectAll(sceneFileTable) + "WHERE scene_id = ? ORDER BY is_primary DESC, path ASC"
+	args := []interface{}{sceneID}
+	return qb.querySceneFiles(query, args)
+}
+
+func (qb *sceneFileQueryBuilder) querySceneFile(query string, args []interface{}) (*models.SceneFile, error) {
+	results, err := qb.querySceneFiles(query, args)
+	if err != nil || len(results) < 1 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (qb *sceneFileQueryBuilder) querySceneFiles(query string, args []interface{}) ([]*models.SceneFile, error) {
+	var ret models.SceneFiles
+	if err := qb.query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.SceneFile(ret), nil
+}
@@ -0,0 +1,170 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	additionalFilePath     = "additional_file_path.mp4"
+	additionalFileChecksum = "additional_file_checksum"
+	additionalFileOSHash   = "additional_file_oshash"
+)
+
+func createSceneFiles(t *testing.T, r models.Repository, s *models.Scene) (primary *models.SceneFile, additional *models.SceneFile) {
+	fqb := r.SceneFile()
+
+	primary, err := fqb.Create(*models.NewSceneFile(s))
+	if err != nil {
+		t.Fatalf("Error creating primary file: %s", err.Error())
+	}
+
+	newFile := *models.NewSceneFile(s)
+	newFile.Path = additionalFilePath
+	newFile.Checksum = models.NullString(additionalFileChecksum)
+	newFile.OSHash = models.NullString(additionalFileOSHash)
+	newFile.Primary = false
+
+	additional, err = fqb.Create(newFile)
+	if err != nil {
+		t.Fatalf("Error creating additional file: %s", err.Error())
+	}
+
+	return primary, additional
+}
+
+func TestSceneFileFind(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		s, err := r.Scene().Find(sceneIDs[sceneIdxWithGallery])
+		if err != nil {
+			t.Errorf("Error finding scene: %s", err.Error())
+			return nil
+		}
+
+		primary, additional := createSceneFiles(t, r, s)
+		fqb := r.SceneFile()
+
+		found, err := fqb.FindByPath(s.Path)
+		if err != nil {
+			t.Errorf("Error finding file by path: %s", err.Error())
+		}
+		assert.Equal(t, primary.ID, found.ID)
+		assert.True(t, found.Primary)
+
+		found, err = fqb.FindByChecksum(additionalFileChecksum)
+		if err != nil {
+			t.Errorf("Error finding file by checksum: %s", err.Error())
+		}
+		assert.Equal(t, additional.ID, found.ID)
+
+		found, err = fqb.FindByOSHash(additionalFileOSHash)
+		if err != nil {
+			t.Errorf("Error finding file by oshash: %s", err.Error())
+		}
+		assert.Equal(t, additional.ID, found.ID)
+
+		found, err = fqb.FindByPath("not_exist")
+		if err != nil {
+			t.Errorf("Error finding file by path: %s", err.Error())
+		}
+		assert.Nil(t, found)
+
+		// primary file is returned first
+		files, err := fqb.FindBySceneID(s.ID)
+		if err != nil {
+			t.Errorf("Error finding files by scene id: %s", err.Error())
+		}
+		if assert.Len(t, files, 2) {
+			assert.Equal(t, primary.ID, files[0].ID)
+			assert.Equal(t, additional.ID, files[1].ID)
+		}
+
+		return nil
+	})
+}
+
+func TestSceneFileSetPrimary(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		qb := r.Scene()
+		fqb := r.SceneFile()
+
+		s, err := qb.Find(sceneIDs[sceneIdxWithPerformer])
+		if err != nil {
+			t.Errorf("Error finding scene: %s", err.Error())
+			return nil
+		}
+
+		primary, additional := createSceneFiles(t, r, s)
+
+		if err := scene.SetPrimaryFile(qb, fqb, s, additional.ID); err != nil {
+			t.Errorf("Error setting primary file: %s", err.Error())
+			return nil
+		}
+
+		found, err := qb.Find(s.ID)
+		if err != nil {
+			t.Errorf("Error finding scene: %s", err.Error())
+		}
+		assert.Equal(t, additionalFilePath, found.Path)
+		assert.Equal(t, additionalFileChecksum, found.Checksum.String)
+
+		files, err := fqb.FindBySceneID(s.ID)
+		if err != nil {
+			t.Errorf("Error finding files by scene id: %s", err.Error())
+		}
+		if assert.Len(t, files, 2) {
+			assert.Equal(t, additional.ID, files[0].ID)
+			assert.True(t, files[0].Primary)
+			assert.Equal(t, primary.ID, files[1].ID)
+			assert.False(t, files[1].Primary)
+		}
+
+		// removing the primary file promotes the remaining file
+		remaining, err := scene.RemoveFile(qb, fqb, s, files[0])
+		if err != nil {
+			t.Errorf("Error removing file: %s", err.Error())
+		}
+		assert.Equal(t, 1, remaining)
+		assert.Equal(t, primary.Path, s.Path)
+
+		found, err = qb.Find(s.ID)
+		if err != nil {
+			t.Errorf("Error finding scene: %s", err.Error())
+		}
+		assert.Equal(t, primary.Path, found.Path)
+
+		return nil
+	})
+}
+
+func TestSceneFileDestroyScene(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		s, err := r.Scene().Find(sceneIDs[sceneIdxWithTag])
+		if err != nil {
+			t.Errorf("Error finding scene: %s", err.Error())
+			return nil
+		}
+
+		createSceneFiles(t, r, s)
+
+		if err := r.Scene().Destroy(s.ID); err != nil {
+			t.Errorf("Error destroying scene: %s", err.Error())
+			return nil
+		}
+
+		// files are removed with the scene
+		files, err := r.SceneFile().FindBySceneID(s.ID)
+		if err != nil {
+			t.Errorf("Error finding files by scene id: %s", err.Error())
+		}
+		assert.Len(t, files, 0)
+
+		return nil
+	})
+}
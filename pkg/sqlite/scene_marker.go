@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/stashapp/stash/pkg/database"
 	"github.com/stashapp/stash/pkg/models"
@@ -340,3 +341,128 @@ func (qb *sceneMarkerQueryBuilder) UpdateTags(id int, tagIDs []int) error {
 	// Delete the existing joins and then create new ones
 	return qb.tagsRepository().replace(id, tagIDs)
 }
+
+const sceneMarkerSuggestionsTable = "scene_marker_suggestions"
+
+func (qb *sceneMarkerQueryBuilder) suggestionsRepository() *repository {
+	return &repository{
+		tx:        qb.tx,
+		tableName: sceneMarkerSuggestionsTable,
+		idColumn:  idColumn,
+	}
+}
+
+func (qb *sceneMarkerQueryBuilder) FindSuggestions(ids []int) ([]*models.SceneMarkerSuggestion, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s WHERE id IN %s ORDER BY scene_id, seconds", sceneMarkerSuggestionsTable, getInBinding(len(ids)))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	var ret models.SceneMarkerSuggestions
+	if err := qb.suggestionsRepository().query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.SceneMarkerSuggestion(ret), nil
+}
+
+func (qb *sceneMarkerQueryBuilder) GetSuggestions(sceneID *int) ([]*models.SceneMarkerSuggestion, error) {
+	query := fmt.Sprintf("SELECT * FROM %s", sceneMarkerSuggestionsTable)
+	var args []interface{}
+	if sceneID != nil {
+		query += " WHERE scene_id = ?"
+		args = append(args, *sceneID)
+	}
+	query += " ORDER BY scene_id, seconds"
+
+	var ret models.SceneMarkerSuggestions
+	if err := qb.suggestionsRepository().query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.SceneMarkerSuggestion(ret), nil
+}
+
+func (qb *sceneMarkerQueryBuilder) UpdateSuggestions(sceneID int, suggestions []models.SceneMarkerSuggestion) error {
+	r := qb.suggestionsRepository()
+	r.idColumn = sceneIDColumn
+	if err := r.destroy([]int{sceneID}); err != nil {
+		return err
+	}
+
+	for _, s := range suggestions {
+		s.SceneID = sceneID
+		if _, err := r.insert(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *sceneMarkerQueryBuilder) DestroySuggestions(ids []int) error {
+	return qb.suggestionsRepository().destroy(ids)
+}
+
+const sceneChapterDetectionsTable = "scene_chapter_detections"
+const sceneMarkerSuggestionRejectionsTable = "scene_marker_suggestion_rejections"
+
+func (qb *sceneMarkerQueryBuilder) RejectSuggestions(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (scene_id, seconds) SELECT scene_id, seconds FROM %s WHERE id IN %s", sceneMarkerSuggestionRejectionsTable, sceneMarkerSuggestionsTable, getInBinding(len(ids)))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	if _, err := qb.tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	return qb.DestroySuggestions(ids)
+}
+
+func (qb *sceneMarkerQueryBuilder) GetRejectedSuggestionSeconds(sceneID int) ([]float64, error) {
+	query := fmt.Sprintf("SELECT seconds FROM %s WHERE scene_id = ? ORDER BY seconds", sceneMarkerSuggestionRejectionsTable)
+
+	var ret []float64
+	if err := qb.tx.Select(&ret, query, sceneID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *sceneMarkerQueryBuilder) ChaptersDetected(sceneID int) (bool, error) {
+	query := fmt.Sprintf("SELECT scene_id AS id FROM %s WHERE scene_id = ?", sceneChapterDetectionsTable)
+	ids, err := qb.runIdsQuery(query, []interface{}{sceneID})
+	if err != nil {
+		return false, err
+	}
+
+	return len(ids) > 0, nil
+}
+
+func (qb *sceneMarkerQueryBuilder) SetChaptersDetected(sceneID int) error {
+	query := fmt.Sprintf("INSERT OR REPLACE INTO %s (scene_id, detected_at) VALUES (?, ?)", sceneChapterDetectionsTable)
+	_, err := qb.tx.Exec(query, sceneID, models.SQLiteTimestamp{Timestamp: time.Now()})
+	return err
+}
+
+func (qb *sceneMarkerQueryBuilder) ResetChapterDetection(sceneID int) error {
+	for _, table := range []string{sceneChapterDetectionsTable, sceneMarkerSuggestionRejectionsTable} {
+		if _, err := qb.tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE scene_id = ?", table), sceneID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package sqlite_test
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/stashapp/stash/pkg/models"
@@ -207,6 +208,139 @@ func queryMarkers(t *testing.T, sqb models.SceneMarkerReader, markerFilter *mode
 	return result
 }
 
+func TestMarkerSuggestions(t *testing.T) {
+	if err := withRollbackTxn(func(r models.Repository) error {
+		mqb := r.SceneMarker()
+
+		sceneID := sceneIDs[sceneIdxWithMarkers]
+		primaryTagID := sql.NullInt64{Int64: int64(tagIDs[tagIdxWithPrimaryMarkers]), Valid: true}
+		if err := mqb.UpdateSuggestions(sceneID, []models.SceneMarkerSuggestion{
+			{Title: "Chapter 2", Seconds: 120, EndSeconds: 300, PrimaryTagID: primaryTagID},
+			{Title: "Chapter 1", Seconds: 0, EndSeconds: 120},
+		}); err != nil {
+			return err
+		}
+
+		suggestions, err := mqb.GetSuggestions(&sceneID)
+		if err != nil {
+			return err
+		}
+
+		if !assert.Len(t, suggestions, 2) {
+			return nil
+		}
+
+		// sorted by time
+		assert.Equal(t, "Chapter 1", suggestions[0].Title)
+		assert.False(t, suggestions[0].PrimaryTagID.Valid)
+		assert.Equal(t, "Chapter 2", suggestions[1].Title)
+		assert.Equal(t, primaryTagID, suggestions[1].PrimaryTagID)
+		assert.Equal(t, 300.0, suggestions[1].EndSeconds)
+
+		// suggestions are replaced
+		if err := mqb.UpdateSuggestions(sceneID, []models.SceneMarkerSuggestion{
+			{Title: "Chapter 1", Seconds: 60, EndSeconds: 300},
+		}); err != nil {
+			return err
+		}
+
+		suggestions, err = mqb.GetSuggestions(nil)
+		if err != nil {
+			return err
+		}
+
+		if !assert.Len(t, suggestions, 1) {
+			return nil
+		}
+		assert.Equal(t, 60.0, suggestions[0].Seconds)
+
+		id := suggestions[0].ID
+		found, err := mqb.FindSuggestions([]int{id})
+		if err != nil {
+			return err
+		}
+		assert.Len(t, found, 1)
+
+		if err := mqb.DestroySuggestions([]int{id}); err != nil {
+			return err
+		}
+
+		suggestions, err = mqb.GetSuggestions(&sceneID)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, suggestions, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestMarkerSuggestionsReject(t *testing.T) {
+	if err := withRollbackTxn(func(r models.Repository) error {
+		mqb := r.SceneMarker()
+
+		sceneID := sceneIDs[sceneIdxWithMarkers]
+		if err := mqb.UpdateSuggestions(sceneID, []models.SceneMarkerSuggestion{
+			{Title: "Chapter 1", Seconds: 0, EndSeconds: 120},
+			{Title: "Chapter 2", Seconds: 120, EndSeconds: 300},
+		}); err != nil {
+			return err
+		}
+		if err := mqb.SetChaptersDetected(sceneID); err != nil {
+			return err
+		}
+
+		detected, err := mqb.ChaptersDetected(sceneID)
+		if err != nil {
+			return err
+		}
+		assert.True(t, detected)
+
+		suggestions, err := mqb.GetSuggestions(&sceneID)
+		if err != nil {
+			return err
+		}
+
+		if err := mqb.RejectSuggestions([]int{suggestions[1].ID}); err != nil {
+			return err
+		}
+
+		suggestions, err = mqb.GetSuggestions(&sceneID)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, suggestions, 1)
+
+		rejected, err := mqb.GetRejectedSuggestionSeconds(sceneID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []float64{120}, rejected)
+
+		if err := mqb.ResetChapterDetection(sceneID); err != nil {
+			return err
+		}
+
+		detected, err = mqb.ChaptersDetected(sceneID)
+		if err != nil {
+			return err
+		}
+		assert.False(t, detected)
+
+		rejected, err = mqb.GetRejectedSuggestionSeconds(sceneID)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, rejected, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TODO Update
 // TODO Destroy
 // TODO Find
@@ -9,9 +9,11 @@ import (
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/stashapp/stash/pkg/database"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -650,6 +652,56 @@ func verifyFloat64(t *testing.T, value sql.NullFloat64, criterion models.IntCrit
 	}
 }
 
+func TestSceneQueryDate(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		sqb := r.Scene()
+		value2 := "2001-12-31"
+		sceneFilter := models.SceneFilterType{
+			Date: &models.TimestampCriterionInput{
+				Value:    "2001-01-01",
+				Value2:   &value2,
+				Modifier: models.CriterionModifierBetween,
+			},
+		}
+
+		scenes := queryScene(t, sqb, &sceneFilter, nil)
+		assert.Greater(t, len(scenes), 0)
+		for _, scene := range scenes {
+			assert.Equal(t, "2001-02-03", scene.Date.String)
+		}
+
+		sceneFilter.Date.Modifier = models.CriterionModifierNotBetween
+		scenes = queryScene(t, sqb, &sceneFilter, nil)
+		assert.Greater(t, len(scenes), 0)
+		for _, scene := range scenes {
+			assert.NotEqual(t, "2001-02-03", scene.Date.String)
+		}
+
+		return nil
+	})
+}
+
+func TestSceneQueryCreatedAt(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		sqb := r.Scene()
+		sceneFilter := models.SceneFilterType{
+			CreatedAt: &models.TimestampCriterionInput{
+				Value:    time.Now().Add(time.Hour).Format(time.RFC3339),
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}
+
+		scenes := queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 0)
+
+		sceneFilter.CreatedAt.Modifier = models.CriterionModifierLessThan
+		scenes = queryScene(t, sqb, &sceneFilter, nil)
+		assert.Greater(t, len(scenes), 0)
+
+		return nil
+	})
+}
+
 func TestSceneQueryResolution(t *testing.T) {
 	verifyScenesResolution(t, models.ResolutionEnumLow)
 	verifyScenesResolution(t, models.ResolutionEnumStandard)
@@ -1218,6 +1270,76 @@ func TestSceneQueryStudio(t *testing.T) {
 	})
 }
 
+func TestSceneQueryPerformersFilter(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		sqb := r.Scene()
+		sceneFilter := models.SceneFilterType{
+			PerformersFilter: &models.PerformerFilterType{
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Value: []string{
+						strconv.Itoa(tagIDs[tagIdxWithPerformer]),
+						strconv.Itoa(tagIDs[tagIdx1WithPerformer]),
+					},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			},
+		}
+
+		scenes := queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 2)
+
+		// ensure ids are correct
+		for _, scene := range scenes {
+			assert.True(t, scene.ID == sceneIDs[sceneIdxWithPerformerTag] || scene.ID == sceneIDs[sceneIdxWithPerformerTwoTags])
+		}
+
+		// combine with a sub-filter of the sub-filter
+		sceneFilter.PerformersFilter.And = &models.PerformerFilterType{
+			TagCount: &models.IntCriterionInput{
+				Value:    1,
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}
+
+		scenes = queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 1)
+		assert.Equal(t, sceneIDs[sceneIdxWithPerformerTwoTags], scenes[0].ID)
+
+		return nil
+	})
+}
+
+func TestSceneQueryStudiosFilter(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		sqb := r.Scene()
+		sceneFilter := models.SceneFilterType{
+			StudiosFilter: &models.StudioFilterType{
+				Parents: &models.MultiCriterionInput{
+					Value: []string{
+						strconv.Itoa(studioIDs[studioIdxWithParentAndChild]),
+					},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			},
+		}
+
+		scenes := queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 1)
+		assert.Equal(t, sceneIDs[sceneIdxWithGrandChildStudio], scenes[0].ID)
+
+		// an invalid sub-filter returns an error
+		sceneFilter.StudiosFilter.And = &models.StudioFilterType{}
+		sceneFilter.StudiosFilter.Or = &models.StudioFilterType{}
+
+		_, err := sqb.Query(models.SceneQueryOptions{
+			SceneFilter: &sceneFilter,
+		})
+		assert.NotNil(t, err)
+
+		return nil
+	})
+}
+
 func TestSceneQueryStudioDepth(t *testing.T) {
 	withTxn(func(r models.Repository) error {
 		sqb := r.Scene()
@@ -1690,6 +1812,299 @@ func TestSceneStashIDs(t *testing.T) {
 	}
 }
 
+func TestSceneQueryFileStatus(t *testing.T) {
+	withRollbackTxn(func(r models.Repository) error {
+		sqb := r.Scene()
+
+		setStatus := func(id int, status models.FileStatusEnum) error {
+			_, err := sqb.Update(models.ScenePartial{
+				ID:            id,
+				FileStatus:    &sql.NullString{String: status.String(), Valid: true},
+				FileCheckedAt: &models.NullSQLiteTimestamp{Timestamp: time.Now(), Valid: true},
+			})
+			return err
+		}
+
+		okID := sceneIDs[sceneIdxWithMovie]
+		corruptID := sceneIDs[sceneIdxWithGallery]
+		if err := setStatus(okID, models.FileStatusEnumOk); err != nil {
+			return err
+		}
+		if err := setStatus(corruptID, models.FileStatusEnumCorrupt); err != nil {
+			return err
+		}
+
+		corrupt := models.FileStatusEnumCorrupt
+		sceneFilter := models.SceneFilterType{
+			FileStatus: &models.FileStatusCriterionInput{
+				Value:    &corrupt,
+				Modifier: models.CriterionModifierEquals,
+			},
+		}
+
+		scenes := queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 1)
+		if len(scenes) == 1 {
+			assert.Equal(t, corruptID, scenes[0].ID)
+		}
+
+		// unverified scenes are included
+		sceneFilter.FileStatus.Modifier = models.CriterionModifierNotEquals
+		ids := sceneIDsFromScenes(queryScene(t, sqb, &sceneFilter, nil))
+		assert.Contains(t, ids, okID)
+		assert.NotContains(t, ids, corruptID)
+		assert.Greater(t, len(ids), 1)
+
+		sceneFilter.FileStatus.Modifier = models.CriterionModifierIsNull
+		ids = sceneIDsFromScenes(queryScene(t, sqb, &sceneFilter, nil))
+		assert.NotContains(t, ids, okID)
+		assert.NotContains(t, ids, corruptID)
+
+		return nil
+	})
+}
+
+func sceneIDsFromScenes(scenes []*models.Scene) []int {
+	var ret []int
+	for _, s := range scenes {
+		ret = append(ret, s.ID)
+	}
+	return ret
+}
+
+func TestSceneFieldSources(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Scene()
+
+		// create scene to test against
+		const name = "TestSceneFieldSources"
+		scene := models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+		}
+		created, err := qb.Create(scene)
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+
+		testFieldSourceReaderWriter(t, qb, created.ID)
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSceneCaptions(t *testing.T) {
+	if err := withRollbackTxn(func(r models.Repository) error {
+		qb := r.Scene()
+
+		const name = "TestSceneCaptions"
+		created, err := qb.Create(models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+
+		captions := []models.SceneCaption{
+			{
+				LanguageCode: "en",
+				CaptionType:  models.CaptionTypeSRT,
+				Source:       models.CaptionSourceFile,
+				Path:         models.NullString(name + ".en.srt"),
+			},
+			{
+				LanguageCode: "de",
+				CaptionType:  models.CaptionTypeASS,
+				Source:       models.CaptionSourceEmbedded,
+				StreamIndex:  sql.NullInt64{Int64: 2, Valid: true},
+			},
+		}
+
+		if err := qb.UpdateCaptions(created.ID, captions); err != nil {
+			return fmt.Errorf("Error updating captions: %s", err.Error())
+		}
+
+		got, err := qb.GetCaptions(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting captions: %s", err.Error())
+		}
+
+		if assert.Len(t, got, 2) {
+			// captions are ordered by source
+			assert.True(t, got[0].Equal(captions[1]))
+			assert.True(t, got[1].Equal(captions[0]))
+			assert.Equal(t, created.ID, got[0].SceneID)
+		}
+
+		scenes := queryScene(t, qb, &models.SceneFilterType{
+			Captions: &models.StringCriterionInput{
+				Value:    "de",
+				Modifier: models.CriterionModifierEquals,
+			},
+		}, nil)
+		if assert.Len(t, scenes, 1) {
+			assert.Equal(t, created.ID, scenes[0].ID)
+		}
+
+		// replacing the captions removes the existing captions
+		if err := qb.UpdateCaptions(created.ID, captions[:1]); err != nil {
+			return fmt.Errorf("Error updating captions: %s", err.Error())
+		}
+
+		scenes = queryScene(t, qb, &models.SceneFilterType{
+			Captions: &models.StringCriterionInput{
+				Value:    "de",
+				Modifier: models.CriterionModifierEquals,
+			},
+		}, nil)
+		assert.Len(t, scenes, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSceneAudioStreams(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Scene()
+
+		const name = "TestSceneAudioStreams"
+		created, err := qb.Create(models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+
+		streams := []models.SceneAudioStream{
+			{
+				StreamIndex:  2,
+				Codec:        "ac3",
+				LanguageCode: "de",
+				Channels:     6,
+			},
+			{
+				StreamIndex:  1,
+				Codec:        "aac",
+				LanguageCode: "en",
+				Channels:     2,
+				Title:        "Stereo",
+				Default:      true,
+			},
+		}
+
+		if err := qb.UpdateAudioStreams(created.ID, streams); err != nil {
+			return fmt.Errorf("Error updating audio streams: %s", err.Error())
+		}
+
+		got, err := qb.GetAudioStreams(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting audio streams: %s", err.Error())
+		}
+
+		// audio streams are ordered by stream index
+		streams[0].SceneID = created.ID
+		streams[1].SceneID = created.ID
+		assert.Equal(t, []*models.SceneAudioStream{&streams[1], &streams[0]}, got)
+
+		// replacing the audio streams removes the existing streams
+		if err := qb.UpdateAudioStreams(created.ID, streams[:1]); err != nil {
+			return fmt.Errorf("Error updating audio streams: %s", err.Error())
+		}
+
+		got, err = qb.GetAudioStreams(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting audio streams: %s", err.Error())
+		}
+		assert.Equal(t, []*models.SceneAudioStream{&streams[0]}, got)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSceneQueryQFullText(t *testing.T) {
+	if err := withRollbackTxn(func(r models.Repository) error {
+		qb := r.Scene()
+
+		create := func(title string) (*models.Scene, error) {
+			return qb.Create(models.Scene{
+				Title:    sql.NullString{String: title, Valid: true},
+				Path:     "fulltext/" + utils.MD5FromString(title),
+				Checksum: sql.NullString{String: utils.MD5FromString(title), Valid: true},
+			})
+		}
+
+		short, err := create("Fulltextrelevance")
+		if err != nil {
+			return err
+		}
+		long, err := create("Fulltextrelevance in a much longer title with many other words")
+		if err != nil {
+			return err
+		}
+
+		// markers are included in the index
+		if _, err := r.SceneMarker().Create(models.SceneMarker{
+			Title:        "Fulltextmarker",
+			PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers],
+			SceneID:      sql.NullInt64{Int64: int64(long.ID), Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		query := func(q string, sort string, direction models.SortDirectionEnum) []int {
+			f := models.FindFilterType{
+				Q:         &q,
+				Sort:      &sort,
+				Direction: &direction,
+			}
+
+			var ret []int
+			for _, s := range queryScene(t, qb, nil, &f) {
+				ret = append(ret, s.ID)
+			}
+			return ret
+		}
+
+		// most relevant first. Results are not ranked without the index.
+		if database.FullTextSearchAvailable() {
+			assert.Equal(t, []int{short.ID, long.ID}, query("fulltextrel", "relevance", models.SortDirectionEnumDesc))
+			assert.Equal(t, []int{long.ID, short.ID}, query("fulltextrel", "relevance", models.SortDirectionEnumAsc))
+		}
+
+		assert.Equal(t, []int{long.ID}, query("fulltextmarker", "relevance", models.SortDirectionEnumDesc))
+		// terms match any part of a field
+		assert.Equal(t, []int{short.ID, long.ID}, query("textrelev", "title", models.SortDirectionEnumAsc))
+		// terms too short for the index
+		assert.Equal(t, []int{long.ID}, query("textrelev \"a m\"", "title", models.SortDirectionEnumAsc))
+		assert.Equal(t, []int{short.ID}, query("textrelev -\"a m\"", "title", models.SortDirectionEnumAsc))
+		assert.Equal(t, []int{short.ID}, query("fulltextrelevance -fulltextmarker", "title", models.SortDirectionEnumAsc))
+		assert.Equal(t, []int{long.ID}, query("fulltextmarker OR missing", "title", models.SortDirectionEnumAsc))
+
+		// index is updated with the scene
+		newTitle := "Fulltextrenamed"
+		if _, err := qb.Update(models.ScenePartial{
+			ID:    short.ID,
+			Title: &sql.NullString{String: newTitle, Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		assert.Equal(t, []int{long.ID}, query("fulltextrelevance", "title", models.SortDirectionEnumAsc))
+		assert.Equal(t, []int{short.ID}, query("fulltextrenamed", "title", models.SortDirectionEnumAsc))
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestSceneQueryQTrim(t *testing.T) {
 	if err := withTxn(func(r models.Repository) error {
 		qb := r.Scene()
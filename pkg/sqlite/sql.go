@@ -8,12 +8,15 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/stashapp/stash/pkg/models"
 )
 
 var randomSortFloat = rand.Float64()
 
+const relevanceSort = "relevance"
+
 func selectAll(tableName string) string {
 	idColumn := getColumn(tableName, "*")
 	return "SELECT " + idColumn + " FROM " + tableName + " "
@@ -100,6 +103,12 @@ func getSort(sort string, direction string, tableName string) string {
 	}
 }
 
+// isRelevanceSort returns true if sort orders by the relevance of full-text
+// search results.
+func isRelevanceSort(sort string) bool {
+	return strings.EqualFold(sort, relevanceSort)
+}
+
 func getRandomSort(tableName string, direction string, seed float64) string {
 	// https://stackoverflow.com/a/24511461
 	colName := getColumn(tableName, "id")
@@ -146,6 +155,80 @@ func getSearchBinding(columns []string, q string, not bool) (string, []interface
 	return "(" + likes + ")", args
 }
 
+// fullTextMinTermLength is the minimum length of a term that can be matched
+// using the full-text index. The trigram tokenizer does not match shorter
+// substrings.
+const fullTextMinTermLength = 3
+
+// isFullTextTerm returns true if t can be matched using the full-text index.
+func isFullTextTerm(t string) bool {
+	return utf8.RuneCountInString(t) >= fullTextMinTermLength
+}
+
+// fullTextTerm returns the full-text match expression for a search term,
+// which matches any part of an indexed field.
+func fullTextTerm(t string) string {
+	return `"` + strings.ReplaceAll(t, `"`, `""`) + `"`
+}
+
+// fullTextQuery is a search string split into the terms matched using the
+// full-text index and the terms matched using LIKE.
+type fullTextQuery struct {
+	// match requires the MustHave terms and one term of each of the AnySets
+	match string
+	// mustNot matches any of the MustNot terms
+	mustNot string
+	// like contains the terms that are too short to match using the index
+	like models.SearchSpecs
+}
+
+// getFullTextQuery splits specs into full-text match expressions and the
+// terms that must be matched using LIKE. AnySets containing a term that is
+// too short for the index are matched using LIKE in full.
+func getFullTextQuery(specs models.SearchSpecs) fullTextQuery {
+	var ret fullTextQuery
+	var clauses []string
+
+	for _, t := range specs.MustHave {
+		if isFullTextTerm(t) {
+			clauses = append(clauses, fullTextTerm(t))
+		} else {
+			ret.like.MustHave = append(ret.like.MustHave, t)
+		}
+	}
+
+	for _, set := range specs.AnySets {
+		var terms []string
+		for _, t := range set {
+			if !isFullTextTerm(t) {
+				terms = nil
+				break
+			}
+			terms = append(terms, fullTextTerm(t))
+		}
+
+		if terms == nil {
+			ret.like.AnySets = append(ret.like.AnySets, set)
+		} else {
+			clauses = append(clauses, "("+strings.Join(terms, " OR ")+")")
+		}
+	}
+
+	var mustNot []string
+	for _, t := range specs.MustNot {
+		if isFullTextTerm(t) {
+			mustNot = append(mustNot, fullTextTerm(t))
+		} else {
+			ret.like.MustNot = append(ret.like.MustNot, t)
+		}
+	}
+
+	ret.match = strings.Join(clauses, " AND ")
+	ret.mustNot = strings.Join(mustNot, " OR ")
+
+	return ret
+}
+
 func getInBinding(length int) string {
 	bindings := strings.Repeat("?, ", length)
 	bindings = strings.TrimRight(bindings, ", ")
@@ -162,29 +245,50 @@ func getIntWhereClause(column string, modifier models.CriterionModifier, value i
 		upper = &u
 	}
 
+	clause, args, err := getComparisonWhereClause(column, modifier, value, *upper)
+	if err != nil {
+		panic("unsupported int modifier type")
+	}
+
+	return clause, args
+}
+
+func getFloatWhereClause(column string, modifier models.CriterionModifier, value float64, upper *float64) (string, []interface{}, error) {
+	if upper == nil {
+		u := 0.0
+		upper = &u
+	}
+
+	return getComparisonWhereClause(column, modifier, value, *upper)
+}
+
+// getComparisonWhereClause returns the where clause comparing column with
+// value. upper is the upper bound of the BETWEEN and NOT_BETWEEN modifiers.
+// Returns an error if the modifier is not a comparison.
+func getComparisonWhereClause(column string, modifier models.CriterionModifier, value interface{}, upper interface{}) (string, []interface{}, error) {
 	args := []interface{}{value}
-	betweenArgs := []interface{}{value, *upper}
+	betweenArgs := []interface{}{value, upper}
 
 	switch modifier {
 	case models.CriterionModifierIsNull:
-		return fmt.Sprintf("%s IS NULL", column), nil
+		return fmt.Sprintf("%s IS NULL", column), nil, nil
 	case models.CriterionModifierNotNull:
-		return fmt.Sprintf("%s IS NOT NULL", column), nil
+		return fmt.Sprintf("%s IS NOT NULL", column), nil, nil
 	case models.CriterionModifierEquals:
-		return fmt.Sprintf("%s = ?", column), args
+		return fmt.Sprintf("%s = ?", column), args, nil
 	case models.CriterionModifierNotEquals:
-		return fmt.Sprintf("%s != ?", column), args
+		return fmt.Sprintf("%s != ?", column), args, nil
 	case models.CriterionModifierBetween:
-		return fmt.Sprintf("%s BETWEEN ? AND ?", column), betweenArgs
+		return fmt.Sprintf("%s BETWEEN ? AND ?", column), betweenArgs, nil
 	case models.CriterionModifierNotBetween:
-		return fmt.Sprintf("%s NOT BETWEEN ? AND ?", column), betweenArgs
+		return fmt.Sprintf("%s NOT BETWEEN ? AND ?", column), betweenArgs, nil
 	case models.CriterionModifierLessThan:
-		return fmt.Sprintf("%s < ?", column), args
+		return fmt.Sprintf("%s < ?", column), args, nil
 	case models.CriterionModifierGreaterThan:
-		return fmt.Sprintf("%s > ?", column), args
+		return fmt.Sprintf("%s > ?", column), args, nil
 	}
 
-	panic("unsupported int modifier type")
+	return "", nil, fmt.Errorf("unsupported comparison modifier %s", modifier)
 }
 
 // returns where clause and having clause
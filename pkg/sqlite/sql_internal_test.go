@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFullTextQuery(t *testing.T) {
+	tests := []struct {
+		q    string
+		want fullTextQuery
+	}{
+		{"foo", fullTextQuery{match: `"foo"`}},
+		{"foo bar", fullTextQuery{match: `"foo" AND "bar"`}},
+		{`"foo bar"`, fullTextQuery{match: `"foo bar"`}},
+		{"foo or bar baz", fullTextQuery{match: `"baz" AND ("foo" OR "bar")`}},
+		{"foo -bar", fullTextQuery{match: `"foo"`, mustNot: `"bar"`}},
+		{"-bar -baz", fullTextQuery{mustNot: `"bar" OR "baz"`}},
+		{`foo"bar`, fullTextQuery{match: `"foo""bar"`}},
+		{"foo ab", fullTextQuery{match: `"foo"`, like: models.SearchSpecs{MustHave: []string{"ab"}}}},
+		{"foo -ab", fullTextQuery{match: `"foo"`, like: models.SearchSpecs{MustNot: []string{"ab"}}}},
+		{"foo or ab", fullTextQuery{like: models.SearchSpecs{AnySets: [][]string{{"foo", "ab"}}}}},
+		{"éé", fullTextQuery{like: models.SearchSpecs{MustHave: []string{"éé"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			got := getFullTextQuery(models.ParseSearchString(tt.q))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
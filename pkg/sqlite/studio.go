@@ -10,10 +10,18 @@ import (
 )
 
 const studioTable = "studios"
+const studiosFTSTable = "studios_fts"
 const studioIDColumn = "studio_id"
 const studioAliasesTable = "studio_aliases"
 const studioAliasColumn = "alias"
 
+var studioFullTextSearch = fullTextSearch{
+	ftsTable: studiosFTSTable,
+	idColumn: "studios.id",
+	columns:  []string{"studios.name", "studio_aliases.alias"},
+	joins:    []join{{table: studioAliasesTable, onClause: "studio_aliases.studio_id = studios.id", joinType: "LEFT"}},
+}
+
 type studioQueryBuilder struct {
 	repository
 }
@@ -228,6 +236,22 @@ func (qb *studioQueryBuilder) makeFilter(studioFilter *models.StudioFilterType)
 	return query
 }
 
+// filterSubQuery returns a query selecting the ids of the studios matching
+// the provided filter.
+func (qb *studioQueryBuilder) filterSubQuery(filter *models.StudioFilterType) queryBuilder {
+	query := qb.newQuery()
+	distinctIDs(&query, studioTable)
+
+	if err := qb.validateFilter(filter); err != nil {
+		query.err = err
+		return query
+	}
+
+	query.addFilter(qb.makeFilter(filter))
+
+	return query
+}
+
 func (qb *studioQueryBuilder) Query(studioFilter *models.StudioFilterType, findFilter *models.FindFilterType) ([]*models.Studio, int, error) {
 	if studioFilter == nil {
 		studioFilter = &models.StudioFilterType{}
@@ -240,10 +264,7 @@ func (qb *studioQueryBuilder) Query(studioFilter *models.StudioFilterType, findF
 	distinctIDs(&query, studioTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(studioAliasesTable, "", "studio_aliases.studio_id = studios.id")
-		searchColumns := []string{"studios.name", "studio_aliases.alias"}
-
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(studioFullTextSearch, *q)
 	}
 
 	if err := qb.validateFilter(studioFilter); err != nil {
@@ -434,6 +455,24 @@ func (qb *studioQueryBuilder) UpdateStashIDs(studioID int, stashIDs []models.Sta
 	return qb.stashIDRepository().replace(studioID, stashIDs)
 }
 
+func (qb *studioQueryBuilder) fieldSourceRepository() *fieldSourceRepository {
+	return &fieldSourceRepository{
+		repository{
+			tx:        qb.tx,
+			tableName: "studio_field_sources",
+			idColumn:  studioIDColumn,
+		},
+	}
+}
+
+func (qb *studioQueryBuilder) GetFieldSources(studioID int) ([]*models.FieldSource, error) {
+	return qb.fieldSourceRepository().get(studioID)
+}
+
+func (qb *studioQueryBuilder) UpdateFieldSources(studioID int, sources []models.FieldSource) error {
+	return qb.fieldSourceRepository().update(studioID, sources)
+}
+
 func (qb *studioQueryBuilder) aliasRepository() *stringRepository {
 	return &stringRepository{
 		repository: repository{
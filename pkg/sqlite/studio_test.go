@@ -599,6 +599,24 @@ func TestStudioStashIDs(t *testing.T) {
 	}
 }
 
+func TestStudioFieldSources(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Studio()
+
+		// create studio to test against
+		const name = "TestStudioFieldSources"
+		created, err := createStudio(r.Studio(), name, nil)
+		if err != nil {
+			return fmt.Errorf("Error creating studio: %s", err.Error())
+		}
+
+		testFieldSourceReaderWriter(t, qb, created.ID)
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestStudioQueryURL(t *testing.T) {
 	const sceneIdx = 1
 	studioURL := getStudioStringValue(sceneIdx, urlField)
@@ -10,10 +10,18 @@ import (
 )
 
 const tagTable = "tags"
+const tagsFTSTable = "tags_fts"
 const tagIDColumn = "tag_id"
 const tagAliasesTable = "tag_aliases"
 const tagAliasColumn = "alias"
 
+var tagFullTextSearch = fullTextSearch{
+	ftsTable: tagsFTSTable,
+	idColumn: "tags.id",
+	columns:  []string{"tags.name", "tag_aliases.alias"},
+	joins:    []join{{table: tagAliasesTable, onClause: "tag_aliases.tag_id = tags.id", joinType: "LEFT"}},
+}
+
 type tagQueryBuilder struct {
 	repository
 }
@@ -314,6 +322,22 @@ func (qb *tagQueryBuilder) makeFilter(tagFilter *models.TagFilterType) *filterBu
 	return query
 }
 
+// filterSubQuery returns a query selecting the ids of the tags matching
+// the provided filter.
+func (qb *tagQueryBuilder) filterSubQuery(filter *models.TagFilterType) queryBuilder {
+	query := qb.newQuery()
+	distinctIDs(&query, tagTable)
+
+	if err := qb.validateFilter(filter); err != nil {
+		query.err = err
+		return query
+	}
+
+	query.addFilter(qb.makeFilter(filter))
+
+	return query
+}
+
 func (qb *tagQueryBuilder) Query(tagFilter *models.TagFilterType, findFilter *models.FindFilterType) ([]*models.Tag, int, error) {
 	if tagFilter == nil {
 		tagFilter = &models.TagFilterType{}
@@ -326,9 +350,7 @@ func (qb *tagQueryBuilder) Query(tagFilter *models.TagFilterType, findFilter *mo
 	distinctIDs(&query, tagTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
-		searchColumns := []string{"tags.name", "tag_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(tagFullTextSearch, *q)
 	}
 
 	if err := qb.validateFilter(tagFilter); err != nil {
@@ -870,6 +870,16 @@ func TestTagUpdateAlias(t *testing.T) {
 		}
 		assert.Equal(t, aliases, storedAliases)
 
+		// ensure aliases are searchable
+		q := "alias2"
+		tags, _, err := qb.Query(nil, &models.FindFilterType{Q: &q})
+		if err != nil {
+			return fmt.Errorf("Error querying tags: %s", err.Error())
+		}
+		if assert.Len(t, tags, 1) {
+			assert.Equal(t, created.ID, tags[0].ID)
+		}
+
 		return nil
 	}); err != nil {
 		t.Error(err.Error())
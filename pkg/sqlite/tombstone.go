@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const tombstoneTable = "tombstones"
+
+// tombstoneTimeFormat is the format of the deleted_at column, which is set
+// by the delete triggers in UTC with millisecond precision. Values in this
+// format can be compared as strings.
+const tombstoneTimeFormat = "2006-01-02T15:04:05.000Z"
+
+type tombstoneQueryBuilder struct {
+	repository
+}
+
+func NewTombstoneReaderWriter(tx dbi) *tombstoneQueryBuilder {
+	return &tombstoneQueryBuilder{
+		repository{
+			tx:        tx,
+			tableName: tombstoneTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+// FindSince returns the tombstones of objects deleted at or after t. Objects
+// deleted within the same millisecond as t are included, so a tombstone may
+// be returned for consecutive times.
+func (qb *tombstoneQueryBuilder) FindSince(t time.Time) ([]*models.Tombstone, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE deleted_at >= ? ORDER BY deleted_at, id`, tombstoneTable)
+
+	var ret models.Tombstones
+	if err := qb.query(query, []interface{}{formatTombstoneTime(t)}, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.Tombstone(ret), nil
+}
+
+func (qb *tombstoneQueryBuilder) DestroyBefore(t time.Time) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE deleted_at < ?`, tombstoneTable)
+	_, err := qb.tx.Exec(query, formatTombstoneTime(t))
+	return err
+}
+
+// formatTombstoneTime formats t for comparison with the deleted_at column.
+// The time is truncated to the precision of the column.
+func formatTombstoneTime(t time.Time) string {
+	return t.UTC().Format(tombstoneTimeFormat)
+}
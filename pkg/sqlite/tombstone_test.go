@@ -0,0 +1,101 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func findTagTombstones(r models.Repository, name string, since time.Time) ([]*models.Tombstone, error) {
+	tombstones, err := r.Tombstone().FindSince(since)
+	if err != nil {
+		return nil, err
+	}
+
+	// other tests may destroy objects concurrently
+	var ret []*models.Tombstone
+	for _, ts := range tombstones {
+		if ts.ObjectType == models.TombstoneObjectTypeTag && ts.Name.String == name {
+			ret = append(ret, ts)
+		}
+	}
+
+	return ret, nil
+}
+
+func TestTombstoneFindSince(t *testing.T) {
+	const tagName = "tagToTombstone"
+
+	// objects deleted in the same second are included
+	since := time.Now()
+
+	if err := withRollbackTxn(func(r models.Repository) error {
+		qb := r.Tag()
+		created, err := qb.Create(models.Tag{
+			Name: tagName,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := qb.Destroy(created.ID); err != nil {
+			return err
+		}
+
+		tombstones, err := findTagTombstones(r, tagName, since)
+		if err != nil {
+			return err
+		}
+
+		if !assert.Len(t, tombstones, 1) {
+			return nil
+		}
+
+		// tombstones deleted at the provided time are included
+		tombstones, err = findTagTombstones(r, tagName, tombstones[0].DeletedAt.Timestamp)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, tombstones, 1)
+
+		tombstones, err = findTagTombstones(r, tagName, time.Now().Add(time.Minute))
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, tombstones, 0)
+
+		// destroying earlier tombstones should leave the new one intact
+		if err := r.Tombstone().DestroyBefore(since); err != nil {
+			return err
+		}
+
+		tombstones, err = findTagTombstones(r, tagName, since)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, tombstones, 1)
+
+		if err := r.Tombstone().DestroyBefore(time.Now().Add(time.Minute)); err != nil {
+			return err
+		}
+
+		tombstones, err = findTagTombstones(r, tagName, since)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, tombstones, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -105,11 +105,21 @@ func (t *transaction) SceneMarker() models.SceneMarkerReaderWriter {
 	return NewSceneMarkerReaderWriter(t.tx)
 }
 
+func (t *transaction) Folder() models.FolderReaderWriter {
+	t.ensureTx()
+	return NewFolderReaderWriter(t.tx)
+}
+
 func (t *transaction) Scene() models.SceneReaderWriter {
 	t.ensureTx()
 	return NewSceneReaderWriter(t.tx)
 }
 
+func (t *transaction) SceneFile() models.SceneFileReaderWriter {
+	t.ensureTx()
+	return NewSceneFileReaderWriter(t.tx)
+}
+
 func (t *transaction) ScrapedItem() models.ScrapedItemReaderWriter {
 	t.ensureTx()
 	return NewScrapedItemReaderWriter(t.tx)
@@ -130,13 +140,30 @@ func (t *transaction) SavedFilter() models.SavedFilterReaderWriter {
 	return NewSavedFilterReaderWriter(t.tx)
 }
 
-type ReadTransaction struct{}
+func (t *transaction) Playlist() models.PlaylistReaderWriter {
+	t.ensureTx()
+	return NewPlaylistReaderWriter(t.tx)
+}
+
+func (t *transaction) Tombstone() models.TombstoneReaderWriter {
+	t.ensureTx()
+	return NewTombstoneReaderWriter(t.tx)
+}
+
+// ReadTransaction reads from the database without a transaction. The
+// database connection is obtained when the transaction begins, so that
+// reads in progress are not affected if the database is closed and reopened,
+// such as when it is restored from a backup.
+type ReadTransaction struct {
+	db *sqlx.DB
+}
 
 func (t *ReadTransaction) Begin() error {
 	if err := database.Ready(); err != nil {
 		return err
 	}
 
+	t.db = database.DB
 	return nil
 }
 
@@ -153,43 +180,59 @@ func (t *ReadTransaction) Repository() models.ReaderRepository {
 }
 
 func (t *ReadTransaction) Gallery() models.GalleryReader {
-	return NewGalleryReaderWriter(database.DB)
+	return NewGalleryReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) Image() models.ImageReader {
-	return NewImageReaderWriter(database.DB)
+	return NewImageReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) Movie() models.MovieReader {
-	return NewMovieReaderWriter(database.DB)
+	return NewMovieReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) Performer() models.PerformerReader {
-	return NewPerformerReaderWriter(database.DB)
+	return NewPerformerReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) SceneMarker() models.SceneMarkerReader {
-	return NewSceneMarkerReaderWriter(database.DB)
+	return NewSceneMarkerReaderWriter(t.db)
+}
+
+func (t *ReadTransaction) Folder() models.FolderReader {
+	return NewFolderReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) Scene() models.SceneReader {
-	return NewSceneReaderWriter(database.DB)
+	return NewSceneReaderWriter(t.db)
+}
+
+func (t *ReadTransaction) SceneFile() models.SceneFileReader {
+	return NewSceneFileReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) ScrapedItem() models.ScrapedItemReader {
-	return NewScrapedItemReaderWriter(database.DB)
+	return NewScrapedItemReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) Studio() models.StudioReader {
-	return NewStudioReaderWriter(database.DB)
+	return NewStudioReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) Tag() models.TagReader {
-	return NewTagReaderWriter(database.DB)
+	return NewTagReaderWriter(t.db)
 }
 
 func (t *ReadTransaction) SavedFilter() models.SavedFilterReader {
-	return NewSavedFilterReaderWriter(database.DB)
+	return NewSavedFilterReaderWriter(t.db)
+}
+
+func (t *ReadTransaction) Playlist() models.PlaylistReader {
+	return NewPlaylistReaderWriter(t.db)
+}
+
+func (t *ReadTransaction) Tombstone() models.TombstoneReader {
+	return NewTombstoneReaderWriter(t.db)
 }
 
 type TransactionManager struct {
@@ -22,6 +22,13 @@ import { ImageEditPanel } from "./ImageEditPanel";
 import { ImageDetailPanel } from "./ImageDetailPanel";
 import { DeleteImagesDialog } from "../DeleteImagesDialog";
 
+// video clips are served in their original format
+const clipExtensions = /\.(m4v|mkv|mov|mp4|webm)$/i;
+
+function isClip(path: string) {
+  return clipExtensions.test(path);
+}
+
 interface IImageParams {
   id?: string;
 }
@@ -277,11 +284,22 @@ export const Image: React.FC = () => {
         {renderTabs()}
       </div>
       <div className="image-container">
-        <img
-          className="m-sm-auto no-gutter image-image"
-          alt={image.title ?? ""}
-          src={image.paths.image ?? ""}
-        />
+        {isClip(image.path) ? (
+          <video
+            className="m-sm-auto no-gutter image-image"
+            src={image.paths.image ?? ""}
+            autoPlay
+            loop
+            muted
+            playsInline
+          />
+        ) : (
+          <img
+            className="m-sm-auto no-gutter image-image"
+            alt={image.title ?? ""}
+            src={image.paths.image ?? ""}
+          />
+        )}
       </div>
     </div>
   );
@@ -6,7 +6,7 @@ import { TagLink, TruncatedText } from "src/components/Shared";
 import { PerformerCard } from "src/components/Performers/PerformerCard";
 import { RatingStars } from "src/components/Scenes/SceneDetails/RatingStars";
 import { sortPerformers } from "src/core/performers";
-import { FormattedMessage, useIntl } from "react-intl";
+import { FormattedDate, FormattedMessage, useIntl } from "react-intl";
 
 interface IImageDetailProps {
   image: GQL.ImageDataFragment;
@@ -90,6 +90,15 @@ export const ImageDetailPanel: React.FC<IImageDetailProps> = (props) => {
               />
             </h3>
           </div>
+          {props.image.date ? (
+            <h5>
+              <FormattedDate
+                value={props.image.date}
+                format="long"
+                timeZone="utc"
+              />
+            </h5>
+          ) : undefined}
           {props.image.rating ? (
             <h6>
               <FormattedMessage id="rating" />:{" "}
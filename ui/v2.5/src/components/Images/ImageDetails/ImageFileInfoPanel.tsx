@@ -3,6 +3,7 @@ import { FormattedNumber } from "react-intl";
 import * as GQL from "src/core/generated-graphql";
 import { TextUtils } from "src/utils";
 import { TextField, URLField } from "src/utils/field";
+import { FileStatusField } from "src/components/Shared";
 
 interface IImageFileInfoPanelProps {
   image: GQL.ImageDataFragment;
@@ -34,6 +35,52 @@ export const ImageFileInfoPanel: React.FC<IImageFileInfoPanelProps> = (
     );
   }
 
+  function renderCamera() {
+    const { camera_make, camera_model } = props.image.file;
+    const value = [camera_make, camera_model].filter((v) => !!v).join(" ");
+
+    return <TextField id="media_info.camera" value={value} truncate />;
+  }
+
+  function renderExposure() {
+    const { focal_length, aperture, exposure_time, iso } = props.image.file;
+    const values: string[] = [];
+
+    if (focal_length) {
+      values.push(`${focal_length}mm`);
+    }
+    if (aperture) {
+      values.push(`f/${aperture}`);
+    }
+    if (exposure_time) {
+      values.push(
+        exposure_time < 1
+          ? `1/${Math.round(1 / exposure_time)}s`
+          : `${exposure_time}s`
+      );
+    }
+    if (iso) {
+      values.push(`ISO ${iso}`);
+    }
+
+    return <TextField id="media_info.exposure" value={values.join(" ")} />;
+  }
+
+  function renderLocation() {
+    const { latitude, longitude } = props.image.file;
+    if (
+      latitude === undefined ||
+      latitude === null ||
+      longitude === undefined ||
+      longitude === null
+    ) {
+      return;
+    }
+
+    const value = `${latitude.toFixed(6)}, ${longitude.toFixed(6)}`;
+    return <TextField id="media_info.location" value={value} />;
+  }
+
   return (
     <dl className="container image-file-info details-list">
       <TextField
@@ -53,6 +100,22 @@ export const ImageFileInfoPanel: React.FC<IImageFileInfoPanelProps> = (
         value={`${props.image.file.width} x ${props.image.file.height}`}
         truncate
       />
+      {props.image.file.duration ? (
+        <TextField
+          id="duration"
+          value={TextUtils.secondsToTimestamp(props.image.file.duration)}
+          truncate
+        />
+      ) : undefined}
+      {renderCamera()}
+      <TextField id="lens" value={props.image.file.lens} truncate />
+      {renderExposure()}
+      {renderLocation()}
+      <FileStatusField
+        status={props.image.file_status}
+        error={props.image.file_status_error}
+        checkedAt={props.image.file_checked_at}
+      />
     </dl>
   );
 };
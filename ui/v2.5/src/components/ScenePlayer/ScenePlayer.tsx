@@ -305,6 +305,13 @@ export class ScenePlayerImpl extends React.Component<
           file: scene.paths.chapters_vtt,
           kind: "chapters",
         },
+        ...scene.captions.map((c) => {
+          return {
+            file: c.url,
+            kind: "captions",
+            label: `${c.language_code || "unknown"} (${c.caption_type})`,
+          };
+        }),
       ],
       sources: this.props.sceneStreams.map((s) => {
         return {
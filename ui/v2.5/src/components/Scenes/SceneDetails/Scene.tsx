@@ -35,6 +35,8 @@ import { OCounterButton } from "./OCounterButton";
 import { ExternalPlayerButton } from "./ExternalPlayerButton";
 import { SceneMoviePanel } from "./SceneMoviePanel";
 import { SceneGalleriesPanel } from "./SceneGalleriesPanel";
+import { SceneCoverDialog } from "./SceneCoverDialog";
+import { SceneTrimDialog } from "./SceneTrimDialog";
 import { DeleteScenesDialog } from "../DeleteScenesDialog";
 import { GenerateDialog } from "../../Dialogs/GenerateDialog";
 import { SceneVideoFilterPanel } from "./SceneVideoFilterPanel";
@@ -81,6 +83,8 @@ const ScenePage: React.FC<IProps> = ({ scene, refetch }) => {
 
   const [isDeleteAlertOpen, setIsDeleteAlertOpen] = useState<boolean>(false);
   const [isGenerateDialogOpen, setIsGenerateDialogOpen] = useState(false);
+  const [isCoverDialogOpen, setIsCoverDialogOpen] = useState(false);
+  const [isTrimDialogOpen, setIsTrimDialogOpen] = useState(false);
 
   const [sceneQueue, setSceneQueue] = useState<SceneQueue>(new SceneQueue());
   const [queueScenes, setQueueScenes] = useState<GQL.SlimSceneDataFragment[]>(
@@ -330,6 +334,28 @@ const ScenePage: React.FC<IProps> = ({ scene, refetch }) => {
     }
   }
 
+  function maybeRenderCoverDialog() {
+    if (isCoverDialogOpen) {
+      return (
+        <SceneCoverDialog
+          scene={scene}
+          onClose={() => setIsCoverDialogOpen(false)}
+        />
+      );
+    }
+  }
+
+  function maybeRenderTrimDialog() {
+    if (isTrimDialogOpen) {
+      return (
+        <SceneTrimDialog
+          scene={scene}
+          onClose={() => setIsTrimDialogOpen(false)}
+        />
+      );
+    }
+  }
+
   function maybeRenderSceneGenerateDialog() {
     if (isGenerateDialogOpen) {
       return (
@@ -384,6 +410,20 @@ const ScenePage: React.FC<IProps> = ({ scene, refetch }) => {
         >
           <FormattedMessage id="actions.generate_thumb_default" />
         </Dropdown.Item>
+        <Dropdown.Item
+          key="choose-cover"
+          className="bg-secondary text-white"
+          onClick={() => setIsCoverDialogOpen(true)}
+        >
+          <FormattedMessage id="actions.choose_cover" />
+        </Dropdown.Item>
+        <Dropdown.Item
+          key="trim"
+          className="bg-secondary text-white"
+          onClick={() => setIsTrimDialogOpen(true)}
+        >
+          <FormattedMessage id="actions.trim" />
+        </Dropdown.Item>
         {boxes.length > 0 && (
           <Dropdown.Item
             key="submit"
@@ -521,6 +561,7 @@ const ScenePage: React.FC<IProps> = ({ scene, refetch }) => {
           <SceneMarkersPanel
             scene={scene}
             onClickMarker={onClickMarker}
+            onSeek={setTimestamp}
             isVisible={activeTabKey === "scene-markers-panel"}
           />
         </Tab.Pane>
@@ -597,6 +638,8 @@ const ScenePage: React.FC<IProps> = ({ scene, refetch }) => {
         <title>{scene.title ?? TextUtils.fileNameFromPath(scene.path)}</title>
       </Helmet>
       {maybeRenderSceneGenerateDialog()}
+      {maybeRenderCoverDialog()}
+      {maybeRenderTrimDialog()}
       {maybeRenderDeleteDialog()}
       <div
         className={`scene-tabs order-xl-first order-last ${
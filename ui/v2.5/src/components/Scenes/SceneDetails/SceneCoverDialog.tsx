@@ -0,0 +1,123 @@
+import React, { useState } from "react";
+import { useIntl } from "react-intl";
+import * as GQL from "src/core/generated-graphql";
+import {
+  useSceneCoverCandidates,
+  useSceneSetCoverCandidate,
+} from "src/core/StashService";
+import { ErrorMessage, LoadingIndicator, Modal } from "src/components/Shared";
+import { useToast } from "src/hooks";
+import { TextUtils } from "src/utils";
+
+interface ISceneCoverDialogProps {
+  scene: GQL.SceneDataFragment;
+  onClose: () => void;
+}
+
+export const SceneCoverDialog: React.FC<ISceneCoverDialogProps> = ({
+  scene,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+
+  const { data, loading, error } = useSceneCoverCandidates(scene.id);
+  const [setCoverCandidate] = useSceneSetCoverCandidate();
+
+  const [selected, setSelected] = useState<number>();
+  const [saving, setSaving] = useState(false);
+
+  const candidates = data?.sceneCoverCandidates ?? [];
+
+  // preselect the best candidate once loaded
+  const best = candidates
+    .filter((c) => !c.poor)
+    .reduce<GQL.SceneCoverCandidate | undefined>(
+      (prev, c) => (!prev || c.score > prev.score ? c : prev),
+      undefined
+    );
+  const selectedAt = selected ?? best?.at;
+
+  async function onSave() {
+    if (selectedAt === undefined) {
+      return;
+    }
+
+    setSaving(true);
+    try {
+      await setCoverCandidate({
+        variables: {
+          id: scene.id,
+          at: selectedAt,
+        },
+      });
+      Toast.success({
+        content: intl.formatMessage(
+          { id: "toast.updated_entity" },
+          {
+            entity: intl
+              .formatMessage({ id: "cover_image" })
+              .toLocaleLowerCase(),
+          }
+        ),
+      });
+      onClose();
+    } catch (e) {
+      Toast.error(e);
+      setSaving(false);
+    }
+  }
+
+  function renderCandidates() {
+    if (loading) {
+      return <LoadingIndicator />;
+    }
+
+    if (error) {
+      return <ErrorMessage error={error.message} />;
+    }
+
+    return (
+      <div className="scene-cover-candidates">
+        {candidates.map((c) => (
+          <button
+            type="button"
+            key={c.at}
+            className={`scene-cover-candidate ${
+              c.at === selectedAt ? "selected" : ""
+            } ${c.poor ? "poor" : ""}`}
+            onClick={() => setSelected(c.at)}
+          >
+            <img src={c.image} alt={TextUtils.secondsToTimestamp(c.at)} />
+            <span className="scene-cover-candidate-info">
+              {TextUtils.secondsToTimestamp(c.at)} &middot;{" "}
+              {Math.round(c.score * 100)}%
+            </span>
+          </button>
+        ))}
+      </div>
+    );
+  }
+
+  return (
+    <Modal
+      show
+      icon="image"
+      header={intl.formatMessage({ id: "dialogs.scene_cover.title" })}
+      dialogClassName="scene-cover-dialog"
+      accept={{
+        text: intl.formatMessage({ id: "actions.set_cover" }),
+        onClick: onSave,
+      }}
+      cancel={{
+        onClick: onClose,
+        variant: "secondary",
+      }}
+      disabled={selectedAt === undefined}
+      isRunning={saving}
+    >
+      <p>{intl.formatMessage({ id: "dialogs.scene_cover.description" })}</p>
+      {renderCandidates()}
+    </Modal>
+  );
+};
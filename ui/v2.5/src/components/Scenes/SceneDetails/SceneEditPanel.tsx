@@ -1,4 +1,5 @@
 import React, { useEffect, useState } from "react";
+import _ from "lodash";
 import { FormattedMessage, useIntl } from "react-intl";
 import {
   Button,
@@ -38,6 +39,26 @@ import { Prompt } from "react-router-dom";
 import { ConfigurationContext } from "src/hooks/Config";
 import { stashboxDisplayName } from "src/utils/stashbox";
 import { SceneMovieTable } from "./SceneMovieTable";
+
+// maps scene input fields to the field names used for field sources
+const trackedFields: Record<string, string> = {
+  title: "title",
+  details: "details",
+  url: "url",
+  date: "date",
+  studio_id: "studio",
+  performer_ids: "performers",
+  tag_ids: "tags",
+};
+
+interface IScrapedFieldSource {
+  source: string;
+  value: unknown;
+}
+
+function scraperSourceID(s: GQL.ScraperSourceInput) {
+  return s.stash_box_endpoint ?? s.scraper_id ?? undefined;
+}
 import { RatingStars } from "./RatingStars";
 import { SceneScrapeDialog } from "./SceneScrapeDialog";
 import { SceneQueryModal } from "./SceneQueryModal";
@@ -74,6 +95,10 @@ export const SceneEditPanel: React.FC<IProps> = ({
   ] = useState<boolean>(false);
   const [scrapedScene, setScrapedScene] = useState<GQL.ScrapedScene | null>();
   const [endpoint, setEndpoint] = useState<string | undefined>();
+  const [scrapedSource, setScrapedSource] = useState<string | undefined>();
+  const [fieldSources, setFieldSources] = useState<
+    Record<string, IScrapedFieldSource>
+  >({});
 
   const [coverImagePreview, setCoverImagePreview] = useState<
     string | undefined
@@ -207,10 +232,23 @@ export const SceneEditPanel: React.FC<IProps> = ({
 
   const imageEncoding = ImageUtils.usePasteImage(onImageLoad, true);
 
+  function getFieldSources(input: InputValues) {
+    // only attribute fields to the scraper if the scraped value is unchanged
+    return Object.entries(fieldSources)
+      .filter(([inputField, s]) =>
+        _.isEqual(input[inputField as keyof InputValues], s.value)
+      )
+      .map(([inputField, s]) => ({
+        field: trackedFields[inputField],
+        source: s.source,
+      }));
+  }
+
   function getSceneInput(input: InputValues): GQL.SceneUpdateInput {
     return {
       id: scene.id,
       ...input,
+      field_sources: getFieldSources(input),
     };
   }
 
@@ -300,6 +338,7 @@ export const SceneEditPanel: React.FC<IProps> = ({
       }
       // assume one returned scene
       setScrapedScene(result.data.scrapeSingleScene[0]);
+      setScrapedSource(scraperSourceID(s));
       setEndpoint(s.stash_box_endpoint ?? undefined);
     } catch (e) {
       Toast.error(e);
@@ -331,6 +370,7 @@ export const SceneEditPanel: React.FC<IProps> = ({
       }
       // assume one returned scene
       setScrapedScene(result.data.scrapeSingleScene[0]);
+      setScrapedSource(scraperSourceID(s));
     } catch (e) {
       Toast.error(e);
     } finally {
@@ -437,6 +477,7 @@ export const SceneEditPanel: React.FC<IProps> = ({
     if (scraper?.stash_box_index !== undefined) {
       // must be stash-box - assume full scene
       setScrapedScene(s);
+      setScrapedSource(scraperSourceID(scraper));
     } else {
       // must be scraper
       scrapeFromQuery(scraper, s);
@@ -502,33 +543,48 @@ export const SceneEditPanel: React.FC<IProps> = ({
     );
   }
 
-  function urlScrapable(scrapedUrl: string): boolean {
-    return (Scrapers?.data?.listSceneScrapers ?? []).some((s) =>
+  function urlScraper(scrapedUrl: string) {
+    return (Scrapers?.data?.listSceneScrapers ?? []).find((s) =>
       (s?.scene?.urls ?? []).some((u) => scrapedUrl.includes(u))
     );
   }
 
+  function urlScrapable(scrapedUrl: string): boolean {
+    return urlScraper(scrapedUrl) !== undefined;
+  }
+
+  function setScrapedFieldValue(field: string, value: unknown) {
+    formik.setFieldValue(field, value);
+    if (scrapedSource && trackedFields[field]) {
+      const source = scrapedSource;
+      setFieldSources((current) => ({
+        ...current,
+        [field]: { source, value },
+      }));
+    }
+  }
+
   function updateSceneFromScrapedScene(
     updatedScene: GQL.ScrapedSceneDataFragment
   ) {
     if (updatedScene.title) {
-      formik.setFieldValue("title", updatedScene.title);
+      setScrapedFieldValue("title", updatedScene.title);
     }
 
     if (updatedScene.details) {
-      formik.setFieldValue("details", updatedScene.details);
+      setScrapedFieldValue("details", updatedScene.details);
     }
 
     if (updatedScene.date) {
-      formik.setFieldValue("date", updatedScene.date);
+      setScrapedFieldValue("date", updatedScene.date);
     }
 
     if (updatedScene.url) {
-      formik.setFieldValue("url", updatedScene.url);
+      setScrapedFieldValue("url", updatedScene.url);
     }
 
     if (updatedScene.studio && updatedScene.studio.stored_id) {
-      formik.setFieldValue("studio_id", updatedScene.studio.stored_id);
+      setScrapedFieldValue("studio_id", updatedScene.studio.stored_id);
     }
 
     if (updatedScene.performers && updatedScene.performers.length > 0) {
@@ -538,7 +594,7 @@ export const SceneEditPanel: React.FC<IProps> = ({
 
       if (idPerfs.length > 0) {
         const newIds = idPerfs.map((p) => p.stored_id);
-        formik.setFieldValue("performer_ids", newIds as string[]);
+        setScrapedFieldValue("performer_ids", newIds as string[]);
       }
     }
 
@@ -560,7 +616,7 @@ export const SceneEditPanel: React.FC<IProps> = ({
 
       if (idTags.length > 0) {
         const newIds = idTags.map((p) => p.stored_id);
-        formik.setFieldValue("tag_ids", newIds as string[]);
+        setScrapedFieldValue("tag_ids", newIds as string[]);
       }
     }
 
@@ -610,6 +666,7 @@ export const SceneEditPanel: React.FC<IProps> = ({
         return;
       }
       setScrapedScene(result.data.scrapeSceneURL);
+      setScrapedSource(urlScraper(formik.values.url)?.id);
     } catch (e) {
       Toast.error(e);
     } finally {
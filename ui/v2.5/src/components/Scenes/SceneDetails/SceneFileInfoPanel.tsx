@@ -3,6 +3,7 @@ import { FormattedMessage, FormattedNumber, useIntl } from "react-intl";
 import * as GQL from "src/core/generated-graphql";
 import { NavUtils, TextUtils, getStashboxBase } from "src/utils";
 import { TextField, URLField } from "src/utils/field";
+import { FileStatusField } from "src/components/Shared";
 
 interface ISceneFileInfoPanelProps {
   scene: GQL.SceneDataFragment;
@@ -96,6 +97,33 @@ export const SceneFileInfoPanel: React.FC<ISceneFileInfoPanelProps> = (
     }
   }
 
+  function renderAudioStreams() {
+    // only worth listing if the file has a choice of audio streams
+    if (props.scene.audio_streams.length < 2) {
+      return;
+    }
+
+    return (
+      <TextField id="media_info.audio_streams">
+        <ul className="pl-0">
+          {props.scene.audio_streams.map((stream) => (
+            <li key={stream.index} className="row no-gutters">
+              {[
+                stream.language_code,
+                stream.title,
+                stream.codec,
+                `${stream.channels}ch`,
+              ]
+                .filter((v) => !!v)
+                .join(" / ")}
+              {stream.default ? " *" : ""}
+            </li>
+          ))}
+        </ul>
+      </TextField>
+    );
+  }
+
   return (
     <dl className="container scene-file-info details-list">
       <TextField id="media_info.hash" value={props.scene.oshash} truncate />
@@ -165,6 +193,12 @@ export const SceneFileInfoPanel: React.FC<ISceneFileInfoPanelProps> = (
         value={props.scene.file.audio_codec}
         truncate
       />
+      {renderAudioStreams()}
+      <FileStatusField
+        status={props.scene.file_status}
+        error={props.scene.file_status_error}
+        checkedAt={props.scene.file_checked_at}
+      />
       <URLField
         id="media_info.downloaded_from"
         url={props.scene.url}
@@ -0,0 +1,125 @@
+import React, { useState } from "react";
+import { Button, ButtonGroup, Table } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import * as GQL from "src/core/generated-graphql";
+import {
+  useFindSceneMarkerSuggestions,
+  useSceneMarkerSuggestionsAccept,
+  useSceneMarkerSuggestionsReject,
+} from "src/core/StashService";
+import { TagSelect } from "src/components/Shared";
+import { useToast } from "src/hooks";
+import { TextUtils } from "src/utils";
+
+interface ISceneMarkerSuggestionsProps {
+  sceneID: string;
+  onClickSuggestion: (seconds: number) => void;
+}
+
+export const SceneMarkerSuggestions: React.FC<ISceneMarkerSuggestionsProps> = ({
+  sceneID,
+  onClickSuggestion,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+
+  const { data } = useFindSceneMarkerSuggestions(sceneID);
+  const [acceptSuggestions] = useSceneMarkerSuggestionsAccept();
+  const [rejectSuggestions] = useSceneMarkerSuggestionsReject();
+
+  const [primaryTagID, setPrimaryTagID] = useState<string>();
+  const [saving, setSaving] = useState(false);
+
+  const suggestions = data?.findSceneMarkerSuggestions ?? [];
+  if (suggestions.length === 0) {
+    return null;
+  }
+
+  async function onAccept(ids: string[]) {
+    setSaving(true);
+    try {
+      await acceptSuggestions({
+        variables: { ids, primary_tag_id: primaryTagID },
+      });
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setSaving(false);
+    }
+  }
+
+  async function onReject(ids: string[]) {
+    setSaving(true);
+    try {
+      await rejectSuggestions({ variables: { ids } });
+    } catch (e) {
+      Toast.error(e);
+    } finally {
+      setSaving(false);
+    }
+  }
+
+  const allIDs = suggestions.map((s) => s.id);
+
+  return (
+    <div className="scene-marker-suggestions">
+      <h5>
+        <FormattedMessage id="marker_suggestions" />
+      </h5>
+      <div className="d-flex mb-2">
+        <TagSelect
+          className="flex-grow-1 mr-2"
+          onSelect={(tags) => setPrimaryTagID(tags[0]?.id)}
+          ids={primaryTagID ? [primaryTagID] : []}
+          noSelectionString={intl.formatMessage({ id: "primary_tag" })}
+        />
+        <ButtonGroup>
+          <Button disabled={saving} onClick={() => onAccept(allIDs)}>
+            <FormattedMessage id="actions.accept_all" />
+          </Button>
+          <Button
+            variant="secondary"
+            disabled={saving}
+            onClick={() => onReject(allIDs)}
+          >
+            <FormattedMessage id="actions.reject_all" />
+          </Button>
+        </ButtonGroup>
+      </div>
+      <Table size="sm" className="text-light">
+        <tbody>
+          {suggestions.map((s) => (
+            <tr key={s.id}>
+              <td>
+                <Button
+                  variant="link"
+                  className="p-0"
+                  onClick={() => onClickSuggestion(s.seconds)}
+                >
+                  {TextUtils.secondsToTimestamp(s.seconds)} -{" "}
+                  {TextUtils.secondsToTimestamp(s.end_seconds)}
+                </Button>
+              </td>
+              <td>{s.title}</td>
+              <td>{s.primary_tag?.name}</td>
+              <td className="text-right">
+                <ButtonGroup size="sm">
+                  <Button disabled={saving} onClick={() => onAccept([s.id])}>
+                    <FormattedMessage id="actions.accept" />
+                  </Button>
+                  <Button
+                    variant="secondary"
+                    disabled={saving}
+                    onClick={() => onReject([s.id])}
+                  >
+                    <FormattedMessage id="actions.reject" />
+                  </Button>
+                </ButtonGroup>
+              </td>
+            </tr>
+          ))}
+        </tbody>
+      </Table>
+    </div>
+  );
+};
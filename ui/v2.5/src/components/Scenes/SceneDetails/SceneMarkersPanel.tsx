@@ -6,11 +6,13 @@ import * as GQL from "src/core/generated-graphql";
 import { WallPanel } from "src/components/Wall/WallPanel";
 import { PrimaryTags } from "./PrimaryTags";
 import { SceneMarkerForm } from "./SceneMarkerForm";
+import { SceneMarkerSuggestions } from "./SceneMarkerSuggestions";
 
 interface ISceneMarkersPanelProps {
   scene: GQL.SceneDataFragment;
   isVisible: boolean;
   onClickMarker: (marker: GQL.SceneMarkerDataFragment) => void;
+  onSeek: (seconds: number) => void;
 }
 
 export const SceneMarkersPanel: React.FC<ISceneMarkersPanelProps> = (
@@ -61,6 +63,10 @@ export const SceneMarkersPanel: React.FC<ISceneMarkersPanelProps> = (
       <Button onClick={() => onOpenEditor()}>
         <FormattedMessage id="actions.create_marker" />
       </Button>
+      <SceneMarkerSuggestions
+        sceneID={props.scene.id}
+        onClickSuggestion={props.onSeek}
+      />
       <div className="container">
         <PrimaryTags
           sceneMarkers={props.scene.scene_markers ?? []}
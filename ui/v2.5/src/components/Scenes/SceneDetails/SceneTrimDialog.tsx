@@ -0,0 +1,133 @@
+import React, { useState } from "react";
+import { Button, Form } from "react-bootstrap";
+import { FormattedMessage, useIntl } from "react-intl";
+import * as GQL from "src/core/generated-graphql";
+import { useSceneTrim } from "src/core/StashService";
+import { DurationInput, Icon, Modal } from "src/components/Shared";
+import { useToast } from "src/hooks";
+import { JWUtils } from "src/utils";
+
+interface ISceneTrimDialogProps {
+  scene: GQL.SceneDataFragment;
+  onClose: () => void;
+}
+
+function playerPosition() {
+  return Math.round(JWUtils.getPlayer()?.getPosition() ?? 0);
+}
+
+export const SceneTrimDialog: React.FC<ISceneTrimDialogProps> = ({
+  scene,
+  onClose,
+}) => {
+  const intl = useIntl();
+  const Toast = useToast();
+  const [sceneTrim] = useSceneTrim();
+
+  const [ranges, setRanges] = useState<GQL.TrimRangeInput[]>([
+    { start: 0, end: playerPosition() },
+  ]);
+  const [replaceOriginal, setReplaceOriginal] = useState(false);
+  const [saving, setSaving] = useState(false);
+
+  const duration = scene.file.duration ?? 0;
+  const valid =
+    ranges.length > 0 &&
+    ranges.every((r) => r.end > r.start && (!duration || r.start < duration));
+
+  function setRange(index: number, range: Partial<GQL.TrimRangeInput>) {
+    setRanges(ranges.map((r, i) => (i === index ? { ...r, ...range } : r)));
+  }
+
+  async function onTrim() {
+    setSaving(true);
+    try {
+      await sceneTrim({
+        variables: {
+          id: scene.id,
+          ranges,
+          mode: replaceOriginal
+            ? GQL.SceneTrimMode.ReplaceOriginal
+            : GQL.SceneTrimMode.KeepOriginal,
+        },
+      });
+      Toast.success({
+        content: intl.formatMessage({ id: "toast.started_trimming" }),
+      });
+      onClose();
+    } catch (e) {
+      Toast.error(e);
+      setSaving(false);
+    }
+  }
+
+  return (
+    <Modal
+      show
+      icon="cut"
+      header={intl.formatMessage({ id: "dialogs.scene_trim.title" })}
+      accept={{
+        text: intl.formatMessage({ id: "actions.trim" }),
+        variant: replaceOriginal ? "danger" : "primary",
+        onClick: onTrim,
+      }}
+      cancel={{
+        onClick: onClose,
+        variant: "secondary",
+      }}
+      disabled={!valid}
+      isRunning={saving}
+    >
+      <p>{intl.formatMessage({ id: "dialogs.scene_trim.description" })}</p>
+      {ranges.map((r, i) => (
+        // eslint-disable-next-line react/no-array-index-key
+        <div className="scene-trim-range d-flex mb-2" key={i}>
+          <DurationInput
+            className="mr-2"
+            numericValue={r.start}
+            mandatory
+            onValueChange={(v) => setRange(i, { start: v ?? 0 })}
+            onReset={() => setRange(i, { start: playerPosition() })}
+          />
+          <DurationInput
+            className="mr-2"
+            numericValue={r.end}
+            mandatory
+            onValueChange={(v) => setRange(i, { end: v ?? 0 })}
+            onReset={() => setRange(i, { end: playerPosition() })}
+          />
+          <Button
+            variant="danger"
+            title={intl.formatMessage({ id: "actions.remove" })}
+            disabled={ranges.length === 1}
+            onClick={() => setRanges(ranges.filter((_, j) => j !== i))}
+          >
+            <Icon icon="times" />
+          </Button>
+        </div>
+      ))}
+      <Button
+        variant="secondary"
+        className="mb-3"
+        onClick={() =>
+          setRanges([...ranges, { start: playerPosition(), end: duration }])
+        }
+      >
+        <FormattedMessage id="dialogs.scene_trim.add_range" />
+      </Button>
+      <Form.Check
+        id="trim-replace-original"
+        checked={replaceOriginal}
+        label={intl.formatMessage({ id: "dialogs.scene_trim.replace_original" })}
+        onChange={() => setReplaceOriginal(!replaceOriginal)}
+      />
+      <Form.Text className="text-muted">
+        {intl.formatMessage({
+          id: replaceOriginal
+            ? "dialogs.scene_trim.replace_original_desc"
+            : "dialogs.scene_trim.keep_original_desc",
+        })}
+      </Form.Text>
+    </Modal>
+  );
+};
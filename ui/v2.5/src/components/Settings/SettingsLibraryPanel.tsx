@@ -126,6 +126,30 @@ export const SettingsLibraryPanel: React.FC = () => {
           onChange={(v) => saveGeneral({ createGalleriesFromFolders: v })}
         />
 
+        <BooleanSetting
+          id="create-image-clips-from-videos"
+          headingID="config.general.create_image_clips_from_videos_label"
+          subHeadingID="config.general.create_image_clips_from_videos_desc"
+          checked={general.createImageClipsFromVideos ?? false}
+          onChange={(v) => saveGeneral({ createImageClipsFromVideos: v })}
+        />
+
+        <BooleanSetting
+          id="create-image-keyword-tags"
+          headingID="config.general.create_image_keyword_tags_label"
+          subHeadingID="config.general.create_image_keyword_tags_desc"
+          checked={general.createImageKeywordTags ?? false}
+          onChange={(v) => saveGeneral({ createImageKeywordTags: v })}
+        />
+
+        <BooleanSetting
+          id="set-image-metadata-rating"
+          headingID="config.general.set_image_metadata_rating_label"
+          subHeadingID="config.general.set_image_metadata_rating_desc"
+          checked={general.setImageMetadataRating ?? false}
+          onChange={(v) => saveGeneral({ setImageMetadataRating: v })}
+        />
+
         <BooleanSetting
           id="write-image-thumbnails"
           headingID="config.ui.images.options.write_image_thumbnails.heading"
@@ -1,4 +1,5 @@
 import React from "react";
+import { Form } from "react-bootstrap";
 import * as GQL from "src/core/generated-graphql";
 import { LoadingIndicator } from "src/components/Shared";
 import { SettingSection } from "./SettingSection";
@@ -269,6 +270,46 @@ export const SettingsConfigurationPanel: React.FC = () => {
         />
       </SettingSection>
 
+      <SettingSection headingID="config.general.chapter_detection">
+        <ModalSetting<number>
+          id="chapter-detection-threshold"
+          headingID="config.general.chapter_detection_threshold_head"
+          subHeadingID="config.general.chapter_detection_threshold_desc"
+          value={general.chapterDetectionThreshold ?? undefined}
+          onChange={(v) => saveGeneral({ chapterDetectionThreshold: v })}
+          renderField={(value, setValue) => (
+            <Form.Control
+              className="text-input"
+              type="number"
+              min={0}
+              max={1}
+              step={0.05}
+              value={value ?? 0}
+              onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                setValue(Number.parseFloat(e.currentTarget.value || "0"))
+              }
+            />
+          )}
+          renderValue={(value) => <span>{value}</span>}
+        />
+
+        <NumberSetting
+          id="chapter-minimum-length"
+          headingID="config.general.chapter_minimum_length_head"
+          subHeadingID="config.general.chapter_minimum_length_desc"
+          value={general.chapterMinimumLength ?? undefined}
+          onChange={(v) => saveGeneral({ chapterMinimumLength: v })}
+        />
+
+        <StringSetting
+          id="chapter-primary-tag"
+          headingID="config.general.chapter_primary_tag_head"
+          subHeadingID="config.general.chapter_primary_tag_desc"
+          value={general.chapterPrimaryTag ?? undefined}
+          onChange={(v) => saveGeneral({ chapterPrimaryTag: v })}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.general.logging">
         <StringSetting
           headingID="config.general.auth.log_file"
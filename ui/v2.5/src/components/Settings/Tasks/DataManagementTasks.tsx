@@ -7,6 +7,7 @@ import {
   mutateBackupDatabase,
   mutateMetadataImport,
   mutateMetadataClean,
+  mutateMetadataVerify,
 } from "src/core/StashService";
 import { useToast } from "src/hooks";
 import { downloadFile } from "src/utils";
@@ -141,6 +142,39 @@ const CleanOptions: React.FC<ICleanOptions> = ({
   );
 };
 
+interface IVerifyOptions {
+  options: GQL.VerifyMetadataInput;
+  setOptions: (s: GQL.VerifyMetadataInput) => void;
+}
+
+const VerifyOptions: React.FC<IVerifyOptions> = ({
+  options,
+  setOptions: setOptionsState,
+}) => {
+  function setOptions(input: Partial<GQL.VerifyMetadataInput>) {
+    setOptionsState({ ...options, ...input });
+  }
+
+  return (
+    <>
+      <BooleanSetting
+        id="verify-sample"
+        checked={options.sample ?? false}
+        headingID="config.tasks.verify.sample"
+        subHeadingID="config.tasks.verify.sample_desc"
+        onChange={(v) => setOptions({ sample: v })}
+      />
+      <BooleanSetting
+        id="verify-skip-unchanged"
+        checked={options.skip_unchanged ?? false}
+        headingID="config.tasks.verify.skip_unchanged"
+        subHeadingID="config.tasks.verify.skip_unchanged_desc"
+        onChange={(v) => setOptions({ skip_unchanged: v })}
+      />
+    </>
+  );
+};
+
 interface IDataManagementTasks {
   setIsBackupRunning: (v: boolean) => void;
 }
@@ -161,6 +195,13 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     dryRun: false,
   });
 
+  const [verifyOptions, setVerifyOptions] = useState<GQL.VerifyMetadataInput>(
+    {
+      sample: false,
+      skip_unchanged: true,
+    }
+  );
+
   type DialogOpenState = typeof dialogOpen;
 
   function setDialogOpen(s: Partial<DialogOpenState>) {
@@ -229,6 +270,21 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onVerify() {
+    try {
+      await mutateMetadataVerify(verifyOptions);
+
+      Toast.success({
+        content: intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          { operation_name: intl.formatMessage({ id: "actions.verify" }) }
+        ),
+      });
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   async function onMigrateHashNaming() {
     try {
       await mutateMigrateHashNaming();
@@ -342,6 +398,32 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
             setOptions={(o) => setCleanOptions(o)}
           />
         </div>
+
+        <div className="setting-group">
+          <Setting
+            heading={
+              <>
+                <FormattedMessage id="actions.verify" />
+                <ManualLink tab="Tasks">
+                  <Icon icon="question-circle" />
+                </ManualLink>
+              </>
+            }
+            subHeadingID="config.tasks.verify.description"
+          >
+            <Button
+              variant="secondary"
+              type="submit"
+              onClick={() => onVerify()}
+            >
+              <FormattedMessage id="actions.verify" />
+            </Button>
+          </Setting>
+          <VerifyOptions
+            options={verifyOptions}
+            setOptions={(o) => setVerifyOptions(o)}
+          />
+        </div>
       </SettingSection>
 
       <SettingSection headingID="metadata">
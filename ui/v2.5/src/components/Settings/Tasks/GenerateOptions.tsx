@@ -136,6 +136,27 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
         headingID="dialogs.scene_gen.interactive_heatmap_speed"
         onChange={(v) => setOptions({ interactiveHeatmapsSpeeds: v })}
       />
+      <BooleanSetting
+        id="image-thumbnail-task"
+        checked={options.imageThumbnails ?? false}
+        headingID="dialogs.scene_gen.image_thumbnails"
+        tooltipID="dialogs.scene_gen.image_thumbnails_tooltip"
+        onChange={(v) => setOptions({ imageThumbnails: v })}
+      />
+      <BooleanSetting
+        id="repick-covers-task"
+        checked={options.repickCovers ?? false}
+        headingID="dialogs.scene_gen.repick_covers"
+        tooltipID="dialogs.scene_gen.repick_covers_tooltip"
+        onChange={(v) => setOptions({ repickCovers: v })}
+      />
+      <BooleanSetting
+        id="chapters-task"
+        checked={options.chapters ?? false}
+        headingID="dialogs.scene_gen.chapters"
+        tooltipID="dialogs.scene_gen.chapters_tooltip"
+        onChange={(v) => setOptions({ chapters: v })}
+      />
       <BooleanSetting
         id="overwrite"
         checked={options.overwrite ?? false}
@@ -13,6 +13,7 @@ export const ScanOptions: React.FC<IScanOptions> = ({
 }) => {
   const {
     useFileMetadata,
+    useNfoMetadata,
     stripFileExtension,
     scanGeneratePreviews,
     scanGenerateImagePreviews,
@@ -75,6 +76,12 @@ export const ScanOptions: React.FC<IScanOptions> = ({
         headingID="config.tasks.set_name_date_details_from_metadata_if_present"
         onChange={(v) => setOptions({ useFileMetadata: v })}
       />
+      <BooleanSetting
+        id="use-nfo-metadata"
+        checked={useNfoMetadata ?? false}
+        headingID="config.tasks.set_metadata_from_nfo_if_present"
+        onChange={(v) => setOptions({ useNfoMetadata: v })}
+      />
     </>
   );
 };
@@ -0,0 +1,38 @@
+import React from "react";
+import { useIntl } from "react-intl";
+import * as GQL from "src/core/generated-graphql";
+import { TextUtils } from "src/utils";
+import { TextField } from "src/utils/field";
+
+interface IFileStatusFieldProps {
+  status?: GQL.FileStatusEnum | null;
+  error?: string | null;
+  checkedAt?: string | null;
+}
+
+export const FileStatusField: React.FC<IFileStatusFieldProps> = ({
+  status,
+  error,
+  checkedAt,
+}) => {
+  const intl = useIntl();
+
+  if (!status) {
+    return null;
+  }
+
+  return (
+    <TextField id="file_status">
+      <span className={`file-status file-status-${status.toLowerCase()}`}>
+        {status}
+      </span>
+      {checkedAt ? (
+        <span className="text-muted">
+          {" "}
+          ({TextUtils.formatDateTime(intl, checkedAt)})
+        </span>
+      ) : undefined}
+      {error ? <pre className="file-status-error">{error}</pre> : undefined}
+    </TextField>
+  );
+};
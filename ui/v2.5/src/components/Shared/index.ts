@@ -20,4 +20,5 @@ export { default as DeleteEntityDialog } from "./DeleteEntityDialog";
 export { IndeterminateCheckbox } from "./IndeterminateCheckbox";
 export { OperationButton } from "./OperationButton";
 export { URLField } from "./URLField";
+export { FileStatusField } from "./FileStatusField";
 export const TITLE_SUFFIX = " | Stash";
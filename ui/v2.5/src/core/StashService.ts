@@ -211,6 +211,12 @@ export const useFindScene = (id: string) =>
 export const useSceneStreams = (id: string) =>
   GQL.useSceneStreamsQuery({ variables: { id } });
 
+export const useSceneCoverCandidates = (id: string, count?: number) =>
+  GQL.useSceneCoverCandidatesQuery({
+    variables: { id, count },
+    fetchPolicy: "network-only",
+  });
+
 export const useFindImage = (id: string) =>
   GQL.useFindImageQuery({ variables: { id } });
 
@@ -269,6 +275,23 @@ export const useSceneMarkerDestroy = () =>
     update: deleteCache(sceneMarkerMutationImpactedQueries),
   });
 
+export const useFindSceneMarkerSuggestions = (sceneID?: string) =>
+  GQL.useFindSceneMarkerSuggestionsQuery({
+    variables: { scene_id: sceneID },
+  });
+export const useSceneMarkerSuggestionsAccept = () =>
+  GQL.useSceneMarkerSuggestionsAcceptMutation({
+    refetchQueries: getQueryNames([
+      GQL.FindSceneDocument,
+      GQL.FindSceneMarkerSuggestionsDocument,
+    ]),
+    update: deleteCache(sceneMarkerMutationImpactedQueries),
+  });
+export const useSceneMarkerSuggestionsReject = () =>
+  GQL.useSceneMarkerSuggestionsRejectMutation({
+    refetchQueries: getQueryNames([GQL.FindSceneMarkerSuggestionsDocument]),
+  });
+
 export const useListPerformerScrapers = () =>
   GQL.useListPerformerScrapersQuery();
 export const useScrapePerformerList = (scraperId: string, q: string) =>
@@ -479,6 +502,13 @@ export const useSceneGenerateScreenshot = () =>
     update: deleteCache([GQL.FindScenesDocument]),
   });
 
+export const useSceneSetCoverCandidate = () =>
+  GQL.useSceneSetCoverCandidateMutation({
+    update: deleteCache([GQL.FindScenesDocument]),
+  });
+
+export const useSceneTrim = () => GQL.useSceneTrimMutation();
+
 const imageMutationImpactedQueries = [
   GQL.FindPerformerDocument,
   GQL.FindPerformersDocument,
@@ -1052,6 +1082,12 @@ export const mutateMetadataIdentify = (input: GQL.IdentifyMetadataInput) =>
     variables: { input },
   });
 
+export const mutateMetadataVerify = (input: GQL.VerifyMetadataInput) =>
+  client.mutate<GQL.MetadataVerifyMutation>({
+    mutation: GQL.MetadataVerifyDocument,
+    variables: { input },
+  });
+
 export const mutateMigrateHashNaming = () =>
   client.mutate<GQL.MigrateHashNamingMutation>({
     mutation: GQL.MigrateHashNamingDocument,
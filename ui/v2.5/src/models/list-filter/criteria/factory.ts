@@ -35,10 +35,12 @@ import {
   TagsCriterionOption,
 } from "./tags";
 import { GenderCriterion } from "./gender";
+import { FileStatusCriterion } from "./file-status";
 import { MoviesCriterionOption } from "./movies";
 import { GalleriesCriterion } from "./galleries";
 import { CriterionType } from "../types";
 import { InteractiveCriterion } from "./interactive";
+import { IsAnimatedCriterion } from "./is-animated";
 import { RatingCriterionOption } from "./rating";
 import { DuplicatedCriterion, PhashCriterionOption } from "./phash";
 
@@ -128,6 +130,8 @@ export function makeCriteria(type: CriterionType = "none") {
       );
     case "gender":
       return new GenderCriterion();
+    case "file_status":
+      return new FileStatusCriterion();
     case "sceneChecksum":
     case "galleryChecksum":
       return new StringCriterion(
@@ -150,6 +154,10 @@ export function makeCriteria(type: CriterionType = "none") {
     case "aliases":
     case "url":
     case "stash_id":
+    case "captions":
+    case "camera_make":
+    case "camera_model":
+    case "lens":
     case "details":
     case "title":
     case "director":
@@ -157,6 +165,8 @@ export function makeCriteria(type: CriterionType = "none") {
       return new StringCriterion(new StringCriterionOption(type, type));
     case "interactive":
       return new InteractiveCriterion();
+    case "is_animated":
+      return new IsAnimatedCriterion();
     case "parent_tag_count":
       return new NumberCriterion(
         new MandatoryNumberCriterionOption(
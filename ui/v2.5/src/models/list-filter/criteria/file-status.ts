@@ -0,0 +1,31 @@
+import {
+  CriterionModifier,
+  FileStatusCriterionInput,
+  FileStatusEnum,
+} from "src/core/generated-graphql";
+import { CriterionOption, StringCriterion } from "./criterion";
+
+export const FileStatusCriterionOption = new CriterionOption({
+  messageID: "file_status",
+  type: "file_status",
+  modifierOptions: [
+    CriterionModifier.Equals,
+    CriterionModifier.NotEquals,
+    CriterionModifier.IsNull,
+    CriterionModifier.NotNull,
+  ],
+  options: Object.values(FileStatusEnum),
+});
+
+export class FileStatusCriterion extends StringCriterion {
+  constructor() {
+    super(FileStatusCriterionOption);
+  }
+
+  protected toCriterionInput(): FileStatusCriterionInput {
+    return {
+      value: (this.value as FileStatusEnum) || undefined,
+      modifier: this.modifier,
+    };
+  }
+}
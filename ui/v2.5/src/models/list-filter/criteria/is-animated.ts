@@ -0,0 +1,12 @@
+import { BooleanCriterion, BooleanCriterionOption } from "./criterion";
+
+export const IsAnimatedCriterionOption = new BooleanCriterionOption(
+  "is_animated",
+  "is_animated"
+);
+
+export class IsAnimatedCriterion extends BooleanCriterion {
+  constructor() {
+    super(IsAnimatedCriterionOption);
+  }
+}
@@ -4,6 +4,8 @@ import {
   createStringCriterionOption,
 } from "./criteria/criterion";
 import { PerformerFavoriteCriterionOption } from "./criteria/favorite";
+import { IsAnimatedCriterionOption } from "./criteria/is-animated";
+import { FileStatusCriterionOption } from "./criteria/file-status";
 import { ImageIsMissingCriterionOption } from "./criteria/is-missing";
 import { OrganizedCriterionOption } from "./criteria/organized";
 import { PerformersCriterionOption } from "./criteria/performers";
@@ -19,9 +21,12 @@ import { DisplayMode } from "./types";
 
 const defaultSortBy = "path";
 
-const sortByOptions = ["o_counter", "filesize", ...MediaSortByOptions].map(
-  ListFilterOptions.createSortBy
-);
+const sortByOptions = [
+  "o_counter",
+  "filesize",
+  "relevance",
+  ...MediaSortByOptions,
+].map(ListFilterOptions.createSortBy);
 
 const displayModeOptions = [DisplayMode.Grid, DisplayMode.Wall];
 const criterionOptions = [
@@ -30,6 +35,11 @@ const criterionOptions = [
   createMandatoryStringCriterionOption("path"),
   RatingCriterionOption,
   OrganizedCriterionOption,
+  IsAnimatedCriterionOption,
+  createStringCriterionOption("camera_make"),
+  createStringCriterionOption("camera_model"),
+  createStringCriterionOption("lens"),
+  FileStatusCriterionOption,
   createMandatoryNumberCriterionOption("o_counter"),
   ResolutionCriterionOption,
   ImageIsMissingCriterionOption,
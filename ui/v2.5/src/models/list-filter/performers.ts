@@ -20,6 +20,7 @@ const sortByOptions = [
   "tag_count",
   "random",
   "rating",
+  "relevance",
 ]
   .map(ListFilterOptions.createSortBy)
   .concat([
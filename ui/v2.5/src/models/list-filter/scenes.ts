@@ -12,6 +12,7 @@ import { RatingCriterionOption } from "./criteria/rating";
 import { ResolutionCriterionOption } from "./criteria/resolution";
 import { StudiosCriterionOption } from "./criteria/studios";
 import { InteractiveCriterionOption } from "./criteria/interactive";
+import { FileStatusCriterionOption } from "./criteria/file-status";
 import {
   PerformerTagsCriterionOption,
   TagsCriterionOption,
@@ -37,6 +38,7 @@ const sortByOptions = [
   "interactive",
   "interactive_speed",
   "perceptual_similarity",
+  "relevance",
   ...MediaSortByOptions,
 ].map(ListFilterOptions.createSortBy);
 
@@ -77,8 +79,10 @@ const criterionOptions = [
   MoviesCriterionOption,
   createStringCriterionOption("url"),
   createStringCriterionOption("stash_id"),
+  createStringCriterionOption("captions"),
   InteractiveCriterionOption,
   createMandatoryNumberCriterionOption("interactive_speed"),
+  FileStatusCriterionOption,
 ];
 
 export const SceneListFilterOptions = new ListFilterOptions(
@@ -102,6 +102,7 @@ export type CriterionType =
   | "piercings"
   | "aliases"
   | "gender"
+  | "file_status"
   | "parent_studios"
   | "scene_count"
   | "marker_count"
@@ -111,7 +112,12 @@ export type CriterionType =
   | "death_year"
   | "url"
   | "stash_id"
+  | "captions"
+  | "camera_make"
+  | "camera_model"
+  | "lens"
   | "interactive"
+  | "is_animated"
   | "interactive_speed"
   | "name"
   | "details"